@@ -0,0 +1,306 @@
+package gocommerce
+
+import (
+	"context"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/inventory"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/products"
+	"github.com/j-low/gocommerce/profiles"
+	"github.com/j-low/gocommerce/transactions"
+	"github.com/j-low/gocommerce/webhooks"
+)
+
+// The *API interfaces mirror each subpackage's endpoint functions with the
+// Config bound, so code can depend on an interface and tests can substitute a
+// mockery or gomock implementation for an HTTP server. NewClient returns the
+// default implementations, which call the subpackage functions.
+
+type ProductsAPI interface {
+	CreateProduct(ctx context.Context, request products.CreateProductRequest) (*products.Product, error)
+	CreateProductVariant(ctx context.Context, request products.CreateProductVariantRequest) (*products.CreateProductVariantResponse, error)
+	UploadProductImage(ctx context.Context, productID, filePath string) (*products.UploadProductImageResponse, error)
+	RetrieveAllStorePages(ctx context.Context, params common.QueryParams) (*products.RetrieveAllStorePagesResponse, error)
+	RetrieveAllProducts(ctx context.Context, params common.QueryParams) (*products.RetrieveAllProductsResponse, error)
+	RetrieveAllProductsIter(ctx context.Context, params common.QueryParams) *common.Iterator[products.Product]
+	RetrieveSpecificProducts(ctx context.Context, productIDs []string) (*products.RetrieveSpecificProductsResponse, error)
+	GetProductImageUploadStatus(ctx context.Context, productID, imageID string) (*products.GetProductImageUploadStatusResponse, error)
+	AssignProductImageToVariant(ctx context.Context, request products.AssignProductImageToVariantRequest) (int, error)
+	ReorderProductImage(ctx context.Context, request products.ReorderProductImageRequest) (int, error)
+	UpdateProduct(ctx context.Context, productID string, request products.UpdateProductRequest) (*products.UpdateProductResponse, error)
+	UpdateProductVariant(ctx context.Context, request products.UpdateProductVariantRequest) (*products.UpdateProductVariantResponse, error)
+	UpdateProductImage(ctx context.Context, request products.UpdateProductImageRequest) (*products.UpdateProductImageResponse, error)
+	DeleteProduct(ctx context.Context, productID string) (int, error)
+	DeleteProductVariant(ctx context.Context, productID, variantID string) (int, error)
+	DeleteProductImage(ctx context.Context, productID, imageID string) (int, error)
+}
+
+type OrdersAPI interface {
+	CreateOrder(ctx context.Context, request orders.CreateOrderRequest) (*orders.Order, error)
+	FulfillOrder(ctx context.Context, orderID string, request orders.FulfillOrderRequest) (int, error)
+	RetrieveAllOrders(ctx context.Context, params common.QueryParams) (*orders.RetrieveAllOrdersResponse, error)
+	RetrieveAllOrdersIter(ctx context.Context, params common.QueryParams) *common.Iterator[orders.Order]
+	RetrieveSpecificOrder(ctx context.Context, orderID string) (*orders.Order, error)
+}
+
+type InventoryAPI interface {
+	RetrieveAllInventory(ctx context.Context, params common.QueryParams) (*inventory.RetrieveAllInventoryResponse, error)
+	RetrieveAllInventoryIter(ctx context.Context, params common.QueryParams) *common.Iterator[inventory.InventoryRecord]
+	RetrieveSpecificInventory(ctx context.Context, inventoryIDs []string) (*inventory.RetrieveSpecificInventoryResponse, error)
+	AdjustStockQuantities(ctx context.Context, request inventory.AdjustStockQuantitiesRequest) (int, error)
+	SetStock(ctx context.Context, variantID string, quantity int) (int, error)
+	SetUnlimited(ctx context.Context, variantID string) (int, error)
+}
+
+type ProfilesAPI interface {
+	RetrieveAllProfiles(ctx context.Context, params common.QueryParams) (*profiles.RetrieveAllProfilesResponse, error)
+	RetrieveAllProfilesIter(ctx context.Context, params common.QueryParams) *common.Iterator[profiles.Profile]
+	RetrieveSpecificProfiles(ctx context.Context, profileIDs []string) (*profiles.RetrieveSpecificProfilesResponse, error)
+}
+
+type TransactionsAPI interface {
+	RetrieveAllTransactions(ctx context.Context, params common.QueryParams) (*transactions.RetrieveAllTransactionsResponse, error)
+	RetrieveAllTransactionsIter(ctx context.Context, params common.QueryParams) *common.Iterator[transactions.Document]
+	RetrieveSpecificTransactions(ctx context.Context, transactionIDs []string) (*transactions.RetrieveSpecificTransactionsResponse, error)
+}
+
+type WebhooksAPI interface {
+	CreateWebhookSubscription(ctx context.Context, request webhooks.WebhookSubscriptionRequest) (*webhooks.WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, subscriptionID string, request webhooks.WebhookSubscriptionRequest) (*webhooks.WebhookSubscription, error)
+	RetrieveAllWebhookSubscriptions(ctx context.Context) (*webhooks.RetrieveAllWebhookSubscriptionsResponse, error)
+	RetrieveSpecificWebhookSubscription(ctx context.Context, subscriptionID string) (*webhooks.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, subscriptionID string) (int, error)
+	SendTestNotification(ctx context.Context, subscriptionID string, request webhooks.SendTestNotificationRequest) (*webhooks.SendTestNotificationResponse, error)
+	RotateSubscriptionSecret(ctx context.Context, subscriptionID string) (*webhooks.RotateSubscriptionSecretResponse, error)
+}
+
+// Client groups the APIs for one site. Fields can be replaced individually,
+// e.g. with a mock in tests.
+type Client struct {
+	Products     ProductsAPI
+	Orders       OrdersAPI
+	Inventory    InventoryAPI
+	Profiles     ProfilesAPI
+	Transactions TransactionsAPI
+	Webhooks     WebhooksAPI
+}
+
+func NewClient(config *common.Config) *Client {
+	return &Client{
+		Products:     NewProductsAPI(config),
+		Orders:       NewOrdersAPI(config),
+		Inventory:    NewInventoryAPI(config),
+		Profiles:     NewProfilesAPI(config),
+		Transactions: NewTransactionsAPI(config),
+		Webhooks:     NewWebhooksAPI(config),
+	}
+}
+
+func NewProductsAPI(config *common.Config) ProductsAPI {
+	return productsClient{config}
+}
+
+func NewOrdersAPI(config *common.Config) OrdersAPI {
+	return ordersClient{config}
+}
+
+func NewInventoryAPI(config *common.Config) InventoryAPI {
+	return inventoryClient{config}
+}
+
+func NewProfilesAPI(config *common.Config) ProfilesAPI {
+	return profilesClient{config}
+}
+
+func NewTransactionsAPI(config *common.Config) TransactionsAPI {
+	return transactionsClient{config}
+}
+
+func NewWebhooksAPI(config *common.Config) WebhooksAPI {
+	return webhooksClient{config}
+}
+
+type productsClient struct {
+	config *common.Config
+}
+
+func (c productsClient) CreateProduct(ctx context.Context, request products.CreateProductRequest) (*products.Product, error) {
+	return products.CreateProduct(ctx, c.config, request)
+}
+
+func (c productsClient) CreateProductVariant(ctx context.Context, request products.CreateProductVariantRequest) (*products.CreateProductVariantResponse, error) {
+	return products.CreateProductVariant(ctx, c.config, request)
+}
+
+func (c productsClient) UploadProductImage(ctx context.Context, productID, filePath string) (*products.UploadProductImageResponse, error) {
+	return products.UploadProductImage(ctx, c.config, productID, filePath)
+}
+
+func (c productsClient) RetrieveAllStorePages(ctx context.Context, params common.QueryParams) (*products.RetrieveAllStorePagesResponse, error) {
+	return products.RetrieveAllStorePages(ctx, c.config, params)
+}
+
+func (c productsClient) RetrieveAllProducts(ctx context.Context, params common.QueryParams) (*products.RetrieveAllProductsResponse, error) {
+	return products.RetrieveAllProducts(ctx, c.config, params)
+}
+
+func (c productsClient) RetrieveAllProductsIter(ctx context.Context, params common.QueryParams) *common.Iterator[products.Product] {
+	return products.RetrieveAllProductsIter(ctx, c.config, params)
+}
+
+func (c productsClient) RetrieveSpecificProducts(ctx context.Context, productIDs []string) (*products.RetrieveSpecificProductsResponse, error) {
+	return products.RetrieveSpecificProducts(ctx, c.config, productIDs)
+}
+
+func (c productsClient) GetProductImageUploadStatus(ctx context.Context, productID, imageID string) (*products.GetProductImageUploadStatusResponse, error) {
+	return products.GetProductImageUploadStatus(ctx, c.config, productID, imageID)
+}
+
+func (c productsClient) AssignProductImageToVariant(ctx context.Context, request products.AssignProductImageToVariantRequest) (int, error) {
+	return products.AssignProductImageToVariant(ctx, c.config, request)
+}
+
+func (c productsClient) ReorderProductImage(ctx context.Context, request products.ReorderProductImageRequest) (int, error) {
+	return products.ReorderProductImage(ctx, c.config, request)
+}
+
+func (c productsClient) UpdateProduct(ctx context.Context, productID string, request products.UpdateProductRequest) (*products.UpdateProductResponse, error) {
+	return products.UpdateProduct(ctx, c.config, productID, request)
+}
+
+func (c productsClient) UpdateProductVariant(ctx context.Context, request products.UpdateProductVariantRequest) (*products.UpdateProductVariantResponse, error) {
+	return products.UpdateProductVariant(ctx, c.config, request)
+}
+
+func (c productsClient) UpdateProductImage(ctx context.Context, request products.UpdateProductImageRequest) (*products.UpdateProductImageResponse, error) {
+	return products.UpdateProductImage(ctx, c.config, request)
+}
+
+func (c productsClient) DeleteProduct(ctx context.Context, productID string) (int, error) {
+	return products.DeleteProduct(ctx, c.config, productID)
+}
+
+func (c productsClient) DeleteProductVariant(ctx context.Context, productID, variantID string) (int, error) {
+	return products.DeleteProductVariant(ctx, c.config, productID, variantID)
+}
+
+func (c productsClient) DeleteProductImage(ctx context.Context, productID, imageID string) (int, error) {
+	return products.DeleteProductImage(ctx, c.config, productID, imageID)
+}
+
+type ordersClient struct {
+	config *common.Config
+}
+
+func (c ordersClient) CreateOrder(ctx context.Context, request orders.CreateOrderRequest) (*orders.Order, error) {
+	return orders.CreateOrder(ctx, c.config, request)
+}
+
+func (c ordersClient) FulfillOrder(ctx context.Context, orderID string, request orders.FulfillOrderRequest) (int, error) {
+	return orders.FulfillOrder(ctx, c.config, orderID, request)
+}
+
+func (c ordersClient) RetrieveAllOrders(ctx context.Context, params common.QueryParams) (*orders.RetrieveAllOrdersResponse, error) {
+	return orders.RetrieveAllOrders(ctx, c.config, params)
+}
+
+func (c ordersClient) RetrieveAllOrdersIter(ctx context.Context, params common.QueryParams) *common.Iterator[orders.Order] {
+	return orders.RetrieveAllOrdersIter(ctx, c.config, params)
+}
+
+func (c ordersClient) RetrieveSpecificOrder(ctx context.Context, orderID string) (*orders.Order, error) {
+	return orders.RetrieveSpecificOrder(ctx, c.config, orderID)
+}
+
+type inventoryClient struct {
+	config *common.Config
+}
+
+func (c inventoryClient) RetrieveAllInventory(ctx context.Context, params common.QueryParams) (*inventory.RetrieveAllInventoryResponse, error) {
+	return inventory.RetrieveAllInventory(ctx, c.config, params)
+}
+
+func (c inventoryClient) RetrieveAllInventoryIter(ctx context.Context, params common.QueryParams) *common.Iterator[inventory.InventoryRecord] {
+	return inventory.RetrieveAllInventoryIter(ctx, c.config, params)
+}
+
+func (c inventoryClient) RetrieveSpecificInventory(ctx context.Context, inventoryIDs []string) (*inventory.RetrieveSpecificInventoryResponse, error) {
+	return inventory.RetrieveSpecificInventory(ctx, c.config, inventoryIDs)
+}
+
+func (c inventoryClient) AdjustStockQuantities(ctx context.Context, request inventory.AdjustStockQuantitiesRequest) (int, error) {
+	return inventory.AdjustStockQuantities(ctx, c.config, request)
+}
+
+func (c inventoryClient) SetStock(ctx context.Context, variantID string, quantity int) (int, error) {
+	return inventory.SetStock(ctx, c.config, variantID, quantity)
+}
+
+func (c inventoryClient) SetUnlimited(ctx context.Context, variantID string) (int, error) {
+	return inventory.SetUnlimited(ctx, c.config, variantID)
+}
+
+type profilesClient struct {
+	config *common.Config
+}
+
+func (c profilesClient) RetrieveAllProfiles(ctx context.Context, params common.QueryParams) (*profiles.RetrieveAllProfilesResponse, error) {
+	return profiles.RetrieveAllProfiles(ctx, c.config, params)
+}
+
+func (c profilesClient) RetrieveAllProfilesIter(ctx context.Context, params common.QueryParams) *common.Iterator[profiles.Profile] {
+	return profiles.RetrieveAllProfilesIter(ctx, c.config, params)
+}
+
+func (c profilesClient) RetrieveSpecificProfiles(ctx context.Context, profileIDs []string) (*profiles.RetrieveSpecificProfilesResponse, error) {
+	return profiles.RetrieveSpecificProfiles(ctx, c.config, profileIDs)
+}
+
+type transactionsClient struct {
+	config *common.Config
+}
+
+func (c transactionsClient) RetrieveAllTransactions(ctx context.Context, params common.QueryParams) (*transactions.RetrieveAllTransactionsResponse, error) {
+	return transactions.RetrieveAllTransactions(ctx, c.config, params)
+}
+
+func (c transactionsClient) RetrieveAllTransactionsIter(ctx context.Context, params common.QueryParams) *common.Iterator[transactions.Document] {
+	return transactions.RetrieveAllTransactionsIter(ctx, c.config, params)
+}
+
+func (c transactionsClient) RetrieveSpecificTransactions(ctx context.Context, transactionIDs []string) (*transactions.RetrieveSpecificTransactionsResponse, error) {
+	return transactions.RetrieveSpecificTransactions(ctx, c.config, transactionIDs)
+}
+
+type webhooksClient struct {
+	config *common.Config
+}
+
+func (c webhooksClient) CreateWebhookSubscription(ctx context.Context, request webhooks.WebhookSubscriptionRequest) (*webhooks.WebhookSubscription, error) {
+	return webhooks.CreateWebhookSubscription(ctx, c.config, request)
+}
+
+func (c webhooksClient) UpdateWebhookSubscription(ctx context.Context, subscriptionID string, request webhooks.WebhookSubscriptionRequest) (*webhooks.WebhookSubscription, error) {
+	return webhooks.UpdateWebhookSubscription(ctx, c.config, subscriptionID, request)
+}
+
+func (c webhooksClient) RetrieveAllWebhookSubscriptions(ctx context.Context) (*webhooks.RetrieveAllWebhookSubscriptionsResponse, error) {
+	return webhooks.RetrieveAllWebhookSubscriptions(ctx, c.config)
+}
+
+func (c webhooksClient) RetrieveSpecificWebhookSubscription(ctx context.Context, subscriptionID string) (*webhooks.WebhookSubscription, error) {
+	return webhooks.RetrieveSpecificWebhookSubscription(ctx, c.config, subscriptionID)
+}
+
+func (c webhooksClient) DeleteWebhookSubscription(ctx context.Context, subscriptionID string) (int, error) {
+	return webhooks.DeleteWebhookSubscription(ctx, c.config, subscriptionID)
+}
+
+func (c webhooksClient) SendTestNotification(ctx context.Context, subscriptionID string, request webhooks.SendTestNotificationRequest) (*webhooks.SendTestNotificationResponse, error) {
+	return webhooks.SendTestNotification(ctx, c.config, subscriptionID, request)
+}
+
+func (c webhooksClient) RotateSubscriptionSecret(ctx context.Context, subscriptionID string) (*webhooks.RotateSubscriptionSecretResponse, error) {
+	return webhooks.RotateSubscriptionSecret(ctx, c.config, subscriptionID)
+}
@@ -0,0 +1,43 @@
+package gocommerce
+
+import (
+	"context"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/mocks"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/profiles"
+)
+
+type stubOrders struct {
+	OrdersAPI
+	order *orders.Order
+}
+
+func (s stubOrders) RetrieveSpecificOrder(ctx context.Context, orderID string) (*orders.Order, error) {
+	return s.order, nil
+}
+
+func TestClient(t *testing.T) {
+	server := mocks.NewServer()
+	defer server.Close()
+	if err := server.Seed(mocks.Profiles, profiles.Profile{ID: "profile-1", Email: "a@example.com"}); err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	client := NewClient(server.Config())
+	resp, err := client.Profiles.RetrieveAllProfiles(context.Background(), common.QueryParams{})
+	if err != nil {
+		t.Fatalf("RetrieveAllProfiles() error = %v", err)
+	}
+	if len(resp.Profiles) != 1 || resp.Profiles[0].ID != "profile-1" {
+		t.Errorf("unexpected profiles %+v", resp.Profiles)
+	}
+
+	client.Orders = stubOrders{order: &orders.Order{ID: "order-1"}}
+	order, err := client.Orders.RetrieveSpecificOrder(context.Background(), "order-1")
+	if err != nil || order.ID != "order-1" {
+		t.Errorf("expected stubbed order, got %+v, %v", order, err)
+	}
+}
@@ -0,0 +1,52 @@
+package bulk
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRetryBudgetExhausted wraps an item's last error when a retry was skipped
+// because it would overrun the retry budget or the context's deadline.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// Budget caps the cumulative time spent retrying, counting both backoff and
+// the retried attempts themselves. Share one across the calls that make up a
+// logical operation, e.g. every chunk of a batched adjustment. It is safe for
+// concurrent use.
+type Budget struct {
+	mu    sync.Mutex
+	limit time.Duration
+	spent time.Duration
+}
+
+func NewBudget(limit time.Duration) *Budget {
+	return &Budget{limit: limit}
+}
+
+// Remaining returns the retry time left, which is zero once exhausted.
+func (b *Budget) Remaining() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return max(b.limit-b.spent, 0)
+}
+
+// reserve claims d for a backoff sleep, reporting false if that would exceed
+// the limit.
+func (b *Budget) reserve(d time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.spent+d > b.limit {
+		return false
+	}
+	b.spent += d
+	return true
+}
+
+// charge records time spent in a retried attempt.
+func (b *Budget) charge(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent += d
+}
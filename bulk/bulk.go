@@ -0,0 +1,219 @@
+// Package bulk runs the same operation over many items with bounded
+// concurrency, an optional shared rate limit, retries and progress reporting.
+// The module's batch helpers build on it so large jobs behave consistently.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const (
+	defaultConcurrency = 4
+	defaultBackoff     = 500 * time.Millisecond
+	defaultMaxBackoff  = 10 * time.Second
+)
+
+type Options struct {
+	// Concurrency is the number of workers. Defaults to 4. With 1, items run
+	// one at a time in input order.
+	Concurrency int
+	// Limiter, when set, is waited on before every attempt. Share one between
+	// jobs to keep their combined request rate under the API's limit.
+	Limiter *Limiter
+	Retry   RetryPolicy
+	// OnProgress is called after each item finishes. Calls are serialized.
+	OnProgress func(Progress)
+}
+
+// RetryPolicy retries failed attempts with exponential backoff. The zero
+// value makes a single attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per item. Defaults to 1.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubling after each one up
+	// to MaxBackoff. Defaults to 500ms and 10s.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Retryable reports whether an error is worth retrying. Defaults to
+	// transport failures and 429 and 5xx responses, so validation errors,
+	// other 4xx responses, dry runs and read-only rejections fail at once.
+	Retryable func(error) bool
+	// Budget, when set, caps the total retry time across every item sharing
+	// it. Retries are also skipped when the backoff would outlast the
+	// context's deadline.
+	Budget *Budget
+}
+
+type Progress struct {
+	Total     int
+	Done      int
+	Failed    int
+	Elapsed   time.Duration
+	Remaining int
+}
+
+type Result[R any] struct {
+	Value    R
+	Err      error
+	Attempts int
+}
+
+// Report holds one result per item, in input order.
+type Report[R any] struct {
+	Results   []Result[R]
+	Succeeded int
+	Failed    int
+}
+
+// Err joins every item's error, prefixed with the item's index, or returns
+// nil if all succeeded.
+func (r *Report[R]) Err() error {
+	var errs []error
+	for i, result := range r.Results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("item %d: %w", i, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run calls fn for every item with the item's index. A failed item does not
+// stop the others; once ctx is done, items not yet started fail with the
+// context's error.
+func Run[T, R any](ctx context.Context, items []T, fn func(ctx context.Context, i int, item T) (R, error), opts Options) *Report[R] {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	concurrency = min(concurrency, max(len(items), 1))
+
+	report := &Report[R]{Results: make([]Result[R], len(items))}
+	start := time.Now()
+	var mu sync.Mutex
+	finish := func(i int, result Result[R]) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		report.Results[i] = result
+		if result.Err != nil {
+			report.Failed++
+		} else {
+			report.Succeeded++
+		}
+		if opts.OnProgress != nil {
+			done := report.Succeeded + report.Failed
+			opts.OnProgress(Progress{
+				Total:     len(items),
+				Done:      done,
+				Failed:    report.Failed,
+				Elapsed:   time.Since(start),
+				Remaining: len(items) - done,
+			})
+		}
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				finish(i, runItem(ctx, i, items[i], fn, opts))
+			}
+		}()
+	}
+
+	for i := range items {
+		if ctx.Err() != nil {
+			finish(i, Result[R]{Err: ctx.Err()})
+			continue
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			finish(i, Result[R]{Err: ctx.Err()})
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	return report
+}
+
+func runItem[T, R any](ctx context.Context, i int, item T, fn func(context.Context, int, T) (R, error), opts Options) Result[R] {
+	policy := opts.Retry
+	attempts := max(policy.MaxAttempts, 1)
+	backoff := policy.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+	maxBackoff := policy.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = isRetryable
+	}
+
+	var result Result[R]
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if opts.Limiter != nil {
+			if err := opts.Limiter.Wait(ctx); err != nil {
+				result.Err = err
+				return result
+			}
+		}
+
+		result.Attempts = attempt
+		start := time.Now()
+		result.Value, result.Err = fn(ctx, i, item)
+		if attempt > 1 && policy.Budget != nil {
+			policy.Budget.charge(time.Since(start))
+		}
+		if result.Err == nil || attempt == attempts || !retryable(result.Err) {
+			return result
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= backoff {
+			result.Err = fmt.Errorf("%w: backoff would pass the context deadline: %w", ErrRetryBudgetExhausted, result.Err)
+			return result
+		}
+		if policy.Budget != nil && !policy.Budget.reserve(backoff) {
+			result.Err = fmt.Errorf("%w: %w", ErrRetryBudgetExhausted, result.Err)
+			return result
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			result.Err = fmt.Errorf("%w: %w", ctx.Err(), result.Err)
+			return result
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+	return result
+}
+
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var respErr *common.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode == http.StatusTooManyRequests || respErr.StatusCode >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,268 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		items         []int
+		opts          Options
+		failOn        map[int]int // item -> failing attempts before success
+		wantValues    []int
+		wantAttempts  []int
+		wantFailed    int
+		wantErr       string
+		maxConcurrent int32
+	}{
+		{
+			name:          "all succeed",
+			items:         []int{1, 2, 3, 4, 5},
+			opts:          Options{Concurrency: 2},
+			wantValues:    []int{2, 4, 6, 8, 10},
+			wantAttempts:  []int{1, 1, 1, 1, 1},
+			maxConcurrent: 2,
+		},
+		{
+			name:         "failures are reported without stopping",
+			items:        []int{1, 2, 3},
+			opts:         Options{Concurrency: 1},
+			failOn:       map[int]int{2: 1},
+			wantValues:   []int{2, 0, 6},
+			wantAttempts: []int{1, 1, 1},
+			wantFailed:   1,
+			wantErr:      `item 1: Post "https://api.squarespace.com": item 2 failed`,
+		},
+		{
+			name:         "retries until success",
+			items:        []int{1, 2},
+			opts:         Options{Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}},
+			failOn:       map[int]int{2: 2},
+			wantValues:   []int{2, 4},
+			wantAttempts: []int{1, 3},
+		},
+		{
+			name:  "non-retryable errors stop retrying",
+			items: []int{1},
+			opts: Options{Retry: RetryPolicy{
+				MaxAttempts: 3,
+				Backoff:     time.Millisecond,
+				Retryable:   func(error) bool { return false },
+			}},
+			failOn:       map[int]int{1: 2},
+			wantValues:   []int{0},
+			wantAttempts: []int{1},
+			wantFailed:   1,
+			wantErr:      `item 0: Post "https://api.squarespace.com": item 1 failed`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, peak int32
+			var attempts [10]int32
+			var progress []Progress
+			tt.opts.OnProgress = func(p Progress) { progress = append(progress, p) }
+
+			report := Run(context.Background(), tt.items, func(_ context.Context, _ int, item int) (int, error) {
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+
+				if int(atomic.AddInt32(&attempts[item], 1)) <= tt.failOn[item] {
+					return 0, transportError("item " + string(rune('0'+item)) + " failed")
+				}
+				return item * 2, nil
+			}, tt.opts)
+
+			for i, result := range report.Results {
+				if result.Value != tt.wantValues[i] {
+					t.Errorf("result %d: expected value %d, got %d", i, tt.wantValues[i], result.Value)
+				}
+				if result.Attempts != tt.wantAttempts[i] {
+					t.Errorf("result %d: expected %d attempts, got %d", i, tt.wantAttempts[i], result.Attempts)
+				}
+			}
+			if report.Failed != tt.wantFailed || report.Succeeded != len(tt.items)-tt.wantFailed {
+				t.Errorf("expected %d failed, got %d failed and %d succeeded", tt.wantFailed, report.Failed, report.Succeeded)
+			}
+
+			err := report.Err()
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+
+			if tt.maxConcurrent > 0 && peak > tt.maxConcurrent {
+				t.Errorf("expected at most %d concurrent calls, got %d", tt.maxConcurrent, peak)
+			}
+			if len(progress) != len(tt.items) {
+				t.Fatalf("expected %d progress calls, got %d", len(tt.items), len(progress))
+			}
+			last := progress[len(progress)-1]
+			if last.Done != len(tt.items) || last.Remaining != 0 || last.Failed != tt.wantFailed {
+				t.Errorf("unexpected final progress %+v", last)
+			}
+		})
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int32
+	report := Run(ctx, []int{1, 2, 3, 4}, func(context.Context, int, int) (struct{}, error) {
+		if atomic.AddInt32(&calls, 1) == 2 {
+			cancel()
+		}
+		return struct{}{}, nil
+	}, Options{Concurrency: 1})
+
+	if calls != 2 {
+		t.Errorf("expected 2 calls before cancellation, got %d", calls)
+	}
+	for i, result := range report.Results[2:] {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("item %d: expected context.Canceled, got %v", i+2, result.Err)
+		}
+	}
+}
+
+func TestRunCanceledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errTemporary := errors.New("temporary")
+
+	report := Run(ctx, []int{1}, func(context.Context, int, int) (struct{}, error) {
+		cancel()
+		return struct{}{}, errTemporary
+	}, Options{Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Hour, Retryable: func(error) bool { return true }}})
+
+	result := report.Results[0]
+	if result.Attempts != 1 {
+		t.Errorf("expected no retry after cancellation, got %d attempts", result.Attempts)
+	}
+	if !errors.Is(result.Err, context.Canceled) || !errors.Is(result.Err, errTemporary) {
+		t.Errorf("expected the error to wrap context.Canceled and the last failure, got %v", result.Err)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewLimiter(10, 2)
+	limiter.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("burst wait %d: %v", i, err)
+		}
+	}
+
+	// The bucket is empty; advancing the clock by one interval refills a token.
+	now = now.Add(100 * time.Millisecond)
+	start := time.Now()
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("refilled wait: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected a refilled token without waiting, waited %v", elapsed)
+	}
+
+	canceled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(canceled); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if limiter.tokens != 0 {
+		t.Errorf("expected the canceled reservation to be returned, got %v tokens", limiter.tokens)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	failing := func(context.Context, int, int) (int, error) {
+		return 0, transportError("unavailable")
+	}
+
+	t.Run("budget is shared across runs", func(t *testing.T) {
+		budget := NewBudget(15 * time.Millisecond)
+		opts := Options{Concurrency: 1, Retry: RetryPolicy{MaxAttempts: 10, Backoff: 10 * time.Millisecond, MaxBackoff: 10 * time.Millisecond, Budget: budget}}
+
+		first := Run(context.Background(), []int{1}, failing, opts)
+		if first.Results[0].Attempts != 2 || !errors.Is(first.Results[0].Err, ErrRetryBudgetExhausted) {
+			t.Errorf("expected one retry before the budget ran out, got %+v", first.Results[0])
+		}
+
+		second := Run(context.Background(), []int{1}, failing, opts)
+		if second.Results[0].Attempts != 1 || !errors.Is(second.Results[0].Err, ErrRetryBudgetExhausted) {
+			t.Errorf("expected no retries from an exhausted budget, got %+v", second.Results[0])
+		}
+		if budget.Remaining() > 5*time.Millisecond {
+			t.Errorf("expected the budget to be mostly spent, %v remaining", budget.Remaining())
+		}
+		if !strings.Contains(second.Err().Error(), "unavailable") {
+			t.Errorf("expected the last error to be kept, got %v", second.Err())
+		}
+	})
+
+	t.Run("backoff never sleeps past the deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		report := Run(ctx, []int{1}, failing, Options{Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Second}})
+		if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+			t.Errorf("expected to give up without sleeping, took %v", elapsed)
+		}
+		if !errors.Is(report.Results[0].Err, ErrRetryBudgetExhausted) || report.Results[0].Attempts != 1 {
+			t.Errorf("expected the retry to be skipped, got %+v", report.Results[0])
+		}
+	})
+}
+
+func transportError(msg string) error {
+	return &url.Error{Op: "Post", URL: "https://api.squarespace.com", Err: errors.New(msg)}
+}
+
+func TestDefaultRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"transport failure", transportError("connection reset"), true},
+		{"rate limited", &common.ResponseError{StatusCode: http.StatusTooManyRequests}, true},
+		{"server error", fmt.Errorf("failed: %w", &common.ResponseError{StatusCode: http.StatusBadGateway}), true},
+		{"validation error", &common.ResponseError{StatusCode: http.StatusBadRequest}, false},
+		{"local error", errors.New("orderID is required"), false},
+		{"dry run", common.ErrDryRun, false},
+		{"read-only mode", fmt.Errorf("%w: POST /x", common.ErrReadOnlyMode), false},
+		{"canceled", &url.Error{Op: "Post", URL: "https://api.squarespace.com", Err: context.Canceled}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.want {
+				t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package cache provides pluggable storage for the SDK's read-through response
+// cache. Wrap a Store with New and assign the result to Config.Cache to cache
+// product, store page and profile reads:
+//
+//	config.Cache = cache.New(cache.NewRedisStore(addr, cache.RedisOptions{}), cache.Options{TTL: time.Minute})
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const defaultTimeout = time.Second
+
+// Store holds cache entries. A ttl of zero or less keeps the entry until it is
+// deleted.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+type Options struct {
+	// TTL is how long responses are cached. Zero caches them until deleted.
+	TTL time.Duration
+	// KeyPrefix is prepended to every key, e.g. to separate environments
+	// sharing a store. Keys already include a hash of the API key, so sites
+	// sharing a store never read each other's entries without one.
+	KeyPrefix string
+	// Timeout bounds each store operation. Defaults to 1s.
+	Timeout time.Duration
+	// OnError is called when the store fails. Failed reads are treated as
+	// misses and failed writes are dropped, so a store outage only costs
+	// extra API requests.
+	OnError func(error)
+}
+
+type storeCache struct {
+	store Store
+	opts  Options
+}
+
+// New adapts store to common.Cache for use as Config.Cache.
+func New(store Store, opts Options) common.Cache {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	return &storeCache{store: store, opts: opts}
+}
+
+func (c *storeCache) Get(key string) ([]byte, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.Timeout)
+	defer cancel()
+
+	value, ok, err := c.store.Get(ctx, c.opts.KeyPrefix+key)
+	if err != nil {
+		c.report(err)
+		return nil, false
+	}
+	return value, ok
+}
+
+func (c *storeCache) Set(key string, value []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.Timeout)
+	defer cancel()
+
+	if err := c.store.Set(ctx, c.opts.KeyPrefix+key, value, c.opts.TTL); err != nil {
+		c.report(err)
+	}
+}
+
+func (c *storeCache) Delete(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.Timeout)
+	defer cancel()
+
+	if err := c.store.Delete(ctx, c.opts.KeyPrefix+key); err != nil {
+		c.report(err)
+	}
+}
+
+func (c *storeCache) report(err error) {
+	if c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
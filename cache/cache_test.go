@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/internal/redis/redistest"
+)
+
+func TestStores(t *testing.T) {
+	server := redistest.NewServer()
+	defer server.Close()
+
+	memory := NewMemoryStore()
+	now := time.Now()
+	memory.now = func() time.Time { return now }
+
+	redisStore := NewRedisStore(server.Addr(), RedisOptions{KeyPrefix: "test:"})
+	defer redisStore.Close()
+
+	tests := []struct {
+		name    string
+		store   Store
+		advance func(time.Duration)
+	}{
+		{"memory", memory, func(d time.Duration) { now = now.Add(d) }},
+		{"redis", redisStore, server.Advance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if _, ok, err := tt.store.Get(ctx, "missing"); err != nil || ok {
+				t.Fatalf("Get(missing) = %v, %v; want false, nil", ok, err)
+			}
+
+			if err := tt.store.Set(ctx, "short", []byte("a"), time.Minute); err != nil {
+				t.Fatalf("Set() unexpected error = %v", err)
+			}
+			if err := tt.store.Set(ctx, "forever", []byte("b"), 0); err != nil {
+				t.Fatalf("Set() unexpected error = %v", err)
+			}
+			if value, ok, err := tt.store.Get(ctx, "short"); err != nil || !ok || string(value) != "a" {
+				t.Errorf("Get(short) = %q, %v, %v; want a, true, nil", value, ok, err)
+			}
+
+			tt.advance(2 * time.Minute)
+			if _, ok, err := tt.store.Get(ctx, "short"); err != nil || ok {
+				t.Errorf("expected short entry to expire, got %v, %v", ok, err)
+			}
+			if value, ok, err := tt.store.Get(ctx, "forever"); err != nil || !ok || string(value) != "b" {
+				t.Errorf("Get(forever) = %q, %v, %v; want b, true, nil", value, ok, err)
+			}
+
+			if err := tt.store.Delete(ctx, "forever"); err != nil {
+				t.Fatalf("Delete() unexpected error = %v", err)
+			}
+			if _, ok, err := tt.store.Get(ctx, "forever"); err != nil || ok {
+				t.Errorf("expected deleted entry to be gone, got %v, %v", ok, err)
+			}
+		})
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("store down")
+}
+
+func (failingStore) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("store down")
+}
+
+func (failingStore) Delete(context.Context, string) error { return nil }
+
+func TestNew(t *testing.T) {
+	store := NewMemoryStore()
+	c := New(store, Options{TTL: time.Minute, KeyPrefix: "site-a:"})
+
+	c.Set("https://api.example.test/products", []byte(`{}`))
+	if value, ok := c.Get("https://api.example.test/products"); !ok || string(value) != `{}` {
+		t.Errorf("Get() = %q, %v; want {}, true", value, ok)
+	}
+	if _, ok, _ := store.Get(context.Background(), "site-a:https://api.example.test/products"); !ok {
+		t.Error("expected the key prefix to be applied in the store")
+	}
+
+	var errs []error
+	failing := New(failingStore{}, Options{OnError: func(err error) { errs = append(errs, err) }})
+	failing.Set("key", []byte("value"))
+	if _, ok := failing.Get("key"); ok {
+		t.Error("expected a failed read to be a miss")
+	}
+	if len(errs) != 2 {
+		t.Errorf("expected 2 reported errors, got %d", len(errs))
+	}
+}
@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/j-low/gocommerce/internal/redis"
+)
+
+type RedisOptions struct {
+	Password string
+	DB       int
+	// KeyPrefix namespaces the stored entries. Defaults to "gocommerce:cache:".
+	KeyPrefix string
+}
+
+// RedisStore is a Store shared by every process connected to the same Redis
+// server, with expiry handled by Redis.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisStore(addr string, opts RedisOptions) *RedisStore {
+	prefix := opts.KeyPrefix
+	if prefix == "" {
+		prefix = "gocommerce:cache:"
+	}
+	return &RedisStore{
+		client: redis.NewClient(addr, redis.Options{Password: opts.Password, DB: opts.DB}),
+		prefix: prefix,
+	}
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := s.client.Do(ctx, "GET", s.prefix+key)
+	if errors.Is(err, redis.ErrNil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get cache entry %s: %w", key, err)
+	}
+	value, ok := reply.(string)
+	if !ok {
+		return nil, false, fmt.Errorf("failed to get cache entry %s: unexpected reply %v", key, reply)
+	}
+	return []byte(value), true, nil
+}
+
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", s.prefix + key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	}
+	if _, err := s.client.Do(ctx, args...); err != nil {
+		return fmt.Errorf("failed to set cache entry %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	if _, err := s.client.Do(ctx, "DEL", s.prefix+key); err != nil {
+		return fmt.Errorf("failed to delete cache entry %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
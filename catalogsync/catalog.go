@@ -0,0 +1,97 @@
+// Package catalogsync pushes the Squarespace catalog to other sales channels
+// such as a POS or marketplace. Snapshot flattens the catalog into one Item
+// per variant, Diff plans the changes that bring a Destination in line with
+// it, and Sync does both and applies the plan.
+//
+// The package is named catalogsync rather than sync so it does not shadow the
+// standard library package.
+package catalogsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/products"
+)
+
+// Item is one sellable variant, keyed by SKU.
+type Item struct {
+	SKU       string `json:"sku"`
+	ProductID string `json:"productId"`
+	VariantID string `json:"variantId"`
+	Name      string `json:"name"`
+	// Price is what a customer pays: the sale price while on sale, otherwise
+	// the base price.
+	Price     common.Amount `json:"price"`
+	Quantity  int           `json:"quantity"`
+	Unlimited bool          `json:"unlimited"`
+	Visible   bool          `json:"visible"`
+	URL       string        `json:"url"`
+}
+
+// Catalog is a snapshot of the Squarespace catalog.
+type Catalog struct {
+	Items []Item
+	// Skipped lists variants without a SKU, which cannot be matched against a
+	// destination.
+	Skipped []Item
+}
+
+// Snapshot reads every product matching params into a Catalog.
+func Snapshot(ctx context.Context, config *common.Config, params common.QueryParams) (*Catalog, error) {
+	catalog := &Catalog{}
+	seen := make(map[string]bool)
+
+	it := products.RetrieveAllProductsIter(ctx, config, params)
+	for it.Next() {
+		product := it.Value()
+		for _, variant := range product.Variants {
+			item := itemFromVariant(product, variant)
+			if item.SKU == "" {
+				catalog.Skipped = append(catalog.Skipped, item)
+				continue
+			}
+			if seen[item.SKU] {
+				return nil, fmt.Errorf("duplicate SKU %s on product %s", item.SKU, product.ID)
+			}
+			seen[item.SKU] = true
+			catalog.Items = append(catalog.Items, item)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve products: %w", err)
+	}
+	return catalog, nil
+}
+
+// ValidateSKUs checks every item's SKU against policy, returning all failures
+// joined into a single error.
+func (c *Catalog) ValidateSKUs(policy products.SKUPolicy) error {
+	var errs []error
+	for _, item := range c.Items {
+		if err := policy.Validate(item.SKU); err != nil {
+			errs = append(errs, fmt.Errorf("product %s: %w", item.ProductID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func itemFromVariant(product products.Product, variant products.ProductVariant) Item {
+	price := variant.Pricing.BasePrice
+	if variant.Pricing.OnSale {
+		price = variant.Pricing.SalePrice
+	}
+	return Item{
+		SKU:       variant.SKU,
+		ProductID: product.ID,
+		VariantID: variant.ID,
+		Name:      product.Name,
+		Price:     price,
+		Quantity:  variant.Stock.Quantity,
+		Unlimited: variant.Stock.Unlimited,
+		Visible:   product.IsVisible,
+		URL:       product.URL,
+	}
+}
@@ -0,0 +1,145 @@
+package catalogsync
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+var csvHeader = []string{"sku", "product_id", "variant_id", "name", "price", "currency", "quantity", "unlimited", "visible", "url"}
+
+// CSVDestination keeps the catalog in a CSV file, e.g. for a channel that
+// imports a feed. Apply rewrites the whole file atomically.
+type CSVDestination struct {
+	Path string
+}
+
+func NewCSVDestination(path string) *CSVDestination {
+	return &CSVDestination{Path: path}
+}
+
+// List reads the file, treating a missing file as empty.
+func (d *CSVDestination) List(ctx context.Context) ([]Item, error) {
+	file, err := os.Open(d.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", d.Path, err)
+	}
+	defer file.Close()
+
+	return readCSV(file)
+}
+
+func (d *CSVDestination) Apply(ctx context.Context, plan *Plan) error {
+	current, err := d.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	items := make(map[string]Item, len(current))
+	for _, item := range current {
+		items[item.SKU] = item
+	}
+	for _, change := range plan.Changes {
+		if change.Action == ActionDelete {
+			delete(items, change.SKU)
+		} else {
+			items[change.SKU] = change.Item
+		}
+	}
+
+	rows := make([]Item, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, item)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].SKU < rows[j].SKU })
+
+	tmp, err := os.CreateTemp(filepath.Dir(d.Path), filepath.Base(d.Path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := writeCSV(tmp, rows); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", d.Path, err)
+	}
+	if err := os.Rename(tmp.Name(), d.Path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", d.Path, err)
+	}
+	return nil
+}
+
+func readCSV(r io.Reader) ([]Item, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	items := make([]Item, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if len(record) != len(csvHeader) {
+			return nil, fmt.Errorf("row %d: expected %d columns, got %d", i+2, len(csvHeader), len(record))
+		}
+		quantity, err := strconv.Atoi(record[6])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid quantity %q", i+2, record[6])
+		}
+		items = append(items, Item{
+			SKU:       record[0],
+			ProductID: record[1],
+			VariantID: record[2],
+			Name:      record[3],
+			Price:     common.Amount{Value: record[4], Currency: record[5]},
+			Quantity:  quantity,
+			Unlimited: record[7] == "true",
+			Visible:   record[8] == "true",
+			URL:       record[9],
+		})
+	}
+	return items, nil
+}
+
+func writeCSV(w io.Writer, items []Item) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, item := range items {
+		if err := cw.Write([]string{
+			item.SKU,
+			item.ProductID,
+			item.VariantID,
+			item.Name,
+			item.Price.Value,
+			item.Price.Currency,
+			strconv.Itoa(item.Quantity),
+			strconv.FormatBool(item.Unlimited),
+			strconv.FormatBool(item.Visible),
+			item.URL,
+		}); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
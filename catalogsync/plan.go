@@ -0,0 +1,215 @@
+package catalogsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/products"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+type Change struct {
+	Action Action
+	SKU    string
+	// Item is the desired state; for deletes it is the destination's item.
+	Item Item
+	// Previous is the destination's item for updates.
+	Previous *Item
+	// Fields names the fields an update changes.
+	Fields []string
+	Err    error
+}
+
+// Plan lists the changes that bring a destination in line with the catalog,
+// ordered by SKU. Destinations record per-change failures on the plan.
+type Plan struct {
+	DryRun  bool
+	Changes []Change
+}
+
+// Err joins the changes' failures. Changes skipped because the requests were
+// made in dry-run mode, see common.WithDryRun, are not failures.
+func (p *Plan) Err() error {
+	var errs []error
+	for _, change := range p.Changes {
+		if change.Err != nil && !errors.Is(change.Err, common.ErrDryRun) {
+			errs = append(errs, fmt.Errorf("%s %s: %w", change.Action, change.SKU, change.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Destination is a channel the catalog is pushed to.
+type Destination interface {
+	// List returns the items the destination currently holds.
+	List(ctx context.Context) ([]Item, error)
+	// Apply makes the plan's changes, setting Err on each change that fails.
+	// The returned error is for failures that stop the whole plan.
+	Apply(ctx context.Context, plan *Plan) error
+}
+
+type DiffOptions struct {
+	// Prune deletes destination items whose SKU is not in the catalog.
+	// Without it they are left alone.
+	Prune bool
+	// HideInsteadOfDelete turns Prune's deletes into updates that set the
+	// destination item's Visible to false, so nothing is ever deleted.
+	HideInsteadOfDelete bool
+	// IgnoreHidden leaves hidden catalog items out of the plan entirely: they
+	// are not created or updated, and Prune does not touch destination items
+	// with their SKUs. It takes precedence over UnhideOnMatch.
+	IgnoreHidden bool
+	// UnhideOnMatch makes every destination item that matches a catalog item
+	// visible, even when the catalog item is hidden, for channels that keep
+	// selling products the storefront hides. It also brings back items hidden
+	// by HideInsteadOfDelete once they return to the catalog.
+	UnhideOnMatch bool
+}
+
+// Diff plans the changes from current, the destination's items, to desired.
+func Diff(desired, current []Item, opts DiffOptions) *Plan {
+	existing := make(map[string]Item, len(current))
+	for _, item := range current {
+		existing[item.SKU] = item
+	}
+
+	plan := &Plan{}
+	wanted := make(map[string]bool, len(desired))
+	for _, item := range desired {
+		wanted[item.SKU] = true
+		if opts.IgnoreHidden && !item.Visible {
+			continue
+		}
+
+		previous, ok := existing[item.SKU]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Action: ActionCreate, SKU: item.SKU, Item: item})
+			continue
+		}
+		if opts.UnhideOnMatch {
+			item.Visible = true
+		}
+		if fields := changedFields(previous, item); len(fields) > 0 {
+			plan.Changes = append(plan.Changes, Change{Action: ActionUpdate, SKU: item.SKU, Item: item, Previous: &previous, Fields: fields})
+		}
+	}
+
+	if opts.Prune {
+		for _, item := range current {
+			if wanted[item.SKU] {
+				continue
+			}
+			if !opts.HideInsteadOfDelete {
+				plan.Changes = append(plan.Changes, Change{Action: ActionDelete, SKU: item.SKU, Item: item})
+				continue
+			}
+			if item.Visible {
+				previous := item
+				item.Visible = false
+				plan.Changes = append(plan.Changes, Change{Action: ActionUpdate, SKU: item.SKU, Item: item, Previous: &previous, Fields: []string{"visible"}})
+			}
+		}
+	}
+
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		return plan.Changes[i].SKU < plan.Changes[j].SKU
+	})
+	return plan
+}
+
+// changedFields compares the fields a destination is expected to store. IDs
+// are not compared since destinations may not keep them.
+func changedFields(previous, desired Item) []string {
+	var fields []string
+	if previous.Name != desired.Name {
+		fields = append(fields, "name")
+	}
+	if !sameAmount(previous.Price, desired.Price) {
+		fields = append(fields, "price")
+	}
+	if previous.Quantity != desired.Quantity {
+		fields = append(fields, "quantity")
+	}
+	if previous.Unlimited != desired.Unlimited {
+		fields = append(fields, "unlimited")
+	}
+	if previous.Visible != desired.Visible {
+		fields = append(fields, "visible")
+	}
+	if previous.URL != desired.URL {
+		fields = append(fields, "url")
+	}
+	return fields
+}
+
+// sameAmount compares amounts numerically, so "10.0" matches "10.00".
+func sameAmount(a, b common.Amount) bool {
+	if a == b {
+		return true
+	}
+	cmp, err := a.Cmp(b)
+	return err == nil && cmp == 0
+}
+
+type Options struct {
+	// Params selects the products to sync.
+	Params common.QueryParams
+	DiffOptions
+	// DryRun returns the plan without applying it. To see the requests a
+	// RESTDestination would send instead, set DryRun on Sync's config, or
+	// apply the plan under a context carrying common.WithDryRun.
+	DryRun bool
+	// SKUPolicy, when set, stops Sync before the destination is touched if
+	// any catalog SKU violates it, since most destinations key items by SKU.
+	SKUPolicy *products.SKUPolicy
+}
+
+// Sync snapshots the catalog, diffs it against dest and applies the plan.
+// Change failures are recorded on the plan and joined into the returned
+// error. The plan is applied under the config's DryRun and ReadOnly modes, so
+// a destination that sends through common.Do, such as RESTDestination, honors
+// them too.
+func Sync(ctx context.Context, config *common.Config, dest Destination, opts Options) (*Plan, error) {
+	catalog, err := Snapshot(ctx, config, opts.Params)
+	if err != nil {
+		return nil, err
+	}
+	if opts.SKUPolicy != nil {
+		if err := catalog.ValidateSKUs(*opts.SKUPolicy); err != nil {
+			return nil, err
+		}
+	}
+
+	current, err := dest.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list destination items: %w", err)
+	}
+
+	plan := Diff(catalog.Items, current, opts.DiffOptions)
+	plan.DryRun = opts.DryRun
+	if opts.DryRun || len(plan.Changes) == 0 {
+		return plan, nil
+	}
+
+	var modes []common.RequestOption
+	if config.ReadOnly {
+		modes = append(modes, common.WithReadOnly())
+	}
+	if config.DryRun {
+		modes = append(modes, common.WithDryRun(config.DryRunLog))
+	}
+	if err := dest.Apply(common.WithRequestOptions(ctx, modes...), plan); err != nil {
+		return plan, fmt.Errorf("failed to apply plan: %w", err)
+	}
+	return plan, plan.Err()
+}
@@ -0,0 +1,193 @@
+package catalogsync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/mocks"
+	"github.com/j-low/gocommerce/products"
+)
+
+func usd(value string) common.Amount {
+	return common.Amount{Currency: "USD", Value: value}
+}
+
+func TestDiff(t *testing.T) {
+	desired := []Item{
+		{SKU: "A", Name: "Mug", Price: usd("10.00"), Quantity: 3},
+		{SKU: "B", Name: "Tee", Price: usd("20.00"), Quantity: 1, Visible: true},
+		{SKU: "C", Name: "Cap", Price: usd("5.00")},
+	}
+	current := []Item{
+		{SKU: "A", Name: "Mug", Price: usd("10.0"), Quantity: 3},
+		{SKU: "B", Name: "T-shirt", Price: usd("20.00"), Quantity: 4, Visible: true},
+		{SKU: "Z", Name: "Old", Visible: true},
+	}
+
+	tests := []struct {
+		name string
+		opts DiffOptions
+		want []Change
+	}{
+		{
+			name: "without pruning",
+			want: []Change{
+				{Action: ActionUpdate, SKU: "B", Item: desired[1], Previous: &current[1], Fields: []string{"name", "quantity"}},
+				{Action: ActionCreate, SKU: "C", Item: desired[2]},
+			},
+		},
+		{
+			name: "with pruning",
+			opts: DiffOptions{Prune: true},
+			want: []Change{
+				{Action: ActionUpdate, SKU: "B", Item: desired[1], Previous: &current[1], Fields: []string{"name", "quantity"}},
+				{Action: ActionCreate, SKU: "C", Item: desired[2]},
+				{Action: ActionDelete, SKU: "Z", Item: current[2]},
+			},
+		},
+		{
+			name: "ignoring hidden items",
+			opts: DiffOptions{Prune: true, IgnoreHidden: true, UnhideOnMatch: true},
+			want: []Change{
+				{Action: ActionUpdate, SKU: "B", Item: desired[1], Previous: &current[1], Fields: []string{"name", "quantity"}},
+				{Action: ActionDelete, SKU: "Z", Item: current[2]},
+			},
+		},
+		{
+			name: "hiding instead of deleting",
+			opts: DiffOptions{Prune: true, HideInsteadOfDelete: true},
+			want: []Change{
+				{Action: ActionUpdate, SKU: "B", Item: desired[1], Previous: &current[1], Fields: []string{"name", "quantity"}},
+				{Action: ActionCreate, SKU: "C", Item: desired[2]},
+				{Action: ActionUpdate, SKU: "Z", Item: Item{SKU: "Z", Name: "Old"}, Previous: &current[2], Fields: []string{"visible"}},
+			},
+		},
+		{
+			name: "unhiding matches",
+			opts: DiffOptions{UnhideOnMatch: true},
+			want: []Change{
+				{Action: ActionUpdate, SKU: "A", Item: Item{SKU: "A", Name: "Mug", Price: usd("10.00"), Quantity: 3, Visible: true}, Previous: &current[0], Fields: []string{"visible"}},
+				{Action: ActionUpdate, SKU: "B", Item: desired[1], Previous: &current[1], Fields: []string{"name", "quantity"}},
+				{Action: ActionCreate, SKU: "C", Item: desired[2]},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := Diff(desired, current, tt.opts)
+			if !reflect.DeepEqual(plan.Changes, tt.want) {
+				t.Errorf("Diff() =\n%+v\nwant\n%+v", plan.Changes, tt.want)
+			}
+		})
+	}
+}
+
+func TestSync(t *testing.T) {
+	server := mocks.NewServer()
+	defer server.Close()
+
+	if err := server.Seed(mocks.Products,
+		products.Product{ID: "p1", Name: "Mug", IsVisible: true, Variants: []products.ProductVariant{
+			{ID: "v1", SKU: "MUG", Pricing: products.Pricing{BasePrice: usd("12.00"), OnSale: true, SalePrice: usd("9.00")}, Stock: products.Stock{Quantity: 5}},
+			{ID: "v2", Pricing: products.Pricing{BasePrice: usd("12.00")}},
+		}},
+		products.Product{ID: "p2", Name: "Tee", Variants: []products.ProductVariant{
+			{ID: "v3", SKU: "TEE", Pricing: products.Pricing{BasePrice: usd("20.00")}, Stock: products.Stock{Unlimited: true}},
+		}},
+	); err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	catalog, err := Snapshot(context.Background(), server.Config(), common.QueryParams{})
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if len(catalog.Items) != 2 || len(catalog.Skipped) != 1 || catalog.Skipped[0].VariantID != "v2" {
+		t.Fatalf("unexpected catalog %+v", catalog)
+	}
+	if catalog.Items[0].Price != usd("9.00") || !catalog.Items[0].Visible {
+		t.Errorf("expected the sale price and visibility, got %+v", catalog.Items[0])
+	}
+
+	dest := NewCSVDestination(filepath.Join(t.TempDir(), "feed.csv"))
+
+	strict := products.SKUPolicy{Pattern: regexp.MustCompile(`^[A-Z]+-[0-9]+$`)}
+	if _, err := Sync(context.Background(), server.Config(), dest, Options{SKUPolicy: &strict}); err == nil || !strings.Contains(err.Error(), `"MUG"`) {
+		t.Errorf("expected Sync to reject SKUs violating the policy, got %v", err)
+	}
+	if items, _ := dest.List(context.Background()); len(items) != 0 {
+		t.Errorf("expected a rejected sync to leave the destination empty, got %+v", items)
+	}
+
+	plan, err := Sync(context.Background(), server.Config(), dest, Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("Sync() dry run error = %v", err)
+	}
+	if len(plan.Changes) != 2 || !plan.DryRun {
+		t.Errorf("expected a dry-run plan with 2 creates, got %+v", plan)
+	}
+	if items, _ := dest.List(context.Background()); len(items) != 0 {
+		t.Errorf("expected dry run to leave the destination empty, got %+v", items)
+	}
+
+	if _, err := Sync(context.Background(), server.Config(), dest, Options{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	items, err := dest.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if !reflect.DeepEqual(items, catalog.Items) {
+		t.Errorf("expected destination to match the catalog\ngot  %+v\nwant %+v", items, catalog.Items)
+	}
+
+	plan, err = Sync(context.Background(), server.Config(), dest, Options{})
+	if err != nil || len(plan.Changes) != 0 {
+		t.Errorf("expected an empty plan once in sync, got %+v, %v", plan, err)
+	}
+}
+
+func TestSyncAppliesConfigModes(t *testing.T) {
+	server := mocks.NewServer()
+	defer server.Close()
+	if err := server.Seed(mocks.Products, products.Product{ID: "p1", Name: "Mug", Variants: []products.ProductVariant{
+		{ID: "v1", SKU: "MUG", Pricing: products.Pricing{BasePrice: usd("12.00")}},
+	}}); err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	feed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer feed.Close()
+	dest := NewRESTDestination(feed.URL+"/items", feed.Client())
+
+	var logged []common.DryRunRequest
+	config := server.Config()
+	config.DryRun = true
+	config.DryRunLog = func(r common.DryRunRequest) { logged = append(logged, r) }
+	if _, err := Sync(context.Background(), config, dest, Options{}); err != nil {
+		t.Fatalf("Sync() dry run error = %v", err)
+	}
+	if len(logged) != 1 || logged[0].Method != http.MethodPost {
+		t.Errorf("expected the create to be logged, got %+v", logged)
+	}
+
+	config = server.Config()
+	config.ReadOnly = true
+	if _, err := Sync(context.Background(), config, dest, Options{}); !errors.Is(err, common.ErrReadOnlyMode) {
+		t.Errorf("expected ErrReadOnlyMode, got %v", err)
+	}
+}
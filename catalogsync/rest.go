@@ -0,0 +1,116 @@
+package catalogsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/j-low/gocommerce/bulk"
+	"github.com/j-low/gocommerce/common"
+)
+
+// RESTDestination pushes items to a JSON API that exposes them as a
+// collection at BaseURL:
+//
+//	GET    {BaseURL}        a JSON array of items
+//	POST   {BaseURL}        create an item
+//	PUT    {BaseURL}/{sku}  replace an item
+//	DELETE {BaseURL}/{sku}  delete an item
+//
+// Items are encoded with Item's JSON field names. Adapt other APIs by
+// wrapping the destination's http.Client transport.
+type RESTDestination struct {
+	BaseURL string
+	Client  *http.Client
+	// Header is added to every request, e.g. for authentication.
+	Header http.Header
+	// Concurrency and the other bulk options bound how changes are sent.
+	Bulk bulk.Options
+}
+
+func NewRESTDestination(baseURL string, client *http.Client) *RESTDestination {
+	if client == nil {
+		client = common.DefaultClient()
+	}
+	return &RESTDestination{BaseURL: strings.TrimSuffix(baseURL, "/"), Client: client}
+}
+
+func (d *RESTDestination) List(ctx context.Context) ([]Item, error) {
+	body, err := d.do(ctx, http.MethodGet, d.BaseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+	return items, nil
+}
+
+func (d *RESTDestination) Apply(ctx context.Context, plan *Plan) error {
+	report := bulk.Run(ctx, plan.Changes, func(ctx context.Context, _ int, change Change) (struct{}, error) {
+		itemURL := d.BaseURL + "/" + url.PathEscape(change.SKU)
+		var err error
+		switch change.Action {
+		case ActionCreate:
+			_, err = d.do(ctx, http.MethodPost, d.BaseURL, change.Item)
+		case ActionUpdate:
+			_, err = d.do(ctx, http.MethodPut, itemURL, change.Item)
+		case ActionDelete:
+			_, err = d.do(ctx, http.MethodDelete, itemURL, nil)
+		default:
+			err = fmt.Errorf("unknown action %q", change.Action)
+		}
+		return struct{}{}, err
+	}, d.Bulk)
+
+	for i, result := range report.Results {
+		plan.Changes[i].Err = result.Err
+	}
+	return nil
+}
+
+func (d *RESTDestination) do(ctx context.Context, method, rawURL string, payload interface{}) ([]byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range d.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := common.Do(&common.Config{Client: d.Client}, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s %s: status: %d, body: %s", method, rawURL, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,121 @@
+package catalogsync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestRESTDestination(t *testing.T) {
+	var mu sync.Mutex
+	items := map[string]Item{"OLD": {SKU: "OLD", Name: "Old"}, "B": {SKU: "B", Name: "T-shirt"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer dest-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		sku := strings.TrimPrefix(r.URL.Path, "/items/")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/items":
+			list := []Item{}
+			for _, item := range items {
+				list = append(list, item)
+			}
+			json.NewEncoder(w).Encode(list)
+		case r.Method == http.MethodPost && r.URL.Path == "/items":
+			var item Item
+			json.NewDecoder(r.Body).Decode(&item)
+			items[item.SKU] = item
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut && sku == "FAIL":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"error":"rejected"}`))
+		case r.Method == http.MethodPut:
+			var item Item
+			json.NewDecoder(r.Body).Decode(&item)
+			items[sku] = item
+		case r.Method == http.MethodDelete:
+			delete(items, sku)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dest := NewRESTDestination(server.URL+"/items/", server.Client())
+	dest.Header = http.Header{"Authorization": []string{"Bearer dest-token"}}
+
+	current, err := dest.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	desired := []Item{{SKU: "A", Name: "Mug"}, {SKU: "B", Name: "Tee"}}
+	plan := Diff(desired, current, DiffOptions{Prune: true})
+	plan.Changes = append(plan.Changes, Change{Action: ActionUpdate, SKU: "FAIL", Item: Item{SKU: "FAIL"}})
+
+	if err := dest.Apply(context.Background(), plan); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	err = plan.Err()
+	if err == nil || !strings.Contains(err.Error(), "update FAIL: PUT") || !strings.Contains(err.Error(), "status: 422") {
+		t.Errorf("expected the failed update to be reported, got %v", err)
+	}
+
+	if len(items) != 2 || items["A"].Name != "Mug" || items["B"].Name != "Tee" {
+		t.Errorf("unexpected destination items %+v", items)
+	}
+}
+
+func TestRESTDestinationDryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s %s in dry-run mode", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	dest := NewRESTDestination(server.URL+"/items", server.Client())
+	plan := Diff([]Item{{SKU: "A", Name: "Mug"}}, []Item{{SKU: "OLD"}}, DiffOptions{Prune: true})
+
+	var mu sync.Mutex
+	var logged []common.DryRunRequest
+	ctx := common.WithRequestOptions(context.Background(), common.WithDryRun(func(r common.DryRunRequest) {
+		mu.Lock()
+		defer mu.Unlock()
+		logged = append(logged, r)
+	}))
+	if err := dest.Apply(ctx, plan); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if err := plan.Err(); err != nil {
+		t.Errorf("expected dry-run changes not to count as failures, got %v", err)
+	}
+
+	if len(logged) != 2 {
+		t.Fatalf("expected 2 logged requests, got %+v", logged)
+	}
+	for _, r := range logged {
+		switch r.Method {
+		case http.MethodPost:
+			if !strings.Contains(string(r.Body), `"sku":"A"`) {
+				t.Errorf("expected the create payload to be logged, got %s", r.Body)
+			}
+		case http.MethodDelete:
+			if !strings.HasSuffix(r.URL, "/items/OLD") {
+				t.Errorf("unexpected delete URL %s", r.URL)
+			}
+		default:
+			t.Errorf("unexpected logged request %+v", r)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package cdc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// Checkpointer persists the watermark each resource has been polled up to, so
+// a restarted poller resumes where it stopped.
+type Checkpointer interface {
+	// Load returns the resource's watermark, or the zero time if none has
+	// been saved.
+	Load(ctx context.Context, resource Resource) (time.Time, error)
+	Save(ctx context.Context, resource Resource, watermark time.Time) error
+}
+
+// MemoryCheckpointer keeps watermarks for the life of the process.
+type MemoryCheckpointer struct {
+	mu         sync.Mutex
+	watermarks map[Resource]time.Time
+}
+
+func NewMemoryCheckpointer() *MemoryCheckpointer {
+	return &MemoryCheckpointer{watermarks: make(map[Resource]time.Time)}
+}
+
+func (c *MemoryCheckpointer) Load(ctx context.Context, resource Resource) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.watermarks[resource], nil
+}
+
+func (c *MemoryCheckpointer) Save(ctx context.Context, resource Resource, watermark time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.watermarks[resource] = watermark
+	return nil
+}
+
+// FileCheckpointer keeps watermarks in a JSON file, replaced atomically on
+// each save. It is safe for one process at a time.
+type FileCheckpointer struct {
+	path string
+
+	mu         sync.Mutex
+	watermarks map[Resource]time.Time
+}
+
+func NewFileCheckpointer(path string) *FileCheckpointer {
+	return &FileCheckpointer{path: path}
+}
+
+func (c *FileCheckpointer) Load(ctx context.Context, resource Resource) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.read(); err != nil {
+		return time.Time{}, err
+	}
+	return c.watermarks[resource], nil
+}
+
+func (c *FileCheckpointer) Save(ctx context.Context, resource Resource, watermark time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.read(); err != nil {
+		return err
+	}
+
+	watermarks := make(map[Resource]time.Time, len(c.watermarks)+1)
+	for r, w := range c.watermarks {
+		watermarks[r] = w
+	}
+	watermarks[resource] = watermark
+
+	data, err := json.MarshalIndent(watermarks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoints: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to replace checkpoint file: %w", err)
+	}
+
+	c.watermarks = watermarks
+	return nil
+}
+
+// read loads the file on first use; a missing file holds no watermarks.
+func (c *FileCheckpointer) read() error {
+	if c.watermarks != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		c.watermarks = make(map[Resource]time.Time)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+
+	watermarks := make(map[Resource]time.Time)
+	if err := json.Unmarshal(data, &watermarks); err != nil {
+		return fmt.Errorf("failed to unmarshal checkpoint file %s: %w", c.path, err)
+	}
+	c.watermarks = watermarks
+	return nil
+}
+
+type SQLOptions struct {
+	// Table holds one row per resource. Defaults to "gocommerce_checkpoints".
+	Table string
+	// DollarPlaceholders uses $1-style placeholders, as PostgreSQL requires,
+	// instead of ?.
+	DollarPlaceholders bool
+}
+
+// SQLCheckpointer keeps watermarks in a database table, so several processes
+// can share them. The driver is left to the caller; watermarks are stored as
+// RFC 3339 text in UTC so the schema works on any database. Pollers sharing a
+// resource should not run concurrently, since a save is an update followed by
+// an insert when no row exists yet.
+type SQLCheckpointer struct {
+	db          *sql.DB
+	table       string
+	loadQuery   string
+	updateQuery string
+	insertQuery string
+}
+
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+func NewSQLCheckpointer(db *sql.DB, opts SQLOptions) (*SQLCheckpointer, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db is required")
+	}
+
+	table := opts.Table
+	if table == "" {
+		table = "gocommerce_checkpoints"
+	}
+	if !sqlIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name: %s", table)
+	}
+
+	p1, p2 := "?", "?"
+	if opts.DollarPlaceholders {
+		p1, p2 = "$1", "$2"
+	}
+
+	return &SQLCheckpointer{
+		db:          db,
+		table:       table,
+		loadQuery:   fmt.Sprintf("SELECT watermark FROM %s WHERE resource = %s", table, p1),
+		updateQuery: fmt.Sprintf("UPDATE %s SET watermark = %s WHERE resource = %s", table, p1, p2),
+		insertQuery: fmt.Sprintf("INSERT INTO %s (watermark, resource) VALUES (%s, %s)", table, p1, p2),
+	}, nil
+}
+
+// CreateTable creates the checkpoint table if it does not exist.
+func (c *SQLCheckpointer) CreateTable(ctx context.Context) error {
+	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (resource VARCHAR(64) PRIMARY KEY, watermark VARCHAR(64) NOT NULL)", c.table)
+	if _, err := c.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create checkpoint table: %w", err)
+	}
+	return nil
+}
+
+func (c *SQLCheckpointer) Load(ctx context.Context, resource Resource) (time.Time, error) {
+	var value string
+	err := c.db.QueryRowContext(ctx, c.loadQuery, string(resource)).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to load %s checkpoint: %w", resource, err)
+	}
+
+	watermark, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse %s checkpoint: %w", resource, err)
+	}
+	return watermark, nil
+}
+
+func (c *SQLCheckpointer) Save(ctx context.Context, resource Resource, watermark time.Time) error {
+	value := watermark.UTC().Format(time.RFC3339Nano)
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to save %s checkpoint: %w", resource, err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, c.updateQuery, value, string(resource))
+	if err != nil {
+		return fmt.Errorf("failed to save %s checkpoint: %w", resource, err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to save %s checkpoint: %w", resource, err)
+	}
+	if updated == 0 {
+		if _, err := tx.ExecContext(ctx, c.insertQuery, value, string(resource)); err != nil {
+			return fmt.Errorf("failed to save %s checkpoint: %w", resource, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save %s checkpoint: %w", resource, err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package cdc
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileCheckpointer(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+	watermark := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	c := NewFileCheckpointer(path)
+	if got, err := c.Load(ctx, ResourceOrders); err != nil || !got.IsZero() {
+		t.Fatalf("Load() = %v, %v, want zero time without a file", got, err)
+	}
+	if err := c.Save(ctx, ResourceOrders, watermark); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := c.Save(ctx, ResourceProducts, watermark.Add(time.Hour)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	restarted := NewFileCheckpointer(path)
+	if got, err := restarted.Load(ctx, ResourceOrders); err != nil || !got.Equal(watermark) {
+		t.Errorf("Load(orders) = %v, %v, want %v", got, err, watermark)
+	}
+	if got, err := restarted.Load(ctx, ResourceProducts); err != nil || !got.Equal(watermark.Add(time.Hour)) {
+		t.Errorf("Load(products) = %v, %v, want %v", got, err, watermark.Add(time.Hour))
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("expected only the checkpoint file to remain, got %d entries", len(entries))
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileCheckpointer(path).Load(ctx, ResourceOrders); err == nil {
+		t.Error("expected an error for a corrupt checkpoint file")
+	}
+}
+
+func TestSQLCheckpointer(t *testing.T) {
+	ctx := context.Background()
+	db := sql.OpenDB(&fakeConnector{rows: make(map[string]string)})
+	defer db.Close()
+
+	if _, err := NewSQLCheckpointer(db, SQLOptions{Table: "checkpoints; DROP TABLE orders"}); err == nil {
+		t.Error("expected an invalid table name to be rejected")
+	}
+
+	c, err := NewSQLCheckpointer(db, SQLOptions{DollarPlaceholders: true})
+	if err != nil {
+		t.Fatalf("NewSQLCheckpointer() error = %v", err)
+	}
+	if err := c.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+
+	if got, err := c.Load(ctx, ResourceOrders); err != nil || !got.IsZero() {
+		t.Fatalf("Load() = %v, %v, want zero time without a row", got, err)
+	}
+
+	watermark := time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("EST", -5*3600))
+	for _, w := range []time.Time{watermark, watermark.Add(time.Minute)} {
+		if err := c.Save(ctx, ResourceOrders, w); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	got, err := c.Load(ctx, ResourceOrders)
+	if err != nil || !got.Equal(watermark.Add(time.Minute)) {
+		t.Errorf("Load() = %v, %v, want %v", got, err, watermark.Add(time.Minute))
+	}
+}
+
+// fakeConnector is a database/sql driver understanding only the statements
+// SQLCheckpointer issues.
+type fakeConnector struct {
+	mu   sync.Mutex
+	rows map[string]string
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{c}, nil }
+func (c *fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ c *fakeConnector }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	if strings.Contains(query, "?") {
+		return nil, fmt.Errorf("expected $n placeholders in %q", query)
+	}
+	return &fakeStmt{c: c.c, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	c     *fakeConnector
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return strings.Count(s.query, "$") }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(s.query, "CREATE TABLE"):
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(s.query, "UPDATE"):
+		resource := args[1].(string)
+		if _, ok := s.c.rows[resource]; !ok {
+			return driver.RowsAffected(0), nil
+		}
+		s.c.rows[resource] = args[0].(string)
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(s.query, "INSERT"):
+		s.c.rows[args[1].(string)] = args[0].(string)
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unexpected statement %q", s.query)
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	rows := &fakeRows{}
+	if value, ok := s.c.rows[args[0].(string)]; ok {
+		rows.values = []string{value}
+	}
+	return rows, nil
+}
+
+type fakeRows struct{ values []string }
+
+func (r *fakeRows) Columns() []string { return []string{"watermark"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
@@ -0,0 +1,241 @@
+// Package cdc polls the Commerce APIs for changed records and emits them as
+// change events, for data that webhooks do not cover.
+package cdc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/inventory"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/products"
+	"github.com/j-low/gocommerce/transactions"
+)
+
+type Resource string
+
+const (
+	ResourceOrders       Resource = "orders"
+	ResourceProducts     Resource = "products"
+	ResourceInventory    Resource = "inventory"
+	ResourceTransactions Resource = "transactions"
+)
+
+const defaultPollInterval = time.Minute
+
+var allResources = []Resource{ResourceOrders, ResourceProducts, ResourceInventory, ResourceTransactions}
+
+// Change is one changed record. Exactly one of Order, Product, Inventory and
+// Transaction is set, matching Resource.
+type Change struct {
+	Resource    Resource
+	ID          string
+	Order       *orders.Order
+	Product     *products.Product
+	Inventory   *inventory.InventoryRecord
+	Transaction *transactions.Document
+	// PreviousInventory is the record from the prior poll for inventory
+	// changes, or nil for a variant not seen before.
+	PreviousInventory *inventory.InventoryRecord
+	// InventoryChange classifies inventory changes. Variants that disappear
+	// between polls are not reported.
+	InventoryChange *inventory.Change
+}
+
+type Options struct {
+	// Interval is the delay between polls. Defaults to 1m.
+	Interval time.Duration
+	// Resources selects what to poll. Defaults to every resource.
+	Resources []Resource
+	// Checkpointer persists watermarks. Defaults to a MemoryCheckpointer.
+	Checkpointer Checkpointer
+	// Start is the watermark used for a resource without a saved one.
+	// Defaults to the first poll's start, so history is not replayed.
+	Start time.Time
+	// OnChange and Changes receive each change; at least one must be set.
+	// Sends on Changes block the poller until received. An OnChange error
+	// stops the resource's poll before its watermark is saved, so the
+	// changes are delivered again on the next poll.
+	OnChange func(context.Context, Change) error
+	Changes  chan<- Change
+	// OnError receives poll failures. The poller keeps polling after an error
+	// either way.
+	OnError func(error)
+}
+
+// Poller emits changes by polling orders, products and transactions with
+// modifiedAfter windows that start at each resource's watermark. Inventory has
+// no modification time, so it is diffed against the previous poll instead and
+// its first poll only establishes a baseline. Delivery is at least once.
+type Poller struct {
+	config    *common.Config
+	opts      Options
+	inventory []inventory.InventoryRecord
+	now       func() time.Time
+}
+
+func NewPoller(config *common.Config, opts Options) (*Poller, error) {
+	if opts.OnChange == nil && opts.Changes == nil {
+		return nil, fmt.Errorf("OnChange or Changes is required")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultPollInterval
+	}
+	if len(opts.Resources) == 0 {
+		opts.Resources = allResources
+	}
+	for _, resource := range opts.Resources {
+		switch resource {
+		case ResourceOrders, ResourceProducts, ResourceInventory, ResourceTransactions:
+		default:
+			return nil, fmt.Errorf("unsupported resource: %s", resource)
+		}
+	}
+	if opts.Checkpointer == nil {
+		opts.Checkpointer = NewMemoryCheckpointer()
+	}
+
+	return &Poller{config: config, opts: opts, now: time.Now}, nil
+}
+
+// Run polls every opts.Interval, starting immediately, until ctx is done and
+// returns the context's error.
+func (p *Poller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Poll(ctx); err != nil && ctx.Err() == nil && p.opts.OnError != nil {
+			p.opts.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll runs a single pass over every resource, stopping at the first failure.
+func (p *Poller) Poll(ctx context.Context) error {
+	if p.opts.Start.IsZero() {
+		p.opts.Start = p.now()
+	}
+
+	for _, resource := range p.opts.Resources {
+		var err error
+		if resource == ResourceInventory {
+			err = p.pollInventory(ctx)
+		} else {
+			err = p.pollWindow(ctx, resource)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to poll %s: %w", resource, err)
+		}
+	}
+	return nil
+}
+
+func (p *Poller) pollWindow(ctx context.Context, resource Resource) error {
+	from, err := p.opts.Checkpointer.Load(ctx, resource)
+	if err != nil {
+		return fmt.Errorf("failed to load watermark: %w", err)
+	}
+	if from.IsZero() {
+		from = p.opts.Start
+	}
+	// The API compares whole seconds, so the window ends on one.
+	to := p.now().Truncate(time.Second)
+	if !from.Before(to) {
+		return nil
+	}
+
+	switch resource {
+	case ResourceOrders:
+		it := orders.RetrieveAllOrdersIter(ctx, p.config, orders.ListParams{ModifiedAfter: from, ModifiedBefore: to}.QueryParams())
+		for it.Next() {
+			order := it.Value()
+			if err := p.emit(ctx, Change{Resource: resource, ID: order.ID, Order: &order}); err != nil {
+				return err
+			}
+		}
+		err = it.Err()
+	case ResourceProducts:
+		it := products.RetrieveAllProductsIter(ctx, p.config, products.ListParams{ModifiedAfter: from, ModifiedBefore: to}.QueryParams())
+		for it.Next() {
+			product := it.Value()
+			if err := p.emit(ctx, Change{Resource: resource, ID: product.ID, Product: &product}); err != nil {
+				return err
+			}
+		}
+		err = it.Err()
+	case ResourceTransactions:
+		it := transactions.RetrieveAllTransactionsIter(ctx, p.config, transactions.ListParams{ModifiedAfter: from, ModifiedBefore: to}.QueryParams())
+		for it.Next() {
+			document := it.Value()
+			if err := p.emit(ctx, Change{Resource: resource, ID: document.ID, Transaction: &document}); err != nil {
+				return err
+			}
+		}
+		err = it.Err()
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := p.opts.Checkpointer.Save(ctx, resource, to); err != nil {
+		return fmt.Errorf("failed to save watermark: %w", err)
+	}
+	return nil
+}
+
+func (p *Poller) pollInventory(ctx context.Context) error {
+	var current []inventory.InventoryRecord
+
+	it := inventory.RetrieveAllInventoryIter(ctx, p.config, inventory.ListParams{}.QueryParams())
+	for it.Next() {
+		current = append(current, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	if p.inventory != nil {
+		for _, diff := range inventory.Diff(p.inventory, current) {
+			if diff.Kind == inventory.ChangeRemoved {
+				continue
+			}
+
+			diff := diff
+			change := Change{Resource: ResourceInventory, ID: diff.VariantID, Inventory: diff.New, PreviousInventory: diff.Old, InventoryChange: &diff}
+			if err := p.emit(ctx, change); err != nil {
+				return err
+			}
+		}
+	}
+
+	if current == nil {
+		current = []inventory.InventoryRecord{}
+	}
+	p.inventory = current
+	return nil
+}
+
+func (p *Poller) emit(ctx context.Context, change Change) error {
+	if p.opts.OnChange != nil {
+		if err := p.opts.OnChange(ctx, change); err != nil {
+			return fmt.Errorf("change %s %s: %w", change.Resource, change.ID, err)
+		}
+	}
+	if p.opts.Changes != nil {
+		select {
+		case p.opts.Changes <- change:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package cdc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/inventory"
+)
+
+func TestPoll(t *testing.T) {
+	var mu sync.Mutex
+	var windows []string
+	inventoryPolls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/commerce/orders"):
+			windows = append(windows, r.URL.Query().Get("modifiedAfter")+"/"+r.URL.Query().Get("modifiedBefore"))
+			fmt.Fprintf(w, `{"result": [{"id": "order-%d"}], "pagination": {"hasNextPage": false}}`, len(windows))
+		case strings.HasSuffix(r.URL.Path, "/commerce/inventory"):
+			inventoryPolls++
+			quantity := 5
+			if inventoryPolls > 1 {
+				quantity = 3
+			}
+			fmt.Fprintf(w, `{"inventory": [{"variantId": "v1", "quantity": %d}, {"variantId": "v2", "quantity": 1}], "pagination": {"hasNextPage": false}}`, quantity)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var changes []Change
+	checkpointer := NewMemoryCheckpointer()
+	poller, err := NewPoller(config, Options{
+		Resources:    []Resource{ResourceOrders, ResourceInventory},
+		Checkpointer: checkpointer,
+		Start:        start,
+		OnChange: func(ctx context.Context, change Change) error {
+			changes = append(changes, change)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewPoller() error = %v", err)
+	}
+
+	now := start.Add(time.Hour)
+	poller.now = func() time.Time { return now }
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	now = now.Add(time.Hour)
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	wantWindows := []string{
+		"2024-01-01T00:00:00Z/2024-01-01T01:00:00Z",
+		"2024-01-01T01:00:00Z/2024-01-01T02:00:00Z",
+	}
+	if strings.Join(windows, ",") != strings.Join(wantWindows, ",") {
+		t.Errorf("expected windows %v, got %v", wantWindows, windows)
+	}
+
+	var got []string
+	for _, change := range changes {
+		got = append(got, string(change.Resource)+":"+change.ID)
+	}
+	want := []string{"orders:order-1", "orders:order-2", "inventory:v1"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected changes %v, got %v", want, got)
+	}
+	if prev, cur := changes[2].PreviousInventory, changes[2].Inventory; prev == nil || prev.Quantity != 5 || cur.Quantity != 3 {
+		t.Errorf("unexpected inventory change %+v -> %+v", prev, cur)
+	}
+	if diff := changes[2].InventoryChange; diff == nil || diff.Kind != inventory.ChangeQuantity || diff.Delta != -2 {
+		t.Errorf("unexpected inventory classification %+v", diff)
+	}
+
+	watermark, _ := checkpointer.Load(context.Background(), ResourceOrders)
+	if !watermark.Equal(now) {
+		t.Errorf("expected watermark %v, got %v", now, watermark)
+	}
+}
+
+func TestPollChangeErrorKeepsWatermark(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"documents": [{"id": "txn-1"}], "pagination": {"hasNextPage": false}}`)
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	checkpointer := NewMemoryCheckpointer()
+	poller, err := NewPoller(config, Options{
+		Resources:    []Resource{ResourceTransactions},
+		Checkpointer: checkpointer,
+		Start:        start,
+		OnChange:     func(context.Context, Change) error { return errors.New("sink unavailable") },
+	})
+	if err != nil {
+		t.Fatalf("NewPoller() error = %v", err)
+	}
+	poller.now = func() time.Time { return start.Add(time.Hour) }
+
+	err = poller.Poll(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "sink unavailable") {
+		t.Fatalf("expected sink error, got %v", err)
+	}
+	if watermark, _ := checkpointer.Load(context.Background(), ResourceTransactions); !watermark.IsZero() {
+		t.Errorf("expected no watermark to be saved, got %v", watermark)
+	}
+}
+
+func TestRunChannel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"products": [{"id": "product-1"}], "pagination": {"hasNextPage": false}}`)
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	changes := make(chan Change)
+	poller, err := NewPoller(config, Options{
+		Interval:  time.Millisecond,
+		Resources: []Resource{ResourceProducts},
+		Start:     time.Now().Add(-time.Hour),
+		Changes:   changes,
+	})
+	if err != nil {
+		t.Fatalf("NewPoller() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- poller.Run(ctx) }()
+
+	select {
+	case change := <-changes:
+		if change.Resource != ResourceProducts || change.Product == nil || change.Product.ID != "product-1" {
+			t.Errorf("unexpected change %+v", change)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for change")
+	}
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestNewPollerOptions(t *testing.T) {
+	config := &common.Config{APIKey: "test-key"}
+
+	if _, err := NewPoller(config, Options{}); err == nil {
+		t.Error("expected error without OnChange or Changes")
+	}
+	_, err := NewPoller(config, Options{Resources: []Resource{"customers"}, Changes: make(chan Change)})
+	if err == nil || !strings.Contains(err.Error(), "unsupported resource: customers") {
+		t.Errorf("expected unsupported resource error, got %v", err)
+	}
+}
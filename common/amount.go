@@ -0,0 +1,106 @@
+package common
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Amount arithmetic is exact: values are parsed as rationals and results are
+// formatted with the larger number of decimal places of the operands, rounding
+// halves away from zero. The zero Amount acts as an identity for Add and Sub so
+// totals can be accumulated from an empty value.
+
+func (a Amount) IsZero() bool {
+	if a.Value == "" {
+		return true
+	}
+	r, ok := new(big.Rat).SetString(a.Value)
+	return ok && r.Sign() == 0
+}
+
+// Rat parses the amount's value. An empty value parses as zero.
+func (a Amount) Rat() (*big.Rat, error) {
+	if a.Value == "" {
+		return new(big.Rat), nil
+	}
+	r, ok := new(big.Rat).SetString(a.Value)
+	if !ok || strings.ContainsAny(a.Value, "/eE") {
+		return nil, fmt.Errorf("invalid amount value: %q", a.Value)
+	}
+	return r, nil
+}
+
+func (a Amount) Add(b Amount) (Amount, error) {
+	return a.combine(b, (*big.Rat).Add)
+}
+
+func (a Amount) Sub(b Amount) (Amount, error) {
+	return a.combine(b, (*big.Rat).Sub)
+}
+
+// Mul multiplies the amount by a decimal factor such as "3" or "0.8", keeping
+// the amount's own precision.
+func (a Amount) Mul(factor string) (Amount, error) {
+	x, err := a.Rat()
+	if err != nil {
+		return Amount{}, err
+	}
+	f, ok := new(big.Rat).SetString(factor)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid factor: %q", factor)
+	}
+	return Amount{Currency: a.Currency, Value: new(big.Rat).Mul(x, f).FloatString(decimalPlaces(a.Value))}, nil
+}
+
+// Cmp compares two amounts of the same currency, returning -1, 0 or +1.
+func (a Amount) Cmp(b Amount) (int, error) {
+	if err := checkCurrencies(a, b); err != nil {
+		return 0, err
+	}
+	x, err := a.Rat()
+	if err != nil {
+		return 0, err
+	}
+	y, err := b.Rat()
+	if err != nil {
+		return 0, err
+	}
+	return x.Cmp(y), nil
+}
+
+func (a Amount) combine(b Amount, op func(z, x, y *big.Rat) *big.Rat) (Amount, error) {
+	if err := checkCurrencies(a, b); err != nil {
+		return Amount{}, err
+	}
+	x, err := a.Rat()
+	if err != nil {
+		return Amount{}, err
+	}
+	y, err := b.Rat()
+	if err != nil {
+		return Amount{}, err
+	}
+
+	currency := a.Currency
+	if currency == "" {
+		currency = b.Currency
+	}
+	places := max(decimalPlaces(a.Value), decimalPlaces(b.Value))
+
+	return Amount{Currency: currency, Value: op(new(big.Rat), x, y).FloatString(places)}, nil
+}
+
+func checkCurrencies(a, b Amount) error {
+	if a.Currency != "" && b.Currency != "" && a.Currency != b.Currency {
+		return fmt.Errorf("currency mismatch: %s and %s", a.Currency, b.Currency)
+	}
+	return nil
+}
+
+func decimalPlaces(value string) int {
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		return len(value) - i - 1
+	}
+	return 0
+}
@@ -0,0 +1,113 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAmountArithmetic(t *testing.T) {
+	usd := func(v string) Amount { return Amount{Currency: "USD", Value: v} }
+
+	tests := []struct {
+		name    string
+		op      func() (Amount, error)
+		want    Amount
+		wantErr string
+	}{
+		{
+			name: "add",
+			op:   func() (Amount, error) { return usd("10.10").Add(usd("0.20")) },
+			want: usd("10.30"),
+		},
+		{
+			name: "add keeps widest precision",
+			op:   func() (Amount, error) { return usd("10").Add(usd("0.125")) },
+			want: usd("10.125"),
+		},
+		{
+			name: "add to zero value",
+			op:   func() (Amount, error) { return Amount{}.Add(usd("5.00")) },
+			want: usd("5.00"),
+		},
+		{
+			name: "sub below zero",
+			op:   func() (Amount, error) { return usd("1.00").Sub(usd("2.50")) },
+			want: usd("-1.50"),
+		},
+		{
+			name: "mul by quantity",
+			op:   func() (Amount, error) { return usd("19.99").Mul("3") },
+			want: usd("59.97"),
+		},
+		{
+			name: "mul rounds half away from zero",
+			op:   func() (Amount, error) { return usd("0.05").Mul("0.5") },
+			want: usd("0.03"),
+		},
+		{
+			name: "mul zero-decimal currency",
+			op:   func() (Amount, error) { return Amount{Currency: "JPY", Value: "1005"}.Mul("0.8") },
+			want: Amount{Currency: "JPY", Value: "804"},
+		},
+		{
+			name:    "currency mismatch",
+			op:      func() (Amount, error) { return usd("1.00").Add(Amount{Currency: "EUR", Value: "1.00"}) },
+			wantErr: "currency mismatch: USD and EUR",
+		},
+		{
+			name:    "invalid value",
+			op:      func() (Amount, error) { return usd("abc").Add(usd("1.00")) },
+			wantErr: "invalid amount value",
+		},
+		{
+			name:    "fraction is not a decimal",
+			op:      func() (Amount, error) { return usd("1/3").Add(usd("1.00")) },
+			wantErr: "invalid amount value",
+		},
+		{
+			name:    "invalid factor",
+			op:      func() (Amount, error) { return usd("1.00").Mul("x") },
+			wantErr: "invalid factor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountCmpAndIsZero(t *testing.T) {
+	a := Amount{Currency: "USD", Value: "10.00"}
+	b := Amount{Currency: "USD", Value: "9.5"}
+
+	if c, err := a.Cmp(b); err != nil || c != 1 {
+		t.Errorf("Cmp() = %d, %v, want 1, nil", c, err)
+	}
+	if c, err := a.Cmp(Amount{Currency: "USD", Value: "10"}); err != nil || c != 0 {
+		t.Errorf("Cmp() = %d, %v, want 0, nil", c, err)
+	}
+	if _, err := a.Cmp(Amount{Currency: "EUR", Value: "1"}); err == nil {
+		t.Error("expected currency mismatch error")
+	}
+
+	if !(Amount{}).IsZero() || !(Amount{Currency: "USD", Value: "0.00"}).IsZero() {
+		t.Error("expected zero amounts to report IsZero")
+	}
+	if a.IsZero() {
+		t.Error("expected non-zero amount to not report IsZero")
+	}
+}
@@ -0,0 +1,41 @@
+package common
+
+import "errors"
+
+// Known APIError types.
+const (
+	TypeError               = "ERROR"
+	TypeInvalidRequestError = "INVALID_REQUEST_ERROR"
+	TypeAuthorizationError  = "AUTHORIZATION_ERROR"
+	TypeRateLimitError      = "RATE_LIMIT_ERROR"
+	TypeNotFound            = "NOT_FOUND"
+	TypeConflict            = "CONFLICT"
+	TypeMethodNotAllowed    = "METHOD_NOT_ALLOWED"
+)
+
+// Known APIError subtypes.
+const (
+	SubtypeInvalidArgument                   = "INVALID_ARGUMENT"
+	SubtypeImageLimitReached                 = "IMAGE_LIMIT_REACHED"
+	SubtypeOperationNotAllowedForProductType = "OPERATION_NOT_ALLOWED_FOR_PRODUCT_TYPE"
+)
+
+func (e APIError) IsType(errorType string) bool {
+	return e.Type == errorType
+}
+
+func (e APIError) IsSubtype(subtype string) bool {
+	return e.Subtype == subtype
+}
+
+// AsAPIError returns the API error decoded from the response that caused err,
+// if any, e.g.
+//
+//	if apiErr, ok := common.AsAPIError(err); ok && apiErr.IsSubtype(common.SubtypeImageLimitReached) {
+func AsAPIError(err error) (APIError, bool) {
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.APIError == (APIError{}) {
+		return APIError{}, false
+	}
+	return respErr.APIError, true
+}
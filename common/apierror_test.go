@@ -0,0 +1,38 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAPIErrorJSON(t *testing.T) {
+	data, err := json.Marshal(APIError{Type: TypeConflict, Message: "Product has reached image limit"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"type":"CONFLICT","message":"Product has reached image limit"}`; string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+}
+
+func TestAsAPIError(t *testing.T) {
+	body := []byte(`{"type":"CONFLICT","subtype":"IMAGE_LIMIT_REACHED","message":"Product has reached image limit"}`)
+	err := fmt.Errorf("failed to upload image: %w", ParseErrorResponse("UploadProductImage", "http://example.com", body, 409))
+
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("expected an API error in %v", err)
+	}
+	if !apiErr.IsType(TypeConflict) || !apiErr.IsSubtype(SubtypeImageLimitReached) || apiErr.IsSubtype(SubtypeInvalidArgument) {
+		t.Errorf("unexpected API error %+v", apiErr)
+	}
+
+	if _, ok := AsAPIError(ParseErrorResponse("UploadProductImage", "http://example.com", []byte("<html>"), 502)); ok {
+		t.Error("expected no API error for an undecodable body")
+	}
+	if _, ok := AsAPIError(errors.New("connection reset")); ok {
+		t.Error("expected no API error for a transport error")
+	}
+}
@@ -0,0 +1,142 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cache is a read-through store for raw GET response bodies. Keys are request
+// URLs prefixed with a hash of the API key, so one Cache can be shared between
+// Configs for different sites without either reading the other's entries.
+// Delete is called after a successful write so the next read of what it
+// changed goes to the API.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+	Delete(key string)
+}
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-memory Cache whose entries expire after a fixed TTL.
+type MemoryCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+	now     func() time.Time
+}
+
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		ttl:     ttl,
+		entries: make(map[string]memoryCacheEntry),
+		now:     time.Now,
+	}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *MemoryCache) Set(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = memoryCacheEntry{value: value, expiresAt: c.now().Add(c.ttl)}
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// Purge removes every entry, e.g. after a bulk catalog change.
+func (c *MemoryCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]memoryCacheEntry)
+}
+
+// CacheHeader is set to "hit" on the Header Call reports for a read served
+// from Config.Cache.
+const CacheHeader = "X-Gocommerce-Cache"
+
+// LoadCached decodes the cached response for a GET of url into out, reporting
+// whether config.Cache could satisfy the read. A hit is recorded for Call as a
+// 200 carrying CacheHeader, since no request is sent.
+func LoadCached(ctx context.Context, config *Config, url string, out any) bool {
+	if config.Cache == nil {
+		return false
+	}
+
+	body, ok := config.Cache.Get(cacheKey(config, url))
+	if !ok || json.Unmarshal(body, out) != nil {
+		return false
+	}
+	if recorder := requestOptionsFrom(ctx).recorder; recorder != nil {
+		recorder.set(http.StatusOK, http.Header{CacheHeader: []string{"hit"}})
+	}
+	return true
+}
+
+// StoreCached keeps body, the raw response to a GET of url, in config.Cache
+// when one is set.
+func StoreCached(config *Config, url string, body []byte) {
+	if config.Cache != nil {
+		config.Cache.Set(cacheKey(config, url), body)
+	}
+}
+
+// DecodeAndCache decodes body, the response to a GET of url, into out,
+// keeping a copy of the raw body in config.Cache when one is set.
+func DecodeAndCache(config *Config, url string, body io.Reader, out any) error {
+	if config.Cache == nil {
+		return DecodeJSON(body, out)
+	}
+
+	raw, err := DecodeJSONBody(body, out)
+	if err != nil {
+		return err
+	}
+	config.Cache.Set(cacheKey(config, url), raw)
+	return nil
+}
+
+// InvalidateCached drops the cached responses for GETs of urls, after a write
+// has made them stale.
+func InvalidateCached(config *Config, urls ...string) {
+	if config.Cache == nil {
+		return
+	}
+	for _, url := range urls {
+		config.Cache.Delete(cacheKey(config, url))
+	}
+}
+
+func cacheKey(config *Config, url string) string {
+	sum := sha256.Sum256([]byte(config.APIKey))
+	return hex.EncodeToString(sum[:8]) + ":" + url
+}
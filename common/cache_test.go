@@ -0,0 +1,76 @@
+package common
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewMemoryCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("expected miss for unknown key")
+	}
+
+	cache.Set("key", []byte("value"))
+	if got, ok := cache.Get("key"); !ok || string(got) != "value" {
+		t.Errorf("Get() = %q, %v, want value, true", got, ok)
+	}
+
+	now = now.Add(59 * time.Second)
+	if _, ok := cache.Get("key"); !ok {
+		t.Error("expected hit before TTL elapses")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expected miss once TTL elapses")
+	}
+
+	cache.Set("a", []byte("1"))
+	cache.Set("b", []byte("2"))
+	cache.Delete("a")
+	if _, ok := cache.Get("a"); ok {
+		t.Error("expected miss after Delete")
+	}
+	cache.Purge()
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected miss after Purge")
+	}
+}
+
+func TestCachedResponses(t *testing.T) {
+	shared := NewMemoryCache(time.Minute)
+	siteA := &Config{APIKey: "site-a", Cache: shared}
+	siteB := &Config{APIKey: "site-b", Cache: shared}
+	const url = "https://api.squarespace.com/1.0/commerce/products"
+
+	var out struct{ ID string }
+	if err := DecodeAndCache(siteA, url, strings.NewReader(`{"id": "a"}`), &out); err != nil || out.ID != "a" {
+		t.Fatalf("DecodeAndCache() = %+v, %v", out, err)
+	}
+
+	out.ID = ""
+	if !LoadCached(context.Background(), siteA, url, &out) || out.ID != "a" {
+		t.Errorf("expected a hit for the site that stored the entry, got %+v", out)
+	}
+	if LoadCached(context.Background(), siteB, url, &out) {
+		t.Error("expected another site's entry not to be served")
+	}
+
+	StoreCached(siteB, url, []byte(`{"id": "b"}`))
+	InvalidateCached(siteA, url)
+	if LoadCached(context.Background(), siteA, url, &out) {
+		t.Error("expected a miss after InvalidateCached")
+	}
+	if !LoadCached(context.Background(), siteB, url, &out) || out.ID != "b" {
+		t.Errorf("expected invalidation to leave other sites' entries, got %+v", out)
+	}
+	if LoadCached(context.Background(), &Config{}, url, &out) {
+		t.Error("expected a miss without a cache")
+	}
+}
@@ -0,0 +1,88 @@
+package common
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	defaultClientTimeout       = 30 * time.Second
+	defaultMaxIdleConnsPerHost = 16
+)
+
+// ClientOptions tunes the client built by NewClient. The zero value is the
+// default client used when Config.Client is nil.
+type ClientOptions struct {
+	// Timeout bounds each request, including reading the body. Defaults to
+	// 30s; negative disables it.
+	Timeout time.Duration
+	// MaxIdleConnsPerHost defaults to 16, enough for the bulk helpers'
+	// workers to reuse connections.
+	MaxIdleConnsPerHost int
+	// MinTLSVersion defaults to TLS 1.2.
+	MinTLSVersion uint16
+	// Proxy defaults to http.ProxyFromEnvironment.
+	Proxy func(*http.Request) (*url.URL, error)
+}
+
+// NewClient builds an http.Client with its own pooled transport.
+func NewClient(opts ClientOptions) *http.Client {
+	timeout := opts.Timeout
+	switch {
+	case timeout == 0:
+		timeout = defaultClientTimeout
+	case timeout < 0:
+		timeout = 0
+	}
+	maxIdlePerHost := opts.MaxIdleConnsPerHost
+	if maxIdlePerHost <= 0 {
+		maxIdlePerHost = defaultMaxIdleConnsPerHost
+	}
+	minTLS := opts.MinTLSVersion
+	if minTLS == 0 {
+		minTLS = tls.VersionTLS12
+	}
+	proxy := opts.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:                 proxy,
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			MaxIdleConnsPerHost:   maxIdlePerHost,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+			TLSClientConfig:       &tls.Config{MinVersion: minTLS},
+		},
+	}
+}
+
+var (
+	defaultClientOnce sync.Once
+	defaultClient     *http.Client
+)
+
+// DefaultClient returns the shared client built from NewClient's defaults.
+func DefaultClient() *http.Client {
+	defaultClientOnce.Do(func() { defaultClient = NewClient(ClientOptions{}) })
+	return defaultClient
+}
+
+// HTTPClient returns c.Client, or DefaultClient when it or c is nil.
+func (c *Config) HTTPClient() *http.Client {
+	if c != nil && c.Client != nil {
+		return c.Client
+	}
+	return DefaultClient()
+}
@@ -0,0 +1,39 @@
+package common
+
+import "net/http"
+
+// Validators are the cache validators a response carried. Pass them to a
+// conditional read to have the API answer 304 Not Modified when nothing
+// changed.
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+func (v Validators) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// ValidatorsFromHeader reads the ETag and Last-Modified headers.
+func ValidatorsFromHeader(header http.Header) Validators {
+	return Validators{ETag: header.Get("ETag"), LastModified: header.Get("Last-Modified")}
+}
+
+// SetConditionalHeaders sets If-None-Match and If-Modified-Since from v.
+func SetConditionalHeaders(req *http.Request, v Validators) {
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+}
+
+// Conditional is the result of a conditional read. When NotModified is true
+// Value is nil and the caller's previous copy is still current; Validators
+// are then the ones sent, unless the API returned fresh ones.
+type Conditional[T any] struct {
+	Value       *T
+	Validators  Validators
+	NotModified bool
+}
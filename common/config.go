@@ -0,0 +1,119 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Credential names the Config field an API authenticates with.
+type Credential string
+
+const (
+	// CredentialAPIKey is used by the Commerce and Profiles APIs.
+	CredentialAPIKey Credential = "APIKey"
+	// CredentialAccessToken is an OAuth token, required by the webhook
+	// subscriptions API.
+	CredentialAccessToken Credential = "AccessToken"
+)
+
+// ErrNilConfig is returned, possibly wrapped, by every entry point given a nil
+// *Config.
+var ErrNilConfig = errors.New("config is nil")
+
+// MissingCredentialError reports that the credential an API authenticates
+// with is not set.
+type MissingCredentialError struct {
+	Credential Credential
+}
+
+func (e *MissingCredentialError) Error() string {
+	if e.Credential == CredentialAccessToken {
+		return "access token is required"
+	}
+	return fmt.Sprintf("%s is required", e.Credential)
+}
+
+// Validate checks c for mistakes that would otherwise surface as a panic or a
+// confusing failure partway through a request: a nil Config, a BaseURL that is
+// not an absolute http or https URL, a missing credential, and a credential
+// that already carries the "Bearer " prefix the client adds, which would be
+// sent twice. Every problem found is reported in a single joined error. A nil
+// Client is valid and means DefaultClient. Setting both credentials is not a
+// conflict: each API authenticates with exactly one of them, and an OAuth
+// token may legitimately be used as both.
+func (c *Config) Validate() error {
+	return c.validate("")
+}
+
+// ValidateFor runs the checks of Validate, but requires the credential the
+// calling API authenticates with rather than either one. Every entry point
+// calls it before building a request.
+func (c *Config) ValidateFor(credential Credential) error {
+	return c.validate(credential)
+}
+
+func (c *Config) validate(required Credential) error {
+	if c == nil {
+		return ErrNilConfig
+	}
+
+	var errs []error
+	switch required {
+	case "":
+		if c.APIKey == "" && c.AccessToken == "" {
+			errs = append(errs, errors.New("either APIKey or AccessToken is required"))
+		}
+	case CredentialAPIKey:
+		if c.APIKey == "" {
+			errs = append(errs, &MissingCredentialError{Credential: required})
+		}
+	case CredentialAccessToken:
+		if c.AccessToken == "" {
+			errs = append(errs, &MissingCredentialError{Credential: required})
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown credential %q", required))
+	}
+
+	for _, credential := range []struct {
+		name  Credential
+		value string
+	}{
+		{CredentialAPIKey, c.APIKey},
+		{CredentialAccessToken, c.AccessToken},
+	} {
+		if strings.HasPrefix(strings.ToLower(credential.value), "bearer ") {
+			errs = append(errs, fmt.Errorf("%s must not include the \"Bearer \" prefix", credential.name))
+		} else if strings.TrimSpace(credential.value) != credential.value {
+			errs = append(errs, fmt.Errorf("%s has leading or trailing whitespace", credential.name))
+		}
+	}
+	if err := validateBaseURL(c.BaseURL); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateBaseURL(baseURL string) error {
+	if baseURL == "" {
+		return nil
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid BaseURL %q: %w", baseURL, err)
+	}
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return fmt.Errorf("invalid BaseURL %q: scheme must be http or https", baseURL)
+	case u.Host == "":
+		return fmt.Errorf("invalid BaseURL %q: host is required", baseURL)
+	case u.RawQuery != "" || u.Fragment != "":
+		return fmt.Errorf("invalid BaseURL %q: must not have a query or fragment", baseURL)
+	case strings.HasSuffix(u.Path, "/"):
+		return fmt.Errorf("invalid BaseURL %q: must not end with a slash", baseURL)
+	}
+	return nil
+}
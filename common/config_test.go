@@ -0,0 +1,125 @@
+package common
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     *Config
+		credential Credential
+		wantErrs   []string
+	}{
+		{
+			name:   "valid with defaults",
+			config: &Config{APIKey: "key"},
+		},
+		{
+			name:       "valid custom base URL",
+			config:     &Config{AccessToken: "token", BaseURL: "http://localhost:8080/squarespace"},
+			credential: CredentialAccessToken,
+		},
+		{
+			name:     "nil config",
+			wantErrs: []string{"config is nil"},
+		},
+		{
+			name:     "no credentials",
+			config:   &Config{},
+			wantErrs: []string{"either APIKey or AccessToken is required"},
+		},
+		{
+			name:       "missing API key for the API",
+			config:     &Config{AccessToken: "token"},
+			credential: CredentialAPIKey,
+			wantErrs:   []string{"APIKey is required"},
+		},
+		{
+			name:       "missing access token for the API",
+			config:     &Config{APIKey: "key"},
+			credential: CredentialAccessToken,
+			wantErrs:   []string{"access token is required"},
+		},
+		{
+			name:     "malformed credentials",
+			config:   &Config{APIKey: "Bearer key", AccessToken: "token\n"},
+			wantErrs: []string{`APIKey must not include the "Bearer " prefix`, "AccessToken has leading or trailing whitespace"},
+		},
+		{
+			name:     "base URL without scheme",
+			config:   &Config{APIKey: "key", BaseURL: "api.example.com"},
+			wantErrs: []string{"scheme must be http or https"},
+		},
+		{
+			name:     "base URL with trailing slash",
+			config:   &Config{APIKey: "key", BaseURL: "https://api.example.com/"},
+			wantErrs: []string{"must not end with a slash"},
+		},
+		{
+			name:     "base URL with query",
+			config:   &Config{APIKey: "key", BaseURL: "https://api.example.com?x=1"},
+			wantErrs: []string{"must not have a query or fragment"},
+		},
+		{
+			name:       "every problem is reported",
+			config:     &Config{BaseURL: "ftp://example.com"},
+			credential: CredentialAPIKey,
+			wantErrs:   []string{"APIKey is required", "scheme must be http or https"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.credential == "" {
+				err = tt.config.Validate()
+			} else {
+				err = tt.config.ValidateFor(tt.credential)
+			}
+
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %q, got nil", tt.wantErrs)
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error containing %q, got %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestNilConfig(t *testing.T) {
+	var config *Config
+
+	if err := config.ValidateFor(CredentialAPIKey); !errors.Is(err, ErrNilConfig) {
+		t.Errorf("ValidateFor() error = %v, want ErrNilConfig", err)
+	}
+	if _, err := BuildBaseURL(config, "1.0", "commerce/orders"); !errors.Is(err, ErrNilConfig) {
+		t.Errorf("BuildBaseURL() error = %v, want ErrNilConfig", err)
+	}
+	if config.HTTPClient() != DefaultClient() {
+		t.Error("expected HTTPClient() to fall back to DefaultClient")
+	}
+}
+
+func TestMissingCredentialError(t *testing.T) {
+	err := (&Config{APIKey: "key"}).ValidateFor(CredentialAccessToken)
+
+	var missing *MissingCredentialError
+	if !errors.As(err, &missing) || missing.Credential != CredentialAccessToken {
+		t.Fatalf("expected a MissingCredentialError for the access token, got %v", err)
+	}
+	if err.Error() != "access token is required" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
@@ -0,0 +1,25 @@
+package common
+
+import (
+	"context"
+	"io"
+)
+
+// ContextReader returns a reader that fails with ctx's error once ctx is done,
+// checked before every read of r. Wrapping a large file in it lets a copy or
+// an upload stop between reads rather than running to the end.
+func ContextReader(ctx context.Context, r io.Reader) io.Reader {
+	return &contextReader{ctx: ctx, r: r}
+}
+
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
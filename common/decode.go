@@ -0,0 +1,60 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// maxPooledBuffer keeps unusually large bodies from pinning memory in the pool.
+const maxPooledBuffer = 1 << 20
+
+var bufferPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// ReadBody reads r through a pooled buffer and returns a copy sized to the
+// content.
+func ReadBody(r io.Reader) ([]byte, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+// DecodeJSON decodes the JSON document in r into v as it streams in, so large
+// list pages are never held in memory twice, then drains r so the connection
+// can be reused.
+func DecodeJSON(r io.Reader, v interface{}) error {
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, r)
+	return nil
+}
+
+// DecodeJSONBody is DecodeJSON that also returns the raw body, for callers
+// that cache it.
+func DecodeJSONBody(r io.Reader, v interface{}) ([]byte, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := DecodeJSON(io.TeeReader(r, buf), v); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
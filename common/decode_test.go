@@ -0,0 +1,63 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{name: "valid body", body: `{"id":"abc","count":2}`},
+		{name: "trailing whitespace is drained", body: "{\"id\":\"abc\",\"count\":2}\n\n"},
+		{name: "truncated body", body: `{"id":"abc",`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got struct {
+				ID    string `json:"id"`
+				Count int    `json:"count"`
+			}
+
+			r := strings.NewReader(tt.body)
+			raw, err := DecodeJSONBody(r, &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID != "abc" || got.Count != 2 {
+				t.Errorf("unexpected value %+v", got)
+			}
+			if string(raw) != tt.body {
+				t.Errorf("expected raw body %q, got %q", tt.body, raw)
+			}
+			if r.Len() != 0 {
+				t.Errorf("expected body to be drained, %d bytes left", r.Len())
+			}
+		})
+	}
+}
+
+func TestReadBody(t *testing.T) {
+	first, err := ReadBody(strings.NewReader("first"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := ReadBody(strings.NewReader("second"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Pooled buffers are reused, so returned bodies must not alias them.
+	if string(first) != "first" || string(second) != "second" {
+		t.Errorf("expected independent bodies, got %q and %q", first, second)
+	}
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// ErrDryRun is wrapped by the error a mutating request returns in dry-run
+// mode, so callers can tell a request that was skipped from one that failed.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// ErrReadOnlyMode is wrapped by the error every mutating request returns when
+// Config.ReadOnly is set.
+var ErrReadOnlyMode = errors.New("read-only mode: mutating requests are disabled")
+
+// DryRunRequest is a mutating request that dry-run mode skipped.
+type DryRunRequest struct {
+	Method string
+	URL    string
+	// Header has the Authorization value redacted.
+	Header http.Header
+	Body   []byte
+}
+
+// WithDryRun makes the mutating requests of a context skip sending, as
+// Config.DryRun does for every request made with a config. Skipped requests
+// are passed to log when it is set, otherwise as Config.DryRun describes.
+func WithDryRun(log func(DryRunRequest)) RequestOption {
+	return func(o *requestOptions) {
+		o.dryRun = true
+		o.dryRunLog = log
+	}
+}
+
+// WithReadOnly makes the mutating requests of a context fail, as
+// Config.ReadOnly does for every request made with a config.
+func WithReadOnly() RequestOption {
+	return func(o *requestOptions) { o.readOnly = true }
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// skipDryRun reports the request to logFn or the config's dry-run log,
+// whichever is set first, and returns the error it fails with. Without either,
+// the standard logger gets the method, URL, Content-Type and body length only,
+// since bodies may hold customer data.
+func skipDryRun(config *Config, logFn func(DryRunRequest), req *http.Request) error {
+	skipped := DryRunRequest{Method: req.Method, URL: req.URL.String(), Header: req.Header.Clone()}
+	if skipped.Header.Get("Authorization") != "" {
+		skipped.Header.Set("Authorization", "[REDACTED]")
+	}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read request body: %w", err)
+		}
+		skipped.Body = body
+	}
+
+	if logFn == nil && config != nil {
+		logFn = config.DryRunLog
+	}
+	if logFn != nil {
+		logFn(skipped)
+	} else {
+		log.Printf("gocommerce: dry run: %s %s (%s, %d bytes)", skipped.Method, skipped.URL, skipped.Header.Get("Content-Type"), len(skipped.Body))
+	}
+	return fmt.Errorf("%w: %s %s", ErrDryRun, skipped.Method, skipped.URL)
+}
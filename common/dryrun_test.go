@@ -0,0 +1,128 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request sent in dry-run mode", r.Method)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var logged []DryRunRequest
+	config := &Config{Client: server.Client(), DryRun: true, DryRunLog: func(r DryRunRequest) { logged = append(logged, r) }}
+
+	get, _ := http.NewRequest(http.MethodGet, server.URL+"/products", nil)
+	resp, err := Do(config, get)
+	if err != nil {
+		t.Fatalf("expected reads to be sent, got %v", err)
+	}
+	resp.Body.Close()
+
+	post, _ := http.NewRequest(http.MethodPost, server.URL+"/products", strings.NewReader(`{"name":"Mug"}`))
+	post.Header.Set("Authorization", "Bearer secret")
+	if _, err := Do(config, post); !errors.Is(err, ErrDryRun) {
+		t.Fatalf("expected ErrDryRun, got %v", err)
+	}
+
+	if len(logged) != 1 {
+		t.Fatalf("expected 1 logged request, got %d", len(logged))
+	}
+	if got := logged[0]; got.Method != http.MethodPost || string(got.Body) != `{"name":"Mug"}` || got.Header.Get("Authorization") != "[REDACTED]" {
+		t.Errorf("unexpected logged request %+v", got)
+	}
+}
+
+func TestDryRunDefaultLogOmitsBody(t *testing.T) {
+	var out bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&out)
+
+	config := &Config{DryRun: true}
+	post, _ := http.NewRequest(http.MethodPost, "http://example.com/profiles", strings.NewReader(`{"email":"jane@example.com"}`))
+	post.Header.Set("Content-Type", "application/json")
+	if _, err := Do(config, post); !errors.Is(err, ErrDryRun) {
+		t.Fatalf("expected ErrDryRun, got %v", err)
+	}
+
+	got := out.String()
+	if !strings.Contains(got, "POST http://example.com/profiles (application/json, 28 bytes)") || strings.Contains(got, "jane@example.com") {
+		t.Errorf("unexpected default dry-run log %q", got)
+	}
+}
+
+func TestWithDryRun(t *testing.T) {
+	var sent int
+	config := &Config{Client: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		sent++
+		return &http.Response{StatusCode: http.StatusNoContent, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(""))}, nil
+	})}}
+
+	var logged []DryRunRequest
+	ctx := WithRequestOptions(context.Background(), WithDryRun(func(r DryRunRequest) { logged = append(logged, r) }))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodDelete, "http://example.com/products/p1", nil)
+	if _, err := Do(config, req); !errors.Is(err, ErrDryRun) {
+		t.Fatalf("expected ErrDryRun, got %v", err)
+	}
+	if sent != 0 || len(logged) != 1 || logged[0].URL != "http://example.com/products/p1" {
+		t.Errorf("expected the delete to be logged and not sent, sent %d, logged %+v", sent, logged)
+	}
+
+	req, _ = http.NewRequest(http.MethodDelete, "http://example.com/products/p1", nil)
+	resp, err := Do(config, req)
+	if err != nil {
+		t.Fatalf("expected requests outside the context to be sent, got %v", err)
+	}
+	resp.Body.Close()
+	if sent != 1 {
+		t.Errorf("expected 1 request sent, got %d", sent)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	var sent []string
+	config := &Config{ReadOnly: true, DryRun: true, Client: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		sent = append(sent, req.Method)
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	})}}
+	config.DryRunLog = func(DryRunRequest) { t.Error("expected read-only mode to take precedence over dry-run mode") }
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		req, _ := http.NewRequest(method, "http://example.com/products", nil)
+		if _, err := Do(config, req); !errors.Is(err, ErrReadOnlyMode) {
+			t.Errorf("%s: expected ErrReadOnlyMode, got %v", method, err)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/products", nil)
+	resp, err := Do(config, req)
+	if err != nil {
+		t.Fatalf("expected reads to be sent, got %v", err)
+	}
+	resp.Body.Close()
+	if len(sent) != 1 || sent[0] != http.MethodGet {
+		t.Errorf("expected only the read to be sent, got %v", sent)
+	}
+
+	config.ReadOnly, config.DryRun = false, false
+	ctx := WithRequestOptions(context.Background(), WithReadOnly())
+	req, _ = http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/products", nil)
+	if _, err := Do(config, req); !errors.Is(err, ErrReadOnlyMode) {
+		t.Errorf("expected ErrReadOnlyMode from WithReadOnly, got %v", err)
+	}
+	if len(sent) != 1 {
+		t.Errorf("expected no request sent under WithReadOnly, got %v", sent)
+	}
+}
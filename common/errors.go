@@ -0,0 +1,65 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxErrorBodySnippet bounds how much of an undecodable error body is kept.
+const maxErrorBodySnippet = 512
+
+// ResponseError is returned for an unsuccessful API response.
+type ResponseError struct {
+	Endpoint    string
+	URL         string
+	StatusCode  int
+	ContentType string
+	// APIError is the decoded error body; it is zero when the body was not
+	// JSON, e.g. an HTML page from a proxy.
+	APIError APIError
+	// Body holds the start of a body that could not be decoded, so the
+	// failure stays diagnosable.
+	Body string
+}
+
+func (e *ResponseError) Error() string {
+	if e.Body != "" || e.APIError == (APIError{}) {
+		msg := fmt.Sprintf("%s: error unmarshalling response body: status: %d", e.Endpoint, e.StatusCode)
+		if e.ContentType != "" {
+			msg += ", content-type: " + e.ContentType
+		}
+		return msg + fmt.Sprintf(", body: %q", e.Body)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s url: %s: status: %d, type: %s", e.Endpoint, e.URL, e.StatusCode, e.APIError.Type)
+	if e.APIError.Subtype != "" {
+		fmt.Fprintf(&b, ", subtype: %s", e.APIError.Subtype)
+	}
+	fmt.Fprintf(&b, ", message: %s", e.APIError.Message)
+	if e.APIError.Detail != "" {
+		fmt.Fprintf(&b, ", detail: %s", e.APIError.Detail)
+	}
+	return b.String()
+}
+
+// ParseResponseError is ParseErrorResponse that also records resp's
+// Content-Type.
+func ParseResponseError(endpoint, url string, resp *http.Response, body []byte) error {
+	err := parseResponseError(endpoint, url, body, resp.StatusCode)
+	err.ContentType = resp.Header.Get("Content-Type")
+	return err
+}
+
+func errorBodySnippet(body []byte) string {
+	if len(body) <= maxErrorBodySnippet {
+		return strings.ToValidUTF8(string(body), "�")
+	}
+	cut := maxErrorBodySnippet
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return strings.ToValidUTF8(string(body[:cut]), "�") + "..."
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type FetchOptions struct {
+	// Concurrency is the number of chunks fetched in parallel. Defaults to 1.
+	Concurrency int
+}
+
+// FetchAll splits ids into chunks of at most batchSize, fetched once each
+// after removing duplicates, and calls fn for each chunk. Items are returned
+// in chunk order; use OrderByIDs to match them back to ids. The first failure
+// cancels the chunks still waiting or running and is returned as
+// "chunk %d: %w".
+func FetchAll[T any](ctx context.Context, ids []string, batchSize int, fn func(ctx context.Context, chunk []string) ([]T, error), opts FetchOptions) ([]T, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batchSize must be positive, got %d", batchSize)
+	}
+
+	unique := uniqueIDs(ids)
+	var chunks [][]string
+	for start := 0; start < len(unique); start += batchSize {
+		end := min(start+batchSize, len(unique))
+		chunks = append(chunks, unique[start:end])
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]T, len(chunks))
+	errs := make([]error, len(chunks))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk []string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			items, err := fn(ctx, chunk)
+			if err != nil {
+				errs[i] = fmt.Errorf("chunk %d: %w", i, err)
+				cancel()
+				return
+			}
+			results[i] = items
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	// Report the failure that triggered cancellation rather than the
+	// cancellations it caused in sibling chunks.
+	var firstErr error
+	for _, err := range errs {
+		if err != nil && (firstErr == nil || errors.Is(firstErr, context.Canceled)) {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var items []T
+	for _, chunk := range results {
+		items = append(items, chunk...)
+	}
+	return items, nil
+}
+
+// OrderByIDs returns items in the order of ids, matched by key, listing the
+// ids no item matched in missing. Duplicate ids appear once.
+func OrderByIDs[T any](ids []string, items []T, key func(T) string) (ordered []T, missing []string) {
+	byID := make(map[string]T, len(items))
+	for _, item := range items {
+		byID[key(item)] = item
+	}
+
+	unique := uniqueIDs(ids)
+	ordered = make([]T, 0, len(unique))
+	for _, id := range unique {
+		if item, ok := byID[id]; ok {
+			ordered = append(ordered, item)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return ordered, missing
+}
+
+func uniqueIDs(ids []string) []string {
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchAll(t *testing.T) {
+	ids := make([]string, 0, 12)
+	for i := 0; i < 10; i++ {
+		ids = append(ids, fmt.Sprintf("id-%d", i))
+	}
+	ids = append(ids, "id-3", "id-7")
+
+	var inFlight, peak, calls int32
+	items, err := FetchAll(context.Background(), ids, 3, func(_ context.Context, chunk []string) ([]string, error) {
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if len(chunk) > 3 {
+			t.Errorf("expected chunks of at most 3, got %d", len(chunk))
+		}
+		// Return the chunk reversed, leaving out id-5.
+		var out []string
+		for i := len(chunk) - 1; i >= 0; i-- {
+			if chunk[i] != "id-5" {
+				out = append(out, chunk[i])
+			}
+		}
+		return out, nil
+	}, FetchOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("FetchAll() error = %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("expected duplicates to be fetched once in 4 chunks, got %d calls", calls)
+	}
+	if peak > 2 {
+		t.Errorf("expected at most 2 concurrent chunks, got %d", peak)
+	}
+
+	ordered, missing := OrderByIDs(ids, items, func(id string) string { return id })
+	if got := strings.Join(ordered, ","); got != "id-0,id-1,id-2,id-3,id-4,id-6,id-7,id-8,id-9" {
+		t.Errorf("expected items in id order, got %s", got)
+	}
+	if len(missing) != 1 || missing[0] != "id-5" {
+		t.Errorf("expected id-5 to be missing, got %v", missing)
+	}
+}
+
+func TestFetchAllFailure(t *testing.T) {
+	boom := errors.New("boom")
+	_, err := FetchAll(context.Background(), []string{"a", "b", "c", "d"}, 1, func(ctx context.Context, chunk []string) ([]string, error) {
+		if chunk[0] == "b" {
+			return nil, boom
+		}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, FetchOptions{Concurrency: 4})
+
+	if !errors.Is(err, boom) || !strings.HasPrefix(err.Error(), "chunk 1: ") {
+		t.Errorf("expected the failing chunk's error rather than the cancellations, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Do sends req with config.HTTPClient(), advertising gzip and decompressing
+// gzip responses itself. Go's transport only does this when it set the header
+// itself, which custom transports often prevent, so every request goes
+// through here to behave the same on any client. Reads of the response body
+// fail once req's context is done, even on transports that do not watch it.
+// In dry-run mode, mutating requests are logged instead of sent, and in
+// read-only mode they fail.
+func Do(config *Config, req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	opts := requestOptionsFrom(req.Context())
+	if (opts.readOnly || config != nil && config.ReadOnly) && isMutating(req.Method) {
+		return nil, fmt.Errorf("%w: %s %s", ErrReadOnlyMode, req.Method, req.URL)
+	}
+	if (opts.dryRun || config != nil && config.DryRun) && isMutating(req.Method) {
+		return nil, skipDryRun(config, opts.dryRunLog, req)
+	}
+	req, client, cancel := opts.apply(req, config.HTTPClient())
+	resp, err := client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, ctx: req.Context(), cancel: cancel}
+	if opts.recorder != nil {
+		opts.recorder.record(resp)
+	}
+
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") || req.Method == http.MethodHead {
+		return resp, nil
+	}
+	if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified {
+		return resp, nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to decompress response body: %w", err)
+	}
+	resp.Body = &gzipBody{Reader: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
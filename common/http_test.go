@@ -0,0 +1,197 @@
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	zw.Write([]byte(`{"ok":true}`))
+	zw.Close()
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		gzipped        bool
+		body           []byte
+		wantAccept     string
+		wantBody       string
+		wantErr        string
+	}{
+		{
+			name:       "gzip response is decoded",
+			gzipped:    true,
+			body:       compressed.Bytes(),
+			wantAccept: "gzip",
+			wantBody:   `{"ok":true}`,
+		},
+		{
+			name:       "identity response is passed through",
+			body:       []byte(`{"ok":true}`),
+			wantAccept: "gzip",
+			wantBody:   `{"ok":true}`,
+		},
+		{
+			name:           "caller's Accept-Encoding is kept",
+			acceptEncoding: "identity",
+			body:           []byte(`{"ok":true}`),
+			wantAccept:     "identity",
+			wantBody:       `{"ok":true}`,
+		},
+		{
+			name:       "corrupt gzip response",
+			gzipped:    true,
+			body:       []byte("not gzip"),
+			wantAccept: "gzip",
+			wantErr:    "failed to decompress response body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAccept string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAccept = r.Header.Get("Accept-Encoding")
+				if tt.gzipped {
+					w.Header().Set("Content-Encoding", "gzip")
+				}
+				w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			// Custom transports commonly disable Go's transparent decompression.
+			client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+
+			resp, err := Do(&Config{Client: client}, req)
+			if gotAccept != tt.wantAccept {
+				t.Errorf("expected Accept-Encoding %q, got %q", tt.wantAccept, gotAccept)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("expected body %q, got %q", tt.wantBody, body)
+			}
+			if resp.Header.Get("Content-Encoding") != "" {
+				t.Errorf("expected Content-Encoding to be removed, got %q", resp.Header.Get("Content-Encoding"))
+			}
+		})
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	custom := &http.Client{}
+	if got := (&Config{Client: custom}).HTTPClient(); got != custom {
+		t.Error("expected the configured client to be used")
+	}
+
+	client := (&Config{}).HTTPClient()
+	if client != DefaultClient() {
+		t.Error("expected a nil Client to use the shared default")
+	}
+	if client.Timeout != 30*time.Second {
+		t.Errorf("expected a 30s timeout, got %v", client.Timeout)
+	}
+	transport := client.Transport.(*http.Transport)
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 || transport.MaxIdleConnsPerHost != 16 || transport.Proxy == nil {
+		t.Errorf("unexpected default transport %+v", transport)
+	}
+
+	tuned := NewClient(ClientOptions{Timeout: -1, MaxIdleConnsPerHost: 64, MinTLSVersion: tls.VersionTLS13})
+	transport = tuned.Transport.(*http.Transport)
+	if tuned.Timeout != 0 || transport.MaxIdleConnsPerHost != 64 || transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("expected options to override defaults, got timeout %v and %+v", tuned.Timeout, transport)
+	}
+}
+
+func TestDoDefaultClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := Do(&Config{}, req)
+	if err != nil {
+		t.Fatalf("expected a nil Client not to panic, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// endlessReader never runs out, standing in for a stalled download on a
+// transport that ignores the request context.
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = ' '
+	}
+	return len(p), nil
+}
+
+func TestDoStopsBodyReadsOnCancel(t *testing.T) {
+	config := &Config{Client: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(endlessReader{})}, nil
+	})}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+	resp, err := Do(config, req)
+	if err != nil {
+		t.Fatalf("Do() unexpected error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	buf := make([]byte, 16)
+	if _, err := resp.Body.Read(buf); err != nil {
+		t.Fatalf("Read() unexpected error = %v", err)
+	}
+	cancel()
+	if _, err := io.ReadAll(resp.Body); !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadAll() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestContextReader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := ContextReader(ctx, strings.NewReader("abcdef"))
+
+	buf := make([]byte, 3)
+	if n, err := r.Read(buf); n != 3 || err != nil {
+		t.Fatalf("Read() = %d, %v", n, err)
+	}
+	cancel()
+	if _, err := r.Read(buf); !errors.Is(err, context.Canceled) {
+		t.Errorf("Read() error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,93 @@
+package common
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrStopIteration may be returned from a page callback to stop paging early
+// without reporting an error.
+var ErrStopIteration = errors.New("stop iteration")
+
+// PageFetcher retrieves one page of results. cursor is empty for the first
+// page and holds the previous page's nextPageCursor afterwards.
+type PageFetcher[T any] func(ctx context.Context, cursor string) ([]T, Pagination, error)
+
+// Iterator yields items one at a time across pages, fetching the next page
+// only once the current one is exhausted:
+//
+//	it := orders.RetrieveAllOrdersIter(ctx, config, params)
+//	for it.Next() {
+//		order := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type Iterator[T any] struct {
+	ctx     context.Context
+	fetch   PageFetcher[T]
+	page    []T
+	index   int
+	cursor  string
+	done    bool
+	current T
+	err     error
+}
+
+func NewIterator[T any](ctx context.Context, fetch PageFetcher[T]) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch}
+}
+
+// Next advances to the next item, reporting false once results are exhausted
+// or an error occurs. Once the iterator's context is done Next stops, with the
+// context's error, even if items of the current page remain.
+func (it *Iterator[T]) Next() bool {
+	for {
+		if it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		if it.index < len(it.page) {
+			it.current = it.page[it.index]
+			it.index++
+			return true
+		}
+		if it.done {
+			return false
+		}
+
+		items, pagination, err := it.fetch(it.ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.page = items
+		it.index = 0
+		if !pagination.HasNext() {
+			it.done = true
+		} else {
+			it.cursor = pagination.NextPageCursor
+		}
+	}
+}
+
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Collect drains the iterator into a slice.
+func (it *Iterator[T]) Collect() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
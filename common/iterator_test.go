@@ -0,0 +1,122 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	pages := map[string]struct {
+		items      []int
+		pagination Pagination
+	}{
+		"":   {items: []int{1, 2}, pagination: Pagination{HasNextPage: true, NextPageCursor: "b"}},
+		"b":  {items: []int{}, pagination: Pagination{HasNextPage: true, NextPageCursor: "c"}},
+		"c":  {items: []int{3}, pagination: Pagination{HasNextPage: false, NextPageCursor: "ignored"}},
+		"no": {},
+	}
+
+	tests := []struct {
+		name      string
+		fetch     PageFetcher[int]
+		want      []int
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name: "follows cursors across empty pages",
+			fetch: func(ctx context.Context, cursor string) ([]int, Pagination, error) {
+				page := pages[cursor]
+				return page.items, page.pagination, nil
+			},
+			want:      []int{1, 2, 3},
+			wantCalls: 3,
+		},
+		{
+			name: "has next page without cursor stops",
+			fetch: func(ctx context.Context, cursor string) ([]int, Pagination, error) {
+				return []int{1}, Pagination{HasNextPage: true}, nil
+			},
+			want:      []int{1},
+			wantCalls: 1,
+		},
+		{
+			name: "fetch error is reported after yielded items",
+			fetch: func(ctx context.Context, cursor string) ([]int, Pagination, error) {
+				if cursor == "" {
+					return []int{1}, Pagination{HasNextPage: true, NextPageCursor: "b"}, nil
+				}
+				return nil, Pagination{}, errBoom
+			},
+			want:      []int{1},
+			wantCalls: 2,
+			wantErr:   errBoom,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			it := NewIterator(context.Background(), func(ctx context.Context, cursor string) ([]int, Pagination, error) {
+				calls++
+				return tt.fetch(ctx, cursor)
+			})
+
+			got, err := it.Collect()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Err() = %v, want %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", got, tt.want)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d fetches, got %d", tt.wantCalls, calls)
+			}
+			if it.Next() {
+				t.Error("expected Next() to stay false once exhausted")
+			}
+		})
+	}
+}
+
+func TestIteratorCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it := NewIterator(ctx, func(ctx context.Context, cursor string) ([]int, Pagination, error) {
+		t.Error("fetch should not be called with a cancelled context")
+		return nil, Pagination{}, nil
+	})
+	if it.Next() {
+		t.Error("expected Next() to return false")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Err() = %v, want context.Canceled", it.Err())
+	}
+}
+
+func TestIteratorStopsMidPageOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	it := NewIterator(ctx, func(ctx context.Context, cursor string) ([]int, Pagination, error) {
+		return []int{1, 2, 3}, Pagination{}, nil
+	})
+	if !it.Next() || it.Value() != 1 {
+		t.Fatalf("expected the first item, got %v", it.Value())
+	}
+	cancel()
+	if it.Next() {
+		t.Errorf("expected Next() to stop after cancellation, got %v", it.Value())
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Err() = %v, want context.Canceled", it.Err())
+	}
+}
+
+var errBoom = errors.New("boom")
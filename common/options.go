@@ -0,0 +1,115 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestOption adjusts the requests made with a context returned by
+// WithRequestOptions.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	timeout   time.Duration
+	deadline  time.Time
+	recorder  *responseRecorder
+	header    http.Header
+	dryRun    bool
+	dryRunLog func(DryRunRequest)
+	readOnly  bool
+}
+
+type requestOptionsKey struct{}
+
+// WithTimeout bounds each request to d, overriding the client's own timeout,
+// e.g. to give an image upload longer than list calls get.
+func WithTimeout(d time.Duration) RequestOption {
+	return func(o *requestOptions) { o.timeout = d }
+}
+
+// WithDeadline is WithTimeout with an absolute deadline.
+func WithDeadline(t time.Time) RequestOption {
+	return func(o *requestOptions) { o.deadline = t }
+}
+
+// WithHeader sets a header on each request, after the library's own headers,
+// e.g. for tracing or experiment flags. Later values for the same key replace
+// earlier ones.
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// WithRequestOptions returns a context whose requests apply opts on top of any
+// options already carried by ctx:
+//
+//	ctx := common.WithRequestOptions(ctx, common.WithTimeout(5*time.Minute))
+//	products.UploadProductImage(ctx, config, productID, path)
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	merged := requestOptionsFrom(ctx)
+	merged.header = merged.header.Clone()
+	for _, opt := range opts {
+		opt(&merged)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, merged)
+}
+
+func requestOptionsFrom(ctx context.Context) requestOptions {
+	o, _ := ctx.Value(requestOptionsKey{}).(requestOptions)
+	return o
+}
+
+// apply returns req and client adjusted for o, and a cancel func to call once
+// the response body is done with.
+func (o requestOptions) apply(req *http.Request, client *http.Client) (*http.Request, *http.Client, context.CancelFunc) {
+	for key, values := range o.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+
+	deadline := o.deadline
+	if o.timeout > 0 {
+		if d := time.Now().Add(o.timeout); deadline.IsZero() || d.Before(deadline) {
+			deadline = d
+		}
+	}
+	if deadline.IsZero() {
+		return req, client, func() {}
+	}
+
+	// The per-call deadline replaces the client's timeout rather than being
+	// capped by it.
+	if client.Timeout > 0 {
+		copied := *client
+		copied.Timeout = 0
+		client = &copied
+	}
+	ctx, cancel := context.WithDeadline(req.Context(), deadline)
+	return req.WithContext(ctx), client, cancel
+}
+
+// cancelBody stops reads once ctx is done and releases a per-call context
+// once the body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Read(p []byte) (int, error) {
+	if err := b.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return b.ReadCloser.Read(p)
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
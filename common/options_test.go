@@ -0,0 +1,100 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithRequestOptionsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		clientTimeout time.Duration
+		opts          []RequestOption
+		wantErr       bool
+	}{
+		{name: "no options uses the client timeout", clientTimeout: 20 * time.Millisecond, wantErr: true},
+		{name: "timeout overrides a shorter client timeout", clientTimeout: 20 * time.Millisecond, opts: []RequestOption{WithTimeout(time.Second)}},
+		{name: "timeout shorter than the response", opts: []RequestOption{WithTimeout(20 * time.Millisecond)}, wantErr: true},
+		{name: "deadline", opts: []RequestOption{WithDeadline(time.Now().Add(20 * time.Millisecond))}, wantErr: true},
+		{name: "earliest of timeout and deadline wins", opts: []RequestOption{WithTimeout(time.Second), WithDeadline(time.Now().Add(20 * time.Millisecond))}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := server.Client()
+			client.Timeout = tt.clientTimeout
+			config := &Config{Client: client}
+
+			ctx := WithRequestOptions(context.Background(), tt.opts...)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			resp, err := Do(config, req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if client.Timeout != tt.clientTimeout {
+				t.Errorf("expected the configured client to be left alone, timeout is %v", client.Timeout)
+			}
+		})
+	}
+}
+
+func TestWithRequestOptionsMerges(t *testing.T) {
+	ctx := WithRequestOptions(context.Background(), WithTimeout(time.Minute))
+	ctx = WithRequestOptions(ctx, WithDeadline(time.Unix(0, 0)))
+
+	o := requestOptionsFrom(ctx)
+	if o.timeout != time.Minute || !o.deadline.Equal(time.Unix(0, 0)) {
+		t.Errorf("expected options to accumulate, got %+v", o)
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.invalid", nil)
+	_, err := Do(&Config{Client: &http.Client{}}, req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a past deadline to fail the request, got %v", err)
+	}
+}
+
+func TestWithHeader(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	defer server.Close()
+
+	base := WithRequestOptions(context.Background(), WithHeader("X-Trace-Id", "trace-1"))
+	ctx := WithRequestOptions(base, WithHeader("X-Experiment", "fast-path"), WithHeader("User-Agent", "override"))
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	req.Header.Set("User-Agent", "gocommerce/default-client")
+	resp, err := Do(&Config{Client: server.Client()}, req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if got.Get("X-Trace-Id") != "trace-1" || got.Get("X-Experiment") != "fast-path" {
+		t.Errorf("expected per-call headers, got %v", got)
+	}
+	if got.Get("User-Agent") != "override" {
+		t.Errorf("expected per-call headers to apply after the library's, got %q", got.Get("User-Agent"))
+	}
+	if requestOptionsFrom(base).header.Get("X-Experiment") != "" {
+		t.Error("expected derived options not to change the parent context's")
+	}
+}
@@ -0,0 +1,139 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const defaultAPIOrigin = "https://api.squarespace.com"
+
+// ErrNoNextPage is returned by FollowNextPage when the pagination has no next
+// page URL to follow.
+var ErrNoNextPage = errors.New("no next page")
+
+// UnmarshalJSON accepts nextCursor and nextUrl as well as the documented
+// nextPageCursor and nextPageUrl. A cursor without hasNextPage means there is
+// a next page.
+func (p *Pagination) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		HasNextPage    *bool  `json:"hasNextPage"`
+		NextPageCursor string `json:"nextPageCursor"`
+		NextCursor     string `json:"nextCursor"`
+		NextPageURL    string `json:"nextPageUrl"`
+		NextURL        string `json:"nextUrl"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*p = Pagination{NextPageCursor: raw.NextPageCursor, NextPageURL: raw.NextPageURL}
+	if p.NextPageCursor == "" {
+		p.NextPageCursor = raw.NextCursor
+	}
+	if p.NextPageURL == "" {
+		p.NextPageURL = raw.NextURL
+	}
+	if raw.HasNextPage != nil {
+		p.HasNextPage = *raw.HasNextPage
+	} else {
+		p.HasNextPage = p.NextPageCursor != ""
+	}
+	return nil
+}
+
+// HasNext reports whether there is a next page that can be requested.
+func (p Pagination) HasNext() bool {
+	return p.HasNextPage && p.NextPageCursor != ""
+}
+
+// NextParams returns the parameters for the next page: only the cursor, since
+// the API rejects a cursor combined with other filters. It is zero when there
+// is no next page.
+func (p Pagination) NextParams() QueryParams {
+	if !p.HasNext() {
+		return QueryParams{}
+	}
+	return QueryParams{Cursor: p.NextPageCursor}
+}
+
+// PageParams returns the parameters for the page at cursor, as handed to a
+// PageFetcher: params for the first page, where cursor is empty, and otherwise
+// NextParams for that cursor, since the API rejects a cursor combined with
+// other filters.
+func PageParams(params QueryParams, cursor string) QueryParams {
+	if cursor == "" {
+		return params
+	}
+	return Pagination{HasNextPage: true, NextPageCursor: cursor}.NextParams()
+}
+
+// FollowNextPage requests pagination.NextPageURL with config's credentials and
+// decodes the response into out, which should be the same response type as
+// the page pagination came from, e.g. *orders.RetrieveAllOrdersResponse. It
+// saves rebuilding the query for the next page. A relative URL is resolved
+// against the API base, and an absolute one must point at the same scheme and
+// host as the API base, so the API key is never sent elsewhere. It returns
+// ErrNoNextPage when there is no URL to follow.
+func FollowNextPage(ctx context.Context, config *Config, pagination Pagination, out any) error {
+	if !pagination.HasNextPage || pagination.NextPageURL == "" {
+		return ErrNoNextPage
+	}
+	if err := config.ValidateFor(CredentialAPIKey); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	nextURL, err := resolveNextPageURL(config, pagination.NextPageURL)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nextURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+config.APIKey)
+	req.Header.Set("User-Agent", UserAgent(config.UserAgent, "common"))
+
+	resp, err := Do(config, req)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve next page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := ReadBody(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+		return ParseResponseError("FollowNextPage", nextURL, resp, body)
+	}
+	if err := DecodeJSON(resp.Body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+	return nil
+}
+
+func resolveNextPageURL(config *Config, nextPageURL string) (string, error) {
+	origin := defaultAPIOrigin
+	if config.BaseURL != "" {
+		origin = config.BaseURL
+	}
+	base, err := url.Parse(origin)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse base URL: %w", err)
+	}
+	next, err := url.Parse(nextPageURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid next page URL %q: %w", nextPageURL, err)
+	}
+
+	resolved := base.ResolveReference(next)
+	if resolved.Scheme != base.Scheme || resolved.Host != base.Host {
+		return "", fmt.Errorf("next page URL %q is not on the API host %s", nextPageURL, base.Host)
+	}
+	return resolved.String(), nil
+}
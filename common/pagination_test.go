@@ -0,0 +1,177 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaginationUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     Pagination
+		wantNext QueryParams
+	}{
+		{
+			name:     "documented shape",
+			data:     `{"hasNextPage":true,"nextPageCursor":"abc","nextPageUrl":"https://api.example.com?cursor=abc"}`,
+			want:     Pagination{HasNextPage: true, NextPageCursor: "abc", NextPageURL: "https://api.example.com?cursor=abc"},
+			wantNext: QueryParams{Cursor: "abc"},
+		},
+		{
+			name:     "short shape implies a next page",
+			data:     `{"nextCursor":"abc","nextUrl":"https://api.example.com?cursor=abc"}`,
+			want:     Pagination{HasNextPage: true, NextPageCursor: "abc", NextPageURL: "https://api.example.com?cursor=abc"},
+			wantNext: QueryParams{Cursor: "abc"},
+		},
+		{
+			name: "hasNextPage false wins over a stale cursor",
+			data: `{"hasNextPage":false,"nextCursor":"abc"}`,
+			want: Pagination{NextPageCursor: "abc"},
+		},
+		{
+			name: "last page",
+			data: `{}`,
+			want: Pagination{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Pagination
+			if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+			if got.HasNext() != (tt.wantNext != QueryParams{}) {
+				t.Errorf("HasNext() = %v", got.HasNext())
+			}
+			if next := got.NextParams(); next != tt.wantNext {
+				t.Errorf("NextParams() = %+v, want %+v", next, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestFollowNextPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if got, want := r.Header.Get("User-Agent"), "test-agent gocommerce/"+LibraryVersion()+" (common)"; got != want {
+			t.Errorf("User-Agent = %q, want %q", got, want)
+		}
+		if r.URL.Path != "/1.0/commerce/orders" || r.URL.Query().Get("cursor") != "abc" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type": "NOT_FOUND", "message": "no such page"}`))
+			return
+		}
+		w.Write([]byte(`{"result": [{"id": "order-2"}], "pagination": {"hasNextPage": false}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	tests := []struct {
+		name       string
+		pagination Pagination
+		wantID     string
+		wantErr    bool
+		wantIs     error
+	}{
+		{
+			name:       "absolute URL",
+			pagination: Pagination{HasNextPage: true, NextPageURL: server.URL + "/1.0/commerce/orders?cursor=abc"},
+			wantID:     "order-2",
+		},
+		{
+			name:       "relative URL",
+			pagination: Pagination{HasNextPage: true, NextPageURL: "/1.0/commerce/orders?cursor=abc"},
+			wantID:     "order-2",
+		},
+		{
+			name:       "no next page",
+			pagination: Pagination{NextPageURL: server.URL + "/1.0/commerce/orders?cursor=abc"},
+			wantErr:    true,
+			wantIs:     ErrNoNextPage,
+		},
+		{
+			name:       "foreign host is refused",
+			pagination: Pagination{HasNextPage: true, NextPageURL: "https://example.com/1.0/commerce/orders?cursor=abc"},
+			wantErr:    true,
+		},
+		{
+			name:       "error response",
+			pagination: Pagination{HasNextPage: true, NextPageURL: server.URL + "/1.0/commerce/orders?cursor=stale"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out struct {
+				Result []struct {
+					ID string `json:"id"`
+				} `json:"result"`
+				Pagination Pagination `json:"pagination"`
+			}
+			err := FollowNextPage(context.Background(), config, tt.pagination, &out)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+					t.Errorf("error = %v, want %v", err, tt.wantIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FollowNextPage() unexpected error = %v", err)
+			}
+			if len(out.Result) != 1 || out.Result[0].ID != tt.wantID {
+				t.Errorf("Result = %+v, want %s", out.Result, tt.wantID)
+			}
+			if out.Pagination.HasNext() {
+				t.Error("expected the last page")
+			}
+		})
+	}
+}
+
+func TestFollowNextPageErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"type": "INVALID_REQUEST_ERROR", "message": "bad cursor"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIKey: "test-key", Client: server.Client(), BaseURL: server.URL}
+	err := FollowNextPage(context.Background(), config, Pagination{HasNextPage: true, NextPageURL: "/1.0/commerce/orders?cursor=x"}, &struct{}{})
+
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected a 400 ResponseError, got %v", err)
+	}
+}
+
+func TestPageParams(t *testing.T) {
+	params := QueryParams{Status: "PENDING"}
+
+	if got := PageParams(params, ""); got != params {
+		t.Errorf("PageParams() first page = %+v, want %+v", got, params)
+	}
+	if got := PageParams(params, "abc"); got != (QueryParams{Cursor: "abc"}) {
+		t.Errorf("PageParams() later page = %+v, want the cursor alone", got)
+	}
+}
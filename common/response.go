@@ -0,0 +1,63 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// Response is what the server returned for a call: the status and headers of
+// its last HTTP response alongside the decoded body.
+type Response[T any] struct {
+	StatusCode int
+	Header     http.Header
+	Body       T
+}
+
+// Call runs fn, one of the module's functions, and wraps its result in a
+// Response, so the status code is known even for functions that only return
+// the body:
+//
+//	resp, err := common.Call(ctx, func(ctx context.Context) (*orders.Order, error) {
+//		return orders.RetrieveSpecificOrder(ctx, config, orderID)
+//	})
+//
+// fn must make its requests with the context it is given. When fn fails after
+// getting a response, Call returns both, so the failed status and headers are
+// still available. Functions that page or fan out report their last response.
+// A read served from Config.Cache reports 200 with CacheHeader set to "hit".
+// A request skipped by a dry run or read-only mode records nothing, so Call
+// returns fn's error without a Response.
+func Call[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) (*Response[T], error) {
+	recorder := &responseRecorder{}
+	body, err := fn(WithRequestOptions(ctx, func(o *requestOptions) { o.recorder = recorder }))
+
+	statusCode, header := recorder.last()
+	if statusCode == 0 && err != nil {
+		return nil, err
+	}
+	return &Response[T]{StatusCode: statusCode, Header: header, Body: body}, err
+}
+
+type responseRecorder struct {
+	mu         sync.Mutex
+	statusCode int
+	header     http.Header
+}
+
+func (r *responseRecorder) record(resp *http.Response) {
+	r.set(resp.StatusCode, resp.Header.Clone())
+}
+
+func (r *responseRecorder) set(statusCode int, header http.Header) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statusCode = statusCode
+	r.header = header
+}
+
+func (r *responseRecorder) last() (int, http.Header) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.statusCode, r.header
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("queued"))
+	}))
+	defer server.Close()
+	config := &Config{Client: server.Client()}
+
+	get := func(path string) func(ctx context.Context) (string, error) {
+		return func(ctx context.Context) (string, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+			if err != nil {
+				return "", err
+			}
+			resp, err := Do(config, req)
+			if err != nil {
+				return "", err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode == http.StatusNotFound {
+				return "", fmt.Errorf("not found")
+			}
+			body, err := io.ReadAll(resp.Body)
+			return string(body), err
+		}
+	}
+
+	resp, err := Call(context.Background(), get("/queue"))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusAccepted || resp.Header.Get("X-Request-Id") != "req-1" || resp.Body != "queued" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	resp, err = Call(context.Background(), get("/missing"))
+	if err == nil || resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected the failed response alongside the error, got %+v, %v", resp, err)
+	}
+
+	wantErr := errors.New("invalid input")
+	resp, err = Call(context.Background(), func(context.Context) (string, error) { return "", wantErr })
+	if resp != nil || !errors.Is(err, wantErr) {
+		t.Errorf("expected no response when no request was made, got %+v, %v", resp, err)
+	}
+}
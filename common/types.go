@@ -18,8 +18,29 @@ type Config struct {
 	AccessToken    string
 	Client         *http.Client
 	IdempotencyKey *uuid.UUID
+	Cache          Cache
+	// DryRun validates, builds and encodes every request as usual but does not
+	// send the mutating ones: they are passed to DryRunLog and fail with an
+	// error wrapping ErrDryRun. When DryRunLog is nil the standard logger gets
+	// each request's method, URL, Content-Type and body length, but not the
+	// body. Reads are still sent. WithDryRun does the same for a single
+	// context. DryRunLog may be called concurrently by bulk operations.
+	DryRun    bool
+	DryRunLog func(DryRunRequest)
+	// ReadOnly makes every mutating request fail with an error wrapping
+	// ErrReadOnlyMode before it is sent, e.g. for analytics jobs that run
+	// with production credentials. It takes precedence over DryRun.
+	// WithReadOnly does the same for a single context.
+	ReadOnly bool
 }
 
+// QueryParams holds every list parameter any endpoint accepts, whether or not
+// the endpoint being called supports it.
+//
+// Deprecated: build list parameters with the calling package's ListParams
+// (products.ListParams, orders.ListParams, ...), which only expose and
+// validate the parameters the endpoint accepts, and convert them with its
+// QueryParams method for the RetrieveAll, Iter and Pages functions.
 type QueryParams struct {
 	Cursor         string
 	Filter         string
@@ -38,10 +59,10 @@ type Pagination struct {
 }
 
 type APIError struct {
-	Type    string
-	Subtype string
-	Message string
-	Detail  string
+	Type    string `json:"type"`
+	Subtype string `json:"subtype,omitempty"`
+	Message string `json:"message"`
+	Detail  string `json:"detail,omitempty"`
 }
 
 type Address struct {
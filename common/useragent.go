@@ -0,0 +1,63 @@
+package common
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+const modulePath = "github.com/j-low/gocommerce"
+
+// Version is the library version reported in User-Agent headers. Release
+// builds may set it with
+//
+//	-ldflags "-X github.com/j-low/gocommerce/common.Version=v1.2.3"
+//
+// otherwise it is read from the build info of the binary the module is
+// linked into, falling back to "devel" for builds from a local checkout.
+var Version string
+
+var (
+	versionOnce     sync.Once
+	resolvedVersion string
+)
+
+// LibraryVersion returns Version, or the version resolved from build info
+// when it is not set.
+func LibraryVersion() string {
+	if Version != "" {
+		return Version
+	}
+	versionOnce.Do(func() {
+		resolvedVersion = "devel"
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		module := &info.Main
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				module = dep
+				break
+			}
+		}
+		if module.Path == modulePath && module.Version != "" && module.Version != "(devel)" {
+			resolvedVersion = module.Version
+		}
+	})
+	return resolvedVersion
+}
+
+// UserAgent composes the User-Agent header for a request made by module, the
+// subpackage sending it: "<callerUA> gocommerce/<version> (<module>)", or
+// without the caller's part when callerUA is empty, so support and server logs
+// can tell which component of which application made a call.
+func UserAgent(callerUA, module string) string {
+	userAgent := "gocommerce/" + LibraryVersion()
+	if module != "" {
+		userAgent += " (" + module + ")"
+	}
+	if callerUA == "" {
+		return userAgent
+	}
+	return callerUA + " " + userAgent
+}
@@ -0,0 +1,38 @@
+package common
+
+import "testing"
+
+func TestUserAgent(t *testing.T) {
+	defer func(v string) { Version = v }(Version)
+	Version = "v1.2.3"
+
+	tests := []struct {
+		name     string
+		callerUA string
+		module   string
+		want     string
+	}{
+		{name: "caller and module", callerUA: "inventory-sync/2.0", module: "products", want: "inventory-sync/2.0 gocommerce/v1.2.3 (products)"},
+		{name: "no caller", module: "orders", want: "gocommerce/v1.2.3 (orders)"},
+		{name: "no module", callerUA: "job", want: "job gocommerce/v1.2.3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UserAgent(tt.callerUA, tt.module); got != tt.want {
+				t.Errorf("UserAgent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLibraryVersionFallback(t *testing.T) {
+	defer func(v string) { Version = v }(Version)
+	Version = ""
+
+	// Test binaries are built from the local checkout, whose version is
+	// "(devel)".
+	if got := LibraryVersion(); got != "devel" {
+		t.Errorf("LibraryVersion() = %q, want devel", got)
+	}
+}
@@ -3,36 +3,35 @@ package common
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 )
 
-func ParseErrorResponse(endpoint string, url string, body []byte, statusCode int) error {
-	var apiError APIError
-
-	if err := json.Unmarshal(body, &apiError); err != nil {
-		return fmt.Errorf("%s: error unmarshalling response body: status: %d", endpoint, statusCode)
-	}
-
-	errorFormat := "%s url: %s: status: %d, type: %s"
-	errorArgs := []interface{}{endpoint, url, statusCode, apiError.Type}
-
-	if apiError.Subtype != "" {
-		errorFormat += ", subtype: %s"
-		errorArgs = append(errorArgs, apiError.Subtype)
-	}
+var (
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	decimalPattern  = regexp.MustCompile(`^\d+(\.\d+)?$`)
+)
 
-	errorFormat += ", message: %s"
-	errorArgs = append(errorArgs, apiError.Message)
+// ParseErrorResponse returns a *ResponseError for an unsuccessful response
+// with the given body.
+func ParseErrorResponse(endpoint string, url string, body []byte, statusCode int) error {
+	return parseResponseError(endpoint, url, body, statusCode)
+}
 
-	if apiError.Detail != "" {
-		errorFormat += ", detail: %s"
-		errorArgs = append(errorArgs, apiError.Detail)
+func parseResponseError(endpoint, url string, body []byte, statusCode int) *ResponseError {
+	respErr := &ResponseError{Endpoint: endpoint, URL: url, StatusCode: statusCode}
+	if err := json.Unmarshal(body, &respErr.APIError); err != nil || respErr.APIError == (APIError{}) {
+		respErr.APIError = APIError{}
+		respErr.Body = errorBodySnippet(body)
 	}
-
-	return fmt.Errorf(errorFormat, errorArgs...)
+	return respErr
 }
 
+// SetUserAgent returns userAgent, or a generic value when it is empty.
+//
+// Deprecated: use UserAgent, which also reports the library version and the
+// calling module.
 func SetUserAgent(userAgent string) string {
 	if userAgent == "" {
 		return "gocommerce/default-client"
@@ -43,10 +42,7 @@ func SetUserAgent(userAgent string) string {
 
 func ValidateQueryParams(params QueryParams) error {
 	if params.Cursor != "" {
-		if params.Filter != "" || params.ModifiedAfter != "" || params.ModifiedBefore != "" ||
-			params.SortDirection != "" || params.SortField != "" || params.Status != "" {
-			return fmt.Errorf("cannot use cursor alongside other query parameters")
-		}
+		return ValidateCursor(params)
 	} else {
 		if params.ModifiedAfter != "" && params.ModifiedBefore == "" || params.ModifiedAfter == "" && params.ModifiedBefore != "" {
 			return fmt.Errorf("modifiedAfter and modifiedBefore must both be specified together or not at all")
@@ -71,6 +67,36 @@ func ValidateQueryParams(params QueryParams) error {
 	return nil
 }
 
+// ValidateCursor rejects params that set Cursor alongside any other
+// parameter, which the API does not allow.
+func ValidateCursor(params QueryParams) error {
+	if params.Cursor != "" && params != (QueryParams{Cursor: params.Cursor}) {
+		return fmt.Errorf("cannot use cursor alongside other query parameters")
+	}
+	return nil
+}
+
+// ValidateModifiedRange applies the modifiedAfter/modifiedBefore rules to the
+// typed list parameters: both or neither, with after before before.
+func ValidateModifiedRange(after, before time.Time) error {
+	if after.IsZero() != before.IsZero() {
+		return fmt.Errorf("modifiedAfter and modifiedBefore must both be specified together or not at all")
+	}
+	if !after.IsZero() && !after.Before(before) {
+		return fmt.Errorf("modifiedAfter (%s) must be before modifiedBefore (%s)", after.UTC().Format(time.RFC3339), before.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// FormatTime formats a typed list parameter time as the API expects, or ""
+// for the zero time.
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 func validateTypeParam(productType string) error {
 	types := strings.Split(productType, ",")
 	validTypes := make(map[string]bool)
@@ -94,8 +120,23 @@ func validateTypeParam(productType string) error {
 // During tests, it uses the config.BaseURL if provided, otherwise defaults
 // to the Squarespace API URL.
 func BuildBaseURL(config *Config, version, path string) (string, error) {
+	if config == nil {
+		return "", ErrNilConfig
+	}
 	if config.BaseURL != "" {
 		return fmt.Sprintf("%s/%s/%s", config.BaseURL, version, path), nil
 	}
 	return fmt.Sprintf("https://api.squarespace.com/%s/%s", version, path), nil
 }
+
+// ValidateAmount checks that an amount carries an ISO 4217 currency code and a
+// non-negative decimal value such as "10.00".
+func ValidateAmount(amount Amount) error {
+	if !currencyPattern.MatchString(amount.Currency) {
+		return fmt.Errorf("currency must be a 3-letter ISO 4217 code, got: %q", amount.Currency)
+	}
+	if !decimalPattern.MatchString(amount.Value) {
+		return fmt.Errorf("value must be a non-negative decimal string, got: %q", amount.Value)
+	}
+	return nil
+}
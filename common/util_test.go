@@ -1,9 +1,12 @@
 package common
 
 import (
+	"errors"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 )
 
 func TestParseErrorResponse(t *testing.T) {
@@ -45,7 +48,7 @@ func TestParseErrorResponse(t *testing.T) {
 			url:        "http://example.com/api",
 			statusCode: 400,
 			body:       []byte(`invalid json`),
-			wantErr:    "TestEndpoint: error unmarshalling response body: status: 400",
+			wantErr:    `TestEndpoint: error unmarshalling response body: status: 400, body: "invalid json"`,
 		},
 	}
 
@@ -62,6 +65,33 @@ func TestParseErrorResponse(t *testing.T) {
 	}
 }
 
+func TestParseResponseError(t *testing.T) {
+	page := "<html><body>502 Bad Gateway</body></html>"
+	resp := &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{"Content-Type": {"text/html"}}}
+
+	err := ParseResponseError("TestEndpoint", "http://example.com/api", resp, []byte(page))
+	want := `TestEndpoint: error unmarshalling response body: status: 502, content-type: text/html, body: "` + page + `"`
+	if err.Error() != want {
+		t.Errorf("ParseResponseError() error = %v, want %v", err, want)
+	}
+
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusBadGateway || respErr.Body != page {
+		t.Errorf("expected a *ResponseError with the raw body, got %#v", err)
+	}
+
+	long := strings.Repeat("é", maxErrorBodySnippet)
+	errors.As(ParseResponseError("TestEndpoint", "http://example.com/api", resp, []byte(long)), &respErr)
+	if !strings.HasSuffix(respErr.Body, "...") || len(respErr.Body) > maxErrorBodySnippet+3 || !utf8.ValidString(respErr.Body) {
+		t.Errorf("expected a valid truncated snippet, got %d bytes", len(respErr.Body))
+	}
+
+	errors.As(ParseResponseError("TestEndpoint", "http://example.com/api", resp, []byte(`{"type":"ERROR_TYPE","message":"Error occurred"}`)), &respErr)
+	if respErr.APIError.Type != "ERROR_TYPE" || respErr.Body != "" {
+		t.Errorf("expected a decoded API error without the raw body, got %#v", respErr)
+	}
+}
+
 func TestSetUserAgent(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -109,6 +139,18 @@ func TestValidateQueryParams(t *testing.T) {
 			},
 			wantErr: "cannot use cursor alongside other query parameters",
 		},
+		{
+			name: "cursor with type",
+			params: QueryParams{
+				Cursor: "abc123",
+				Type:   "PHYSICAL",
+			},
+			wantErr: "cannot use cursor alongside other query parameters",
+		},
+		{
+			name:   "cursor alone",
+			params: QueryParams{Cursor: "abc123"},
+		},
 		{
 			name: "only modifiedAfter",
 			params: QueryParams{
@@ -315,3 +357,58 @@ func TestBuildBaseURL(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  Amount
+		wantErr string
+	}{
+		{
+			name:   "valid amount",
+			amount: Amount{Currency: "USD", Value: "10.00"},
+		},
+		{
+			name:   "valid whole amount",
+			amount: Amount{Currency: "JPY", Value: "1000"},
+		},
+		{
+			name:    "lowercase currency",
+			amount:  Amount{Currency: "usd", Value: "10.00"},
+			wantErr: "currency must be a 3-letter ISO 4217 code",
+		},
+		{
+			name:    "missing currency",
+			amount:  Amount{Value: "10.00"},
+			wantErr: "currency must be a 3-letter ISO 4217 code",
+		},
+		{
+			name:    "missing value",
+			amount:  Amount{Currency: "USD"},
+			wantErr: "value must be a non-negative decimal string",
+		},
+		{
+			name:    "negative value",
+			amount:  Amount{Currency: "USD", Value: "-1.00"},
+			wantErr: "value must be a non-negative decimal string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAmount(tt.amount)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateAmount() unexpected error = %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ValidateAmount() error = %v, want to contain %v", err.Error(), tt.wantErr)
+				}
+			}
+		})
+	}
+}
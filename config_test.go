@@ -0,0 +1,93 @@
+package gocommerce
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/mocks"
+)
+
+type failingTransport struct {
+	t *testing.T
+}
+
+func (f failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.t.Errorf("unexpected request to %s", req.URL)
+	return nil, errors.New("unexpected request")
+}
+
+// TestClientRejectsUnusableConfigs calls every API method with a nil and a
+// zero Config and checks that each returns an error without panicking or
+// sending a request.
+func TestClientRejectsUnusableConfigs(t *testing.T) {
+	configs := map[string]*common.Config{
+		"nil":  nil,
+		"zero": {Client: &http.Client{Transport: failingTransport{t}}},
+	}
+
+	for name, config := range configs {
+		client := reflect.ValueOf(NewClient(config)).Elem()
+		for i := 0; i < client.NumField(); i++ {
+			api := client.Field(i)
+			for j := 0; j < api.NumMethod(); j++ {
+				method := api.Type().Method(j)
+				t.Run(name+"/"+method.Name, func(t *testing.T) {
+					if err := callWithZeroArgs(t, api.Method(j)); err == nil {
+						t.Fatal("expected an error")
+					}
+				})
+			}
+		}
+	}
+}
+
+// callWithZeroArgs calls method with a background context and zero values for
+// its other arguments, returning its error. Iterators are advanced once.
+func callWithZeroArgs(t *testing.T, method reflect.Value) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("panic: %v", r)
+		}
+	}()
+
+	args := []reflect.Value{reflect.ValueOf(context.Background())}
+	for k := 1; k < method.Type().NumIn(); k++ {
+		args = append(args, reflect.Zero(method.Type().In(k)))
+	}
+	results := method.Call(args)
+
+	last := results[len(results)-1]
+	if it, ok := last.Interface().(interface {
+		Next() bool
+		Err() error
+	}); ok {
+		if it.Next() {
+			t.Error("expected the iterator to yield nothing")
+		}
+		return it.Err()
+	}
+	if last.IsNil() {
+		return nil
+	}
+	return last.Interface().(error)
+}
+
+func TestReadOnlyConfig(t *testing.T) {
+	server := mocks.NewServer()
+	defer server.Close()
+
+	config := server.Config()
+	config.ReadOnly = true
+	client := NewClient(config)
+
+	if _, err := client.Products.DeleteProduct(context.Background(), "product-1"); !errors.Is(err, common.ErrReadOnlyMode) {
+		t.Errorf("DeleteProduct() error = %v, want ErrReadOnlyMode", err)
+	}
+	if _, err := client.Profiles.RetrieveAllProfiles(context.Background(), common.QueryParams{}); err != nil {
+		t.Errorf("RetrieveAllProfiles() unexpected error = %v", err)
+	}
+}
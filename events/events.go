@@ -0,0 +1,292 @@
+// Package events merges webhook deliveries and the cdc poller into one event
+// stream, preferring webhooks and polling only while deliveries have stopped.
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/j-low/gocommerce/cdc"
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/webhooks"
+)
+
+type Source string
+
+const (
+	SourceWebhook Source = "webhook"
+	SourcePoller  Source = "poller"
+)
+
+const (
+	defaultHeartbeatInterval = 5 * time.Minute
+	defaultPollInterval      = time.Minute
+)
+
+// Event is one change, from either transport. Resource and ID identify the
+// changed record the same way for both: the order ID for orders and, for
+// polled inventory, the variant ID.
+type Event struct {
+	Source   Source
+	Resource cdc.Resource
+	ID       string
+	// Topic is the webhook topic. Poller order events use order.update, the
+	// topic the webhook would have sent; no webhook topic covers inventory,
+	// so polled inventory events have none.
+	Topic string
+	// Webhook is set for webhook events and Change for poller events.
+	Webhook *webhooks.Event
+	Change  *cdc.Change
+}
+
+type Options struct {
+	// SubscriptionID is the webhook subscription heartbeats are sent to.
+	SubscriptionID string
+	// HeartbeatTopic is the topic of the test notification sent as a
+	// heartbeat. It must be one of the subscription's topics. Defaults to
+	// order.create.
+	HeartbeatTopic string
+	// HeartbeatInterval is the delay between heartbeats. Defaults to 5m.
+	HeartbeatInterval time.Duration
+	// StaleAfter is how long without a delivery or successful heartbeat
+	// before falling back to polling. Defaults to twice HeartbeatInterval.
+	StaleAfter time.Duration
+	// A test notification looks like a real one, so the stream cannot tell
+	// heartbeats apart on its own: either IsHeartbeat or HeartbeatOnly is
+	// required. IsHeartbeat reports whether a delivery is a heartbeat rather
+	// than a real event. HeartbeatOnly declares that SubscriptionID is
+	// dedicated to heartbeats, so every delivery it sends is one; real events
+	// must come from another subscription to the same endpoint.
+	IsHeartbeat   func(webhooks.Event) bool
+	HeartbeatOnly bool
+
+	// Resources and PollInterval configure the fallback poller. Resources
+	// defaults to orders, the only records webhooks cover; PollInterval
+	// defaults to 1m. Watermarks and the inventory baseline are kept in
+	// memory, so they start over when the process restarts.
+	Resources    []cdc.Resource
+	PollInterval time.Duration
+
+	// OnEvent and Events receive each event; at least one must be set. An
+	// OnEvent error fails the webhook delivery, so Squarespace redelivers
+	// it, or stops the poll before its watermark is saved.
+	OnEvent func(context.Context, Event) error
+	Events  chan<- Event
+	// OnError receives heartbeat and poll failures.
+	OnError func(error)
+	// OnFallback is called when polling starts (true) and stops (false).
+	OnFallback func(polling bool)
+}
+
+// Stream delivers webhook notifications received by Handler and, while they
+// have stopped arriving, changes found by polling. Delivery is at least once:
+// changes around a switch between transports may be seen from both.
+type Stream struct {
+	config       *common.Config
+	opts         Options
+	poller       *cdc.Poller
+	checkpointer *cdc.MemoryCheckpointer
+	now          func() time.Time
+
+	mu           sync.Mutex
+	lastDelivery time.Time
+	polling      bool
+}
+
+func NewStream(config *common.Config, opts Options) (*Stream, error) {
+	if opts.SubscriptionID == "" {
+		return nil, fmt.Errorf("SubscriptionID is required")
+	}
+	if opts.OnEvent == nil && opts.Events == nil {
+		return nil, fmt.Errorf("OnEvent or Events is required")
+	}
+	if opts.IsHeartbeat == nil && !opts.HeartbeatOnly {
+		return nil, fmt.Errorf("IsHeartbeat or HeartbeatOnly is required")
+	}
+	if opts.HeartbeatTopic == "" {
+		opts.HeartbeatTopic = webhooks.TopicOrderCreate
+	}
+	if opts.HeartbeatInterval <= 0 {
+		opts.HeartbeatInterval = defaultHeartbeatInterval
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = 2 * opts.HeartbeatInterval
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if len(opts.Resources) == 0 {
+		opts.Resources = []cdc.Resource{cdc.ResourceOrders}
+	}
+
+	s := &Stream{config: config, opts: opts, checkpointer: cdc.NewMemoryCheckpointer(), now: time.Now}
+	poller, err := cdc.NewPoller(config, cdc.Options{
+		Resources:    opts.Resources,
+		Checkpointer: s.checkpointer,
+		OnChange:     s.handleChange,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.poller = poller
+	return s, nil
+}
+
+// Handler returns the webhook endpoint feeding the stream; see
+// webhooks.NewHandler for opts.
+func (s *Stream) Handler(opts webhooks.HandlerOptions) http.Handler {
+	return webhooks.NewHandler(s.handleWebhook, opts)
+}
+
+// Polling reports whether the stream has fallen back to polling.
+func (s *Stream) Polling() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.polling
+}
+
+// Run sends heartbeats, starting immediately, and polls while deliveries have
+// stopped, until ctx is done. It returns the context's error.
+func (s *Stream) Run(ctx context.Context) error {
+	s.mu.Lock()
+	if s.lastDelivery.IsZero() {
+		s.lastDelivery = s.now()
+	}
+	s.mu.Unlock()
+
+	heartbeat := time.NewTicker(s.opts.HeartbeatInterval)
+	defer heartbeat.Stop()
+	poll := time.NewTicker(s.opts.PollInterval)
+	defer poll.Stop()
+
+	s.heartbeat(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-heartbeat.C:
+			s.heartbeat(ctx)
+		case <-poll.C:
+			if s.Polling() {
+				s.poll(ctx)
+			}
+		}
+	}
+}
+
+// heartbeat sends a test notification, counting a 2xx from the endpoint as a
+// delivery, then switches transports if the webhooks went stale or recovered.
+func (s *Stream) heartbeat(ctx context.Context) {
+	results, err := webhooks.SendTestNotifications(ctx, s.config, s.opts.SubscriptionID, s.opts.HeartbeatTopic)
+	if err == nil {
+		err = results[0].Err
+	}
+	if err == nil && !results[0].OK() {
+		err = fmt.Errorf("endpoint responded %d", results[0].StatusCode)
+	}
+
+	s.mu.Lock()
+	if err == nil {
+		s.lastDelivery = s.now()
+	}
+	s.mu.Unlock()
+	if err != nil {
+		s.reportError(fmt.Errorf("heartbeat failed: %w", err))
+	}
+
+	s.mu.Lock()
+	stale := s.now().Sub(s.lastDelivery) > s.opts.StaleAfter
+	start := stale && !s.polling
+	stop := !stale && s.polling
+	since := s.lastDelivery
+	s.mu.Unlock()
+
+	switch {
+	case start:
+		// Poll orders from the last sign of life. Inventory has no modified
+		// dates, so its first poll only takes a baseline and changes made
+		// before it are not reported.
+		for _, resource := range s.opts.Resources {
+			s.checkpointer.Save(ctx, resource, since)
+		}
+		s.setPolling(true)
+		s.poll(ctx)
+	case stop:
+		// Catch up to the moment webhooks resumed before handing back.
+		s.poll(ctx)
+		s.setPolling(false)
+	}
+}
+
+func (s *Stream) setPolling(polling bool) {
+	s.mu.Lock()
+	s.polling = polling
+	s.mu.Unlock()
+	if s.opts.OnFallback != nil {
+		s.opts.OnFallback(polling)
+	}
+}
+
+func (s *Stream) poll(ctx context.Context) {
+	if err := s.poller.Poll(ctx); err != nil && ctx.Err() == nil {
+		s.reportError(err)
+	}
+}
+
+func (s *Stream) reportError(err error) {
+	if s.opts.OnError != nil {
+		s.opts.OnError(err)
+	}
+}
+
+func (s *Stream) handleWebhook(ctx context.Context, event webhooks.Event) error {
+	s.mu.Lock()
+	s.lastDelivery = s.now()
+	s.mu.Unlock()
+	if s.isHeartbeat(event) {
+		return nil
+	}
+
+	e := Event{Source: SourceWebhook, Topic: event.Topic, Webhook: &event}
+	switch payload := event.Payload.(type) {
+	case *webhooks.OrderCreatePayload:
+		e.Resource, e.ID = cdc.ResourceOrders, payload.OrderID
+	case *webhooks.OrderUpdatePayload:
+		e.Resource, e.ID = cdc.ResourceOrders, payload.OrderID
+	}
+	return s.emit(ctx, e)
+}
+
+func (s *Stream) isHeartbeat(event webhooks.Event) bool {
+	if s.opts.HeartbeatOnly && event.SubscriptionID == s.opts.SubscriptionID {
+		return true
+	}
+	return s.opts.IsHeartbeat != nil && s.opts.IsHeartbeat(event)
+}
+
+func (s *Stream) handleChange(ctx context.Context, change cdc.Change) error {
+	e := Event{Source: SourcePoller, Resource: change.Resource, ID: change.ID, Change: &change}
+	if change.Resource == cdc.ResourceOrders {
+		e.Topic = webhooks.TopicOrderUpdate
+	}
+	return s.emit(ctx, e)
+}
+
+func (s *Stream) emit(ctx context.Context, event Event) error {
+	if s.opts.OnEvent != nil {
+		if err := s.opts.OnEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	if s.opts.Events != nil {
+		select {
+		case s.opts.Events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
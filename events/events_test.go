@@ -0,0 +1,165 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/cdc"
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/mocks"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/webhooks"
+)
+
+func TestStreamFallback(t *testing.T) {
+	server := mocks.NewServer()
+	defer server.Close()
+	config := server.Config()
+	ctx := context.Background()
+
+	subscription, err := webhooks.CreateWebhookSubscription(ctx, config, webhooks.WebhookSubscriptionRequest{
+		EndpointURL: "https://example.com/hooks",
+		Topics:      []string{webhooks.TopicOrderCreate, webhooks.TopicOrderUpdate},
+	})
+	if err != nil {
+		t.Fatalf("CreateWebhookSubscription() error = %v", err)
+	}
+
+	// The poller's windows end at the real time, so the fake clock runs an
+	// hour behind it.
+	now := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := server.Seed(mocks.Orders, orders.Order{ID: "order-polled", ModifiedOn: now.Add(30 * time.Minute).Format(time.RFC3339)}); err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	var events []Event
+	var fallbacks []bool
+	var errs []error
+	stream, err := NewStream(config, Options{
+		SubscriptionID:    subscription.ID,
+		HeartbeatInterval: 5 * time.Minute,
+		HeartbeatOnly:     true,
+		Resources:         []cdc.Resource{cdc.ResourceOrders},
+		OnEvent: func(ctx context.Context, event Event) error {
+			events = append(events, event)
+			return nil
+		},
+		OnError:    func(err error) { errs = append(errs, err) },
+		OnFallback: func(polling bool) { fallbacks = append(fallbacks, polling) },
+	})
+	if err != nil {
+		t.Fatalf("NewStream() error = %v", err)
+	}
+	stream.now = func() time.Time { return now }
+	stream.lastDelivery = now
+
+	handler := httptest.NewServer(stream.Handler(webhooks.HandlerOptions{InsecureSkipVerify: true}))
+	defer handler.Close()
+	deliver := func(body string) {
+		t.Helper()
+		resp, err := http.Post(handler.URL, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("delivery failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("delivery responded %d", resp.StatusCode)
+		}
+	}
+
+	deliver(`{"id":"n1","topic":"order.update","data":{"orderId":"order-1","update":"FULFILLED"}}`)
+	stream.heartbeat(ctx)
+	if stream.Polling() || len(errs) != 0 {
+		t.Fatalf("expected healthy webhooks, got polling=%v errors=%v", stream.Polling(), errs)
+	}
+
+	// Two failed heartbeats later, nothing has arrived for longer than
+	// StaleAfter and the stream polls from the last delivery.
+	for i := 0; i < 2; i++ {
+		now = now.Add(6 * time.Minute)
+		server.FailNext(http.MethodPost, "/actions/sendTestNotification", http.StatusServiceUnavailable, common.APIError{Type: common.TypeError, Message: "Unavailable"})
+		stream.heartbeat(ctx)
+	}
+	if !stream.Polling() || len(errs) != 2 {
+		t.Fatalf("expected polling after two failed heartbeats, got polling=%v errors=%v", stream.Polling(), errs)
+	}
+
+	now = now.Add(5 * time.Minute)
+	stream.heartbeat(ctx)
+	if stream.Polling() {
+		t.Fatal("expected webhooks to take over again after a successful heartbeat")
+	}
+
+	if len(fallbacks) != 2 || !fallbacks[0] || fallbacks[1] {
+		t.Errorf("expected fallback to start and stop, got %v", fallbacks)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected a webhook event and a polled event, got %+v", events)
+	}
+	if e := events[0]; e.Source != SourceWebhook || e.Resource != cdc.ResourceOrders || e.ID != "order-1" || e.Webhook == nil {
+		t.Errorf("unexpected webhook event %+v", e)
+	}
+	if e := events[1]; e.Source != SourcePoller || e.Topic != webhooks.TopicOrderUpdate || e.ID != "order-polled" || e.Change == nil {
+		t.Errorf("unexpected polled event %+v", e)
+	}
+}
+
+func TestStreamSkipsHeartbeats(t *testing.T) {
+	create := func(subscriptionID, orderID string) webhooks.Event {
+		return webhooks.Event{SubscriptionID: subscriptionID, Topic: webhooks.TopicOrderCreate, Payload: &webhooks.OrderCreatePayload{OrderID: orderID}}
+	}
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{
+			name: "heartbeat-only subscription",
+			opts: Options{HeartbeatOnly: true},
+		},
+		{
+			name: "IsHeartbeat",
+			opts: Options{IsHeartbeat: func(event webhooks.Event) bool {
+				return event.SubscriptionID == "subscription-1"
+			}},
+		},
+		{
+			name:    "no way to identify heartbeats",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []Event
+			tt.opts.SubscriptionID = "subscription-1"
+			tt.opts.OnEvent = func(ctx context.Context, event Event) error {
+				events = append(events, event)
+				return nil
+			}
+			stream, err := NewStream(&common.Config{}, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewStream() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			ctx := context.Background()
+			stream.handleWebhook(ctx, create("subscription-1", "order-test"))
+			stream.handleWebhook(ctx, create("subscription-2", "order-1"))
+
+			if len(events) != 1 || events[0].ID != "order-1" {
+				t.Errorf("expected only the real delivery, got %+v", events)
+			}
+			if stream.lastDelivery.IsZero() {
+				t.Error("expected heartbeats to count as deliveries")
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/mocks"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/profiles"
+)
+
+func TestOrders(t *testing.T) {
+	server := mocks.NewServer()
+	defer server.Close()
+	server.SetPageSize(2)
+
+	if err := server.Seed(mocks.Orders,
+		orders.Order{ID: "o1", CreatedOn: "2026-10-13T23:30:00-02:00"},
+		orders.Order{ID: "o2", CreatedOn: "2026-10-14T09:00:00Z"},
+		orders.Order{ID: "o3", CreatedOn: "2026-10-13T08:00:00Z"},
+		orders.Order{ID: "o4"},
+	); err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		partition Partitioning
+		wantFiles map[string]int
+	}{
+		{
+			name:      "unpartitioned",
+			wantFiles: map[string]int{"orders/orders.ndjson": 4},
+		},
+		{
+			name:      "by day",
+			partition: PartitionDay,
+			wantFiles: map[string]int{
+				"orders/dt=2026-10-14/orders.ndjson":                 2,
+				"orders/dt=2026-10-13/orders.ndjson":                 1,
+				"orders/dt=__HIVE_DEFAULT_PARTITION__/orders.ndjson": 1,
+			},
+		},
+		{
+			name:      "by month",
+			partition: PartitionMonth,
+			wantFiles: map[string]int{
+				"orders/dt=2026-10/orders.ndjson":                    3,
+				"orders/dt=__HIVE_DEFAULT_PARTITION__/orders.ndjson": 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			result, err := Orders(context.Background(), server.Config(), common.QueryParams{}, Options{Dir: dir, Partition: tt.partition})
+			if err != nil {
+				t.Fatalf("Orders() error = %v", err)
+			}
+			if result.Rows != 4 {
+				t.Errorf("expected 4 rows, got %d", result.Rows)
+			}
+			if len(result.Files) != len(tt.wantFiles) {
+				t.Errorf("expected files %v, got %v", tt.wantFiles, result.Files)
+			}
+
+			for name, wantLines := range tt.wantFiles {
+				file, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+				if err != nil {
+					t.Errorf("expected %s to be written: %v", name, err)
+					continue
+				}
+				lines := 0
+				scanner := bufio.NewScanner(file)
+				for scanner.Scan() {
+					lines++
+				}
+				file.Close()
+				if lines != wantLines {
+					t.Errorf("%s: expected %d rows, got %d", name, wantLines, lines)
+				}
+			}
+		})
+	}
+}
+
+func TestProfilesRequiresDestination(t *testing.T) {
+	server := mocks.NewServer()
+	defer server.Close()
+	if err := server.Seed(mocks.Profiles, profiles.Profile{ID: "p1"}); err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	if _, err := Profiles(context.Background(), server.Config(), common.QueryParams{}, Options{}); err == nil {
+		t.Error("expected an error without Dir or Create")
+	}
+}
+
+func TestSchema(t *testing.T) {
+	find := func(fields []Field, names ...string) *Field {
+		for _, name := range names {
+			var next *Field
+			for i := range fields {
+				if fields[i].Name == name {
+					next = &fields[i]
+				}
+			}
+			if next == nil {
+				return nil
+			}
+			if name == names[len(names)-1] {
+				return next
+			}
+			fields = next.Fields
+		}
+		return nil
+	}
+
+	tests := []struct {
+		table Table
+		path  []string
+		want  Field
+	}{
+		{TableOrders, []string{"id"}, Field{Name: "id", Type: "STRING", Mode: "NULLABLE"}},
+		{TableOrders, []string{"grandTotal", "currency"}, Field{Name: "currency", Type: "STRING", Mode: "NULLABLE"}},
+		{TableProducts, []string{"createdOn"}, Field{Name: "createdOn", Type: "TIMESTAMP", Mode: "NULLABLE"}},
+		{TableProducts, []string{"tags"}, Field{Name: "tags", Type: "STRING", Mode: "REPEATED"}},
+		{TableProducts, []string{"variants", "attributes"}, Field{Name: "attributes", Type: "JSON", Mode: "NULLABLE"}},
+		{TableProfiles, []string{"hasAccount"}, Field{Name: "hasAccount", Type: "BOOLEAN", Mode: "NULLABLE"}},
+	}
+
+	for _, tt := range tests {
+		schema, err := Schema(tt.table)
+		if err != nil {
+			t.Fatalf("Schema(%s) error = %v", tt.table, err)
+		}
+		got := find(schema, tt.path...)
+		if got == nil {
+			t.Errorf("%s: field %v not found", tt.table, tt.path)
+			continue
+		}
+		got.Fields = nil
+		if !reflect.DeepEqual(*got, tt.want) {
+			t.Errorf("%s %v: got %+v, want %+v", tt.table, tt.path, *got, tt.want)
+		}
+	}
+
+	if _, err := Schema("unknown"); err == nil {
+		t.Error("expected an error for an unknown table")
+	}
+
+	dir := t.TempDir()
+	if err := WriteSchemas(dir); err != nil {
+		t.Fatalf("WriteSchemas() error = %v", err)
+	}
+	for _, table := range Tables {
+		if _, err := os.Stat(filepath.Join(dir, string(table)+".schema.json")); err != nil {
+			t.Errorf("expected %s schema file: %v", table, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/products"
+	"github.com/j-low/gocommerce/profiles"
+	"github.com/j-low/gocommerce/transactions"
+)
+
+// Field is a BigQuery schema field, encoded the way bq and the BigQuery API
+// expect.
+type Field struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Mode   string  `json:"mode"`
+	Fields []Field `json:"fields,omitempty"`
+}
+
+var tableTypes = map[Table]reflect.Type{
+	TableOrders:       reflect.TypeOf(orders.Order{}),
+	TableTransactions: reflect.TypeOf(transactions.Document{}),
+	TableProducts:     reflect.TypeOf(products.Product{}),
+	TableProfiles:     reflect.TypeOf(profiles.Profile{}),
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Schema returns the BigQuery schema for table's NDJSON rows. Timestamps the
+// API returns as strings are typed STRING, since unset ones are exported as
+// empty strings BigQuery cannot parse as TIMESTAMP.
+func Schema(table Table) ([]Field, error) {
+	t, ok := tableTypes[table]
+	if !ok {
+		return nil, fmt.Errorf("unknown table: %s", table)
+	}
+	return structFields(t, map[reflect.Type]bool{}), nil
+}
+
+// WriteSchemas writes <table>.schema.json for every table to dir.
+func WriteSchemas(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create schema directory: %w", err)
+	}
+	for _, table := range Tables {
+		schema, err := Schema(table)
+		if err != nil {
+			return err
+		}
+		body, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s schema: %w", table, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, string(table)+".schema.json"), append(body, '\n'), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s schema: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// structFields maps t's JSON-encoded fields. seen guards against recursive
+// types, which are typed JSON instead.
+func structFields(t reflect.Type, seen map[reflect.Type]bool) []Field {
+	seen[t] = true
+	defer delete(seen, t)
+
+	var fields []Field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" && indirect(sf.Type).Kind() == reflect.Struct {
+			fields = append(fields, structFields(indirect(sf.Type), seen)...)
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field(name, sf.Type, seen))
+	}
+	return fields
+}
+
+func field(name string, t reflect.Type, seen map[reflect.Type]bool) Field {
+	mode := "NULLABLE"
+	t = indirect(t)
+	if (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8 {
+		mode = "REPEATED"
+		t = indirect(t.Elem())
+	}
+
+	f := Field{Name: name, Mode: mode}
+	switch {
+	case t == timeType:
+		f.Type = "TIMESTAMP"
+	case t.Kind() == reflect.Struct && !seen[t]:
+		f.Type = "RECORD"
+		f.Fields = structFields(t, seen)
+		if len(f.Fields) == 0 {
+			f.Type, f.Fields = "JSON", nil
+		}
+	default:
+		f.Type = scalarType(t)
+	}
+	return f
+}
+
+func scalarType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "STRING"
+	case reflect.Bool:
+		return "BOOLEAN"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "INTEGER"
+	case reflect.Float32, reflect.Float64:
+		return "FLOAT"
+	case reflect.Slice, reflect.Array:
+		return "BYTES"
+	default:
+		return "JSON"
+	}
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,76 @@
+// Package fixtures provides canonical sample payloads for the Commerce APIs,
+// shaped as the API returns them, for use in table tests.
+package fixtures
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+//go:embed data/*.json
+var files embed.FS
+
+// Fixture names. List responses include their pagination envelope; event
+// fixtures are full webhook notification bodies.
+const (
+	ProductsList             = "products_list"
+	ProductDigital           = "product_digital"
+	StorePagesList           = "store_pages_list"
+	OrdersList               = "orders_list"
+	OrderRefunded            = "order_refunded"
+	InventoryList            = "inventory_list"
+	ProfilesList             = "profiles_list"
+	TransactionsList         = "transactions_list"
+	WebhookSubscriptionsList = "webhook_subscriptions_list"
+	EventOrderCreate         = "event_order_create"
+	EventOrderUpdate         = "event_order_update"
+	EventExtensionUninstall  = "event_extension_uninstall"
+	ErrorInvalidRequest      = "error_invalid_request"
+	ErrorRateLimit           = "error_rate_limit"
+)
+
+// Names returns every fixture name in sorted order.
+func Names() []string {
+	entries, _ := fs.ReadDir(files, "data")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Bytes returns a fixture's raw JSON. The slice is a copy and may be modified.
+func Bytes(name string) ([]byte, error) {
+	data, err := files.ReadFile("data/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown fixture: %s", name)
+	}
+	return bytes.Clone(data), nil
+}
+
+// MustBytes is like Bytes but panics on an unknown name.
+func MustBytes(name string) []byte {
+	data, err := Bytes(name)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// Load decodes a fixture into v.
+func Load(name string, v interface{}) error {
+	data, err := Bytes(name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode fixture %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/inventory"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/products"
+	"github.com/j-low/gocommerce/profiles"
+	"github.com/j-low/gocommerce/transactions"
+	"github.com/j-low/gocommerce/webhooks"
+)
+
+// TestFixturesMatchTypes decodes every fixture strictly, so a fixture field
+// the module's types do not have fails here.
+func TestFixturesMatchTypes(t *testing.T) {
+	targets := map[string]func() interface{}{
+		ProductsList:             func() interface{} { return &products.RetrieveAllProductsResponse{} },
+		ProductDigital:           func() interface{} { return &products.Product{} },
+		StorePagesList:           func() interface{} { return &products.RetrieveAllStorePagesResponse{} },
+		OrdersList:               func() interface{} { return &orders.RetrieveAllOrdersResponse{} },
+		OrderRefunded:            func() interface{} { return &orders.Order{} },
+		InventoryList:            func() interface{} { return &inventory.RetrieveAllInventoryResponse{} },
+		ProfilesList:             func() interface{} { return &profiles.RetrieveAllProfilesResponse{} },
+		TransactionsList:         func() interface{} { return &transactions.RetrieveAllTransactionsResponse{} },
+		WebhookSubscriptionsList: func() interface{} { return &webhooks.RetrieveAllWebhookSubscriptionsResponse{} },
+		EventOrderCreate:         func() interface{} { return &webhooks.Event{} },
+		EventOrderUpdate:         func() interface{} { return &webhooks.Event{} },
+		EventExtensionUninstall:  func() interface{} { return &webhooks.Event{} },
+		ErrorInvalidRequest:      func() interface{} { return &common.APIError{} },
+		ErrorRateLimit:           func() interface{} { return &common.APIError{} },
+	}
+
+	names := Names()
+	if len(names) != len(targets) {
+		t.Errorf("expected %d fixtures, got %d: %v", len(targets), len(names), names)
+	}
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			target, ok := targets[name]
+			if !ok {
+				t.Fatalf("no target type for fixture %s", name)
+			}
+			decoder := json.NewDecoder(bytes.NewReader(MustBytes(name)))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(target()); err != nil {
+				t.Errorf("failed to decode: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	var order orders.Order
+	if err := Load(OrderRefunded, &order); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if order.RefundedTotal.Value != "20.16" || len(order.Fulfillments) != 1 {
+		t.Errorf("unexpected order %+v", order)
+	}
+
+	event, err := webhooks.ParseEvent(MustBytes(EventOrderUpdate))
+	if err != nil {
+		t.Fatalf("ParseEvent() error = %v", err)
+	}
+	if payload, ok := event.Payload.(*webhooks.OrderUpdatePayload); !ok || payload.Update != "CANCELED" {
+		t.Errorf("unexpected payload %+v", event.Payload)
+	}
+
+	if _, err := Bytes("missing"); err == nil {
+		t.Error("expected error for unknown fixture")
+	}
+}
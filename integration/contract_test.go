@@ -0,0 +1,111 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/inventory"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/products"
+	"github.com/j-low/gocommerce/profiles"
+	"github.com/j-low/gocommerce/transactions"
+	"github.com/j-low/gocommerce/webhooks"
+)
+
+func TestResponseShapes(t *testing.T) {
+	h := New(t)
+
+	tests := []struct {
+		path   string
+		target interface{}
+	}{
+		{"1.0/commerce/products", &products.RetrieveAllProductsResponse{}},
+		{"1.0/commerce/store_pages", &products.RetrieveAllStorePagesResponse{}},
+		{"1.0/commerce/orders", &orders.RetrieveAllOrdersResponse{}},
+		{"1.0/commerce/inventory", &inventory.RetrieveAllInventoryResponse{}},
+		{"1.0/profiles", &profiles.RetrieveAllProfilesResponse{}},
+		{"1.0/commerce/transactions", &transactions.RetrieveAllTransactionsResponse{}},
+		{"1.0/webhook_subscriptions", &webhooks.RetrieveAllWebhookSubscriptionsResponse{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			sub := *h
+			sub.t = t
+			sub.CheckShape(tt.path, tt.target)
+		})
+	}
+}
+
+func TestReadOnlyEndpoints(t *testing.T) {
+	h := New(t)
+	ctx := h.Context()
+
+	if _, err := products.RetrieveAllProductsIter(ctx, h.Config, common.QueryParams{}).Collect(); err != nil {
+		t.Errorf("RetrieveAllProductsIter() error = %v", err)
+	}
+	if _, err := orders.RetrieveAllOrders(ctx, h.Config, common.QueryParams{}); err != nil {
+		t.Errorf("RetrieveAllOrders() error = %v", err)
+	}
+	if _, err := inventory.RetrieveAllInventory(ctx, h.Config, common.QueryParams{}); err != nil {
+		t.Errorf("RetrieveAllInventory() error = %v", err)
+	}
+	if _, err := profiles.RetrieveAllProfiles(ctx, h.Config, common.QueryParams{}); err != nil {
+		t.Errorf("RetrieveAllProfiles() error = %v", err)
+	}
+	if _, err := transactions.RetrieveAllTransactions(ctx, h.Config, common.QueryParams{}); err != nil {
+		t.Errorf("RetrieveAllTransactions() error = %v", err)
+	}
+}
+
+func TestProductLifecycle(t *testing.T) {
+	h := New(t)
+	h.Sweep()
+
+	product := h.CreateProduct(products.CreateProductRequest{
+		Type: common.ProductTypePhysical,
+		Name: "product",
+		Variants: []products.ProductVariant{{
+			SKU:     h.Name("sku"),
+			Pricing: products.Pricing{BasePrice: common.Amount{Currency: "USD", Value: "1.00"}},
+			Stock:   products.Stock{Quantity: 1},
+		}},
+	})
+
+	updated, err := products.UpdateBuilder(product.ID).SetDescription("integration test").Do(h.Context(), h.Config)
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if updated.Description != "integration test" {
+		t.Errorf("expected updated description, got %q", updated.Description)
+	}
+
+	variantID := product.Variants[0].ID
+	if _, err := inventory.SetStock(h.Context(), h.Config, variantID, 3); err != nil {
+		t.Fatalf("SetStock() error = %v", err)
+	}
+	stock, err := inventory.RetrieveSpecificInventory(h.Context(), h.Config, []string{variantID})
+	if err != nil {
+		t.Fatalf("RetrieveSpecificInventory() error = %v", err)
+	}
+	if len(stock.Inventory) != 1 || stock.Inventory[0].Quantity != 3 {
+		t.Errorf("expected stock 3, got %+v", stock.Inventory)
+	}
+}
+
+func TestWebhookLifecycle(t *testing.T) {
+	h := New(t)
+
+	subscription := h.CreateWebhook(webhooks.TopicOrderCreate)
+	if subscription.Secret == "" {
+		t.Error("expected a subscription secret")
+	}
+
+	rotated, err := webhooks.RotateSubscriptionSecret(h.Context(), h.Config, subscription.ID)
+	if err != nil {
+		t.Fatalf("RotateSubscriptionSecret() error = %v", err)
+	}
+	if rotated.Secret == subscription.Secret {
+		t.Error("expected the secret to change")
+	}
+}
@@ -0,0 +1,229 @@
+// Package integration runs contract tests against a real Squarespace site to
+// detect API drift. The tests only read data or create resources they delete
+// again, and skip unless GOCOMMERCE_INTEGRATION_API_KEY is set:
+//
+//	GOCOMMERCE_INTEGRATION_API_KEY=... go test ./integration/ -v
+//
+// Use a sandbox site; products and webhook subscriptions are created on it.
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/products"
+	"github.com/j-low/gocommerce/webhooks"
+)
+
+const (
+	EnvAPIKey = "GOCOMMERCE_INTEGRATION_API_KEY"
+	// EnvAccessToken is an OAuth token for the webhook subscription tests,
+	// which are skipped without it.
+	EnvAccessToken = "GOCOMMERCE_INTEGRATION_ACCESS_TOKEN"
+	// EnvStorePageID is the store page test products are created on; the
+	// product tests are skipped without it.
+	EnvStorePageID = "GOCOMMERCE_INTEGRATION_STORE_PAGE_ID"
+	// EnvWebhookURL is an https endpoint test subscriptions point at. It is
+	// never called unless a test notification is sent.
+	EnvWebhookURL = "GOCOMMERCE_INTEGRATION_WEBHOOK_URL"
+	// EnvBaseURL overrides the API host, e.g. for a recording proxy.
+	EnvBaseURL = "GOCOMMERCE_INTEGRATION_BASE_URL"
+
+	// NamePrefix marks every resource the harness creates, so leftovers from
+	// an interrupted run can be found and swept.
+	NamePrefix = "gocommerce-integration-"
+
+	webhookMarker   = "gocommerce-integration"
+	defaultTimeout  = 30 * time.Second
+	integrationUser = "gocommerce-integration-tests"
+)
+
+type Harness struct {
+	Config      *common.Config
+	StorePageID string
+	WebhookURL  string
+
+	t testing.TB
+}
+
+// New returns a harness for the site in the environment, skipping t when no
+// API key is configured.
+func New(t testing.TB) *Harness {
+	t.Helper()
+
+	apiKey := os.Getenv(EnvAPIKey)
+	if apiKey == "" {
+		t.Skipf("%s is not set", EnvAPIKey)
+	}
+	return &Harness{
+		Config: &common.Config{
+			APIKey:      apiKey,
+			AccessToken: os.Getenv(EnvAccessToken),
+			UserAgent:   integrationUser,
+			BaseURL:     os.Getenv(EnvBaseURL),
+			Client:      &http.Client{Timeout: defaultTimeout},
+		},
+		StorePageID: os.Getenv(EnvStorePageID),
+		WebhookURL:  os.Getenv(EnvWebhookURL),
+		t:           t,
+	}
+}
+
+// Context returns a context bounded by the default timeout and t's lifetime.
+func (h *Harness) Context() context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	h.t.Cleanup(cancel)
+	return ctx
+}
+
+// Name returns a unique name carrying NamePrefix.
+func (h *Harness) Name(suffix string) string {
+	return fmt.Sprintf("%s%s-%d", NamePrefix, suffix, time.Now().UnixNano())
+}
+
+// CreateProduct creates a hidden product on the configured store page and
+// deletes it when the test ends. The product's name is replaced with a
+// prefixed one.
+func (h *Harness) CreateProduct(request products.CreateProductRequest) *products.Product {
+	h.t.Helper()
+	if h.StorePageID == "" {
+		h.t.Skipf("%s is not set", EnvStorePageID)
+	}
+
+	request.StorePageID = h.StorePageID
+	request.Name = h.Name(request.Name)
+	request.IsVisible = false
+
+	product, err := products.CreateProduct(h.Context(), h.Config, request)
+	if err != nil {
+		h.t.Fatalf("failed to create test product: %v", err)
+	}
+	h.t.Cleanup(func() {
+		if _, err := products.DeleteProduct(context.Background(), h.Config, product.ID); err != nil {
+			h.t.Errorf("failed to delete test product %s: %v", product.ID, err)
+		}
+	})
+	return product
+}
+
+// CreateWebhook subscribes the configured endpoint to topics and deletes the
+// subscription when the test ends.
+func (h *Harness) CreateWebhook(topics ...string) *webhooks.WebhookSubscription {
+	h.t.Helper()
+	h.requireWebhooks()
+
+	subscription, err := webhooks.CreateWebhookSubscription(h.Context(), h.Config, webhooks.WebhookSubscriptionRequest{
+		EndpointURL: h.webhookEndpoint(),
+		Topics:      topics,
+	})
+	if err != nil {
+		h.t.Fatalf("failed to create test webhook subscription: %v", err)
+	}
+	h.t.Cleanup(func() {
+		if _, err := webhooks.DeleteWebhookSubscription(context.Background(), h.Config, subscription.ID); err != nil {
+			h.t.Errorf("failed to delete test webhook subscription %s: %v", subscription.ID, err)
+		}
+	})
+	return subscription
+}
+
+// Sweep deletes products and webhook subscriptions left behind by earlier
+// runs that did not clean up.
+func (h *Harness) Sweep() {
+	h.t.Helper()
+	ctx := h.Context()
+
+	it := products.RetrieveAllProductsIter(ctx, h.Config, products.ListParams{}.QueryParams())
+	for it.Next() {
+		product := it.Value()
+		if !strings.HasPrefix(product.Name, NamePrefix) {
+			continue
+		}
+		if _, err := products.DeleteProduct(ctx, h.Config, product.ID); err != nil {
+			h.t.Errorf("failed to sweep product %s: %v", product.ID, err)
+		}
+	}
+	if err := it.Err(); err != nil {
+		h.t.Errorf("failed to list products to sweep: %v", err)
+	}
+
+	if h.Config.AccessToken == "" || h.WebhookURL == "" {
+		return
+	}
+	if _, err := webhooks.DeleteAll(ctx, h.Config, webhooks.DeleteFilter{EndpointPrefix: h.webhookEndpoint()}); err != nil {
+		h.t.Errorf("failed to sweep webhook subscriptions: %v", err)
+	}
+}
+
+// CheckShape GETs path, e.g. "1.0/commerce/orders", and decodes the response
+// into v, failing the test for any field v does not declare. That is how new
+// or renamed API fields surface.
+func (h *Harness) CheckShape(path string, v interface{}) {
+	h.t.Helper()
+
+	version, rest, _ := strings.Cut(path, "/")
+	url, err := common.BuildBaseURL(h.Config, version, rest)
+	if err != nil {
+		h.t.Fatalf("failed to build URL: %v", err)
+	}
+	req, err := http.NewRequestWithContext(h.Context(), http.MethodGet, url, nil)
+	if err != nil {
+		h.t.Fatalf("failed to create request: %v", err)
+	}
+	token := h.Config.APIKey
+	if strings.HasPrefix(rest, "webhook_subscriptions") {
+		h.requireWebhooks()
+		token = h.Config.AccessToken
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("User-Agent", h.Config.UserAgent)
+
+	resp, err := common.Do(h.Config, req)
+	if err != nil {
+		h.t.Fatalf("GET %s failed: %v", path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		h.t.Fatalf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		h.t.Fatalf("GET %s: status %d: %s", path, resp.StatusCode, body)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		h.t.Errorf("GET %s: response does not match %T: %v", path, v, err)
+	}
+}
+
+func (h *Harness) requireWebhooks() {
+	h.t.Helper()
+	if h.Config.AccessToken == "" {
+		h.t.Skipf("%s is not set", EnvAccessToken)
+	}
+	if h.WebhookURL == "" {
+		h.t.Skipf("%s is not set", EnvWebhookURL)
+	}
+}
+
+// webhookEndpoint tags the configured URL so Sweep only matches
+// subscriptions the harness created.
+func (h *Harness) webhookEndpoint() string {
+	separator := "?"
+	if strings.Contains(h.WebhookURL, "?") {
+		separator = "&"
+	}
+	return h.WebhookURL + separator + webhookMarker + "=1"
+}
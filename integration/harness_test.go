@@ -0,0 +1,37 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/j-low/gocommerce/mocks"
+	"github.com/j-low/gocommerce/products"
+)
+
+// TestHarnessAgainstFakeServer runs the contract tests against the mocks
+// server, so the harness itself is exercised without a real site.
+func TestHarnessAgainstFakeServer(t *testing.T) {
+	server := mocks.NewServer()
+	defer server.Close()
+
+	if err := server.Seed(mocks.Products, products.Product{ID: "stale", Name: NamePrefix + "old"}); err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	t.Setenv(EnvAPIKey, mocks.APIKey)
+	t.Setenv(EnvAccessToken, mocks.APIKey)
+	t.Setenv(EnvBaseURL, server.URL())
+	t.Setenv(EnvStorePageID, "store-page-1")
+	t.Setenv(EnvWebhookURL, "https://example.com/hooks")
+
+	t.Run("shapes", TestResponseShapes)
+	t.Run("read only", TestReadOnlyEndpoints)
+	t.Run("products", TestProductLifecycle)
+	t.Run("webhooks", TestWebhookLifecycle)
+
+	if server.Len(mocks.Products) != 0 {
+		t.Errorf("expected every test product to be cleaned up, %d remain", server.Len(mocks.Products))
+	}
+	if server.Len(mocks.WebhookSubscriptions) != 0 {
+		t.Errorf("expected every test subscription to be cleaned up, %d remain", server.Len(mocks.WebhookSubscriptions))
+	}
+}
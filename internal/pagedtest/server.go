@@ -0,0 +1,38 @@
+// Package pagedtest serves canned pages of a cursor-paginated list endpoint so
+// the list, iterator and stream helpers of every API can be tested against the
+// same fake.
+package pagedtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+)
+
+// Reporter is the part of testing.TB the server reports bad requests through.
+type Reporter interface {
+	Errorf(format string, args ...any)
+}
+
+// NewServer answers GET requests with pages[cursor], where the first page is
+// keyed by the empty cursor. It reports non-GET requests and follow-up
+// requests that send other parameters alongside the cursor to t, and answers
+// an unknown cursor with the API's 400 invalid-cursor error.
+func NewServer(t Reporter, pages map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET request, got %s", r.Method)
+		}
+		cursor := r.URL.Query().Get("cursor")
+		if cursor != "" && len(r.URL.Query()) != 1 {
+			t.Errorf("expected cursor to be sent alone, got %s", r.URL.RawQuery)
+		}
+		page, ok := pages[cursor]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"type":"INVALID_REQUEST_ERROR","message":"Invalid cursor"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(page))
+	}))
+}
@@ -0,0 +1,213 @@
+// Package redis is a minimal RESP client covering the handful of commands the
+// SDK's Redis-backed stores need, so the module does not depend on a full
+// Redis driver.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrNil is returned by Do when the server replies with a null bulk string or
+// array, such as GET on a missing key.
+var ErrNil = errors.New("redis: nil reply")
+
+// Error is an error reply sent by the server.
+type Error string
+
+func (e Error) Error() string { return string(e) }
+
+type Options struct {
+	Password string
+	DB       int
+	// DialTimeout bounds connecting to the server. Defaults to 5s.
+	DialTimeout time.Duration
+}
+
+// Client sends commands over a single connection, serialized by a mutex. The
+// connection is dialed lazily and redialed after any I/O error.
+type Client struct {
+	addr string
+	opts Options
+
+	mu   sync.Mutex
+	conn net.Conn
+	rw   *bufio.ReadWriter
+}
+
+func NewClient(addr string, opts Options) *Client {
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = 5 * time.Second
+	}
+	return &Client{addr: addr, opts: opts}
+}
+
+// Do sends a command and returns its reply: a string for simple and bulk
+// strings, an int64 for integers and a []interface{} for arrays. Error
+// replies inside an array, as EXEC returns for a failed command, are Error
+// elements, and null elements are nil.
+func (c *Client) Do(ctx context.Context, args ...string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		if err := c.connect(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := c.roundTrip(ctx, args)
+	var redisErr Error
+	if err != nil && !errors.As(err, &redisErr) && !errors.Is(err, ErrNil) {
+		c.closeLocked()
+	}
+	return reply, err
+}
+
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closeLocked()
+}
+
+func (c *Client) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: c.opts.DialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	c.conn = conn
+	c.rw = bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+
+	if c.opts.Password != "" {
+		if _, err := c.roundTrip(ctx, []string{"AUTH", c.opts.Password}); err != nil {
+			c.closeLocked()
+			return fmt.Errorf("failed to authenticate to redis: %w", err)
+		}
+	}
+	if c.opts.DB != 0 {
+		if _, err := c.roundTrip(ctx, []string{"SELECT", strconv.Itoa(c.opts.DB)}); err != nil {
+			c.closeLocked()
+			return fmt.Errorf("failed to select redis database: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) closeLocked() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn, c.rw = nil, nil
+	return err
+}
+
+func (c *Client) roundTrip(ctx context.Context, args []string) (interface{}, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Time{}
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if err := writeCommand(c.rw.Writer, args); err != nil {
+		return nil, fmt.Errorf("failed to write redis command: %w", err)
+	}
+	if err := c.rw.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to write redis command: %w", err)
+	}
+	return readReply(c.rw.Reader)
+}
+
+func writeCommand(w *bufio.Writer, args []string) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		if _, err := fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, fmt.Errorf("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		n, err := strconv.ParseInt(line[1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid integer reply %q", line)
+		}
+		return n, nil
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length %q", line)
+		}
+		if n < 0 {
+			return nil, ErrNil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length %q", line)
+		}
+		if n < 0 {
+			return nil, ErrNil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			// An element's error reply must not stop the read, or the rest
+			// of the array would be left on the connection.
+			item, err := readReply(r)
+			var redisErr Error
+			switch {
+			case errors.As(err, &redisErr):
+				item = redisErr
+			case err != nil && !errors.Is(err, ErrNil):
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("redis: malformed reply line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
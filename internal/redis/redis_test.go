@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/internal/redis/redistest"
+)
+
+func TestClientDo(t *testing.T) {
+	server := redistest.NewServer()
+	server.RequirePassword("hunter2")
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.Addr(), Options{Password: "hunter2", DB: 2})
+	defer client.Close()
+
+	if reply, err := client.Do(ctx, "SET", "key", "value", "PX", "1000"); err != nil || reply != "OK" {
+		t.Fatalf("SET = %v, %v", reply, err)
+	}
+	if reply, err := client.Do(ctx, "GET", "key"); err != nil || reply != "value" {
+		t.Errorf("GET = %v, %v", reply, err)
+	}
+	if reply, err := client.Do(ctx, "SET", "key", "other", "NX"); !errors.Is(err, ErrNil) {
+		t.Errorf("expected SET NX on existing key to return ErrNil, got %v, %v", reply, err)
+	}
+	if reply, err := client.Do(ctx, "EXISTS", "key", "missing"); err != nil || reply != int64(1) {
+		t.Errorf("EXISTS = %v, %v", reply, err)
+	}
+
+	server.Advance(2 * time.Second)
+	if _, err := client.Do(ctx, "GET", "key"); !errors.Is(err, ErrNil) {
+		t.Errorf("expected expired key to return ErrNil, got %v", err)
+	}
+
+	var redisErr Error
+	if _, err := client.Do(ctx, "INCRBY"); !errors.As(err, &redisErr) {
+		t.Errorf("expected server error reply, got %v", err)
+	}
+	if reply, err := client.Do(ctx, "PING"); err != nil || reply != "PONG" {
+		t.Errorf("expected connection to survive error reply, got %v, %v", reply, err)
+	}
+}
+
+func TestClientAuthFailure(t *testing.T) {
+	server := redistest.NewServer()
+	server.RequirePassword("hunter2")
+	defer server.Close()
+
+	client := NewClient(server.Addr(), Options{Password: "wrong"})
+	defer client.Close()
+
+	if _, err := client.Do(context.Background(), "PING"); err == nil {
+		t.Fatal("expected authentication error, got nil")
+	}
+}
+
+func TestReadReplyArrayWithError(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("*4\r\n:1\r\n-ERR boom\r\n$-1\r\n+OK\r\n+PONG\r\n"))
+
+	reply, err := readReply(r)
+	if err != nil {
+		t.Fatalf("readReply() error = %v", err)
+	}
+	if want := []interface{}{int64(1), Error("ERR boom"), nil, "OK"}; !reflect.DeepEqual(reply, want) {
+		t.Errorf("readReply() = %#v, want %#v", reply, want)
+	}
+	if reply, err := readReply(r); err != nil || reply != "PONG" {
+		t.Errorf("expected the next reply after the array, got %v, %v", reply, err)
+	}
+}
@@ -0,0 +1,257 @@
+// Package redistest runs an in-memory stand-in for a Redis server that speaks
+// enough RESP for the SDK's Redis-backed stores to be tested without one.
+package redistest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server supports PING, AUTH, SELECT, GET, SET (with EX, PX and NX), DEL and
+// EXISTS.
+type Server struct {
+	listener net.Listener
+	mu       sync.Mutex
+	values   map[string]entry
+	conns    map[net.Conn]bool
+	password string
+	now      func() time.Time
+	wg       sync.WaitGroup
+}
+
+type entry struct {
+	value   string
+	expires time.Time
+}
+
+// NewServer starts a server on a random local port.
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("redistest: failed to listen: %v", err))
+	}
+	s := &Server{listener: listener, values: make(map[string]entry), conns: make(map[net.Conn]bool), now: time.Now}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// RequirePassword makes new connections AUTH with password before running
+// other commands.
+func (s *Server) RequirePassword(password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.password = password
+}
+
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Advance moves the server's clock forward, expiring keys without sleeping.
+func (s *Server) Advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.now = func() time.Time { return now.Add(d) }
+}
+
+// Close stops the server and drops its open connections.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = true
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer func() {
+		conn.Close()
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+	r := bufio.NewReader(conn)
+	s.mu.Lock()
+	password := s.password
+	s.mu.Unlock()
+	authed := password == ""
+
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		command := strings.ToUpper(args[0])
+		var reply string
+		switch {
+		case command == "AUTH":
+			if len(args) == 2 && args[1] == password {
+				authed = true
+				reply = "+OK\r\n"
+			} else {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authed:
+			reply = "-NOAUTH Authentication required.\r\n"
+		default:
+			reply = s.execute(command, args[1:])
+		}
+
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) execute(command string, args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch command {
+	case "PING":
+		return "+PONG\r\n"
+	case "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		if len(args) != 1 {
+			return wrongArgs(command)
+		}
+		e, ok := s.lookup(args[0])
+		if !ok {
+			return "$-1\r\n"
+		}
+		return bulk(e.value)
+	case "SET":
+		if len(args) < 2 {
+			return wrongArgs(command)
+		}
+		e := entry{value: args[1]}
+		nx := false
+		for i := 2; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "NX":
+				nx = true
+			case "EX", "PX":
+				if i+1 >= len(args) {
+					return "-ERR syntax error\r\n"
+				}
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n <= 0 {
+					return "-ERR invalid expire time in 'set' command\r\n"
+				}
+				unit := time.Second
+				if strings.ToUpper(args[i]) == "PX" {
+					unit = time.Millisecond
+				}
+				e.expires = s.now().Add(time.Duration(n) * unit)
+				i++
+			default:
+				return "-ERR syntax error\r\n"
+			}
+		}
+		if _, exists := s.lookup(args[0]); nx && exists {
+			return "$-1\r\n"
+		}
+		s.values[args[0]] = e
+		return "+OK\r\n"
+	case "DEL", "EXISTS":
+		if len(args) == 0 {
+			return wrongArgs(command)
+		}
+		n := 0
+		for _, key := range args {
+			if _, ok := s.lookup(key); ok {
+				n++
+				if command == "DEL" {
+					delete(s.values, key)
+				}
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", n)
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", command)
+	}
+}
+
+func (s *Server) lookup(key string) (entry, bool) {
+	e, ok := s.values[key]
+	if ok && !e.expires.IsZero() && !s.now().Before(e.expires) {
+		delete(s.values, key)
+		return entry{}, false
+	}
+	return e, ok
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if !strings.HasPrefix(line, "*") {
+		return strings.Fields(line), nil
+	}
+
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimRight(header, "\r\n")[1:])
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func bulk(value string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+func wrongArgs(command string) string {
+	return fmt.Sprintf("-ERR wrong number of arguments for '%s' command\r\n", strings.ToLower(command))
+}
@@ -0,0 +1,145 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/j-low/gocommerce/bulk"
+	"github.com/j-low/gocommerce/common"
+)
+
+// MaxAdjustmentOperations is the most operations, across all groups, the API
+// accepts in a single stock adjustment request.
+const MaxAdjustmentOperations = 50
+
+type AdjustBatchOptions struct {
+	// ChunkSize is the number of operations per request. Defaults to, and is
+	// capped at, MaxAdjustmentOperations.
+	ChunkSize int
+	// Concurrency is the number of chunks sent at once. Defaults to 1, which
+	// sends chunks in order.
+	Concurrency int
+	// PreventNegativeStock runs CheckDecrements before sending anything and
+	// returns its error instead of relying on the API to reject overdrafts.
+	PreventNegativeStock bool
+	// Limiter, Retry and OnProgress are passed to bulk.Run. Retried chunks
+	// reuse their idempotency key.
+	Limiter    *bulk.Limiter
+	Retry      bulk.RetryPolicy
+	OnProgress func(bulk.Progress)
+}
+
+type AdjustChunkResult struct {
+	Request    AdjustStockQuantitiesRequest
+	StatusCode int
+	Err        error
+}
+
+// AdjustStockQuantitiesBatched splits request into chunks the API will accept
+// and sends each one with its own idempotency key. When config carries an
+// IdempotencyKey the chunk keys are derived from it, so retrying the same
+// batched call replays the same keys. Results are returned in chunk order; the
+// error joins every chunk failure.
+func AdjustStockQuantitiesBatched(ctx context.Context, config *common.Config, request AdjustStockQuantitiesRequest, opts AdjustBatchOptions) ([]AdjustChunkResult, error) {
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 || chunkSize > MaxAdjustmentOperations {
+		chunkSize = MaxAdjustmentOperations
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	if err := errors.Join(request.validateOperations()...); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	if opts.PreventNegativeStock {
+		if err := CheckDecrements(ctx, config, request); err != nil {
+			return nil, err
+		}
+	}
+
+	chunks := chunkAdjustments(request, chunkSize)
+
+	keys := make([]uuid.UUID, len(chunks))
+	for i := range chunks {
+		keys[i] = chunkIdempotencyKey(config.IdempotencyKey, i)
+	}
+
+	report := bulk.Run(ctx, chunks, func(ctx context.Context, i int, chunk AdjustStockQuantitiesRequest) (int, error) {
+		chunkConfig := *config
+		chunkConfig.IdempotencyKey = &keys[i]
+		return AdjustStockQuantities(ctx, &chunkConfig, chunk)
+	}, bulk.Options{
+		Concurrency: concurrency,
+		Limiter:     opts.Limiter,
+		Retry:       opts.Retry,
+		OnProgress:  opts.OnProgress,
+	})
+
+	results := make([]AdjustChunkResult, len(chunks))
+	var errs []error
+	for i, result := range report.Results {
+		results[i] = AdjustChunkResult{Request: chunks[i], StatusCode: result.Value, Err: result.Err}
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("chunk %d: %w", i, result.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// chunkAdjustments splits request into requests of at most size operations,
+// preserving the order of operations within each group.
+func chunkAdjustments(request AdjustStockQuantitiesRequest, size int) []AdjustStockQuantitiesRequest {
+	var chunks []AdjustStockQuantitiesRequest
+	var current AdjustStockQuantitiesRequest
+	count := 0
+
+	next := func() *AdjustStockQuantitiesRequest {
+		if count == size {
+			chunks = append(chunks, current)
+			current = AdjustStockQuantitiesRequest{}
+			count = 0
+		}
+		count++
+		return &current
+	}
+
+	for _, op := range request.IncrementOperations {
+		chunk := next()
+		chunk.IncrementOperations = append(chunk.IncrementOperations, op)
+	}
+	for _, op := range request.DecrementOperations {
+		chunk := next()
+		chunk.DecrementOperations = append(chunk.DecrementOperations, op)
+	}
+	for _, op := range request.SetFiniteOperations {
+		chunk := next()
+		chunk.SetFiniteOperations = append(chunk.SetFiniteOperations, op)
+	}
+	for _, variantID := range request.SetUnlimitedOperations {
+		chunk := next()
+		chunk.SetUnlimitedOperations = append(chunk.SetUnlimitedOperations, variantID)
+	}
+
+	if count > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+func chunkIdempotencyKey(base *uuid.UUID, index int) uuid.UUID {
+	if base == nil {
+		return uuid.New()
+	}
+	return uuid.NewSHA1(*base, []byte(strconv.Itoa(index)))
+}
@@ -0,0 +1,183 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/j-low/gocommerce/bulk"
+	"github.com/j-low/gocommerce/common"
+)
+
+func quantityOps(prefix string, n int) []QuantityOperation {
+	ops := make([]QuantityOperation, n)
+	for i := range ops {
+		ops[i] = QuantityOperation{VariantID: fmt.Sprintf("%s-%d", prefix, i), Quantity: 1}
+	}
+	return ops
+}
+
+func TestAdjustStockQuantitiesBatched(t *testing.T) {
+	baseKey := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	tests := []struct {
+		name        string
+		request     AdjustStockQuantitiesRequest
+		opts        AdjustBatchOptions
+		baseKey     *uuid.UUID
+		failOn      string
+		wantChunks  []int
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "splits across groups",
+			request: AdjustStockQuantitiesRequest{
+				IncrementOperations:    quantityOps("inc", 60),
+				DecrementOperations:    quantityOps("dec", 30),
+				SetUnlimitedOperations: []string{"unl-0"},
+			},
+			wantChunks: []int{50, 41},
+		},
+		{
+			name: "custom chunk size in parallel",
+			request: AdjustStockQuantitiesRequest{
+				SetFiniteOperations: quantityOps("set", 25),
+			},
+			opts:       AdjustBatchOptions{ChunkSize: 10, Concurrency: 3},
+			baseKey:    &baseKey,
+			wantChunks: []int{10, 10, 5},
+		},
+		{
+			name: "chunk failure is reported",
+			request: AdjustStockQuantitiesRequest{
+				IncrementOperations: quantityOps("inc", 15),
+			},
+			opts:        AdjustBatchOptions{ChunkSize: 10},
+			failOn:      "inc-10",
+			wantChunks:  []int{10, 5},
+			wantErr:     true,
+			errContains: "chunk 1: ",
+		},
+		{
+			name:        "empty request",
+			wantErr:     true,
+			errContains: "at least one operation is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			keys := make(map[string]bool)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body AdjustStockQuantitiesRequest
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("failed to decode request body: %v", err)
+				}
+				count := len(body.IncrementOperations) + len(body.DecrementOperations) + len(body.SetFiniteOperations) + len(body.SetUnlimitedOperations)
+				if count > MaxAdjustmentOperations {
+					t.Errorf("chunk has %d operations", count)
+				}
+
+				mu.Lock()
+				key := r.Header.Get("Idempotency-Key")
+				if key == "" || keys[key] {
+					t.Errorf("expected distinct idempotency key, got %q", key)
+				}
+				keys[key] = true
+				mu.Unlock()
+
+				for _, op := range body.IncrementOperations {
+					if op.VariantID == tt.failOn {
+						w.WriteHeader(http.StatusConflict)
+						w.Write([]byte(`{"type":"CONFLICT","message":"Insufficient stock"}`))
+						return
+					}
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:         "test-key",
+				Client:         server.Client(),
+				UserAgent:      "test-agent",
+				BaseURL:        server.URL,
+				IdempotencyKey: tt.baseKey,
+			}
+
+			results, err := AdjustStockQuantitiesBatched(context.Background(), config, tt.request, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AdjustStockQuantitiesBatched() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+			}
+
+			if len(results) != len(tt.wantChunks) {
+				t.Fatalf("expected %d chunks, got %d", len(tt.wantChunks), len(results))
+			}
+			for i, result := range results {
+				r := result.Request
+				if got := len(r.IncrementOperations) + len(r.DecrementOperations) + len(r.SetFiniteOperations) + len(r.SetUnlimitedOperations); got != tt.wantChunks[i] {
+					t.Errorf("chunk %d: expected %d operations, got %d", i, tt.wantChunks[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestChunkIdempotencyKeyIsStable(t *testing.T) {
+	base := uuid.New()
+	if chunkIdempotencyKey(&base, 1) != chunkIdempotencyKey(&base, 1) {
+		t.Error("expected derived keys to be stable")
+	}
+	if chunkIdempotencyKey(&base, 0) == chunkIdempotencyKey(&base, 1) {
+		t.Error("expected derived keys to differ per chunk")
+	}
+}
+
+func TestAdjustStockQuantitiesBatchedRetryReusesKey(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		first := len(keys) == 1
+		mu.Unlock()
+
+		if first {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"type":"ERROR","message":"Try again"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	config := &common.Config{APIKey: "test-key", Client: server.Client(), UserAgent: "test-agent", BaseURL: server.URL}
+	request := AdjustStockQuantitiesRequest{IncrementOperations: quantityOps("inc", 1)}
+
+	results, err := AdjustStockQuantitiesBatched(context.Background(), config, request, AdjustBatchOptions{
+		Retry: bulk.RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].StatusCode != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", results[0].StatusCode)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected the retry to reuse the chunk's idempotency key, got %v", keys)
+	}
+}
@@ -0,0 +1,109 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Cache holds a snapshot of every inventory record for lookups that must not
+// hit the API, such as storefront availability checks. It is safe for
+// concurrent use; each refresh replaces the whole snapshot at once.
+type Cache struct {
+	config *common.Config
+
+	refreshMu sync.Mutex
+
+	mu          sync.RWMutex
+	byVariant   map[string]InventoryRecord
+	bySKU       map[string]InventoryRecord
+	refreshedOn time.Time
+}
+
+// NewCache returns an empty cache. Call Refresh or Run to load it.
+func NewCache(config *common.Config) *Cache {
+	return &Cache{
+		config:    config,
+		byVariant: make(map[string]InventoryRecord),
+		bySKU:     make(map[string]InventoryRecord),
+	}
+}
+
+func (c *Cache) Get(variantID string) (InventoryRecord, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	record, ok := c.byVariant[variantID]
+	return record, ok
+}
+
+func (c *Cache) GetBySKU(sku string) (InventoryRecord, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	record, ok := c.bySKU[sku]
+	return record, ok
+}
+
+// RefreshedOn reports when the current snapshot was loaded, or the zero time
+// if it never has been.
+func (c *Cache) RefreshedOn() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.refreshedOn
+}
+
+// Refresh reloads every inventory record. On error the previous snapshot is
+// kept. Concurrent calls are serialized.
+func (c *Cache) Refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	byVariant := make(map[string]InventoryRecord)
+	bySKU := make(map[string]InventoryRecord)
+
+	it := RetrieveAllInventoryIter(ctx, c.config, ListParams{}.QueryParams())
+	for it.Next() {
+		record := it.Value()
+		byVariant[record.VariantID] = record
+		if record.SKU != "" {
+			bySKU[record.SKU] = record
+		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to refresh inventory cache: %w", err)
+	}
+
+	c.mu.Lock()
+	c.byVariant = byVariant
+	c.bySKU = bySKU
+	c.refreshedOn = time.Now()
+	c.mu.Unlock()
+
+	return nil
+}
+
+// Run refreshes the cache immediately and then every interval until ctx is
+// done, returning the context's error. Refresh failures are passed to onError,
+// if set, and the stale snapshot stays in place until the next success.
+func (c *Cache) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Refresh(ctx); err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
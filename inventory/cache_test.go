@@ -0,0 +1,89 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestCache(t *testing.T) {
+	var quantity, fail int32 = 5, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&fail) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"type":"ERROR","message":"Internal Server Error"}`))
+			return
+		}
+		cursor := r.URL.Query().Get("cursor")
+		w.WriteHeader(http.StatusOK)
+		if cursor == "" {
+			fmt.Fprintf(w, `{"inventory": [{"variantId": "v1", "sku": "SKU-1", "quantity": %d}], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`, atomic.LoadInt32(&quantity))
+			return
+		}
+		w.Write([]byte(`{"inventory": [{"variantId": "v2", "isUnlimited": true}], "pagination": {"hasNextPage": false}}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	cache := NewCache(config)
+	if _, ok := cache.Get("v1"); ok {
+		t.Error("expected empty cache before refresh")
+	}
+	if !cache.RefreshedOn().IsZero() {
+		t.Error("expected zero RefreshedOn before refresh")
+	}
+
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() unexpected error = %v", err)
+	}
+	if record, ok := cache.GetBySKU("SKU-1"); !ok || record.Quantity != 5 {
+		t.Errorf("GetBySKU() = %+v, %v", record, ok)
+	}
+	if record, ok := cache.Get("v2"); !ok || !record.IsUnlimited {
+		t.Errorf("Get() = %+v, %v", record, ok)
+	}
+
+	atomic.StoreInt32(&fail, 1)
+	if err := cache.Refresh(context.Background()); err == nil {
+		t.Error("expected refresh error")
+	}
+	if _, ok := cache.Get("v1"); !ok {
+		t.Error("expected stale snapshot to be kept on error")
+	}
+	atomic.StoreInt32(&fail, 0)
+
+	atomic.StoreInt32(&quantity, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- cache.Run(ctx, time.Millisecond, nil) }()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		if record, _ := cache.Get("v1"); record.Quantity == 1 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for background refresh")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -18,6 +18,10 @@ func RetrieveAllInventory(ctx context.Context, config *common.Config, params com
 		return nil, fmt.Errorf("invalid query parameters: %w", err)
 	}
 
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, InventoryAPIVersion, "commerce/inventory")
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -40,25 +44,24 @@ func RetrieveAllInventory(ctx context.Context, config *common.Config, params com
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "inventory"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveAllInventory", u.String(), body, resp.StatusCode)
+		body, err := common.ReadBody(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, common.ParseResponseError("RetrieveAllInventory", u.String(), resp, body)
 	}
 
 	var response RetrieveAllInventoryResponse
-	if err := json.Unmarshal(body, &response); err != nil {
+	if err := common.DecodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
@@ -69,11 +72,15 @@ func RetrieveSpecificInventory(ctx context.Context, config *common.Config, inven
 	if len(inventoryIDs) == 0 {
 		return nil, fmt.Errorf("no inventory IDs provided")
 	}
-	if len(inventoryIDs) > 50 {
+	if len(inventoryIDs) > maxSpecificInventoryIDs {
 		return nil, fmt.Errorf("cannot retrieve more than 50 inventory IDs")
 	}
 
 	idsPath := strings.Join(inventoryIDs, ",")
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, InventoryAPIVersion, fmt.Sprintf("commerce/inventory/%s", idsPath))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -90,9 +97,9 @@ func RetrieveSpecificInventory(ctx context.Context, config *common.Config, inven
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "inventory"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve specific inventory: %w", err)
 	}
@@ -104,7 +111,7 @@ func RetrieveSpecificInventory(ctx context.Context, config *common.Config, inven
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveSpecificInventory", u.String(), body, resp.StatusCode)
+		return nil, common.ParseResponseError("RetrieveSpecificInventory", u.String(), resp, body)
 	}
 
 	var response RetrieveSpecificInventoryResponse
@@ -116,6 +123,14 @@ func RetrieveSpecificInventory(ctx context.Context, config *common.Config, inven
 }
 
 func AdjustStockQuantities(ctx context.Context, config *common.Config, request AdjustStockQuantitiesRequest) (int, error) {
+	if err := request.Validate(); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid request: %w", err)
+	}
+
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, InventoryAPIVersion, "commerce/inventory/adjustments")
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to build base URL: %w", err)
@@ -132,14 +147,14 @@ func AdjustStockQuantities(ctx context.Context, config *common.Config, request A
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "inventory"))
 	req.Header.Set("Content-Type", "application/json")
 
 	if config.IdempotencyKey != nil {
 		req.Header.Set("Idempotency-Key", config.IdempotencyKey.String())
 	}
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to adjust stock quantities: %w", err)
 	}
@@ -153,5 +168,19 @@ func AdjustStockQuantities(ctx context.Context, config *common.Config, request A
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to read response body: %w", err)
 	}
-	return resp.StatusCode, common.ParseErrorResponse("AdjustStockQuantities", baseURL, body, resp.StatusCode)
+	return resp.StatusCode, common.ParseResponseError("AdjustStockQuantities", baseURL, resp, body)
+}
+
+// SetStock sets a single variant's stock to a finite quantity.
+func SetStock(ctx context.Context, config *common.Config, variantID string, quantity int) (int, error) {
+	return AdjustStockQuantities(ctx, config, AdjustStockQuantitiesRequest{
+		SetFiniteOperations: []QuantityOperation{{VariantID: variantID, Quantity: quantity}},
+	})
+}
+
+// SetUnlimited marks a single variant's stock as unlimited.
+func SetUnlimited(ctx context.Context, config *common.Config, variantID string) (int, error) {
+	return AdjustStockQuantities(ctx, config, AdjustStockQuantitiesRequest{
+		SetUnlimitedOperations: []string{variantID},
+	})
 }
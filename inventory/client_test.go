@@ -2,6 +2,7 @@ package inventory
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -207,6 +208,16 @@ func TestAdjustStockQuantities(t *testing.T) {
 			wantErr:     true,
 			errContains: "Invalid request",
 		},
+		{
+			name: "invalid request",
+			request: AdjustStockQuantitiesRequest{
+				DecrementOperations: []QuantityOperation{
+					{VariantID: "123", Quantity: 0},
+				},
+			},
+			wantErr:     true,
+			errContains: "invalid request: decrementOperations[0]: quantity must be positive",
+		},
 	}
 
 	for _, tt := range tests {
@@ -255,3 +266,84 @@ func TestAdjustStockQuantities(t *testing.T) {
 		})
 	}
 }
+
+func TestSetStock(t *testing.T) {
+	tests := []struct {
+		name        string
+		call        func(context.Context, *common.Config) (int, error)
+		wantBody    string
+		wantStatus  int
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "finite quantity",
+			call: func(ctx context.Context, config *common.Config) (int, error) {
+				return SetStock(ctx, config, "variant-123", 0)
+			},
+			wantBody:   `{"setFiniteOperations":[{"variantId":"variant-123","quantity":0}]}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "unlimited",
+			call: func(ctx context.Context, config *common.Config) (int, error) {
+				return SetUnlimited(ctx, config, "variant-123")
+			},
+			wantBody:   `{"setUnlimitedOperations":["variant-123"]}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "negative quantity",
+			call: func(ctx context.Context, config *common.Config) (int, error) {
+				return SetStock(ctx, config, "variant-123", -1)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			errContains: "quantity cannot be negative",
+		},
+		{
+			name: "missing variant ID",
+			call: func(ctx context.Context, config *common.Config) (int, error) {
+				return SetUnlimited(ctx, config, "")
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			errContains: "variantId is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("failed to read request body: %v", err)
+				}
+				if string(body) != tt.wantBody {
+					t.Errorf("expected request body %s, got %s", tt.wantBody, string(body))
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			status, err := tt.call(context.Background(), config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+			}
+			if status != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, status)
+			}
+		})
+	}
+}
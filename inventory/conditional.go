@@ -0,0 +1,110 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// RetrieveAllInventoryIfModified is RetrieveAllInventory as a conditional GET.
+// Pass the validators from the previous result to skip the body when the page
+// has not changed, which is cheaper for frequent polling. Zero validators make
+// an unconditional request.
+func RetrieveAllInventoryIfModified(ctx context.Context, config *common.Config, params common.QueryParams, validators common.Validators) (*common.Conditional[RetrieveAllInventoryResponse], error) {
+	if err := common.ValidateQueryParams(params); err != nil {
+		return nil, fmt.Errorf("invalid query parameters: %w", err)
+	}
+
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	baseURL, err := common.BuildBaseURL(config, InventoryAPIVersion, "commerce/inventory")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build base URL: %w", err)
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+
+	query := u.Query()
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	u.RawQuery = query.Encode()
+
+	return conditionalGet[RetrieveAllInventoryResponse](ctx, config, "RetrieveAllInventory", u.String(), validators)
+}
+
+// RetrieveSpecificInventoryIfModified is RetrieveSpecificInventory as a
+// conditional GET; see RetrieveAllInventoryIfModified.
+func RetrieveSpecificInventoryIfModified(ctx context.Context, config *common.Config, inventoryIDs []string, validators common.Validators) (*common.Conditional[RetrieveSpecificInventoryResponse], error) {
+	if len(inventoryIDs) == 0 {
+		return nil, fmt.Errorf("no inventory IDs provided")
+	}
+	if len(inventoryIDs) > maxSpecificInventoryIDs {
+		return nil, fmt.Errorf("cannot retrieve more than 50 inventory IDs")
+	}
+
+	idsPath := strings.Join(inventoryIDs, ",")
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	baseURL, err := common.BuildBaseURL(config, InventoryAPIVersion, fmt.Sprintf("commerce/inventory/%s", idsPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build base URL: %w", err)
+	}
+
+	return conditionalGet[RetrieveSpecificInventoryResponse](ctx, config, "RetrieveSpecificInventory", baseURL, validators)
+}
+
+func conditionalGet[T any](ctx context.Context, config *common.Config, endpoint, rawURL string, validators common.Validators) (*common.Conditional[T], error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+config.APIKey)
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "inventory"))
+	common.SetConditionalHeaders(req, validators)
+
+	resp, err := common.Do(config, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	returned := common.ValidatorsFromHeader(resp.Header)
+
+	if resp.StatusCode == http.StatusNotModified {
+		if returned.IsZero() {
+			returned = validators
+		}
+		return &common.Conditional[T]{Validators: returned, NotModified: true}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, common.ParseResponseError(endpoint, rawURL, resp, body)
+	}
+
+	var response T
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return &common.Conditional[T]{Value: &response, Validators: returned}, nil
+}
@@ -0,0 +1,108 @@
+package inventory
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestRetrieveAllInventoryIfModified(t *testing.T) {
+	const etag = `"v1"`
+	const lastModified = "Wed, 14 Oct 2026 10:00:00 GMT"
+
+	tests := []struct {
+		name            string
+		validators      common.Validators
+		mockStatus      int
+		wantNotModified bool
+		wantValidators  common.Validators
+		wantErr         bool
+		errContains     string
+	}{
+		{
+			name:           "unconditional request captures validators",
+			mockStatus:     http.StatusOK,
+			wantValidators: common.Validators{ETag: etag, LastModified: lastModified},
+		},
+		{
+			name:            "matching etag is not modified",
+			validators:      common.Validators{ETag: etag},
+			wantNotModified: true,
+			wantValidators:  common.Validators{ETag: etag},
+		},
+		{
+			name:           "stale etag returns the body",
+			validators:     common.Validators{ETag: `"v0"`},
+			mockStatus:     http.StatusOK,
+			wantValidators: common.Validators{ETag: etag, LastModified: lastModified},
+		},
+		{
+			name:            "if-modified-since is honored",
+			validators:      common.Validators{LastModified: lastModified},
+			wantNotModified: true,
+			wantValidators:  common.Validators{LastModified: lastModified},
+		},
+		{
+			name:        "error status",
+			mockStatus:  http.StatusTooManyRequests,
+			wantErr:     true,
+			errContains: "status: 429",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.mockStatus == http.StatusTooManyRequests {
+					w.WriteHeader(tt.mockStatus)
+					w.Write([]byte(`{"type":"RATE_LIMITED","message":"Slow down"}`))
+					return
+				}
+				if r.Header.Get("If-None-Match") == etag || r.Header.Get("If-Modified-Since") == lastModified {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", etag)
+				w.Header().Set("Last-Modified", lastModified)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"inventory": [{"variantId": "v1", "quantity": 3}], "pagination": {}}`))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			result, err := RetrieveAllInventoryIfModified(context.Background(), config, common.QueryParams{}, tt.validators)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.NotModified != tt.wantNotModified {
+				t.Errorf("expected NotModified %v, got %v", tt.wantNotModified, result.NotModified)
+			}
+			if result.Validators != tt.wantValidators {
+				t.Errorf("expected validators %+v, got %+v", tt.wantValidators, result.Validators)
+			}
+			if tt.wantNotModified && result.Value != nil {
+				t.Error("expected no value when not modified")
+			}
+			if !tt.wantNotModified && (result.Value == nil || len(result.Value.Inventory) != 1) {
+				t.Errorf("expected one inventory record, got %+v", result.Value)
+			}
+		})
+	}
+}
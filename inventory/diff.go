@@ -0,0 +1,108 @@
+package inventory
+
+import "sort"
+
+type ChangeKind string
+
+const (
+	// ChangeAdded is a variant present only in the new snapshot.
+	ChangeAdded ChangeKind = "ADDED"
+	// ChangeRemoved is a variant present only in the old snapshot.
+	ChangeRemoved ChangeKind = "REMOVED"
+	// ChangeQuantity is a finite variant whose quantity changed.
+	ChangeQuantity ChangeKind = "QUANTITY"
+	// ChangeUnlimited is a variant that switched between finite and
+	// unlimited stock; New.IsUnlimited tells which way.
+	ChangeUnlimited ChangeKind = "UNLIMITED"
+	// ChangeDetails is a variant whose SKU or descriptor changed while its
+	// stock did not.
+	ChangeDetails ChangeKind = "DETAILS"
+)
+
+// Change is one difference between two inventory snapshots.
+type Change struct {
+	Kind      ChangeKind
+	VariantID string
+	// Old is nil for added variants and New is nil for removed ones.
+	Old *InventoryRecord
+	New *InventoryRecord
+	// Delta is the change in finite stock, treating a missing record as zero.
+	// It is zero when either side is unlimited.
+	Delta int
+}
+
+// Diff compares two inventory snapshots keyed by variant ID and returns one
+// change per variant that differs, ordered by variant ID. A record whose
+// unlimited flag changed is reported as ChangeUnlimited even if its quantity
+// changed too, and a quantity change takes precedence over a details change.
+// When a snapshot lists a variant more than once its last record is used.
+func Diff(old, new []InventoryRecord) []Change {
+	before := indexRecords(old)
+	after := indexRecords(new)
+
+	variantIDs := make([]string, 0, len(before)+len(after))
+	for variantID := range before {
+		variantIDs = append(variantIDs, variantID)
+	}
+	for variantID := range after {
+		if _, ok := before[variantID]; !ok {
+			variantIDs = append(variantIDs, variantID)
+		}
+	}
+	sort.Strings(variantIDs)
+
+	var changes []Change
+	for _, variantID := range variantIDs {
+		oldRecord, hadOld := before[variantID]
+		newRecord, hasNew := after[variantID]
+
+		change := Change{VariantID: variantID}
+		if hadOld {
+			change.Old = &oldRecord
+		}
+		if hasNew {
+			change.New = &newRecord
+		}
+
+		switch {
+		case !hadOld:
+			change.Kind = ChangeAdded
+		case !hasNew:
+			change.Kind = ChangeRemoved
+		case oldRecord.IsUnlimited != newRecord.IsUnlimited:
+			change.Kind = ChangeUnlimited
+		case !newRecord.IsUnlimited && oldRecord.Quantity != newRecord.Quantity:
+			change.Kind = ChangeQuantity
+		case oldRecord != newRecord:
+			change.Kind = ChangeDetails
+		default:
+			continue
+		}
+		if !isUnlimited(change.Old) && !isUnlimited(change.New) {
+			change.Delta = finiteQuantity(change.New) - finiteQuantity(change.Old)
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func indexRecords(records []InventoryRecord) map[string]InventoryRecord {
+	index := make(map[string]InventoryRecord, len(records))
+	for _, record := range records {
+		index[record.VariantID] = record
+	}
+	return index
+}
+
+func finiteQuantity(record *InventoryRecord) int {
+	if record == nil {
+		return 0
+	}
+	return record.Quantity
+}
+
+func isUnlimited(record *InventoryRecord) bool {
+	return record != nil && record.IsUnlimited
+}
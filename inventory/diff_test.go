@@ -0,0 +1,63 @@
+package inventory
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	old := []InventoryRecord{
+		{VariantID: "v1", SKU: "SKU-1", Quantity: 5},
+		{VariantID: "v2", SKU: "SKU-2", Quantity: 3},
+		{VariantID: "v3", SKU: "SKU-3", Quantity: 4},
+		{VariantID: "v4", SKU: "SKU-4", IsUnlimited: true},
+		{VariantID: "v5", SKU: "SKU-5", Quantity: 1},
+		{VariantID: "v6", SKU: "SKU-6", Quantity: 2},
+	}
+	new := []InventoryRecord{
+		{VariantID: "v1", SKU: "SKU-1", Quantity: 2},
+		{VariantID: "v3", SKU: "SKU-3", IsUnlimited: true},
+		{VariantID: "v4", SKU: "SKU-4", Quantity: 7},
+		{VariantID: "v5", SKU: "SKU-5-NEW", Quantity: 1},
+		{VariantID: "v6", SKU: "SKU-6", Quantity: 2},
+		{VariantID: "v7", SKU: "SKU-7", Quantity: 9},
+	}
+
+	want := []struct {
+		variantID string
+		kind      ChangeKind
+		delta     int
+	}{
+		{"v1", ChangeQuantity, -3},
+		{"v2", ChangeRemoved, -3},
+		{"v3", ChangeUnlimited, 0},
+		{"v4", ChangeUnlimited, 0},
+		{"v5", ChangeDetails, 0},
+		{"v7", ChangeAdded, 9},
+	}
+
+	changes := Diff(old, new)
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), changes)
+	}
+	for i, w := range want {
+		got := changes[i]
+		if got.VariantID != w.variantID || got.Kind != w.kind || got.Delta != w.delta {
+			t.Errorf("change %d = {%s %s %d}, want {%s %s %d}", i, got.VariantID, got.Kind, got.Delta, w.variantID, w.kind, w.delta)
+		}
+	}
+
+	if changes[1].New != nil || changes[1].Old == nil || changes[1].Old.SKU != "SKU-2" {
+		t.Errorf("expected a removal to carry only the old record, got %+v", changes[1])
+	}
+	if changes[5].Old != nil || changes[5].New == nil || changes[5].New.SKU != "SKU-7" {
+		t.Errorf("expected an addition to carry only the new record, got %+v", changes[5])
+	}
+	if !changes[2].New.IsUnlimited || changes[3].New.IsUnlimited {
+		t.Errorf("expected v3 to become unlimited and v4 finite, got %+v and %+v", changes[2].New, changes[3].New)
+	}
+
+	if changes := Diff(new, new); len(changes) != 0 {
+		t.Errorf("expected no changes between identical snapshots, got %+v", changes)
+	}
+	if changes := Diff(nil, nil); changes != nil {
+		t.Errorf("expected nil for empty snapshots, got %+v", changes)
+	}
+}
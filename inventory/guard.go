@@ -0,0 +1,73 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type StockViolation struct {
+	VariantID string
+	SKU       string
+	Quantity  int
+	Decrement int
+}
+
+// NegativeStockError reports decrements that would take finite stock below
+// zero.
+type NegativeStockError struct {
+	Violations []StockViolation
+}
+
+func (e *NegativeStockError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = fmt.Sprintf("%s (sku %q): decrement %d exceeds quantity %d", v.VariantID, v.SKU, v.Decrement, v.Quantity)
+	}
+	return "decrements would make stock negative: " + strings.Join(parts, "; ")
+}
+
+// CheckDecrements fetches the current stock of every variant decremented by
+// request and returns a *NegativeStockError if any decrement exceeds it.
+// Unlimited variants and variants the API does not return are not checked.
+func CheckDecrements(ctx context.Context, config *common.Config, request AdjustStockQuantitiesRequest) error {
+	if len(request.DecrementOperations) == 0 {
+		return nil
+	}
+
+	variantIDs := make([]string, len(request.DecrementOperations))
+	for i, op := range request.DecrementOperations {
+		variantIDs[i] = op.VariantID
+	}
+
+	current, err := RetrieveManyInventory(ctx, config, variantIDs, BatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve current inventory: %w", err)
+	}
+
+	records := make(map[string]InventoryRecord, len(current.Inventory))
+	for _, record := range current.Inventory {
+		records[record.VariantID] = record
+	}
+
+	var violations []StockViolation
+	for _, op := range request.DecrementOperations {
+		record, ok := records[op.VariantID]
+		if !ok || record.IsUnlimited || op.Quantity <= record.Quantity {
+			continue
+		}
+		violations = append(violations, StockViolation{
+			VariantID: op.VariantID,
+			SKU:       record.SKU,
+			Quantity:  record.Quantity,
+			Decrement: op.Quantity,
+		})
+	}
+
+	if len(violations) > 0 {
+		return &NegativeStockError{Violations: violations}
+	}
+	return nil
+}
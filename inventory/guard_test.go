@@ -0,0 +1,118 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestCheckDecrements(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        AdjustStockQuantitiesRequest
+		wantViolations []StockViolation
+	}{
+		{
+			name: "within stock",
+			request: AdjustStockQuantitiesRequest{
+				DecrementOperations: []QuantityOperation{{VariantID: "v1", Quantity: 5}, {VariantID: "v3", Quantity: 100}},
+			},
+		},
+		{
+			name: "overdraft reported",
+			request: AdjustStockQuantitiesRequest{
+				DecrementOperations: []QuantityOperation{{VariantID: "v1", Quantity: 6}, {VariantID: "v2", Quantity: 1}, {VariantID: "v9", Quantity: 1}},
+			},
+			wantViolations: []StockViolation{
+				{VariantID: "v1", SKU: "SKU-1", Quantity: 5, Decrement: 6},
+				{VariantID: "v2", SKU: "SKU-2", Quantity: 0, Decrement: 1},
+			},
+		},
+		{
+			name: "no decrements",
+			request: AdjustStockQuantitiesRequest{
+				IncrementOperations: []QuantityOperation{{VariantID: "v1", Quantity: 1}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("expected only GET requests, got %s", r.Method)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"inventory": [
+					{"variantId": "v1", "sku": "SKU-1", "quantity": 5},
+					{"variantId": "v2", "sku": "SKU-2", "quantity": 0},
+					{"variantId": "v3", "sku": "SKU-3", "isUnlimited": true}
+				]}`))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			err := CheckDecrements(context.Background(), config, tt.request)
+			if len(tt.wantViolations) == 0 {
+				if err != nil {
+					t.Errorf("CheckDecrements() unexpected error = %v", err)
+				}
+				return
+			}
+
+			var stockErr *NegativeStockError
+			if !errors.As(err, &stockErr) {
+				t.Fatalf("expected *NegativeStockError, got %v", err)
+			}
+			if len(stockErr.Violations) != len(tt.wantViolations) {
+				t.Fatalf("expected %d violations, got %+v", len(tt.wantViolations), stockErr.Violations)
+			}
+			for i, want := range tt.wantViolations {
+				if stockErr.Violations[i] != want {
+					t.Errorf("violation %d = %+v, want %+v", i, stockErr.Violations[i], want)
+				}
+			}
+			if !strings.Contains(err.Error(), "v1 (sku \"SKU-1\"): decrement 6 exceeds quantity 5") {
+				t.Errorf("unexpected error message %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestAdjustStockQuantitiesBatchedPreventNegativeStock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			t.Error("expected no adjustment to be sent")
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"inventory": [{"variantId": "v1", "quantity": 1}]}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	_, err := AdjustStockQuantitiesBatched(context.Background(), config, AdjustStockQuantitiesRequest{
+		DecrementOperations: []QuantityOperation{{VariantID: "v1", Quantity: 2}},
+	}, AdjustBatchOptions{PreventNegativeStock: true})
+
+	var stockErr *NegativeStockError
+	if !errors.As(err, &stockErr) {
+		t.Errorf("expected *NegativeStockError, got %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// RetrieveAllInventoryIter returns an iterator over every inventory record
+// matching params, following pagination cursors automatically.
+func RetrieveAllInventoryIter(ctx context.Context, config *common.Config, params common.QueryParams) *common.Iterator[InventoryRecord] {
+	return common.NewIterator(ctx, func(ctx context.Context, cursor string) ([]InventoryRecord, common.Pagination, error) {
+		resp, err := RetrieveAllInventory(ctx, config, common.PageParams(params, cursor))
+		if err != nil {
+			return nil, common.Pagination{}, err
+		}
+		return resp.Inventory, resp.Pagination, nil
+	})
+}
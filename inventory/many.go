@@ -0,0 +1,42 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type BatchOptions struct {
+	// Concurrency is the number of chunks fetched in parallel. Defaults to 1.
+	Concurrency int
+}
+
+type RetrieveManyInventoryResponse struct {
+	Inventory []InventoryRecord
+	// Missing lists the requested IDs the API returned no record for.
+	Missing []string
+}
+
+// RetrieveManyInventory fetches any number of inventory records by splitting
+// inventoryIDs into chunks accepted by RetrieveSpecificInventory. Records are
+// returned in the order of inventoryIDs; duplicate IDs are fetched once.
+func RetrieveManyInventory(ctx context.Context, config *common.Config, inventoryIDs []string, opts BatchOptions) (*RetrieveManyInventoryResponse, error) {
+	if len(inventoryIDs) == 0 {
+		return nil, fmt.Errorf("no inventory IDs provided")
+	}
+
+	records, err := common.FetchAll(ctx, inventoryIDs, maxSpecificInventoryIDs, func(ctx context.Context, chunk []string) ([]InventoryRecord, error) {
+		resp, err := RetrieveSpecificInventory(ctx, config, chunk)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Inventory, nil
+	}, common.FetchOptions{Concurrency: opts.Concurrency})
+	if err != nil {
+		return nil, err
+	}
+
+	inventory, missing := common.OrderByIDs(inventoryIDs, records, func(record InventoryRecord) string { return record.VariantID })
+	return &RetrieveManyInventoryResponse{Inventory: inventory, Missing: missing}, nil
+}
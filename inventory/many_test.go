@@ -0,0 +1,144 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestRetrieveManyInventory(t *testing.T) {
+	makeIDs := func(n int) []string {
+		ids := make([]string, n)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("v%03d", n-i)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name         string
+		inventoryIDs []string
+		opts         BatchOptions
+		missing      string
+		failChunkOf  string
+		wantCalls    int
+		wantCount    int
+		wantMissing  []string
+		wantErr      bool
+		errContains  string
+	}{
+		{
+			name:         "multiple chunks in parallel",
+			inventoryIDs: makeIDs(120),
+			opts:         BatchOptions{Concurrency: 3},
+			wantCalls:    3,
+			wantCount:    120,
+		},
+		{
+			name:         "duplicates fetched once",
+			inventoryIDs: append(makeIDs(50), "v001"),
+			wantCalls:    1,
+			wantCount:    50,
+		},
+		{
+			name:         "missing IDs are reported",
+			inventoryIDs: makeIDs(5),
+			missing:      "v003",
+			wantCalls:    1,
+			wantCount:    4,
+			wantMissing:  []string{"v003"},
+		},
+		{
+			name:         "chunk failure",
+			inventoryIDs: makeIDs(60),
+			failChunkOf:  "v005",
+			wantErr:      true,
+			errContains:  "Inventory lookup failed",
+		},
+		{
+			name:        "no IDs",
+			wantErr:     true,
+			errContains: "no inventory IDs provided",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			calls := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				calls++
+				mu.Unlock()
+
+				ids := strings.Split(strings.TrimPrefix(r.URL.Path, "/1.0/commerce/inventory/"), ",")
+				if len(ids) > 50 {
+					t.Errorf("chunk exceeds 50 IDs: %d", len(ids))
+				}
+
+				var records []string
+				for _, id := range ids {
+					if id == tt.failChunkOf {
+						w.WriteHeader(http.StatusInternalServerError)
+						w.Write([]byte(`{"type":"ERROR","message":"Inventory lookup failed"}`))
+						return
+					}
+					if id != tt.missing {
+						records = append(records, fmt.Sprintf(`{"variantId": %q}`, id))
+					}
+				}
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintf(w, `{"inventory": [%s]}`, strings.Join(records, ","))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			resp, err := RetrieveManyInventory(context.Background(), config, tt.inventoryIDs, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RetrieveManyInventory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d requests, got %d", tt.wantCalls, calls)
+			}
+			if len(resp.Inventory) != tt.wantCount {
+				t.Fatalf("expected %d records, got %d", tt.wantCount, len(resp.Inventory))
+			}
+			if strings.Join(resp.Missing, ",") != strings.Join(tt.wantMissing, ",") {
+				t.Errorf("expected missing %v, got %v", tt.wantMissing, resp.Missing)
+			}
+
+			i := 0
+			for _, id := range tt.inventoryIDs[:tt.wantCount] {
+				if id == tt.missing {
+					continue
+				}
+				if resp.Inventory[i].VariantID != id {
+					t.Errorf("expected record %d to be %s, got %s", i, id, resp.Inventory[i].VariantID)
+				}
+				i++
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const defaultMonitorInterval = 5 * time.Minute
+
+type LowStockAlert struct {
+	VariantID  string
+	SKU        string
+	Descriptor string
+	Quantity   int
+	Threshold  int
+}
+
+type MonitorOptions struct {
+	// Interval is the delay between inventory polls. Defaults to 5m.
+	Interval time.Duration
+	// Threshold applies to every variant without an entry in Thresholds.
+	Threshold int
+	// Thresholds overrides Threshold per variant, keyed by variant ID or SKU.
+	Thresholds map[string]int
+	// OnLowStock and Alerts receive each alert; at least one must be set.
+	// Sends on Alerts block the monitor until received.
+	OnLowStock func(LowStockAlert)
+	Alerts     chan<- LowStockAlert
+	// OnError receives poll failures. The monitor keeps polling after an
+	// error either way.
+	OnError func(error)
+}
+
+// Monitor polls inventory every opts.Interval, starting immediately, and
+// reports finite variants whose quantity is below their threshold. A variant is
+// reported once when it drops below its threshold and again only after it has
+// recovered. Monitor blocks until ctx is done and returns the context's error.
+func Monitor(ctx context.Context, config *common.Config, opts MonitorOptions) error {
+	if opts.OnLowStock == nil && opts.Alerts == nil {
+		return fmt.Errorf("OnLowStock or Alerts is required")
+	}
+	if opts.Threshold <= 0 && len(opts.Thresholds) == 0 {
+		return fmt.Errorf("a positive Threshold or per-variant Thresholds are required")
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultMonitorInterval
+	}
+
+	alerted := make(map[string]bool)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := pollLowStock(ctx, config, opts, alerted); err != nil && ctx.Err() == nil && opts.OnError != nil {
+			opts.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func pollLowStock(ctx context.Context, config *common.Config, opts MonitorOptions, alerted map[string]bool) error {
+	var alerts []LowStockAlert
+	low := make(map[string]bool)
+
+	it := RetrieveAllInventoryIter(ctx, config, ListParams{}.QueryParams())
+	for it.Next() {
+		record := it.Value()
+		if record.IsUnlimited {
+			continue
+		}
+
+		threshold := opts.Threshold
+		if t, ok := opts.Thresholds[record.VariantID]; ok {
+			threshold = t
+		} else if t, ok := opts.Thresholds[record.SKU]; ok && record.SKU != "" {
+			threshold = t
+		}
+		if record.Quantity >= threshold {
+			continue
+		}
+
+		low[record.VariantID] = true
+		if !alerted[record.VariantID] {
+			alerts = append(alerts, LowStockAlert{
+				VariantID:  record.VariantID,
+				SKU:        record.SKU,
+				Descriptor: record.Descriptor,
+				Quantity:   record.Quantity,
+				Threshold:  threshold,
+			})
+		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to poll inventory: %w", err)
+	}
+
+	for variantID := range alerted {
+		if !low[variantID] {
+			delete(alerted, variantID)
+		}
+	}
+
+	for _, alert := range alerts {
+		alerted[alert.VariantID] = true
+		if opts.OnLowStock != nil {
+			opts.OnLowStock(alert)
+		}
+		if opts.Alerts != nil {
+			select {
+			case opts.Alerts <- alert:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return nil
+}
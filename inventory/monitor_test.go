@@ -0,0 +1,97 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestMonitor(t *testing.T) {
+	polls := []string{
+		`[{"variantId": "v1", "sku": "SKU-1", "quantity": 2}, {"variantId": "v2", "sku": "SKU-2", "quantity": 10}, {"variantId": "v3", "isUnlimited": true}]`,
+		`[{"variantId": "v1", "sku": "SKU-1", "quantity": 1}, {"variantId": "v2", "sku": "SKU-2", "descriptor": "Blue", "quantity": 3}]`,
+		`[{"variantId": "v1", "sku": "SKU-1", "quantity": 10}, {"variantId": "v2", "sku": "SKU-2", "quantity": 3}]`,
+		`[{"variantId": "v1", "sku": "SKU-1", "quantity": 0}, {"variantId": "v2", "sku": "SKU-2", "quantity": 3}]`,
+	}
+
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&count, 1))
+		if n == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"type":"ERROR","message":"Internal Server Error"}`))
+			return
+		}
+		if n > 2 {
+			n--
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"inventory": %s, "pagination": {"hasNextPage": false}}`, polls[min(n, len(polls))-1])
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	alerts := make(chan LowStockAlert)
+	var pollErrors int32
+	done := make(chan error, 1)
+	go func() {
+		done <- Monitor(ctx, config, MonitorOptions{
+			Interval:   time.Millisecond,
+			Threshold:  5,
+			Thresholds: map[string]int{"SKU-2": 4},
+			Alerts:     alerts,
+			OnError:    func(error) { atomic.AddInt32(&pollErrors, 1) },
+		})
+	}()
+
+	want := []LowStockAlert{
+		{VariantID: "v1", SKU: "SKU-1", Quantity: 2, Threshold: 5},
+		{VariantID: "v2", SKU: "SKU-2", Descriptor: "Blue", Quantity: 3, Threshold: 4},
+		{VariantID: "v1", SKU: "SKU-1", Quantity: 0, Threshold: 5},
+	}
+	for i, w := range want {
+		select {
+		case got := <-alerts:
+			if got != w {
+				t.Errorf("alert %d = %+v, want %+v", i, got, w)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for alert %d", i)
+		}
+	}
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if atomic.LoadInt32(&pollErrors) != 1 {
+		t.Errorf("expected 1 poll error, got %d", pollErrors)
+	}
+}
+
+func TestMonitorOptionsRequired(t *testing.T) {
+	config := &common.Config{APIKey: "test-key"}
+
+	if err := Monitor(context.Background(), config, MonitorOptions{Threshold: 5}); err == nil {
+		t.Error("expected error without a callback or channel")
+	}
+	if err := Monitor(context.Background(), config, MonitorOptions{OnLowStock: func(LowStockAlert) {}}); err == nil {
+		t.Error("expected error without a threshold")
+	}
+}
@@ -0,0 +1,21 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// ListParams is a typed alternative to common.QueryParams for
+// RetrieveAllInventory, which only accepts a cursor.
+type ListParams struct {
+	Cursor string
+}
+
+func (p ListParams) QueryParams() common.QueryParams {
+	return common.QueryParams{Cursor: p.Cursor}
+}
+
+func RetrieveAllInventoryWithParams(ctx context.Context, config *common.Config, params ListParams) (*RetrieveAllInventoryResponse, error) {
+	return RetrieveAllInventory(ctx, config, params.QueryParams())
+}
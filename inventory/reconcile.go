@@ -0,0 +1,73 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Reconcile computes the operations that bring current stock to desired, keyed
+// by variant ID. Finite variants are moved with increments and decrements,
+// while unlimited variants and variants missing from current are set outright.
+// Variants already at their desired quantity, and variants in current that are
+// not in desired, are left alone. Operations are ordered by variant ID.
+func Reconcile(current []InventoryRecord, desired map[string]int) AdjustStockQuantitiesRequest {
+	records := make(map[string]InventoryRecord, len(current))
+	for _, record := range current {
+		records[record.VariantID] = record
+	}
+
+	variantIDs := make([]string, 0, len(desired))
+	for variantID := range desired {
+		variantIDs = append(variantIDs, variantID)
+	}
+	sort.Strings(variantIDs)
+
+	var request AdjustStockQuantitiesRequest
+	for _, variantID := range variantIDs {
+		want := desired[variantID]
+		record, ok := records[variantID]
+
+		switch {
+		case !ok || record.IsUnlimited:
+			request.SetFiniteOperations = append(request.SetFiniteOperations, QuantityOperation{VariantID: variantID, Quantity: want})
+		case want > record.Quantity:
+			request.IncrementOperations = append(request.IncrementOperations, QuantityOperation{VariantID: variantID, Quantity: want - record.Quantity})
+		case want < record.Quantity:
+			request.DecrementOperations = append(request.DecrementOperations, QuantityOperation{VariantID: variantID, Quantity: record.Quantity - want})
+		}
+	}
+
+	return request
+}
+
+// Apply retrieves the current stock of every variant in desired, reconciles it
+// and sends the resulting operations with AdjustStockQuantitiesBatched. It
+// returns no results when stock already matches.
+func Apply(ctx context.Context, config *common.Config, desired map[string]int, opts AdjustBatchOptions) ([]AdjustChunkResult, error) {
+	variantIDs := make([]string, 0, len(desired))
+	for variantID, quantity := range desired {
+		if quantity < 0 {
+			return nil, fmt.Errorf("desired quantity for variant %s cannot be negative, got: %d", variantID, quantity)
+		}
+		variantIDs = append(variantIDs, variantID)
+	}
+	if len(variantIDs) == 0 {
+		return nil, nil
+	}
+	sort.Strings(variantIDs)
+
+	current, err := RetrieveManyInventory(ctx, config, variantIDs, BatchOptions{Concurrency: opts.Concurrency})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve current inventory: %w", err)
+	}
+
+	request := Reconcile(current.Inventory, desired)
+	if request.operationCount() == 0 {
+		return nil, nil
+	}
+
+	return AdjustStockQuantitiesBatched(ctx, config, request, opts)
+}
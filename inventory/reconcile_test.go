@@ -0,0 +1,107 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestReconcile(t *testing.T) {
+	current := []InventoryRecord{
+		{VariantID: "v1", Quantity: 5},
+		{VariantID: "v2", Quantity: 5},
+		{VariantID: "v3", Quantity: 5},
+		{VariantID: "v4", IsUnlimited: true},
+		{VariantID: "v6", Quantity: 9},
+	}
+	desired := map[string]int{"v1": 8, "v2": 2, "v3": 5, "v4": 10, "v5": 3}
+
+	want := AdjustStockQuantitiesRequest{
+		IncrementOperations: []QuantityOperation{{VariantID: "v1", Quantity: 3}},
+		DecrementOperations: []QuantityOperation{{VariantID: "v2", Quantity: 3}},
+		SetFiniteOperations: []QuantityOperation{{VariantID: "v4", Quantity: 10}, {VariantID: "v5", Quantity: 3}},
+	}
+
+	if got := Reconcile(current, desired); !reflect.DeepEqual(got, want) {
+		t.Errorf("Reconcile() = %+v, want %+v", got, want)
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name        string
+		desired     map[string]int
+		wantAdjust  string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:       "sends reconciled operations",
+			desired:    map[string]int{"v1": 8, "v2": 5},
+			wantAdjust: `{"incrementOperations":[{"variantId":"v1","quantity":3}]}`,
+		},
+		{
+			name:    "already in sync",
+			desired: map[string]int{"v2": 5},
+		},
+		{
+			name:        "negative quantity",
+			desired:     map[string]int{"v1": -1},
+			wantErr:     true,
+			errContains: "cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var adjusted string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/commerce/inventory/"):
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(`{"inventory": [{"variantId": "v1", "quantity": 5}, {"variantId": "v2", "quantity": 5}]}`))
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/commerce/inventory/adjustments"):
+					var body json.RawMessage
+					json.NewDecoder(r.Body).Decode(&body)
+					adjusted = string(body)
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			results, err := Apply(context.Background(), config, tt.desired, AdjustBatchOptions{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if adjusted != tt.wantAdjust {
+				t.Errorf("expected adjustment %s, got %s", tt.wantAdjust, adjusted)
+			}
+			if tt.wantAdjust == "" && results != nil {
+				t.Errorf("expected no results, got %+v", results)
+			}
+		})
+	}
+}
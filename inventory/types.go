@@ -4,6 +4,8 @@ import "github.com/j-low/gocommerce/common"
 
 const (
 	InventoryAPIVersion = "1.0"
+
+	maxSpecificInventoryIDs = 50
 )
 
 type RetrieveAllInventoryResponse struct {
@@ -0,0 +1,74 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the request for problems the API would reject, returning all
+// of them joined into a single error. Increment and decrement quantities must
+// be positive, set-finite quantities non-negative, and each variant may appear
+// in only one operation.
+func (r AdjustStockQuantitiesRequest) Validate() error {
+	errs := r.validateOperations()
+
+	if count := r.operationCount(); count > MaxAdjustmentOperations {
+		errs = append(errs, fmt.Errorf("request has %d operations, the limit is %d", count, MaxAdjustmentOperations))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateOperations runs every check except the per-request operation limit,
+// which AdjustStockQuantitiesBatched handles by chunking.
+func (r AdjustStockQuantitiesRequest) validateOperations() []error {
+	var errs []error
+
+	if r.operationCount() == 0 {
+		return []error{fmt.Errorf("at least one operation is required")}
+	}
+
+	seen := make(map[string]string)
+	checkVariant := func(label, variantID string) {
+		if variantID == "" {
+			errs = append(errs, fmt.Errorf("%s: variantId is required", label))
+			return
+		}
+		if first, ok := seen[variantID]; ok {
+			errs = append(errs, fmt.Errorf("%s: variant %s already appears in %s", label, variantID, first))
+			return
+		}
+		seen[variantID] = label
+	}
+
+	for i, op := range r.IncrementOperations {
+		label := fmt.Sprintf("incrementOperations[%d]", i)
+		checkVariant(label, op.VariantID)
+		if op.Quantity <= 0 {
+			errs = append(errs, fmt.Errorf("%s: quantity must be positive, got: %d", label, op.Quantity))
+		}
+	}
+	for i, op := range r.DecrementOperations {
+		label := fmt.Sprintf("decrementOperations[%d]", i)
+		checkVariant(label, op.VariantID)
+		if op.Quantity <= 0 {
+			errs = append(errs, fmt.Errorf("%s: quantity must be positive, got: %d", label, op.Quantity))
+		}
+	}
+	for i, op := range r.SetFiniteOperations {
+		label := fmt.Sprintf("setFiniteOperations[%d]", i)
+		checkVariant(label, op.VariantID)
+		if op.Quantity < 0 {
+			errs = append(errs, fmt.Errorf("%s: quantity cannot be negative, got: %d", label, op.Quantity))
+		}
+	}
+	for i, variantID := range r.SetUnlimitedOperations {
+		checkVariant(fmt.Sprintf("setUnlimitedOperations[%d]", i), variantID)
+	}
+
+	return errs
+}
+
+func (r AdjustStockQuantitiesRequest) operationCount() int {
+	return len(r.IncrementOperations) + len(r.DecrementOperations) + len(r.SetFiniteOperations) + len(r.SetUnlimitedOperations)
+}
@@ -0,0 +1,71 @@
+package inventory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAdjustStockQuantitiesRequestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     AdjustStockQuantitiesRequest
+		errContains []string
+	}{
+		{
+			name: "valid request",
+			request: AdjustStockQuantitiesRequest{
+				IncrementOperations:    []QuantityOperation{{VariantID: "v1", Quantity: 1}},
+				SetFiniteOperations:    []QuantityOperation{{VariantID: "v2", Quantity: 0}},
+				SetUnlimitedOperations: []string{"v3"},
+			},
+		},
+		{
+			name:        "empty request",
+			errContains: []string{"at least one operation is required"},
+		},
+		{
+			name: "reports every problem",
+			request: AdjustStockQuantitiesRequest{
+				IncrementOperations:    []QuantityOperation{{VariantID: "v1", Quantity: -1}},
+				DecrementOperations:    []QuantityOperation{{VariantID: "v1", Quantity: 2}, {Quantity: 1}},
+				SetFiniteOperations:    []QuantityOperation{{VariantID: "v2", Quantity: -5}},
+				SetUnlimitedOperations: []string{"v2"},
+			},
+			errContains: []string{
+				"incrementOperations[0]: quantity must be positive, got: -1",
+				"decrementOperations[0]: variant v1 already appears in incrementOperations[0]",
+				"decrementOperations[1]: variantId is required",
+				"setFiniteOperations[0]: quantity cannot be negative, got: -5",
+				"setUnlimitedOperations[0]: variant v2 already appears in setFiniteOperations[0]",
+			},
+		},
+		{
+			name: "too many operations",
+			request: AdjustStockQuantitiesRequest{
+				IncrementOperations: quantityOps("inc", MaxAdjustmentOperations+1),
+			},
+			errContains: []string{"request has 51 operations, the limit is 50"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if len(tt.errContains) == 0 {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			for _, want := range tt.errContains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error message should contain %q, got %q", want, err.Error())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package mocks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Responder produces the response to a request.
+type Responder func(*http.Request) (*http.Response, error)
+
+// MockHTTPClient is an http.RoundTripper that answers requests with DoFunc and
+// records each one. Use Client or Config to plug it into the module.
+type MockHTTPClient struct {
+	DoFunc Responder
+
+	mu       sync.Mutex
+	requests []RecordedRequest
+}
+
+// RecordedRequest is a captured request with its body already read.
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// JSON decodes the request body into v.
+func (r RecordedRequest) JSON(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return m.RoundTrip(req)
+}
+
+func (m *MockHTTPClient) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	m.mu.Lock()
+	m.requests = append(m.requests, RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+		Header: req.Header.Clone(),
+		Body:   body,
+	})
+	do := m.DoFunc
+	m.mu.Unlock()
+
+	if do == nil {
+		return nil, fmt.Errorf("mocks: no DoFunc set for %s %s", req.Method, req.URL.Path)
+	}
+	resp, err := do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body == nil {
+		resp.Body = http.NoBody
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	resp.Request = req
+	return resp, nil
+}
+
+// Client returns an *http.Client that sends requests through m.
+func (m *MockHTTPClient) Client() *http.Client {
+	return &http.Client{Transport: m}
+}
+
+// Config returns a Config whose requests go through m.
+func (m *MockHTTPClient) Config() *common.Config {
+	return &common.Config{
+		APIKey:      APIKey,
+		AccessToken: APIKey,
+		Client:      m.Client(),
+		UserAgent:   "gocommerce-mocks",
+		BaseURL:     "https://api.example.test",
+	}
+}
+
+// Requests returns the requests seen so far in order.
+func (m *MockHTTPClient) Requests() []RecordedRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]RecordedRequest(nil), m.requests...)
+}
+
+// TB is the part of testing.TB the assertions report through. Pass the test's
+// *testing.T, or any other reporter with these methods.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Fatal(args ...any)
+}
+
+// LastRequest returns the most recent request, failing t if there is none.
+func (m *MockHTTPClient) LastRequest(t TB) RecordedRequest {
+	t.Helper()
+	requests := m.Requests()
+	if len(requests) == 0 {
+		t.Fatal("mocks: no requests were made")
+	}
+	return requests[len(requests)-1]
+}
+
+func (m *MockHTTPClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = nil
+}
+
+// NewResponse builds a response carrying body.
+func NewResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// Respond answers every request with status and body.
+func Respond(status int, body string) Responder {
+	return func(*http.Request) (*http.Response, error) {
+		return NewResponse(status, body), nil
+	}
+}
+
+// RespondJSON answers every request with status and v marshalled as JSON.
+func RespondJSON(status int, v interface{}) Responder {
+	data, err := json.Marshal(v)
+	return func(*http.Request) (*http.Response, error) {
+		if err != nil {
+			return nil, fmt.Errorf("mocks: failed to marshal response: %w", err)
+		}
+		return NewResponse(status, string(data)), nil
+	}
+}
+
+// RespondError answers every request with status and an API error body.
+func RespondError(status int, errorType, message string) Responder {
+	return RespondJSON(status, common.APIError{Type: errorType, Message: message})
+}
+
+// RespondNoContent answers every request with 204 and no body.
+func RespondNoContent() Responder {
+	return Respond(http.StatusNoContent, "")
+}
+
+// Sequence answers successive requests with successive responders, repeating
+// the last one once they run out. It panics if no responder is given.
+func Sequence(responders ...Responder) Responder {
+	if len(responders) == 0 {
+		panic("mocks: Sequence requires at least one responder")
+	}
+	var mu sync.Mutex
+	next := 0
+	return func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		responder := responders[min(next, len(responders)-1)]
+		next++
+		mu.Unlock()
+		return responder(req)
+	}
+}
+
+// AssertRequest fails t unless req has method and its path ends with path.
+func AssertRequest(t TB, req RecordedRequest, method, path string) {
+	t.Helper()
+	if req.Method != method {
+		t.Errorf("expected %s request, got %s", method, req.Method)
+	}
+	if !strings.HasSuffix(req.Path, path) {
+		t.Errorf("expected path ending in %s, got %s", path, req.Path)
+	}
+}
+
+// AssertHeader fails t unless req's header key equals want.
+func AssertHeader(t TB, req RecordedRequest, key, want string) {
+	t.Helper()
+	if got := req.Header.Get(key); got != want {
+		t.Errorf("expected header %s %q, got %q", key, want, got)
+	}
+}
+
+// AssertJSONBody fails t unless req's body is JSON equal to want, ignoring
+// formatting and key order.
+func AssertJSONBody(t TB, req RecordedRequest, want string) {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(req.Body, &gotValue); err != nil {
+		t.Errorf("request body is not JSON: %v: %s", err, req.Body)
+		return
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Errorf("expected body is not JSON: %v", err)
+		return
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("expected request body %s, got %s", want, req.Body)
+	}
+}
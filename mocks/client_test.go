@@ -0,0 +1,79 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/inventory"
+	"github.com/j-low/gocommerce/webhooks"
+)
+
+func TestMockHTTPClient(t *testing.T) {
+	mock := &MockHTTPClient{
+		DoFunc: Sequence(
+			RespondError(http.StatusConflict, "CONFLICT", "Stock changed"),
+			RespondNoContent(),
+		),
+	}
+	config := mock.Config()
+
+	request := inventory.AdjustStockQuantitiesRequest{
+		DecrementOperations: []inventory.QuantityOperation{{VariantID: "v1", Quantity: 2}},
+	}
+	_, err := inventory.AdjustStockQuantities(context.Background(), config, request)
+	if err == nil || !strings.Contains(err.Error(), "status: 409, type: CONFLICT, message: Stock changed") {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+	if _, err := inventory.AdjustStockQuantities(context.Background(), config, request); err != nil {
+		t.Errorf("expected second call to succeed, got %v", err)
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	last := mock.LastRequest(t)
+	AssertRequest(t, last, http.MethodPost, "/commerce/inventory/adjustments")
+	AssertHeader(t, last, "Authorization", "Bearer "+APIKey)
+	AssertJSONBody(t, last, `{"decrementOperations": [{"quantity": 2, "variantId": "v1"}]}`)
+
+	mock.Reset()
+	if len(mock.Requests()) != 0 {
+		t.Error("expected Reset to clear recorded requests")
+	}
+}
+
+func TestRespondJSON(t *testing.T) {
+	mock := &MockHTTPClient{DoFunc: RespondJSON(http.StatusOK, webhooks.RetrieveAllWebhookSubscriptionsResponse{
+		WebhookSubscriptions: []webhooks.WebhookSubscription{{ID: "sub-1"}},
+	})}
+
+	resp, err := webhooks.RetrieveAllWebhookSubscriptions(context.Background(), mock.Config())
+	if err != nil {
+		t.Fatalf("RetrieveAllWebhookSubscriptions() error = %v", err)
+	}
+	if len(resp.WebhookSubscriptions) != 1 || resp.WebhookSubscriptions[0].ID != "sub-1" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	AssertRequest(t, mock.LastRequest(t), http.MethodGet, "/webhook_subscriptions")
+}
+
+func TestMockHTTPClientWithoutDoFunc(t *testing.T) {
+	mock := &MockHTTPClient{}
+	_, err := webhooks.RetrieveAllWebhookSubscriptions(context.Background(), mock.Config())
+	if err == nil || !strings.Contains(err.Error(), "no DoFunc set") {
+		t.Errorf("expected missing DoFunc error, got %v", err)
+	}
+}
+
+func TestSequenceWithoutResponders(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "at least one responder") {
+			t.Errorf("expected a clear panic, got %v", r)
+		}
+	}()
+	Sequence()
+}
@@ -0,0 +1,130 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/products"
+	"github.com/j-low/gocommerce/transactions"
+)
+
+func seedCatalog(tb testing.TB, server *Server, n int) {
+	tb.Helper()
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		if err := server.Seed(Products, products.Product{
+			ID:          fmt.Sprintf("product-%d", i),
+			Type:        common.ProductTypePhysical,
+			StorePageID: "store-page-1",
+			Name:        fmt.Sprintf("Organic Cotton Tee %d", i),
+			Description: "<p>A heavyweight organic cotton tee, garment dyed and pre-shrunk.</p>",
+			URL:         fmt.Sprintf("https://example.com/shop/p/organic-cotton-tee-%d", i),
+			URLSlug:     fmt.Sprintf("organic-cotton-tee-%d", i),
+			Tags:        []string{"apparel", "tees", "organic"},
+			IsVisible:   true,
+			Variants: []products.ProductVariant{{
+				ID:         fmt.Sprintf("variant-%d", i),
+				SKU:        fmt.Sprintf("SQ-TEE-%05d", i),
+				Pricing:    products.Pricing{BasePrice: common.Amount{Currency: "USD", Value: "32.00"}},
+				Attributes: map[string]string{"Size": "M", "Color": "Natural"},
+			}},
+			CreatedOn:  created,
+			ModifiedOn: created,
+		}); err != nil {
+			tb.Fatalf("Seed() error = %v", err)
+		}
+	}
+}
+
+func seedTransactions(tb testing.TB, server *Server, n int) {
+	tb.Helper()
+	for i := 0; i < n; i++ {
+		total := common.Amount{Currency: "USD", Value: "64.00"}
+		if err := server.Seed(Transactions, transactions.Document{
+			ID:              fmt.Sprintf("document-%d", i),
+			CreatedOn:       "2026-01-01T00:00:00Z",
+			ModifiedOn:      "2026-01-01T00:00:00Z",
+			TotalSales:      total,
+			TotalNetSales:   total,
+			Total:           total,
+			TotalNetPayment: total,
+			Payments: []transactions.Payment{{
+				ID:                    fmt.Sprintf("payment-%d", i),
+				Amount:                total,
+				NetAmount:             total,
+				Provider:              "STRIPE",
+				PaidOn:                "2026-01-01T00:00:00Z",
+				ExternalTransactionID: fmt.Sprintf("ch_%020d", i),
+			}},
+		}); err != nil {
+			tb.Fatalf("Seed() error = %v", err)
+		}
+	}
+}
+
+func TestServerCompression(t *testing.T) {
+	var sent [2]int64
+	for i, compress := range []bool{false, true} {
+		server := NewServer()
+		server.SetCompression(compress)
+		seedCatalog(t, server, 100)
+
+		all, err := products.RetrieveAllProductsIter(context.Background(), server.Config(), common.QueryParams{}).Collect()
+		server.Close()
+		if err != nil {
+			t.Fatalf("compress=%v: Collect() error = %v", compress, err)
+		}
+		if len(all) != 100 || all[99].Variants[0].SKU != "SQ-TEE-00099" {
+			t.Fatalf("compress=%v: unexpected products, got %d", compress, len(all))
+		}
+		sent[i] = server.BytesSent()
+	}
+
+	if sent[1]*2 > sent[0] {
+		t.Errorf("expected gzip to at least halve the bytes sent, got %d vs %d", sent[1], sent[0])
+	}
+}
+
+func benchmarkPull(b *testing.B, seed func(testing.TB, *Server, int), pull func(*common.Config) (int, error)) {
+	for _, compress := range []bool{false, true} {
+		name := "identity"
+		if compress {
+			name = "gzip"
+		}
+		b.Run(name, func(b *testing.B) {
+			server := NewServer()
+			defer server.Close()
+			server.SetCompression(compress)
+			seed(b, server, 1000)
+			config := server.Config()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				n, err := pull(config)
+				if err != nil || n != 1000 {
+					b.Fatalf("pulled %d records: %v", n, err)
+				}
+			}
+			b.ReportMetric(float64(server.BytesSent())/float64(b.N), "wire-B/op")
+		})
+	}
+}
+
+// BenchmarkCatalogPull and BenchmarkTransactionsPull compare full pulls with
+// and without gzip; wire-B/op is the response bytes sent per pull.
+func BenchmarkCatalogPull(b *testing.B) {
+	benchmarkPull(b, seedCatalog, func(config *common.Config) (int, error) {
+		all, err := products.RetrieveAllProductsIter(context.Background(), config, common.QueryParams{}).Collect()
+		return len(all), err
+	})
+}
+
+func BenchmarkTransactionsPull(b *testing.B) {
+	benchmarkPull(b, seedTransactions, func(config *common.Config) (int, error) {
+		all, err := transactions.RetrieveAllTransactionsIter(context.Background(), config, common.QueryParams{}).Collect()
+		return len(all), err
+	})
+}
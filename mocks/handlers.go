@@ -0,0 +1,855 @@
+package mocks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func (s *Server) routeProducts(w http.ResponseWriter, r *http.Request, segs []string) {
+	switch {
+	case len(segs) == 0 && r.Method == http.MethodGet:
+		s.handleList(w, r, Products, "products", productFilter)
+	case len(segs) == 0 && r.Method == http.MethodPost:
+		s.createProduct(w, r)
+	case len(segs) == 1 && r.Method == http.MethodGet:
+		s.handleSpecific(w, Products, "products", segs[0])
+	case len(segs) == 1 && r.Method == http.MethodPost:
+		s.updateProduct(w, r, segs[0])
+	case len(segs) == 1 && r.Method == http.MethodDelete:
+		s.deleteProduct(w, segs[0])
+	case len(segs) == 2 && segs[1] == "variants" && r.Method == http.MethodPost:
+		s.createVariant(w, r, segs[0])
+	case len(segs) == 3 && segs[1] == "variants" && r.Method == http.MethodPost:
+		s.updateVariant(w, r, segs[0], segs[2])
+	case len(segs) == 3 && segs[1] == "variants" && r.Method == http.MethodDelete:
+		s.deleteVariant(w, segs[0], segs[2])
+	case len(segs) == 4 && segs[1] == "variants" && segs[3] == "image" && r.Method == http.MethodPost:
+		s.assignVariantImage(w, r, segs[0], segs[2])
+	case len(segs) == 2 && segs[1] == "images" && r.Method == http.MethodPost:
+		s.uploadImage(w, r, segs[0])
+	case len(segs) == 3 && segs[1] == "images" && r.Method == http.MethodPost:
+		s.updateImage(w, r, segs[0], segs[2])
+	case len(segs) == 3 && segs[1] == "images" && r.Method == http.MethodDelete:
+		s.deleteImage(w, segs[0], segs[2])
+	case len(segs) == 4 && segs[1] == "images" && segs[3] == "status" && r.Method == http.MethodGet:
+		s.imageStatus(w, segs[0], segs[2])
+	case len(segs) == 4 && segs[1] == "images" && segs[3] == "order" && r.Method == http.MethodPost:
+		s.reorderImage(w, r, segs[0], segs[2])
+	case len(segs) <= 4:
+		writeMethodNotAllowed(w)
+	default:
+		writeError(w, http.StatusNotFound, common.TypeNotFound, "Unknown endpoint")
+	}
+}
+
+func productFilter(query url.Values, records []record) ([]record, error) {
+	records, err := modifiedFilter(query, records)
+	if err != nil || query.Get("type") == "" {
+		return records, err
+	}
+
+	types := make(map[string]bool)
+	for _, t := range strings.Split(query.Get("type"), ",") {
+		types[strings.TrimSpace(t)] = true
+	}
+	var filtered []record
+	for _, r := range records {
+		if types[stringField(r, "type")] {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
+func (s *Server) createProduct(w http.ResponseWriter, r *http.Request) {
+	product, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	switch stringField(product, "type") {
+	case "PHYSICAL", "DIGITAL":
+	default:
+		writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "type must be PHYSICAL or DIGITAL")
+		return
+	}
+	if stringField(product, "storePageId") == "" {
+		writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "storePageId is required")
+		return
+	}
+	variants := records(product["variants"])
+	if stringField(product, "type") == "DIGITAL" {
+		if len(variants) > 0 {
+			writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "Digital products cannot have variants")
+			return
+		}
+		if _, ok := product["pricing"].(map[string]interface{}); !ok {
+			writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "pricing is required for digital products")
+			return
+		}
+		product["variants"] = []interface{}{}
+	} else if len(variants) == 0 {
+		writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "At least one variant is required")
+		return
+	}
+
+	id := s.newID()
+	now := s.timestamp()
+	product["id"] = id
+	if stringField(product, "urlSlug") == "" {
+		product["urlSlug"] = strings.ToLower(strings.Join(strings.Fields(stringField(product, "name")), "-"))
+	}
+	product["url"] = "/store/p/" + stringField(product, "urlSlug")
+	product["images"] = []interface{}{}
+	product["createdOn"] = now
+	product["modifiedOn"] = now
+	for _, variant := range variants {
+		variant["id"] = s.newID()
+	}
+
+	s.collections[Products].put(id, product)
+	s.syncInventory(product)
+	writeJSON(w, http.StatusCreated, product)
+}
+
+func (s *Server) updateProduct(w http.ResponseWriter, r *http.Request, id string) {
+	product, ok := s.collections[Products].records[id]
+	if !ok {
+		writeNotFound(w, "product", id)
+		return
+	}
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	for _, key := range []string{"id", "type", "storePageId", "variants", "images", "createdOn", "modifiedOn"} {
+		delete(body, key)
+	}
+	for key, value := range body {
+		product[key] = value
+	}
+	product["modifiedOn"] = s.timestamp()
+	s.syncInventory(product)
+	writeJSON(w, http.StatusOK, product)
+}
+
+func (s *Server) deleteProduct(w http.ResponseWriter, id string) {
+	product, ok := s.collections[Products].records[id]
+	if !ok {
+		writeNotFound(w, "product", id)
+		return
+	}
+	for _, variant := range records(product["variants"]) {
+		s.collections[Inventory].remove(stringField(variant, "id"))
+	}
+	s.collections[Products].remove(id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createVariant(w http.ResponseWriter, r *http.Request, productID string) {
+	product, ok := s.collections[Products].records[productID]
+	if !ok {
+		writeNotFound(w, "product", productID)
+		return
+	}
+	if isDigital(product) {
+		writeNotAllowedForDigital(w)
+		return
+	}
+	variant, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	variant["id"] = s.newID()
+	variants, _ := product["variants"].([]interface{})
+	product["variants"] = append(variants, map[string]interface{}(variant))
+	product["modifiedOn"] = s.timestamp()
+	s.syncInventory(product)
+	writeJSON(w, http.StatusCreated, variant)
+}
+
+func (s *Server) updateVariant(w http.ResponseWriter, r *http.Request, productID, variantID string) {
+	product, variant, ok := s.findVariant(w, productID, variantID)
+	if !ok {
+		return
+	}
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := body["shippingMeasurements"]; ok && isDigital(product) {
+		writeNotAllowedForDigital(w)
+		return
+	}
+
+	// Stock is only changed through inventory adjustments.
+	delete(body, "id")
+	delete(body, "stock")
+	for key, value := range body {
+		variant[key] = value
+	}
+	product["modifiedOn"] = s.timestamp()
+	s.syncInventory(product)
+	writeJSON(w, http.StatusOK, variant)
+}
+
+func (s *Server) deleteVariant(w http.ResponseWriter, productID, variantID string) {
+	product, _, ok := s.findVariant(w, productID, variantID)
+	if !ok {
+		return
+	}
+
+	var kept []interface{}
+	for _, variant := range records(product["variants"]) {
+		if stringField(variant, "id") != variantID {
+			kept = append(kept, map[string]interface{}(variant))
+		}
+	}
+	product["variants"] = kept
+	product["modifiedOn"] = s.timestamp()
+	s.collections[Inventory].remove(variantID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) assignVariantImage(w http.ResponseWriter, r *http.Request, productID, variantID string) {
+	product, variant, ok := s.findVariant(w, productID, variantID)
+	if !ok {
+		return
+	}
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	image, _ := findImage(product, stringField(body, "imageId"))
+	if image == nil {
+		writeNotFound(w, "image", stringField(body, "imageId"))
+		return
+	}
+	variant["image"] = map[string]interface{}(image)
+	product["modifiedOn"] = s.timestamp()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// uploadImage accepts a multipart "file" upload. Images are READY as soon as
+// they are uploaded.
+func (s *Server) uploadImage(w http.ResponseWriter, r *http.Request, productID string) {
+	product, ok := s.collections[Products].records[productID]
+	if !ok {
+		writeNotFound(w, "product", productID)
+		return
+	}
+	_, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "A multipart file field is required")
+		return
+	}
+
+	id := s.newID()
+	image := map[string]interface{}{
+		"id":               id,
+		"altText":          "",
+		"url":              fmt.Sprintf("https://images.squarespace-cdn.com/%s/%s", id, header.Filename),
+		"originalSize":     map[string]interface{}{"width": 0, "height": 0},
+		"availableFormats": []interface{}{},
+	}
+	images, _ := product["images"].([]interface{})
+	product["images"] = append(images, image)
+	product["modifiedOn"] = s.timestamp()
+	writeJSON(w, http.StatusAccepted, map[string]string{"imageId": id})
+}
+
+func (s *Server) updateImage(w http.ResponseWriter, r *http.Request, productID, imageID string) {
+	product, image, ok := s.findProductImage(w, productID, imageID)
+	if !ok {
+		return
+	}
+	if isDigital(product) {
+		writeNotAllowedForDigital(w)
+		return
+	}
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	image["altText"] = stringField(body, "altText")
+	product["modifiedOn"] = s.timestamp()
+	writeJSON(w, http.StatusOK, image)
+}
+
+func (s *Server) deleteImage(w http.ResponseWriter, productID, imageID string) {
+	product, _, ok := s.findProductImage(w, productID, imageID)
+	if !ok {
+		return
+	}
+
+	_, index := findImage(product, imageID)
+	images := product["images"].([]interface{})
+	product["images"] = append(images[:index:index], images[index+1:]...)
+	product["modifiedOn"] = s.timestamp()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) imageStatus(w http.ResponseWriter, productID, imageID string) {
+	if _, _, ok := s.findProductImage(w, productID, imageID); !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "READY"})
+}
+
+func (s *Server) reorderImage(w http.ResponseWriter, r *http.Request, productID, imageID string) {
+	product, image, ok := s.findProductImage(w, productID, imageID)
+	if !ok {
+		return
+	}
+	if isDigital(product) {
+		writeNotAllowedForDigital(w)
+		return
+	}
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	_, index := findImage(product, imageID)
+	images := product["images"].([]interface{})
+	images = append(images[:index:index], images[index+1:]...)
+
+	position := 0
+	if afterID, _ := body["afterImageId"].(string); afterID != "" {
+		_, after := findImage(record{"images": images}, afterID)
+		if after < 0 {
+			writeNotFound(w, "image", afterID)
+			return
+		}
+		position = after + 1
+	}
+	images = append(images[:position:position], append([]interface{}{map[string]interface{}(image)}, images[position:]...)...)
+	product["images"] = images
+	product["modifiedOn"] = s.timestamp()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func isDigital(product record) bool {
+	return stringField(product, "type") == "DIGITAL"
+}
+
+func writeNotAllowedForDigital(w http.ResponseWriter) {
+	writeJSON(w, http.StatusMethodNotAllowed, common.APIError{
+		Type:    common.TypeMethodNotAllowed,
+		Subtype: common.SubtypeOperationNotAllowedForProductType,
+		Message: "Operation not allowed for digital products",
+	})
+}
+
+func (s *Server) findVariant(w http.ResponseWriter, productID, variantID string) (record, record, bool) {
+	product, ok := s.collections[Products].records[productID]
+	if !ok {
+		writeNotFound(w, "product", productID)
+		return nil, nil, false
+	}
+	for _, variant := range records(product["variants"]) {
+		if stringField(variant, "id") == variantID {
+			return product, variant, true
+		}
+	}
+	writeNotFound(w, "variant", variantID)
+	return nil, nil, false
+}
+
+func (s *Server) findProductImage(w http.ResponseWriter, productID, imageID string) (record, record, bool) {
+	product, ok := s.collections[Products].records[productID]
+	if !ok {
+		writeNotFound(w, "product", productID)
+		return nil, nil, false
+	}
+	image, _ := findImage(product, imageID)
+	if image == nil {
+		writeNotFound(w, "image", imageID)
+		return nil, nil, false
+	}
+	return product, image, true
+}
+
+func findImage(product record, imageID string) (record, int) {
+	for i, image := range records(product["images"]) {
+		if stringField(image, "id") == imageID {
+			return image, i
+		}
+	}
+	return nil, -1
+}
+
+// syncInventory creates inventory records for new variants of product and
+// keeps the SKU and descriptor of existing ones current.
+func (s *Server) syncInventory(product record) {
+	for _, variant := range records(product["variants"]) {
+		id := stringField(variant, "id")
+		if id == "" {
+			continue
+		}
+		inv, ok := s.collections[Inventory].records[id]
+		if !ok {
+			stock, _ := variant["stock"].(map[string]interface{})
+			quantity, _ := stock["quantity"].(float64)
+			unlimited, _ := stock["unlimited"].(bool)
+			inv = record{"variantId": id, "isUnlimited": unlimited, "quantity": quantity}
+			s.collections[Inventory].put(id, inv)
+		}
+		inv["sku"] = stringField(variant, "sku")
+		inv["descriptor"] = variantDescriptor(product, variant)
+	}
+}
+
+func variantDescriptor(product, variant record) string {
+	descriptor := stringField(product, "name")
+	attributes, _ := variant["attributes"].(map[string]interface{})
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		descriptor += fmt.Sprintf(" [%s: %v]", key, attributes[key])
+	}
+	return descriptor
+}
+
+// setVariantStock mirrors an inventory change onto the owning product variant.
+func (s *Server) setVariantStock(variantID string, inv record) {
+	for _, product := range s.collections[Products].records {
+		for _, variant := range records(product["variants"]) {
+			if stringField(variant, "id") == variantID {
+				variant["stock"] = map[string]interface{}{"quantity": inv["quantity"], "unlimited": inv["isUnlimited"]}
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) routeInventory(w http.ResponseWriter, r *http.Request, segs []string) {
+	switch {
+	case len(segs) == 0:
+		s.handleList(w, r, Inventory, "inventory", nil)
+	case len(segs) == 1 && segs[0] == "adjustments" && r.Method == http.MethodPost:
+		s.adjustInventory(w, r)
+	case len(segs) == 1 && r.Method == http.MethodGet:
+		s.handleSpecific(w, Inventory, "inventory", segs[0])
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+type stockChange struct {
+	variantID string
+	delta     float64
+	set       *float64
+	unlimited bool
+}
+
+// adjustInventory applies every operation or none: an unknown variant or a
+// decrement below zero fails the whole request.
+func (s *Server) adjustInventory(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	var changes []stockChange
+	for _, op := range records(body["incrementOperations"]) {
+		quantity, _ := op["quantity"].(float64)
+		changes = append(changes, stockChange{variantID: stringField(op, "variantId"), delta: quantity})
+	}
+	for _, op := range records(body["decrementOperations"]) {
+		quantity, _ := op["quantity"].(float64)
+		changes = append(changes, stockChange{variantID: stringField(op, "variantId"), delta: -quantity})
+	}
+	for _, op := range records(body["setFiniteOperations"]) {
+		quantity, _ := op["quantity"].(float64)
+		changes = append(changes, stockChange{variantID: stringField(op, "variantId"), set: &quantity})
+	}
+	unlimited, _ := body["setUnlimitedOperations"].([]interface{})
+	for _, id := range unlimited {
+		variantID, _ := id.(string)
+		changes = append(changes, stockChange{variantID: variantID, unlimited: true})
+	}
+	if len(changes) == 0 {
+		writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "At least one operation is required")
+		return
+	}
+
+	if status, err := s.applyStockChanges(changes); err != nil {
+		writeError(w, status, errorType(status), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) applyStockChanges(changes []stockChange) (int, error) {
+	quantities := make(map[string]float64)
+	for _, change := range changes {
+		inv, ok := s.collections[Inventory].records[change.variantID]
+		if !ok {
+			return http.StatusNotFound, fmt.Errorf("No inventory found for variant %s", change.variantID)
+		}
+		if unlimited, _ := inv["isUnlimited"].(bool); unlimited || change.unlimited || change.set != nil {
+			continue
+		}
+		if _, ok := quantities[change.variantID]; !ok {
+			quantities[change.variantID], _ = inv["quantity"].(float64)
+		}
+		quantities[change.variantID] += change.delta
+		if quantities[change.variantID] < 0 {
+			return http.StatusConflict, fmt.Errorf("Insufficient stock for variant %s", change.variantID)
+		}
+	}
+
+	for _, change := range changes {
+		inv := s.collections[Inventory].records[change.variantID]
+		switch {
+		case change.unlimited:
+			inv["isUnlimited"] = true
+			inv["quantity"] = float64(0)
+		case change.set != nil:
+			inv["isUnlimited"] = false
+			inv["quantity"] = *change.set
+		default:
+			if unlimited, _ := inv["isUnlimited"].(bool); !unlimited {
+				quantity, _ := inv["quantity"].(float64)
+				inv["quantity"] = quantity + change.delta
+			}
+		}
+		s.setVariantStock(change.variantID, inv)
+	}
+	return 0, nil
+}
+
+func (s *Server) routeOrders(w http.ResponseWriter, r *http.Request, segs []string) {
+	switch {
+	case len(segs) == 0 && r.Method == http.MethodGet:
+		s.handleList(w, r, Orders, "result", orderFilter)
+	case len(segs) == 0 && r.Method == http.MethodPost:
+		s.createOrder(w, r)
+	case len(segs) == 1 && r.Method == http.MethodGet:
+		order, ok := s.collections[Orders].records[segs[0]]
+		if !ok {
+			writeNotFound(w, "order", segs[0])
+			return
+		}
+		writeJSON(w, http.StatusOK, order)
+	case len(segs) == 2 && segs[1] == "fulfillments" && r.Method == http.MethodPost:
+		s.fulfillOrder(w, r, segs[0])
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func orderFilter(query url.Values, records []record) ([]record, error) {
+	records, err := modifiedFilter(query, records)
+	status := query.Get("fulfillmentStatus")
+	if err != nil || status == "" {
+		return records, err
+	}
+
+	var filtered []record
+	for _, r := range records {
+		if stringField(r, "fulfillmentStatus") == status {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
+// createOrder deducts stock for the order's variants unless inventoryBehavior
+// is SKIP, failing with a conflict when stock is short.
+func (s *Server) createOrder(w http.ResponseWriter, r *http.Request) {
+	order, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	for _, field := range []string{"channelName", "externalOrderReference", "priceTaxInterpretation"} {
+		if stringField(order, field) == "" {
+			writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, field+" is required")
+			return
+		}
+	}
+	lineItems := records(order["lineItems"])
+	if len(lineItems) == 0 {
+		writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "At least one line item is required")
+		return
+	}
+	for _, existing := range s.collections[Orders].records {
+		if stringField(existing, "channelName") == stringField(order, "channelName") &&
+			stringField(existing, "externalOrderReference") == stringField(order, "externalOrderReference") {
+			writeError(w, http.StatusConflict, common.TypeConflict, "An order with this externalOrderReference already exists")
+			return
+		}
+	}
+
+	if stringField(order, "inventoryBehavior") != "SKIP" {
+		var changes []stockChange
+		for _, item := range lineItems {
+			variantID := stringField(item, "variantId")
+			if _, ok := s.collections[Inventory].records[variantID]; !ok {
+				continue
+			}
+			quantity, _ := item["quantity"].(float64)
+			changes = append(changes, stockChange{variantID: variantID, delta: -quantity})
+		}
+		if status, err := s.applyStockChanges(changes); err != nil {
+			writeError(w, status, errorType(status), err.Error())
+			return
+		}
+	}
+
+	id := s.newID()
+	now := s.timestamp()
+	order["id"] = id
+	order["orderNumber"] = fmt.Sprintf("%d", 1000+len(s.collections[Orders].ids)+1)
+	if stringField(order, "createdOn") == "" {
+		order["createdOn"] = now
+	}
+	order["modifiedOn"] = now
+	if stringField(order, "fulfillmentStatus") == "" {
+		order["fulfillmentStatus"] = "PENDING"
+	}
+	for _, item := range lineItems {
+		item["id"] = s.newID()
+	}
+
+	s.collections[Orders].put(id, order)
+	writeJSON(w, http.StatusCreated, order)
+}
+
+func (s *Server) fulfillOrder(w http.ResponseWriter, r *http.Request, orderID string) {
+	order, ok := s.collections[Orders].records[orderID]
+	if !ok {
+		writeNotFound(w, "order", orderID)
+		return
+	}
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if stringField(order, "fulfillmentStatus") == "CANCELED" {
+		writeError(w, http.StatusConflict, common.TypeConflict, "Canceled orders cannot be fulfilled")
+		return
+	}
+
+	fulfillments, _ := order["fulfillments"].([]interface{})
+	shipments, _ := body["shipments"].([]interface{})
+	now := s.timestamp()
+	order["fulfillments"] = append(fulfillments, shipments...)
+	order["fulfillmentStatus"] = "FULFILLED"
+	order["fulfilledOn"] = now
+	order["modifiedOn"] = now
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) routeTransactions(w http.ResponseWriter, r *http.Request, segs []string) {
+	switch {
+	case len(segs) == 0:
+		s.handleList(w, r, Transactions, "documents", modifiedFilter)
+	case len(segs) == 1 && r.Method == http.MethodGet:
+		s.handleSpecific(w, Transactions, "documents", segs[0])
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (s *Server) routeProfiles(w http.ResponseWriter, r *http.Request, segs []string) {
+	switch {
+	case len(segs) == 0:
+		s.handleList(w, r, Profiles, "profiles", profileFilter)
+	case len(segs) == 1 && r.Method == http.MethodGet:
+		s.handleSpecific(w, Profiles, "profiles", segs[0])
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// profileFilter applies the "field,value;field,value" filter and sort
+// parameters of the profiles API.
+func profileFilter(query url.Values, records []record) ([]record, error) {
+	if raw := query.Get("filter"); raw != "" {
+		for _, clause := range strings.Split(raw, ";") {
+			field, value, ok := strings.Cut(clause, ",")
+			if !ok {
+				return nil, fmt.Errorf("invalid filter: %s", clause)
+			}
+			var filtered []record
+			for _, r := range records {
+				switch field {
+				case "email":
+					if strings.EqualFold(stringField(r, "email"), value) {
+						filtered = append(filtered, r)
+					}
+				case "isCustomer", "hasAccount":
+					if fmt.Sprint(r[field] == true) == value {
+						filtered = append(filtered, r)
+					}
+				default:
+					return nil, fmt.Errorf("unsupported filter field: %s", field)
+				}
+			}
+			records = filtered
+		}
+	}
+
+	if field := query.Get("sortField"); field != "" {
+		switch field {
+		case "createdOn", "id", "email", "lastName":
+		default:
+			return nil, fmt.Errorf("unsupported sort field: %s", field)
+		}
+		records = append([]record(nil), records...)
+		sortRecords(records, field, query.Get("sortDirection") == "desc")
+	}
+	return records, nil
+}
+
+func (s *Server) routeWebhooks(w http.ResponseWriter, r *http.Request, segs []string) {
+	switch {
+	case len(segs) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"webhookSubscriptions": s.collections[WebhookSubscriptions].list()})
+	case len(segs) == 0 && r.Method == http.MethodPost:
+		s.saveWebhook(w, r, "")
+	case len(segs) == 1 && r.Method == http.MethodGet:
+		subscription, ok := s.collections[WebhookSubscriptions].records[segs[0]]
+		if !ok {
+			writeNotFound(w, "webhook subscription", segs[0])
+			return
+		}
+		writeJSON(w, http.StatusOK, subscription)
+	case len(segs) == 1 && r.Method == http.MethodPost:
+		s.saveWebhook(w, r, segs[0])
+	case len(segs) == 1 && r.Method == http.MethodDelete:
+		if _, ok := s.collections[WebhookSubscriptions].records[segs[0]]; !ok {
+			writeNotFound(w, "webhook subscription", segs[0])
+			return
+		}
+		s.collections[WebhookSubscriptions].remove(segs[0])
+		w.WriteHeader(http.StatusNoContent)
+	case len(segs) == 3 && segs[1] == "actions" && r.Method == http.MethodPost:
+		s.webhookAction(w, r, segs[0], segs[2])
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// saveWebhook creates a subscription when id is empty and updates it
+// otherwise.
+func (s *Server) saveWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	subscription := record{}
+	if id != "" {
+		var ok bool
+		if subscription, ok = s.collections[WebhookSubscriptions].records[id]; !ok {
+			writeNotFound(w, "webhook subscription", id)
+			return
+		}
+	}
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	if endpoint, ok := body["endpointUrl"].(string); ok && endpoint != "" {
+		subscription["endpointUrl"] = endpoint
+	}
+	if topics, ok := body["topics"].([]interface{}); ok && len(topics) > 0 {
+		subscription["topics"] = topics
+	}
+	if stringField(subscription, "endpointUrl") == "" || subscription["topics"] == nil {
+		writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "endpointUrl and topics are required")
+		return
+	}
+
+	now := s.timestamp()
+	subscription["updatedOn"] = now
+	status := http.StatusOK
+	if id == "" {
+		id = s.newID()
+		subscription["id"] = id
+		subscription["secret"] = newSecret()
+		subscription["createdOn"] = now
+		s.collections[WebhookSubscriptions].put(id, subscription)
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, subscription)
+}
+
+// webhookAction handles sendTestNotification and rotateSecret. Test
+// notifications are not delivered; they report the endpoint as having
+// returned 200.
+func (s *Server) webhookAction(w http.ResponseWriter, r *http.Request, id, action string) {
+	subscription, ok := s.collections[WebhookSubscriptions].records[id]
+	if !ok {
+		writeNotFound(w, "webhook subscription", id)
+		return
+	}
+
+	switch action {
+	case "sendTestNotification":
+		body, ok := decodeBody(w, r)
+		if !ok {
+			return
+		}
+		topic := stringField(body, "topic")
+		topics, _ := subscription["topics"].([]interface{})
+		for _, t := range topics {
+			if t == topic {
+				writeJSON(w, http.StatusOK, map[string]int{"statusCode": http.StatusOK})
+				return
+			}
+		}
+		writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, fmt.Sprintf("Subscription is not subscribed to %s", topic))
+	case "rotateSecret":
+		subscription["secret"] = newSecret()
+		subscription["updatedOn"] = s.timestamp()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"secret": subscription["secret"]})
+	default:
+		writeError(w, http.StatusNotFound, common.TypeNotFound, "Unknown action")
+	}
+}
+
+func newSecret() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// records returns the objects of a decoded JSON array. The returned records
+// share storage with v, so changes to them are visible in v.
+func records(v interface{}) []record {
+	items, _ := v.([]interface{})
+	result := make([]record, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			result = append(result, record(m))
+		}
+	}
+	return result
+}
+
+func errorType(status int) string {
+	switch status {
+	case http.StatusNotFound:
+		return common.TypeNotFound
+	case http.StatusConflict:
+		return common.TypeConflict
+	default:
+		return common.TypeInvalidRequestError
+	}
+}
@@ -0,0 +1,551 @@
+// Package mocks provides test doubles for the Squarespace Commerce APIs.
+package mocks
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const (
+	// APIKey is the token the fake server accepts, and the one Config sets.
+	APIKey = "test-key"
+
+	defaultPageSize = 50
+)
+
+// Collection names a set of records held by the fake server.
+type Collection string
+
+const (
+	StorePages           Collection = "storePages"
+	Products             Collection = "products"
+	Orders               Collection = "orders"
+	Inventory            Collection = "inventory"
+	Profiles             Collection = "profiles"
+	Transactions         Collection = "transactions"
+	WebhookSubscriptions Collection = "webhookSubscriptions"
+)
+
+type record map[string]interface{}
+
+// collection keeps records in insertion order, which is the order list
+// endpoints return them in unless a sort is requested.
+type collection struct {
+	ids     []string
+	records map[string]record
+}
+
+func (c *collection) put(id string, r record) {
+	if _, ok := c.records[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+	c.records[id] = r
+}
+
+func (c *collection) remove(id string) {
+	delete(c.records, id)
+	for i, existing := range c.ids {
+		if existing == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *collection) list() []record {
+	records := make([]record, 0, len(c.ids))
+	for _, id := range c.ids {
+		records = append(records, c.records[id])
+	}
+	return records
+}
+
+type failure struct {
+	method string
+	path   string
+	status int
+	err    common.APIError
+}
+
+// Server is an in-memory fake of the products, orders, inventory, profiles,
+// transactions and webhook subscription endpoints. Records are plain JSON
+// objects, so any of the module's types can be seeded and read back. Lists are
+// paginated with opaque cursors, and failures use the API's error body shape.
+type Server struct {
+	server *httptest.Server
+
+	mu          sync.Mutex
+	collections map[Collection]*collection
+	failures    []failure
+	idempotent  map[string]idempotentResponse
+	pageSize    int
+	nextID      int
+	now         func() time.Time
+	compress    bool
+	bytesSent   int64
+}
+
+type idempotentResponse struct {
+	status int
+	body   []byte
+}
+
+// NewServer starts an empty fake server. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		collections: make(map[Collection]*collection),
+		idempotent:  make(map[string]idempotentResponse),
+		pageSize:    defaultPageSize,
+		now:         time.Now,
+		compress:    true,
+	}
+	for _, c := range []Collection{StorePages, Products, Orders, Inventory, Profiles, Transactions, WebhookSubscriptions} {
+		s.collections[c] = &collection{records: make(map[string]record)}
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Config returns a Config that sends every request to the fake server.
+func (s *Server) Config() *common.Config {
+	return &common.Config{
+		APIKey:      APIKey,
+		AccessToken: APIKey,
+		Client:      s.server.Client(),
+		UserAgent:   "gocommerce-mocks",
+		BaseURL:     s.server.URL,
+	}
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// SetPageSize sets how many records each list page holds. Defaults to 50.
+func (s *Server) SetPageSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n <= 0 {
+		n = defaultPageSize
+	}
+	s.pageSize = n
+}
+
+// Seed adds records to a collection, replacing any with the same ID. Each
+// record is marshalled to a JSON object and must have an "id", or a
+// "variantId" for inventory. Seeding products also seeds inventory for their
+// variants.
+func (s *Server) Seed(c Collection, records ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.collections[c]; !ok {
+		return fmt.Errorf("unknown collection: %s", c)
+	}
+	for i, v := range records {
+		r, err := toRecord(v)
+		if err != nil {
+			return fmt.Errorf("records[%d]: %w", i, err)
+		}
+		id := recordID(c, r)
+		if id == "" {
+			return fmt.Errorf("records[%d]: missing id", i)
+		}
+		s.collections[c].put(id, r)
+		if c == Products {
+			s.syncInventory(r)
+		}
+	}
+	return nil
+}
+
+// Record decodes the record with the given ID into v, reporting whether it
+// exists.
+func (s *Server) Record(c Collection, id string, v interface{}) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	col, ok := s.collections[c]
+	if !ok {
+		return false, fmt.Errorf("unknown collection: %s", c)
+	}
+	r, ok := col.records[id]
+	if !ok {
+		return false, nil
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return true, err
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// Len returns the number of records in a collection.
+func (s *Server) Len(c Collection) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if col, ok := s.collections[c]; ok {
+		return len(col.ids)
+	}
+	return 0
+}
+
+// FailNext makes the next request matching method and path fail with status
+// and apiError instead of being served. path is matched against the end of
+// the request path, e.g. "/commerce/orders".
+func (s *Server) FailNext(method, path string, status int, apiError common.APIError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{method: method, path: path, status: status, err: apiError})
+}
+
+// SetCompression sets whether responses are gzipped for clients that accept
+// it, as the API does. Defaults to true.
+func (s *Server) SetCompression(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.compress = enabled
+}
+
+// BytesSent returns the number of response body bytes written so far, after
+// compression.
+func (s *Server) BytesSent() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bytesSent
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter := &countingWriter{ResponseWriter: w}
+	defer func() { s.bytesSent += counter.n }()
+	w = counter
+	if s.compress && strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		zw := &gzipWriter{ResponseWriter: counter}
+		defer zw.Close()
+		w = zw
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+APIKey {
+		writeError(w, http.StatusUnauthorized, common.TypeAuthorizationError, "Invalid or missing credentials")
+		return
+	}
+
+	for i, f := range s.failures {
+		if f.method == r.Method && strings.HasSuffix(r.URL.Path, f.path) {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			writeJSON(w, f.status, f.err)
+			return
+		}
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	version, path, _ := strings.Cut(path, "/")
+	if version != "1.0" {
+		writeError(w, http.StatusNotFound, common.TypeNotFound, "Unknown API version")
+		return
+	}
+
+	// Replay responses for a repeated Idempotency-Key, as the API does.
+	key := r.Header.Get("Idempotency-Key")
+	if key != "" && r.Method == http.MethodPost {
+		key = path + " " + key
+		if saved, ok := s.idempotent[key]; ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(saved.status)
+			w.Write(saved.body)
+			return
+		}
+		rec := httptest.NewRecorder()
+		s.route(rec, r, strings.Split(path, "/"))
+		if rec.Code < http.StatusInternalServerError {
+			s.idempotent[key] = idempotentResponse{status: rec.Code, body: rec.Body.Bytes()}
+		}
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rec.Code)
+		w.Write(rec.Body.Bytes())
+		return
+	}
+
+	s.route(w, r, strings.Split(path, "/"))
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segs []string) {
+	switch {
+	case len(segs) >= 2 && segs[0] == "commerce" && segs[1] == "products":
+		s.routeProducts(w, r, segs[2:])
+	case len(segs) == 2 && segs[0] == "commerce" && segs[1] == "store_pages":
+		s.handleList(w, r, StorePages, "storePages", nil)
+	case len(segs) >= 2 && segs[0] == "commerce" && segs[1] == "orders":
+		s.routeOrders(w, r, segs[2:])
+	case len(segs) >= 2 && segs[0] == "commerce" && segs[1] == "inventory":
+		s.routeInventory(w, r, segs[2:])
+	case len(segs) >= 2 && segs[0] == "commerce" && segs[1] == "transactions":
+		s.routeTransactions(w, r, segs[2:])
+	case segs[0] == "profiles":
+		s.routeProfiles(w, r, segs[1:])
+	case segs[0] == "webhook_subscriptions":
+		s.routeWebhooks(w, r, segs[1:])
+	default:
+		writeError(w, http.StatusNotFound, common.TypeNotFound, "Unknown endpoint")
+	}
+}
+
+type listFilter func(query url.Values, records []record) ([]record, error)
+
+// pageCursor is what the opaque cursor encodes: the offset of the next page
+// and the query that produced the first one.
+type pageCursor struct {
+	Offset int    `json:"o"`
+	Query  string `json:"q"`
+}
+
+// handleList serves a paginated list of c under key. The first page's query
+// is carried in the cursor, so follow-up requests may only send a cursor.
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request, c Collection, key string, filter listFilter) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	query := r.URL.Query()
+	offset := 0
+	if raw := query.Get("cursor"); raw != "" {
+		if len(query) > 1 {
+			writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "Cursor cannot be combined with other query parameters")
+			return
+		}
+		cursor, err := decodeCursor(raw)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "Invalid cursor")
+			return
+		}
+		if query, err = url.ParseQuery(cursor.Query); err != nil {
+			writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "Invalid cursor")
+			return
+		}
+		offset = cursor.Offset
+	}
+
+	records := s.collections[c].list()
+	if filter != nil {
+		var err error
+		if records, err = filter(query, records); err != nil {
+			writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, err.Error())
+			return
+		}
+	}
+
+	if records == nil {
+		records = []record{}
+	}
+	offset = min(offset, len(records))
+	end := min(offset+s.pageSize, len(records))
+	pagination := common.Pagination{HasNextPage: end < len(records)}
+	if pagination.HasNextPage {
+		pagination.NextPageCursor = encodeCursor(pageCursor{Offset: end, Query: query.Encode()})
+		pagination.NextPageURL = s.server.URL + r.URL.Path + "?cursor=" + url.QueryEscape(pagination.NextPageCursor)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		key:          records[offset:end],
+		"pagination": pagination,
+	})
+}
+
+// handleSpecific serves the comma-separated ids under key, failing if any is
+// unknown.
+func (s *Server) handleSpecific(w http.ResponseWriter, c Collection, key, ids string) {
+	var records []record
+	for _, id := range strings.Split(ids, ",") {
+		r, ok := s.collections[c].records[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, common.TypeNotFound, fmt.Sprintf("No resource found with id %s", id))
+			return
+		}
+		records = append(records, r)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{key: records})
+}
+
+// modifiedFilter applies modifiedAfter/modifiedBefore on the record's
+// modifiedOn, which must both be set or both be absent.
+func modifiedFilter(query url.Values, records []record) ([]record, error) {
+	after, before := query.Get("modifiedAfter"), query.Get("modifiedBefore")
+	if after == "" && before == "" {
+		return records, nil
+	}
+	if after == "" || before == "" {
+		return nil, fmt.Errorf("modifiedAfter and modifiedBefore must be specified together")
+	}
+	from, err := time.Parse(time.RFC3339, after)
+	if err != nil {
+		return nil, fmt.Errorf("modifiedAfter is not a valid ISO 8601 date-time")
+	}
+	to, err := time.Parse(time.RFC3339, before)
+	if err != nil {
+		return nil, fmt.Errorf("modifiedBefore is not a valid ISO 8601 date-time")
+	}
+
+	var filtered []record
+	for _, r := range records {
+		modified, err := time.Parse(time.RFC3339, stringField(r, "modifiedOn"))
+		if err != nil || modified.Before(from) || modified.After(to) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered, nil
+}
+
+func sortRecords(records []record, field string, descending bool) {
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := stringField(records[i], field), stringField(records[j], field)
+		if descending {
+			return a > b
+		}
+		return a < b
+	})
+}
+
+func encodeCursor(c pageCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(raw string) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return c, err
+	}
+	return c, json.Unmarshal(data, &c)
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("%024x", s.nextID)
+}
+
+func (s *Server) timestamp() string {
+	return s.now().UTC().Format(time.RFC3339)
+}
+
+func toRecord(v interface{}) (record, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("record must be a JSON object: %w", err)
+	}
+	return r, nil
+}
+
+func recordID(c Collection, r record) string {
+	if c == Inventory {
+		return stringField(r, "variantId")
+	}
+	return stringField(r, "id")
+}
+
+func stringField(r record, key string) string {
+	s, _ := r[key].(string)
+	return s
+}
+
+// decodeBody decodes a JSON request body into a record, writing a 400 and
+// returning false if it is not an object.
+func decodeBody(w http.ResponseWriter, r *http.Request) (record, bool) {
+	var body record
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body == nil {
+		writeError(w, http.StatusBadRequest, common.TypeInvalidRequestError, "Request body must be a JSON object")
+		return nil, false
+	}
+	return body, true
+}
+
+type countingWriter struct {
+	http.ResponseWriter
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// gzipWriter compresses the body of any response that may have one.
+type gzipWriter struct {
+	http.ResponseWriter
+	zw          *gzip.Writer
+	wroteHeader bool
+}
+
+func (w *gzipWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if status != http.StatusNoContent && status != http.StatusNotModified {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Add("Vary", "Accept-Encoding")
+		w.zw = gzip.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *gzipWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	if w.zw == nil {
+		return w.ResponseWriter.Write(p)
+	}
+	return w.zw.Write(p)
+}
+
+func (w *gzipWriter) Close() error {
+	if w.zw == nil {
+		return nil
+	}
+	return w.zw.Close()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, errorType, message string) {
+	writeJSON(w, status, common.APIError{Type: errorType, Message: message})
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, common.TypeMethodNotAllowed, "Method not allowed")
+}
+
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, common.TypeNotFound, fmt.Sprintf("No %s found with id %s", kind, id))
+}
@@ -0,0 +1,245 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/inventory"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/products"
+	"github.com/j-low/gocommerce/profiles"
+	"github.com/j-low/gocommerce/webhooks"
+)
+
+func TestServerCatalogToFulfillment(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	config := server.Config()
+	ctx := context.Background()
+
+	product, err := products.CreateProduct(ctx, config, products.CreateProductRequest{
+		Type:        common.ProductTypePhysical,
+		StorePageID: "store-page-1",
+		Name:        "Blue Mug",
+		Variants: []products.ProductVariant{{
+			SKU:     "MUG-1",
+			Pricing: products.Pricing{BasePrice: common.Amount{Currency: "USD", Value: "12.00"}},
+			Stock:   products.Stock{Quantity: 5},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct() error = %v", err)
+	}
+	variantID := product.Variants[0].ID
+	if product.ID == "" || variantID == "" || product.URLSlug != "blue-mug" {
+		t.Fatalf("unexpected product %+v", product)
+	}
+
+	stock, err := inventory.RetrieveSpecificInventory(ctx, config, []string{variantID})
+	if err != nil {
+		t.Fatalf("RetrieveSpecificInventory() error = %v", err)
+	}
+	if got := stock.Inventory[0]; got.SKU != "MUG-1" || got.Quantity != 5 {
+		t.Errorf("unexpected inventory %+v", got)
+	}
+
+	key := uuid.New()
+	orderConfig := *config
+	orderConfig.IdempotencyKey = &key
+	request := orders.CreateOrderRequest{
+		ChannelName:            "POS",
+		ExternalOrderReference: "pos-1",
+		PriceTaxInterpretation: "EXCLUSIVE",
+		LineItems:              []orders.LineItem{{LineItemType: "PHYSICAL_PRODUCT", VariantID: variantID, Quantity: 2}},
+		GrandTotal:             common.Amount{Currency: "USD", Value: "24.00"},
+		CreatedOn:              "2024-01-01T00:00:00Z",
+	}
+	order, err := orders.CreateOrder(ctx, &orderConfig, request)
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	retried, err := orders.CreateOrder(ctx, &orderConfig, request)
+	if err != nil {
+		t.Fatalf("CreateOrder() retry error = %v", err)
+	}
+	if retried.ID != order.ID || server.Len(Orders) != 1 {
+		t.Errorf("expected idempotent retry to return order %s, got %s with %d orders", order.ID, retried.ID, server.Len(Orders))
+	}
+
+	_, err = orders.CreateOrder(ctx, config, orders.CreateOrderRequest{
+		ChannelName:            "POS",
+		ExternalOrderReference: "pos-2",
+		PriceTaxInterpretation: "EXCLUSIVE",
+		LineItems:              []orders.LineItem{{LineItemType: "PHYSICAL_PRODUCT", VariantID: variantID, Quantity: 4}},
+		GrandTotal:             common.Amount{Currency: "USD", Value: "48.00"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "status: 409") {
+		t.Errorf("expected insufficient stock conflict, got %v", err)
+	}
+
+	if _, err := orders.FulfillOrder(ctx, config, order.ID, orders.FulfillOrderRequest{
+		Shipments: []orders.Shipment{{CarrierName: "UPS", TrackingNumber: "1Z"}},
+	}); err != nil {
+		t.Fatalf("FulfillOrder() error = %v", err)
+	}
+	fulfilled, err := orders.RetrieveSpecificOrder(ctx, config, order.ID)
+	if err != nil {
+		t.Fatalf("RetrieveSpecificOrder() error = %v", err)
+	}
+	if fulfilled.FulfillmentStatus != orders.StatusFulfilled || len(fulfilled.Fulfillments) != 1 {
+		t.Errorf("unexpected fulfilled order %+v", fulfilled)
+	}
+
+	var stored products.Product
+	if ok, err := server.Record(Products, product.ID, &stored); !ok || err != nil {
+		t.Fatalf("Record() = %v, %v", ok, err)
+	}
+	if stored.Variants[0].Stock.Quantity != 3 {
+		t.Errorf("expected variant stock 3 after order, got %d", stored.Variants[0].Stock.Quantity)
+	}
+}
+
+func TestServerDigitalProducts(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	config := server.Config()
+	ctx := context.Background()
+
+	product, err := products.CreateProduct(ctx, config, products.NewDigitalProductRequest("store-page-1", "Glazing Guide", common.Amount{Currency: "USD", Value: "15.00"}))
+	if err != nil {
+		t.Fatalf("CreateProduct() error = %v", err)
+	}
+	if !product.IsDigital() || product.Pricing.BasePrice.Value != "15.00" || len(product.Variants) != 0 {
+		t.Fatalf("unexpected product %+v", product)
+	}
+
+	_, err = products.CreateProductVariant(ctx, config, products.CreateProductVariantRequest{
+		ProductID: product.ID,
+		SKU:       "GUIDE-1",
+		Pricing:   products.Pricing{BasePrice: common.Amount{Currency: "USD", Value: "15.00"}},
+	})
+	if apiErr, ok := common.AsAPIError(err); !ok || !apiErr.IsSubtype(common.SubtypeOperationNotAllowedForProductType) || !errors.Is(err, products.ErrNotSupportedForDigital) {
+		t.Errorf("expected variants to be rejected for digital products, got %v", err)
+	}
+}
+
+func TestServerPagination(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SetPageSize(2)
+
+	for i := 1; i <= 5; i++ {
+		if err := server.Seed(Profiles, profiles.Profile{
+			ID:         fmt.Sprintf("profile-%d", i),
+			Email:      fmt.Sprintf("user%d@example.com", i),
+			IsCustomer: i%2 == 1,
+		}); err != nil {
+			t.Fatalf("Seed() error = %v", err)
+		}
+	}
+
+	all, err := profiles.RetrieveAllProfilesIter(context.Background(), server.Config(), common.QueryParams{}).Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(all) != 5 {
+		t.Errorf("expected 5 profiles, got %d", len(all))
+	}
+
+	customers, err := profiles.RetrieveAllProfilesIter(context.Background(), server.Config(), common.QueryParams{
+		Filter:        "isCustomer,true",
+		SortField:     "email",
+		SortDirection: "desc",
+	}).Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	var ids []string
+	for _, p := range customers {
+		ids = append(ids, p.ID)
+	}
+	if got := strings.Join(ids, ","); got != "profile-5,profile-3,profile-1" {
+		t.Errorf("expected filtered, sorted profiles, got %s", got)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	unauthorized := server.Config()
+	unauthorized.APIKey = "wrong"
+	_, err := inventory.RetrieveAllInventory(ctx, unauthorized, common.QueryParams{})
+	if err == nil || !strings.Contains(err.Error(), "status: 401, type: AUTHORIZATION_ERROR") {
+		t.Errorf("expected authorization error, got %v", err)
+	}
+
+	server.FailNext(http.MethodGet, "/commerce/inventory", http.StatusTooManyRequests, common.APIError{Type: "RATE_LIMIT_ERROR", Message: "Slow down"})
+	_, err = inventory.RetrieveAllInventory(ctx, server.Config(), common.QueryParams{})
+	if err == nil || !strings.Contains(err.Error(), "status: 429, type: RATE_LIMIT_ERROR, message: Slow down") {
+		t.Errorf("expected injected error, got %v", err)
+	}
+	if _, err := inventory.RetrieveAllInventory(ctx, server.Config(), common.QueryParams{}); err != nil {
+		t.Errorf("expected failure to apply once, got %v", err)
+	}
+
+	_, err = products.RetrieveSpecificProducts(ctx, server.Config(), []string{"missing"})
+	if err == nil || !strings.Contains(err.Error(), "status: 404, type: NOT_FOUND") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	if err := server.Seed(Orders, map[string]string{"orderNumber": "1"}); err == nil {
+		t.Error("expected error seeding a record without an id")
+	}
+}
+
+func TestServerWebhookSubscriptions(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	config := server.Config()
+	ctx := context.Background()
+
+	created, err := webhooks.CreateWebhookSubscription(ctx, config, webhooks.WebhookSubscriptionRequest{
+		EndpointURL: "https://example.com/hooks",
+		Topics:      []string{webhooks.TopicOrderCreate},
+	})
+	if err != nil {
+		t.Fatalf("CreateWebhookSubscription() error = %v", err)
+	}
+	if created.ID == "" || created.Secret == "" {
+		t.Fatalf("unexpected subscription %+v", created)
+	}
+
+	if _, err := webhooks.SendTestNotification(ctx, config, created.ID, webhooks.SendTestNotificationRequest{Topic: webhooks.TopicOrderCreate}); err != nil {
+		t.Errorf("SendTestNotification() error = %v", err)
+	}
+	if _, err := webhooks.SendTestNotification(ctx, config, created.ID, webhooks.SendTestNotificationRequest{Topic: webhooks.TopicOrderUpdate}); err == nil {
+		t.Error("expected error for an unsubscribed topic")
+	}
+
+	rotated, err := webhooks.RotateSubscriptionSecret(ctx, config, created.ID)
+	if err != nil {
+		t.Fatalf("RotateSubscriptionSecret() error = %v", err)
+	}
+	if rotated.Secret == created.Secret {
+		t.Error("expected a new secret")
+	}
+
+	if _, err := webhooks.DeleteWebhookSubscription(ctx, config, created.ID); err != nil {
+		t.Fatalf("DeleteWebhookSubscription() error = %v", err)
+	}
+	all, err := webhooks.RetrieveAllWebhookSubscriptions(ctx, config)
+	if err != nil {
+		t.Fatalf("RetrieveAllWebhookSubscriptions() error = %v", err)
+	}
+	if len(all.WebhookSubscriptions) != 0 {
+		t.Errorf("expected no subscriptions, got %d", len(all.WebhookSubscriptions))
+	}
+}
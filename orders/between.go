@@ -0,0 +1,47 @@
+package orders
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type ListOptions struct {
+	Status FulfillmentStatus
+}
+
+// ListBetween returns every order modified between from and to, paging through
+// all results. A zero from means the beginning of time and a zero to means now,
+// so the API's both-or-neither rule for modifiedAfter/modifiedBefore is always
+// satisfied.
+func ListBetween(ctx context.Context, config *common.Config, from, to time.Time, opts ListOptions) ([]Order, error) {
+	params, err := betweenParams(from, to, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return RetrieveAllOrdersIter(ctx, config, params).Collect()
+}
+
+func betweenParams(from, to time.Time, opts ListOptions) (common.QueryParams, error) {
+	params := ListParams{Status: opts.Status}
+
+	if from.IsZero() && to.IsZero() {
+		return params.QueryParams(), nil
+	}
+	if from.IsZero() {
+		from = time.Unix(0, 0)
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.Before(to) {
+		return ListParams{}.QueryParams(), fmt.Errorf("from (%s) must be before to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	params.ModifiedAfter = from
+	params.ModifiedBefore = to
+	return params.QueryParams(), nil
+}
@@ -0,0 +1,105 @@
+package orders
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestListBetween(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		from        time.Time
+		to          time.Time
+		opts        ListOptions
+		wantQuery   string
+		wantIDs     []string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:      "date range with status",
+			from:      from,
+			to:        to,
+			opts:      ListOptions{Status: StatusPending},
+			wantQuery: "fulfillmentStatus=PENDING&modifiedAfter=2024-01-01T00%3A00%3A00Z&modifiedBefore=2024-01-31T23%3A59%3A59Z",
+			wantIDs:   []string{"o1", "o2", "o3"},
+		},
+		{
+			name:      "open-ended start",
+			to:        to,
+			wantQuery: "modifiedAfter=1970-01-01T00%3A00%3A00Z&modifiedBefore=2024-01-31T23%3A59%3A59Z",
+			wantIDs:   []string{"o1", "o2", "o3"},
+		},
+		{
+			name:      "no bounds",
+			wantQuery: "",
+			wantIDs:   []string{"o1", "o2", "o3"},
+		},
+		{
+			name:        "inverted range",
+			from:        to,
+			to:          from,
+			wantErr:     true,
+			errContains: "must be before",
+		},
+		{
+			name:        "invalid status",
+			from:        from,
+			to:          to,
+			opts:        ListOptions{Status: "SHIPPED"},
+			wantErr:     true,
+			errContains: "status must be one of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				cursor := r.URL.Query().Get("cursor")
+				if cursor == "" && r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("expected query %s, got %s", tt.wantQuery, r.URL.RawQuery)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(pagedOrders[cursor]))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			orders, err := ListBetween(context.Background(), config, tt.from, tt.to, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListBetween() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			var ids []string
+			for _, order := range orders {
+				ids = append(ids, order.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected orders %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
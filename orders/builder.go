@@ -0,0 +1,302 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const (
+	PriceTaxInclusive = "INCLUSIVE"
+	PriceTaxExclusive = "EXCLUSIVE"
+)
+
+// Builder accumulates the parts of a CreateOrderRequest and computes its
+// subtotal, shipping, discount and grand totals so they are consistent before
+// the order is submitted.
+type Builder struct {
+	request CreateOrderRequest
+	tax     common.Amount
+}
+
+func NewBuilder(channelName, externalOrderReference string) *Builder {
+	return &Builder{
+		request: CreateOrderRequest{
+			ChannelName:            channelName,
+			ExternalOrderReference: externalOrderReference,
+			PriceTaxInterpretation: PriceTaxExclusive,
+		},
+	}
+}
+
+func (b *Builder) SetCustomerEmail(email string) *Builder {
+	b.request.CustomerEmail = email
+	return b
+}
+
+func (b *Builder) SetBillingAddress(address common.Address) *Builder {
+	b.request.BillingAddress = address
+	return b
+}
+
+func (b *Builder) SetShippingAddress(address common.Address) *Builder {
+	b.request.ShippingAddress = address
+	return b
+}
+
+func (b *Builder) SetInventoryBehavior(behavior string) *Builder {
+	b.request.InventoryBehavior = behavior
+	return b
+}
+
+// SetPriceTaxInterpretation declares whether line item prices already include
+// tax. Defaults to PriceTaxExclusive.
+func (b *Builder) SetPriceTaxInterpretation(interpretation string) *Builder {
+	b.request.PriceTaxInterpretation = interpretation
+	return b
+}
+
+func (b *Builder) SetFulfillmentStatus(status FulfillmentStatus) *Builder {
+	b.request.FulfillmentStatus = status
+	return b
+}
+
+// SetCreatedOn overrides the order's creation time. Defaults to the time Build
+// is called.
+func (b *Builder) SetCreatedOn(createdOn time.Time) *Builder {
+	b.request.CreatedOn = createdOn.UTC().Format(time.RFC3339)
+	return b
+}
+
+func (b *Builder) AddLineItem(item LineItem) *Builder {
+	b.request.LineItems = append(b.request.LineItems, item)
+	return b
+}
+
+func (b *Builder) AddShipping(method string, amount common.Amount) *Builder {
+	b.request.ShippingLines = append(b.request.ShippingLines, ShippingLine{Method: method, Amount: amount})
+	return b
+}
+
+func (b *Builder) AddDiscount(discount DiscountLine) *Builder {
+	b.request.DiscountLines = append(b.request.DiscountLines, discount)
+	return b
+}
+
+func (b *Builder) SetTaxTotal(amount common.Amount) *Builder {
+	b.tax = amount
+	return b
+}
+
+// Build computes the order totals and returns the request, or every problem
+// found while validating it.
+func (b *Builder) Build() (CreateOrderRequest, error) {
+	request := b.request
+	request.LineItems = append([]LineItem{}, b.request.LineItems...)
+	request.ShippingLines = append([]ShippingLine{}, b.request.ShippingLines...)
+	request.DiscountLines = append([]DiscountLine{}, b.request.DiscountLines...)
+	if request.CreatedOn == "" {
+		request.CreatedOn = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	totals, err := computeTotals(request, b.tax)
+	if err != nil {
+		return CreateOrderRequest{}, err
+	}
+	request.Subtotal = totals.Subtotal
+	request.ShippingTotal = totals.ShippingTotal
+	request.DiscountTotal = totals.DiscountTotal
+	request.TaxTotal = totals.TaxTotal
+	request.GrandTotal = totals.GrandTotal
+
+	if err := ValidateTotals(request); err != nil {
+		return CreateOrderRequest{}, err
+	}
+	return request, nil
+}
+
+func (b *Builder) Submit(ctx context.Context, config *common.Config) (*Order, error) {
+	request, err := b.Build()
+	if err != nil {
+		return nil, fmt.Errorf("invalid order: %w", err)
+	}
+
+	return CreateOrder(ctx, config, request)
+}
+
+type orderTotals struct {
+	Subtotal      common.Amount
+	ShippingTotal common.Amount
+	DiscountTotal common.Amount
+	TaxTotal      common.Amount
+	GrandTotal    common.Amount
+}
+
+// computeTotals derives totals from the request's lines. grandTotal is
+// subtotal + shipping - discounts, plus tax when prices exclude it.
+func computeTotals(request CreateOrderRequest, tax common.Amount) (orderTotals, error) {
+	var totals orderTotals
+	var errs []error
+
+	currency := ""
+	places := 0
+	checkCurrency := func(label string, amount common.Amount) bool {
+		if err := common.ValidateAmount(amount); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", label, err))
+			return false
+		}
+		if currency == "" {
+			currency = amount.Currency
+		} else if amount.Currency != currency {
+			errs = append(errs, fmt.Errorf("%s: currency %s does not match order currency %s", label, amount.Currency, currency))
+			return false
+		}
+		if _, fraction, ok := strings.Cut(amount.Value, "."); ok {
+			places = max(places, len(fraction))
+		}
+		return true
+	}
+
+	for i, item := range request.LineItems {
+		label := fmt.Sprintf("lineItems[%d]", i)
+		if item.Quantity <= 0 {
+			errs = append(errs, fmt.Errorf("%s: quantity must be positive, got: %d", label, item.Quantity))
+			continue
+		}
+		if !checkCurrency(label+".unitPricePaid", item.UnitPricePaid) {
+			continue
+		}
+		lineTotal, err := item.UnitPricePaid.Mul(strconv.Itoa(item.Quantity))
+		if err == nil {
+			totals.Subtotal, err = totals.Subtotal.Add(lineTotal)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", label, err))
+		}
+	}
+
+	for i, line := range request.ShippingLines {
+		label := fmt.Sprintf("shippingLines[%d].amount", i)
+		if !checkCurrency(label, line.Amount) {
+			continue
+		}
+		var err error
+		if totals.ShippingTotal, err = totals.ShippingTotal.Add(line.Amount); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", label, err))
+		}
+	}
+
+	for i, line := range request.DiscountLines {
+		label := fmt.Sprintf("discountLines[%d].amount", i)
+		if !checkCurrency(label, line.Amount) {
+			continue
+		}
+		var err error
+		if totals.DiscountTotal, err = totals.DiscountTotal.Add(line.Amount); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", label, err))
+		}
+	}
+
+	if tax != (common.Amount{}) && checkCurrency("taxTotal", tax) {
+		totals.TaxTotal = tax
+	}
+
+	if len(errs) > 0 {
+		return orderTotals{}, errors.Join(errs...)
+	}
+
+	totals.Subtotal = zeroIfEmpty(totals.Subtotal, currency, places)
+	totals.ShippingTotal = zeroIfEmpty(totals.ShippingTotal, currency, places)
+	totals.DiscountTotal = zeroIfEmpty(totals.DiscountTotal, currency, places)
+	totals.TaxTotal = zeroIfEmpty(totals.TaxTotal, currency, places)
+
+	grandTotal, err := totals.Subtotal.Add(totals.ShippingTotal)
+	if err == nil {
+		grandTotal, err = grandTotal.Sub(totals.DiscountTotal)
+	}
+	if err == nil && request.PriceTaxInterpretation != PriceTaxInclusive {
+		grandTotal, err = grandTotal.Add(totals.TaxTotal)
+	}
+	if err != nil {
+		return orderTotals{}, err
+	}
+	totals.GrandTotal = grandTotal
+
+	return totals, nil
+}
+
+// ValidateTotals checks that a CreateOrderRequest's required fields are present
+// and that its totals agree with its line items, returning every problem found.
+func ValidateTotals(request CreateOrderRequest) error {
+	var errs []error
+
+	if request.ChannelName == "" {
+		errs = append(errs, fmt.Errorf("channelName is required"))
+	}
+	if request.ExternalOrderReference == "" {
+		errs = append(errs, fmt.Errorf("externalOrderReference is required"))
+	}
+	if request.CreatedOn == "" {
+		errs = append(errs, fmt.Errorf("createdOn is required"))
+	}
+	if len(request.LineItems) == 0 {
+		errs = append(errs, fmt.Errorf("at least one line item is required"))
+	}
+	switch request.PriceTaxInterpretation {
+	case PriceTaxInclusive, PriceTaxExclusive:
+	default:
+		errs = append(errs, fmt.Errorf("priceTaxInterpretation must be INCLUSIVE or EXCLUSIVE, got: %q", request.PriceTaxInterpretation))
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	totals, err := computeTotals(request, request.TaxTotal)
+	if err != nil {
+		return err
+	}
+
+	if sign, err := totals.GrandTotal.Cmp(common.Amount{Currency: totals.GrandTotal.Currency, Value: "0"}); err == nil && sign < 0 {
+		errs = append(errs, fmt.Errorf("grandTotal cannot be negative, got: %s", totals.GrandTotal.Value))
+	}
+
+	for _, check := range []struct {
+		name     string
+		declared common.Amount
+		computed common.Amount
+		optional bool
+	}{
+		{"subtotal", request.Subtotal, totals.Subtotal, true},
+		{"shippingTotal", request.ShippingTotal, totals.ShippingTotal, true},
+		{"discountTotal", request.DiscountTotal, totals.DiscountTotal, true},
+		{"grandTotal", request.GrandTotal, totals.GrandTotal, false},
+	} {
+		if check.optional && check.declared == (common.Amount{}) {
+			continue
+		}
+		if check.declared.Currency != check.computed.Currency {
+			errs = append(errs, fmt.Errorf("%s currency %s does not match line item currency %s", check.name, check.declared.Currency, check.computed.Currency))
+			continue
+		}
+		if c, err := check.declared.Cmp(check.computed); err != nil || c != 0 {
+			errs = append(errs, fmt.Errorf("%s %s does not match computed %s", check.name, check.declared.Value, check.computed.Value))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// zeroIfEmpty fills in an unset total as zero with the precision of the
+// order's other amounts, so a zero-decimal currency such as JPY gets "0"
+// rather than "0.00" and its grand total keeps no decimals.
+func zeroIfEmpty(amount common.Amount, currency string, places int) common.Amount {
+	if amount == (common.Amount{}) {
+		return common.Amount{Currency: currency, Value: strconv.FormatFloat(0, 'f', places, 64)}
+	}
+	return amount
+}
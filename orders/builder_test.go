@@ -0,0 +1,174 @@
+package orders
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func usd(value string) common.Amount {
+	return common.Amount{Currency: "USD", Value: value}
+}
+
+func TestBuilderBuild(t *testing.T) {
+	tests := []struct {
+		name           string
+		builder        *Builder
+		wantSubtotal   string
+		wantGrandTotal string
+		errContains    []string
+	}{
+		{
+			name: "exclusive tax is added to grand total",
+			builder: NewBuilder("pos", "ext-1").
+				AddLineItem(LineItem{LineItemType: "PHYSICAL_PRODUCT", Quantity: 2, UnitPricePaid: usd("10.00")}).
+				AddLineItem(LineItem{LineItemType: "PHYSICAL_PRODUCT", Quantity: 1, UnitPricePaid: usd("5.50")}).
+				AddShipping("Ground", usd("4.00")).
+				AddDiscount(DiscountLine{Name: "Promo", Amount: usd("3.00")}).
+				SetTaxTotal(usd("2.05")),
+			wantSubtotal:   "25.50",
+			wantGrandTotal: "28.55",
+		},
+		{
+			name: "inclusive tax is not added again",
+			builder: NewBuilder("pos", "ext-1").
+				SetPriceTaxInterpretation(PriceTaxInclusive).
+				AddLineItem(LineItem{LineItemType: "PHYSICAL_PRODUCT", Quantity: 1, UnitPricePaid: usd("12.00")}).
+				SetTaxTotal(usd("2.00")),
+			wantSubtotal:   "12.00",
+			wantGrandTotal: "12.00",
+		},
+		{
+			name: "zero-decimal currency keeps its precision",
+			builder: NewBuilder("pos", "ext-1").
+				AddLineItem(LineItem{LineItemType: "PHYSICAL_PRODUCT", Quantity: 2, UnitPricePaid: common.Amount{Currency: "JPY", Value: "1000"}}),
+			wantSubtotal:   "2000",
+			wantGrandTotal: "2000",
+		},
+		{
+			name:        "no line items",
+			builder:     NewBuilder("", "ext-1"),
+			errContains: []string{"channelName is required", "at least one line item is required"},
+		},
+		{
+			name: "mixed currencies",
+			builder: NewBuilder("pos", "ext-1").
+				AddLineItem(LineItem{Quantity: 1, UnitPricePaid: usd("10.00")}).
+				AddShipping("Ground", common.Amount{Currency: "EUR", Value: "4.00"}),
+			errContains: []string{"shippingLines[0].amount: currency EUR does not match order currency USD"},
+		},
+		{
+			name: "invalid quantity",
+			builder: NewBuilder("pos", "ext-1").
+				AddLineItem(LineItem{Quantity: 0, UnitPricePaid: usd("10.00")}),
+			errContains: []string{"lineItems[0]: quantity must be positive"},
+		},
+		{
+			name: "discount exceeds total",
+			builder: NewBuilder("pos", "ext-1").
+				AddLineItem(LineItem{Quantity: 1, UnitPricePaid: usd("10.00")}).
+				AddDiscount(DiscountLine{Name: "Too much", Amount: usd("15.00")}),
+			errContains: []string{"grandTotal cannot be negative"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := tt.builder.Build()
+			if len(tt.errContains) > 0 {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				for _, want := range tt.errContains {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("error message should contain %q, got %q", want, err.Error())
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build() unexpected error = %v", err)
+			}
+
+			if request.Subtotal.Value != tt.wantSubtotal {
+				t.Errorf("expected subtotal %s, got %s", tt.wantSubtotal, request.Subtotal.Value)
+			}
+			if request.GrandTotal.Value != tt.wantGrandTotal {
+				t.Errorf("expected grand total %s, got %s", tt.wantGrandTotal, request.GrandTotal.Value)
+			}
+			if request.CreatedOn == "" {
+				t.Error("expected createdOn to default")
+			}
+		})
+	}
+}
+
+func TestValidateTotals(t *testing.T) {
+	valid := CreateOrderRequest{
+		ChannelName:            "pos",
+		ExternalOrderReference: "ext-1",
+		CreatedOn:              "2024-01-01T00:00:00Z",
+		PriceTaxInterpretation: PriceTaxExclusive,
+		LineItems:              []LineItem{{Quantity: 2, UnitPricePaid: usd("10.00")}},
+		TaxTotal:               usd("1.00"),
+		GrandTotal:             usd("21.00"),
+	}
+
+	if err := ValidateTotals(valid); err != nil {
+		t.Errorf("ValidateTotals() unexpected error = %v", err)
+	}
+
+	mismatched := valid
+	mismatched.Subtotal = usd("19.00")
+	mismatched.GrandTotal = usd("20.00")
+	err := ValidateTotals(mismatched)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	for _, want := range []string{"subtotal 19.00 does not match computed 20.00", "grandTotal 20.00 does not match computed 21.00"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error message should contain %q, got %q", want, err.Error())
+		}
+	}
+}
+
+func TestBuilderSubmit(t *testing.T) {
+	var received CreateOrderRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "order-123"}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	order, err := NewBuilder("pos", "ext-1").
+		AddLineItem(LineItem{LineItemType: "PHYSICAL_PRODUCT", Quantity: 3, UnitPricePaid: usd("1.25")}).
+		Submit(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Submit() unexpected error = %v", err)
+	}
+	if order.ID != "order-123" {
+		t.Errorf("expected order ID order-123, got %s", order.ID)
+	}
+	if received.GrandTotal != usd("3.75") {
+		t.Errorf("expected grand total 3.75 USD, got %+v", received.GrandTotal)
+	}
+
+	if _, err := NewBuilder("pos", "ext-1").Submit(context.Background(), config); err == nil || !strings.Contains(err.Error(), "invalid order") {
+		t.Errorf("expected invalid order error, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/j-low/gocommerce/bulk"
+	"github.com/j-low/gocommerce/common"
+)
+
+type BulkFulfillment struct {
+	OrderID string
+	Request FulfillOrderRequest
+}
+
+type BulkFulfillmentResult struct {
+	OrderID    string
+	StatusCode int
+	Err        error
+}
+
+// FulfillOrders fulfills every order using bulk.Run. Results are returned in
+// input order; the error joins every failed fulfillment.
+//
+// Fulfillment is not idempotent: a retry after a timeout or 5xx may fulfill
+// the order twice and send the customer a second shipping email. Unless
+// opts.Retry.Retryable is set, only 429 responses, which the API rejects
+// before doing anything, are retried.
+func FulfillOrders(ctx context.Context, config *common.Config, fulfillments []BulkFulfillment, opts bulk.Options) ([]BulkFulfillmentResult, error) {
+	if opts.Retry.Retryable == nil {
+		opts.Retry.Retryable = isRateLimited
+	}
+	report := bulk.Run(ctx, fulfillments, func(ctx context.Context, _ int, fulfillment BulkFulfillment) (int, error) {
+		if fulfillment.OrderID == "" {
+			return 0, fmt.Errorf("orderID is required")
+		}
+		return FulfillOrder(ctx, config, fulfillment.OrderID, fulfillment.Request)
+	}, opts)
+
+	results := make([]BulkFulfillmentResult, len(fulfillments))
+	var errs []error
+	for i, result := range report.Results {
+		results[i] = BulkFulfillmentResult{OrderID: fulfillments[i].OrderID, StatusCode: result.Value, Err: result.Err}
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", fulfillments[i].OrderID, result.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func isRateLimited(err error) bool {
+	var respErr *common.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,120 @@
+package orders
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/j-low/gocommerce/bulk"
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestFulfillOrders(t *testing.T) {
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		orderID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/1.0/commerce/orders/"), "/fulfillments")
+		mu.Lock()
+		attempts[orderID]++
+		n := attempts[orderID]
+		mu.Unlock()
+
+		switch {
+		case orderID == "flaky" && n == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"type":"ERROR","message":"Try again"}`))
+		case orderID == "canceled":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"type":"CONFLICT","message":"Order is canceled"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	var progress []bulk.Progress
+	results, err := FulfillOrders(context.Background(), config, []BulkFulfillment{
+		{OrderID: "ok"},
+		{OrderID: "flaky"},
+		{OrderID: "canceled"},
+	}, bulk.Options{
+		Concurrency: 2,
+		Retry: bulk.RetryPolicy{
+			MaxAttempts: 2,
+			Backoff:     1,
+			Retryable:   func(err error) bool { return strings.Contains(err.Error(), "status: 503") },
+		},
+		OnProgress: func(p bulk.Progress) { progress = append(progress, p) },
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "order canceled: ") {
+		t.Fatalf("expected canceled order error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "order flaky") {
+		t.Errorf("expected flaky order to succeed on retry, got %v", err)
+	}
+
+	wantStatus := []int{http.StatusNoContent, http.StatusNoContent, http.StatusConflict}
+	for i, result := range results {
+		if result.StatusCode != wantStatus[i] {
+			t.Errorf("result %d (%s): expected status %d, got %d", i, result.OrderID, wantStatus[i], result.StatusCode)
+		}
+	}
+	if attempts["canceled"] != 1 {
+		t.Errorf("expected non-retryable error to be attempted once, got %d", attempts["canceled"])
+	}
+	if len(progress) != 3 || progress[2].Done != 3 || progress[2].Failed != 1 {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+}
+
+func TestFulfillOrdersDefaultRetries(t *testing.T) {
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		orderID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/1.0/commerce/orders/"), "/fulfillments")
+		mu.Lock()
+		attempts[orderID]++
+		n := attempts[orderID]
+		mu.Unlock()
+
+		switch {
+		case orderID == "limited" && n == 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"type":"RATE_LIMIT_ERROR","message":"Slow down"}`))
+		case orderID == "unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"type":"ERROR","message":"Try again"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	config := &common.Config{APIKey: "test-key", Client: server.Client(), UserAgent: "test-agent", BaseURL: server.URL}
+
+	_, err := FulfillOrders(context.Background(), config, []BulkFulfillment{
+		{OrderID: "limited"},
+		{OrderID: "unavailable"},
+	}, bulk.Options{Retry: bulk.RetryPolicy{MaxAttempts: 3, Backoff: 1}})
+
+	if err == nil || !strings.Contains(err.Error(), "order unavailable") || strings.Contains(err.Error(), "order limited") {
+		t.Fatalf("expected only the unavailable order to fail, got %v", err)
+	}
+	if attempts["limited"] != 2 {
+		t.Errorf("expected a rate-limited fulfillment to be retried, got %d attempts", attempts["limited"])
+	}
+	if attempts["unavailable"] != 1 {
+		t.Errorf("expected a 503 fulfillment not to be retried, got %d attempts", attempts["unavailable"])
+	}
+}
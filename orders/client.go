@@ -13,6 +13,10 @@ import (
 )
 
 func CreateOrder(ctx context.Context, config *common.Config, request CreateOrderRequest) (*Order, error) {
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, OrdersAPIVersion, "commerce/orders")
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -29,14 +33,14 @@ func CreateOrder(ctx context.Context, config *common.Config, request CreateOrder
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "orders"))
 	req.Header.Set("Content-Type", "application/json")
 
 	if config.IdempotencyKey != nil {
 		req.Header.Set("Idempotency-Key", config.IdempotencyKey.String())
 	}
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
@@ -48,7 +52,7 @@ func CreateOrder(ctx context.Context, config *common.Config, request CreateOrder
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, common.ParseErrorResponse("CreateOrder", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("CreateOrder", baseURL, resp, body)
 	}
 
 	var response Order
@@ -60,11 +64,17 @@ func CreateOrder(ctx context.Context, config *common.Config, request CreateOrder
 }
 
 func FulfillOrder(ctx context.Context, config *common.Config, orderID string, request FulfillOrderRequest) (int, error) {
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, OrdersAPIVersion, fmt.Sprintf("commerce/orders/%s/fulfillments", orderID))
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to build base URL: %w", err)
 	}
 
+	request.Shipments = withTrackingURLs(request.Shipments)
+
 	reqBody, err := json.Marshal(request)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to marshal request body: %w", err)
@@ -76,10 +86,10 @@ func FulfillOrder(ctx context.Context, config *common.Config, orderID string, re
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "orders"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to fulfill order: %w", err)
 	}
@@ -90,25 +100,42 @@ func FulfillOrder(ctx context.Context, config *common.Config, orderID string, re
 		if readErr != nil {
 			return http.StatusBadRequest, fmt.Errorf("failed to read response body: %w", readErr)
 		}
-		return resp.StatusCode, common.ParseErrorResponse("FulfillOrder", baseURL, body, resp.StatusCode)
+		return resp.StatusCode, common.ParseResponseError("FulfillOrder", baseURL, resp, body)
 	}
 
 	return http.StatusNoContent, nil
 }
 
 func RetrieveAllOrders(ctx context.Context, config *common.Config, params common.QueryParams) (*RetrieveAllOrdersResponse, error) {
+	var response RetrieveAllOrdersResponse
+	if err := retrieveAllOrders(ctx, config, params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// retrieveAllOrders fetches one page of orders into response, which must
+// decode the orders list shape.
+func retrieveAllOrders(ctx context.Context, config *common.Config, params common.QueryParams, response interface{}) error {
 	if err := common.ValidateQueryParams(params); err != nil {
-		return nil, fmt.Errorf("invalid query parameters: %w", err)
+		return fmt.Errorf("invalid query parameters: %w", err)
+	}
+	if params.Status != "" && !FulfillmentStatus(params.Status).Valid() {
+		return fmt.Errorf("invalid query parameters: status must be one of PENDING, FULFILLED or CANCELED, got: %s", params.Status)
+	}
+
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
 	}
 
 	baseURL, err := common.BuildBaseURL(config, OrdersAPIVersion, "commerce/orders")
 	if err != nil {
-		return nil, fmt.Errorf("failed to build base URL: %w", err)
+		return fmt.Errorf("failed to build base URL: %w", err)
 	}
 
 	u, err := url.Parse(baseURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+		return fmt.Errorf("failed to parse base URL: %w", err)
 	}
 
 	query := u.Query()
@@ -128,36 +155,38 @@ func RetrieveAllOrders(ctx context.Context, config *common.Config, params common
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "orders"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve all orders: %w", err)
+		return fmt.Errorf("failed to retrieve all orders: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveAllOrders", u.String(), body, resp.StatusCode)
+		body, err := common.ReadBody(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+		return common.ParseResponseError("RetrieveAllOrders", u.String(), resp, body)
 	}
 
-	var response RetrieveAllOrdersResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	if err := common.DecodeJSON(resp.Body, response); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
-	return &response, nil
+	return nil
 }
 
 func RetrieveSpecificOrder(ctx context.Context, config *common.Config, orderID string) (*Order, error) {
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, OrdersAPIVersion, fmt.Sprintf("commerce/orders/%s", orderID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -169,9 +198,9 @@ func RetrieveSpecificOrder(ctx context.Context, config *common.Config, orderID s
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "orders"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve order: %w", err)
 	}
@@ -183,7 +212,7 @@ func RetrieveSpecificOrder(ctx context.Context, config *common.Config, orderID s
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveSpecificOrder", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("RetrieveSpecificOrder", baseURL, resp, body)
 	}
 
 	var response Order
@@ -2,6 +2,8 @@ package orders
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -106,6 +108,7 @@ func TestFulfillOrder(t *testing.T) {
 		request     FulfillOrderRequest
 		mockStatus  int
 		mockResp    string
+		wantURLs    []string
 		wantErr     bool
 		errContains string
 	}{
@@ -124,6 +127,20 @@ func TestFulfillOrder(t *testing.T) {
 				},
 			},
 			mockStatus: http.StatusNoContent,
+			wantURLs:   []string{"https://www.ups.com/track?tracknum=1Z999999999"},
+			wantErr:    false,
+		},
+		{
+			name:    "explicit and unknown carrier tracking URLs are kept",
+			orderID: "order-123",
+			request: FulfillOrderRequest{
+				Shipments: []Shipment{
+					{CarrierName: "UPS", TrackingNumber: "1Z999999999", TrackingURL: "https://example.com/track/1"},
+					{CarrierName: "Local Courier", TrackingNumber: "LC-1"},
+				},
+			},
+			mockStatus: http.StatusNoContent,
+			wantURLs:   []string{"https://example.com/track/1", ""},
 			wantErr:    false,
 		},
 		{
@@ -153,6 +170,16 @@ func TestFulfillOrder(t *testing.T) {
 					t.Errorf("expected Content-Type header 'application/json', got %s", contentType)
 				}
 
+				var received FulfillOrderRequest
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					t.Errorf("failed to decode request body: %v", err)
+				}
+				for i, want := range tt.wantURLs {
+					if got := received.Shipments[i].TrackingURL; got != want {
+						t.Errorf("shipment %d: expected tracking URL %q, got %q", i, want, got)
+					}
+				}
+
 				w.WriteHeader(tt.mockStatus)
 				if tt.mockResp != "" {
 					w.Write([]byte(tt.mockResp))
@@ -229,6 +256,19 @@ func TestRetrieveAllOrders(t *testing.T) {
 			wantErr:     true,
 			errContains: "Internal Server Error",
 		},
+		{
+			name:       "valid fulfillment status",
+			params:     common.QueryParams{Status: string(StatusFulfilled)},
+			mockStatus: http.StatusOK,
+			mockResp:   `{"result": [], "pagination": {}}`,
+			wantErr:    false,
+		},
+		{
+			name:        "invalid fulfillment status",
+			params:      common.QueryParams{Status: "SHIPPED"},
+			wantErr:     true,
+			errContains: "status must be one of PENDING, FULFILLED or CANCELED, got: SHIPPED",
+		},
 	}
 
 	for _, tt := range tests {
@@ -240,6 +280,9 @@ func TestRetrieveAllOrders(t *testing.T) {
 				if auth := r.Header.Get("Authorization"); auth != "Bearer test-key" {
 					t.Errorf("expected Authorization header 'Bearer test-key', got %s", auth)
 				}
+				if status := r.URL.Query().Get("fulfillmentStatus"); status != tt.params.Status {
+					t.Errorf("expected fulfillmentStatus %q, got %q", tt.params.Status, status)
+				}
 
 				w.WriteHeader(tt.mockStatus)
 				w.Write([]byte(tt.mockResp))
@@ -381,3 +424,85 @@ func TestRetrieveSpecificOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestRetrieveSpecificOrderWithCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "299")
+		w.Write([]byte(`{"id":"order-1"}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	resp, err := common.Call(context.Background(), func(ctx context.Context) (*Order, error) {
+		return RetrieveSpecificOrder(ctx, config, "order-1")
+	})
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-RateLimit-Remaining") != "299" || resp.Body.ID != "order-1" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestCallWithoutResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"type":"NOT_FOUND","message":"Order not found"}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	resp, err := common.Call(context.Background(), func(ctx context.Context) (*Order, error) {
+		return RetrieveSpecificOrder(ctx, config, "order-1")
+	})
+	if err == nil || resp == nil || resp.StatusCode != http.StatusNotFound || resp.Header.Get("X-Request-Id") != "req-1" {
+		t.Errorf("expected the 404 alongside the error, got %+v, %v", resp, err)
+	}
+
+	dryRun := *config
+	dryRun.DryRun = true
+	dryRun.DryRunLog = func(common.DryRunRequest) {}
+	status, err := common.Call(context.Background(), func(ctx context.Context) (int, error) {
+		return FulfillOrder(ctx, &dryRun, "order-1", FulfillOrderRequest{})
+	})
+	if status != nil || !errors.Is(err, common.ErrDryRun) {
+		t.Errorf("expected no response for a dry run, got %+v, %v", status, err)
+	}
+}
+
+func TestUserAgentNamesModule(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"id": "order-1"}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+	if _, err := RetrieveSpecificOrder(context.Background(), config, "order-1"); err != nil {
+		t.Fatalf("RetrieveSpecificOrder() unexpected error = %v", err)
+	}
+
+	want := "test-agent gocommerce/" + common.LibraryVersion() + " (orders)"
+	if got != want {
+		t.Errorf("User-Agent = %q, want %q", got, want)
+	}
+}
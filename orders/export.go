@@ -0,0 +1,245 @@
+package orders
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV        ExportFormat = "csv"
+	ExportFormatQuickBooks ExportFormat = "quickbooks"
+	ExportFormatXero       ExportFormat = "xero"
+)
+
+const defaultXeroAccountCode = "200"
+
+type ExportOptions struct {
+	// Format selects the column layout. Defaults to ExportFormatCSV.
+	Format ExportFormat
+	From   time.Time
+	To     time.Time
+	Status FulfillmentStatus
+	// AccountCode is the revenue account used by the Xero layout. Defaults to
+	// "200", Xero's default Sales account.
+	AccountCode string
+}
+
+// Export streams orders modified between opts.From and opts.To to w, one row
+// per line item. Orders are written as they are paged in, so memory use is
+// bounded by a single page. The accounting layouts add shipping, discount and
+// tax rows so each invoice's rows sum to the order's grand total.
+func Export(ctx context.Context, config *common.Config, w io.Writer, opts ExportOptions) error {
+	format := opts.Format
+	if format == "" {
+		format = ExportFormatCSV
+	}
+
+	var writeOrder func(*csv.Writer, Order) error
+	var header []string
+	switch format {
+	case ExportFormatCSV:
+		header, writeOrder = genericExportHeader, writeGenericOrder
+	case ExportFormatQuickBooks:
+		header, writeOrder = quickBooksExportHeader, writeQuickBooksOrder
+	case ExportFormatXero:
+		accountCode := opts.AccountCode
+		if accountCode == "" {
+			accountCode = defaultXeroAccountCode
+		}
+		header = xeroExportHeader
+		writeOrder = func(cw *csv.Writer, order Order) error {
+			return writeXeroOrder(cw, order, accountCode)
+		}
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	params, err := betweenParams(opts.From, opts.To, ListOptions{Status: opts.Status})
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	it := RetrieveAllOrdersIter(ctx, config, params)
+	for it.Next() {
+		order := it.Value()
+		if err := writeOrder(cw, order); err != nil {
+			return fmt.Errorf("failed to export order %s: %w", order.ID, err)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to retrieve orders: %w", err)
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+var genericExportHeader = []string{
+	"order_id", "order_number", "created_on", "modified_on", "channel", "customer_email",
+	"fulfillment_status", "fulfilled_on", "tracking_numbers", "currency",
+	"subtotal", "shipping_total", "discount_total", "tax_total", "refunded_total", "grand_total",
+	"line_item_id", "variant_id", "sku", "product_name", "quantity", "unit_price", "line_total",
+}
+
+func writeGenericOrder(cw *csv.Writer, order Order) error {
+	var trackingNumbers []string
+	for _, fulfillment := range order.Fulfillments {
+		if fulfillment.TrackingNumber != "" {
+			trackingNumbers = append(trackingNumbers, fulfillment.TrackingNumber)
+		}
+	}
+
+	orderColumns := []string{
+		order.ID, order.OrderNumber, order.CreatedOn, order.ModifiedOn, order.Channel, order.CustomerEmail,
+		string(order.FulfillmentStatus), order.FulfilledOn, strings.Join(trackingNumbers, ";"), order.GrandTotal.Currency,
+		order.Subtotal.Value, order.ShippingTotal.Value, order.DiscountTotal.Value, order.TaxTotal.Value,
+		order.RefundedTotal.Value, order.GrandTotal.Value,
+	}
+
+	if len(order.LineItems) == 0 {
+		return cw.Write(append(orderColumns, "", "", "", "", "", "", ""))
+	}
+
+	for _, item := range order.LineItems {
+		lineTotal, err := item.UnitPricePaid.Mul(strconv.Itoa(item.Quantity))
+		if err != nil {
+			return err
+		}
+		row := append(append([]string{}, orderColumns...),
+			item.ID, item.VariantID, item.SKU, item.ProductName, strconv.Itoa(item.Quantity),
+			item.UnitPricePaid.Value, lineTotal.Value,
+		)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var quickBooksExportHeader = []string{
+	"*InvoiceNo", "*Customer", "*InvoiceDate", "*DueDate", "Item(Product/Service)",
+	"ItemDescription", "ItemQuantity", "ItemRate", "*ItemAmount", "Currency",
+}
+
+func writeQuickBooksOrder(cw *csv.Writer, order Order) error {
+	date := exportDate(order.CreatedOn)
+	lines, err := accountingLines(order)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		if err := cw.Write([]string{
+			order.OrderNumber, customerName(order), date, date, line.item,
+			line.description, line.quantity, line.rate, line.amount, order.GrandTotal.Currency,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var xeroExportHeader = []string{
+	"*ContactName", "EmailAddress", "*InvoiceNumber", "Reference", "*InvoiceDate", "*DueDate",
+	"InventoryItemCode", "*Description", "*Quantity", "*UnitAmount", "*AccountCode", "Currency",
+}
+
+func writeXeroOrder(cw *csv.Writer, order Order, accountCode string) error {
+	date := exportDate(order.CreatedOn)
+	lines, err := accountingLines(order)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		if err := cw.Write([]string{
+			customerName(order), order.CustomerEmail, order.OrderNumber, order.ExternalOrderReference, date, date,
+			line.item, line.description, line.quantity, line.rate, accountCode, order.GrandTotal.Currency,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type accountingLine struct {
+	item        string
+	description string
+	quantity    string
+	rate        string
+	amount      string
+}
+
+// accountingLines flattens an order into invoice lines: one per line item plus
+// shipping, a negative discount line and tax, each only when non-zero.
+func accountingLines(order Order) ([]accountingLine, error) {
+	var lines []accountingLine
+
+	for _, item := range order.LineItems {
+		amount, err := item.UnitPricePaid.Mul(strconv.Itoa(item.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		description := item.ProductName
+		for _, option := range item.VariantOptions {
+			description += fmt.Sprintf(" (%s: %s)", option.OptionName, option.Value)
+		}
+		lines = append(lines, accountingLine{
+			item:        item.SKU,
+			description: description,
+			quantity:    strconv.Itoa(item.Quantity),
+			rate:        item.UnitPricePaid.Value,
+			amount:      amount.Value,
+		})
+	}
+
+	if !order.ShippingTotal.IsZero() {
+		lines = append(lines, singleLine("Shipping", "Shipping", order.ShippingTotal.Value))
+	}
+	if !order.DiscountTotal.IsZero() {
+		discount, err := common.Amount{}.Sub(order.DiscountTotal)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, singleLine("Discount", "Discount", discount.Value))
+	}
+	if !order.TaxTotal.IsZero() && order.PriceTaxInterpretation != "INCLUSIVE" {
+		lines = append(lines, singleLine("Tax", "Sales tax", order.TaxTotal.Value))
+	}
+
+	return lines, nil
+}
+
+func singleLine(item, description, value string) accountingLine {
+	return accountingLine{item: item, description: description, quantity: "1", rate: value, amount: value}
+}
+
+func customerName(order Order) string {
+	name := strings.TrimSpace(order.BillingAddress.FirstName + " " + order.BillingAddress.LastName)
+	if name == "" {
+		return order.CustomerEmail
+	}
+	return name
+}
+
+func exportDate(timestamp string) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return timestamp
+	}
+	return t.UTC().Format("2006-01-02")
+}
@@ -0,0 +1,148 @@
+package orders
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const exportOrdersPage = `{
+	"result": [
+		{
+			"id": "o1",
+			"orderNumber": "1001",
+			"createdOn": "2024-03-05T14:00:00Z",
+			"channel": "web",
+			"customerEmail": "jane@example.com",
+			"billingAddress": {"firstName": "Jane", "lastName": "Doe"},
+			"fulfillmentStatus": "FULFILLED",
+			"fulfillments": [{"trackingNumber": "1Z999"}],
+			"lineItems": [
+				{"id": "li1", "sku": "TEE-S", "productName": "Tee", "quantity": 2, "unitPricePaid": {"currency": "USD", "value": "10.00"},
+				 "variantOptions": [{"optionName": "Size", "value": "S"}]},
+				{"id": "li2", "sku": "MUG", "productName": "Mug", "quantity": 1, "unitPricePaid": {"currency": "USD", "value": "5.50"}}
+			],
+			"subtotal": {"currency": "USD", "value": "25.50"},
+			"shippingTotal": {"currency": "USD", "value": "4.00"},
+			"discountTotal": {"currency": "USD", "value": "2.50"},
+			"taxTotal": {"currency": "USD", "value": "1.00"},
+			"refundedTotal": {"currency": "USD", "value": "0.00"},
+			"grandTotal": {"currency": "USD", "value": "28.00"},
+			"priceTaxInterpretation": "EXCLUSIVE"
+		},
+		{
+			"id": "o2",
+			"orderNumber": "1002",
+			"createdOn": "2024-03-06T09:00:00Z",
+			"customerEmail": "sam@example.com",
+			"fulfillmentStatus": "PENDING",
+			"grandTotal": {"currency": "USD", "value": "0.00"}
+		}
+	],
+	"pagination": {"hasNextPage": false}
+}`
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        ExportOptions
+		wantHeader  string
+		wantRows    []string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:       "generic csv",
+			opts:       ExportOptions{},
+			wantHeader: strings.Join(genericExportHeader, ","),
+			wantRows: []string{
+				"o1,1001,2024-03-05T14:00:00Z,,web,jane@example.com,FULFILLED,,1Z999,USD,25.50,4.00,2.50,1.00,0.00,28.00,li1,,TEE-S,Tee,2,10.00,20.00",
+				"o1,1001,2024-03-05T14:00:00Z,,web,jane@example.com,FULFILLED,,1Z999,USD,25.50,4.00,2.50,1.00,0.00,28.00,li2,,MUG,Mug,1,5.50,5.50",
+				"o2,1002,2024-03-06T09:00:00Z,,,sam@example.com,PENDING,,,USD,,,,,,0.00,,,,,,,",
+			},
+		},
+		{
+			name:       "quickbooks",
+			opts:       ExportOptions{Format: ExportFormatQuickBooks},
+			wantHeader: strings.Join(quickBooksExportHeader, ","),
+			wantRows: []string{
+				"1001,Jane Doe,2024-03-05,2024-03-05,TEE-S,Tee (Size: S),2,10.00,20.00,USD",
+				"1001,Jane Doe,2024-03-05,2024-03-05,MUG,Mug,1,5.50,5.50,USD",
+				"1001,Jane Doe,2024-03-05,2024-03-05,Shipping,Shipping,1,4.00,4.00,USD",
+				"1001,Jane Doe,2024-03-05,2024-03-05,Discount,Discount,1,-2.50,-2.50,USD",
+				"1001,Jane Doe,2024-03-05,2024-03-05,Tax,Sales tax,1,1.00,1.00,USD",
+			},
+		},
+		{
+			name:       "xero with custom account",
+			opts:       ExportOptions{Format: ExportFormatXero, AccountCode: "400"},
+			wantHeader: strings.Join(xeroExportHeader, ","),
+			wantRows: []string{
+				"Jane Doe,jane@example.com,1001,,2024-03-05,2024-03-05,TEE-S,Tee (Size: S),2,10.00,400,USD",
+				"Jane Doe,jane@example.com,1001,,2024-03-05,2024-03-05,MUG,Mug,1,5.50,400,USD",
+				"Jane Doe,jane@example.com,1001,,2024-03-05,2024-03-05,Shipping,Shipping,1,4.00,400,USD",
+				"Jane Doe,jane@example.com,1001,,2024-03-05,2024-03-05,Discount,Discount,1,-2.50,400,USD",
+				"Jane Doe,jane@example.com,1001,,2024-03-05,2024-03-05,Tax,Sales tax,1,1.00,400,USD",
+			},
+		},
+		{
+			name:        "unsupported format",
+			opts:        ExportOptions{Format: "pdf"},
+			wantErr:     true,
+			errContains: "unsupported export format: pdf",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(exportOrdersPage))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			var buf bytes.Buffer
+			err := Export(context.Background(), config, &buf, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Export() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			records, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("failed to parse export: %v", err)
+			}
+			if got := strings.Join(records[0], ","); got != tt.wantHeader {
+				t.Errorf("header = %s, want %s", got, tt.wantHeader)
+			}
+			if len(records)-1 != len(tt.wantRows) {
+				t.Fatalf("expected %d rows, got %d", len(tt.wantRows), len(records)-1)
+			}
+			for i, want := range tt.wantRows {
+				if got := strings.Join(records[i+1], ","); got != want {
+					t.Errorf("row %d = %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
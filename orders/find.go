@@ -0,0 +1,47 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+var ErrOrderNotFound = errors.New("order not found")
+
+// FindByExternalReference scans every order for the one whose
+// externalOrderReference equals ref. The API has no direct lookup, so this
+// pages through the full order list; use FindByExternalReferenceBetween to
+// bound the scan when the order's modification window is known.
+func FindByExternalReference(ctx context.Context, config *common.Config, ref string) (*Order, error) {
+	return FindByExternalReferenceBetween(ctx, config, ref, time.Time{}, time.Time{})
+}
+
+// FindByExternalReferenceBetween is FindByExternalReference restricted to
+// orders modified between from and to, with the same open-ended bounds as
+// ListBetween. It returns ErrOrderNotFound when no order matches.
+func FindByExternalReferenceBetween(ctx context.Context, config *common.Config, ref string, from, to time.Time) (*Order, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("external order reference is required")
+	}
+
+	params, err := betweenParams(from, to, ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	it := RetrieveAllOrdersIter(ctx, config, params)
+	for it.Next() {
+		order := it.Value()
+		if order.ExternalOrderReference == ref {
+			return &order, nil
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("%w: external reference %q", ErrOrderNotFound, ref)
+}
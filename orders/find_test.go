@@ -0,0 +1,95 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/internal/pagedtest"
+)
+
+func TestFindByExternalReference(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"result": [{"id": "o1", "externalOrderReference": "ext-1"}], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`,
+		"c2": `{"result": [{"id": "o2", "externalOrderReference": "ext-2"}], "pagination": {"hasNextPage": false}}`,
+	}
+
+	tests := []struct {
+		name        string
+		ref         string
+		from        time.Time
+		to          time.Time
+		wantID      string
+		wantErr     bool
+		notFound    bool
+		errContains string
+	}{
+		{
+			name:   "match on later page",
+			ref:    "ext-2",
+			wantID: "o2",
+		},
+		{
+			name:   "match with date bounds",
+			ref:    "ext-1",
+			from:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantID: "o1",
+		},
+		{
+			name:     "no match",
+			ref:      "ext-9",
+			wantErr:  true,
+			notFound: true,
+		},
+		{
+			name:        "missing reference",
+			wantErr:     true,
+			errContains: "external order reference is required",
+		},
+		{
+			name:        "inverted range",
+			ref:         "ext-1",
+			from:        time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			to:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:     true,
+			errContains: "must be before",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := pagedtest.NewServer(t, pages)
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			order, err := FindByExternalReferenceBetween(context.Background(), config, tt.ref, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindByExternalReferenceBetween() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				if tt.notFound && !errors.Is(err, ErrOrderNotFound) {
+					t.Errorf("expected ErrOrderNotFound, got %v", err)
+				}
+				if tt.errContains != "" && !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if order.ID != tt.wantID {
+				t.Errorf("expected order %s, got %s", tt.wantID, order.ID)
+			}
+		})
+	}
+}
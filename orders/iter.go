@@ -0,0 +1,43 @@
+package orders
+
+import (
+	"context"
+	"errors"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// RetrieveAllOrdersIter returns an iterator over every order matching params,
+// following pagination cursors automatically.
+func RetrieveAllOrdersIter(ctx context.Context, config *common.Config, params common.QueryParams) *common.Iterator[Order] {
+	return common.NewIterator(ctx, func(ctx context.Context, cursor string) ([]Order, common.Pagination, error) {
+		resp, err := RetrieveAllOrders(ctx, config, common.PageParams(params, cursor))
+		if err != nil {
+			return nil, common.Pagination{}, err
+		}
+		return resp.Result, resp.Pagination, nil
+	})
+}
+
+// RetrieveAllOrdersPages calls fn with each page of orders matching params.
+// Returning common.ErrStopIteration from fn stops paging without an error.
+func RetrieveAllOrdersPages(ctx context.Context, config *common.Config, params common.QueryParams, fn func(*RetrieveAllOrdersResponse) error) error {
+	for {
+		resp, err := RetrieveAllOrders(ctx, config, params)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(resp); err != nil {
+			if errors.Is(err, common.ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+
+		if !resp.Pagination.HasNext() {
+			return nil
+		}
+		params = resp.Pagination.NextParams()
+	}
+}
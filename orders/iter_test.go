@@ -0,0 +1,140 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/internal/pagedtest"
+)
+
+var pagedOrders = map[string]string{
+	"":   `{"result": [{"id": "o1"}, {"id": "o2"}], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`,
+	"c2": `{"result": [{"id": "o3"}], "pagination": {"hasNextPage": false}}`,
+}
+
+func TestRetrieveAllOrdersIter(t *testing.T) {
+	tests := []struct {
+		name        string
+		pages       map[string]string
+		params      common.QueryParams
+		wantIDs     []string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:    "follows cursors",
+			pages:   pagedOrders,
+			params:  common.QueryParams{Status: "PENDING"},
+			wantIDs: []string{"o1", "o2", "o3"},
+		},
+		{
+			name: "error on later page",
+			pages: map[string]string{
+				"": `{"result": [{"id": "o1"}], "pagination": {"hasNextPage": true, "nextPageCursor": "bad"}}`,
+			},
+			wantIDs:     []string{"o1"},
+			wantErr:     true,
+			errContains: "Invalid cursor",
+		},
+		{
+			name:        "invalid params",
+			pages:       pagedOrders,
+			params:      common.QueryParams{Cursor: "c2", Status: "PENDING"},
+			wantErr:     true,
+			errContains: "invalid query parameters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := pagedtest.NewServer(t, tt.pages)
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			var ids []string
+			it := RetrieveAllOrdersIter(context.Background(), config, tt.params)
+			for it.Next() {
+				ids = append(ids, it.Value().ID)
+			}
+
+			err := it.Err()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RetrieveAllOrdersIter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && tt.errContains != "" && !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected orders %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
+
+func TestRetrieveAllOrdersPages(t *testing.T) {
+	errCallback := errors.New("callback failed")
+
+	tests := []struct {
+		name      string
+		stopAfter int
+		stopErr   error
+		wantPages int
+		wantErr   error
+	}{
+		{
+			name:      "all pages",
+			wantPages: 2,
+		},
+		{
+			name:      "stop iteration",
+			stopAfter: 1,
+			stopErr:   common.ErrStopIteration,
+			wantPages: 1,
+		},
+		{
+			name:      "callback error",
+			stopAfter: 1,
+			stopErr:   errCallback,
+			wantPages: 1,
+			wantErr:   errCallback,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := pagedtest.NewServer(t, pagedOrders)
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			pages := 0
+			err := RetrieveAllOrdersPages(context.Background(), config, common.QueryParams{}, func(resp *RetrieveAllOrdersResponse) error {
+				pages++
+				if tt.stopAfter > 0 && pages == tt.stopAfter {
+					return tt.stopErr
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RetrieveAllOrdersPages() error = %v, want %v", err, tt.wantErr)
+			}
+			if pages != tt.wantPages {
+				t.Errorf("expected %d pages, got %d", tt.wantPages, pages)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package orders
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type RetrieveAllLazyOrdersResponse struct {
+	Result     []LazyOrder       `json:"result"`
+	Pagination common.Pagination `json:"pagination"`
+}
+
+// LazyOrder is an Order whose line items and form submission are left
+// undecoded until asked for, for consumers that only need IDs, statuses and
+// totals. The embedded Order's LineItems and FormSubmission are always empty;
+// use the accessor methods.
+type LazyOrder struct {
+	Order
+	RawLineItems      json.RawMessage `json:"lineItems"`
+	RawFormSubmission json.RawMessage `json:"formSubmission"`
+}
+
+// LineItems decodes the order's line items.
+func (o *LazyOrder) LineItems() ([]LineItem, error) {
+	var items []LineItem
+	return items, decodeRaw(o.RawLineItems, &items)
+}
+
+// FormSubmission decodes the order's checkout form submission.
+func (o *LazyOrder) FormSubmission() ([]FormSubmission, error) {
+	var submission []FormSubmission
+	return submission, decodeRaw(o.RawFormSubmission, &submission)
+}
+
+// Decode returns the fully decoded order.
+func (o *LazyOrder) Decode() (Order, error) {
+	order := o.Order
+	var err error
+	if order.LineItems, err = o.LineItems(); err != nil {
+		return Order{}, err
+	}
+	if order.FormSubmission, err = o.FormSubmission(); err != nil {
+		return Order{}, err
+	}
+	return order, nil
+}
+
+func decodeRaw(raw json.RawMessage, v interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// RetrieveAllOrdersLazy is RetrieveAllOrders returning LazyOrders.
+func RetrieveAllOrdersLazy(ctx context.Context, config *common.Config, params common.QueryParams) (*RetrieveAllLazyOrdersResponse, error) {
+	var response RetrieveAllLazyOrdersResponse
+	if err := retrieveAllOrders(ctx, config, params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// RetrieveAllOrdersLazyIter is RetrieveAllOrdersIter over LazyOrders.
+func RetrieveAllOrdersLazyIter(ctx context.Context, config *common.Config, params common.QueryParams) *common.Iterator[LazyOrder] {
+	return common.NewIterator(ctx, func(ctx context.Context, cursor string) ([]LazyOrder, common.Pagination, error) {
+		resp, err := RetrieveAllOrdersLazy(ctx, config, common.PageParams(params, cursor))
+		if err != nil {
+			return nil, common.Pagination{}, err
+		}
+		return resp.Result, resp.Pagination, nil
+	})
+}
@@ -0,0 +1,104 @@
+package orders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const lazyOrderJSON = `{
+	"id": "order-1",
+	"orderNumber": "1001",
+	"fulfillmentStatus": "PENDING",
+	"grandTotal": {"currency": "USD", "value": "42.00"},
+	"lineItems": [{"id": "item-1", "sku": "SQ-1", "quantity": 2}, {"id": "item-2", "sku": "SQ-2", "quantity": 1}],
+	"formSubmission": [{"label": "Gift note", "value": "Happy birthday"}]
+}`
+
+func TestRetrieveAllOrdersLazy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result": [` + lazyOrderJSON + `], "pagination": {"hasNextPage": false}}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	all, err := RetrieveAllOrdersLazyIter(context.Background(), config, common.QueryParams{}).Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("expected 1 order, got %d", len(all))
+	}
+
+	order := all[0]
+	if order.ID != "order-1" || order.GrandTotal.Value != "42.00" || order.FulfillmentStatus != StatusPending {
+		t.Errorf("expected eagerly decoded fields, got %+v", order.Order)
+	}
+	if order.Order.LineItems != nil || len(order.RawLineItems) == 0 {
+		t.Errorf("expected line items to be left raw")
+	}
+
+	items, err := order.LineItems()
+	if err != nil {
+		t.Fatalf("LineItems() error = %v", err)
+	}
+	if len(items) != 2 || items[0].SKU != "SQ-1" || items[0].Quantity != 2 {
+		t.Errorf("unexpected line items %+v", items)
+	}
+
+	full, err := order.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(full.LineItems) != 2 || len(full.FormSubmission) != 1 || full.FormSubmission[0].Value != "Happy birthday" {
+		t.Errorf("unexpected decoded order %+v", full)
+	}
+
+	var empty LazyOrder
+	if items, err := empty.LineItems(); err != nil || items != nil {
+		t.Errorf("expected no line items from an empty order, got %v, %v", items, err)
+	}
+}
+
+func BenchmarkDecodeOrdersPage(b *testing.B) {
+	orders := make([]string, 100)
+	for i := range orders {
+		items := make([]string, 20)
+		for j := range items {
+			items[j] = fmt.Sprintf(`{"id":"item-%d","sku":"SQ-%d","productName":"Tee","quantity":1,"unitPricePaid":{"currency":"USD","value":"10.00"},"customizations":[{"label":"Size","value":"M"}]}`, j, j)
+		}
+		orders[i] = fmt.Sprintf(`{"id":"order-%d","grandTotal":{"currency":"USD","value":"200.00"},"lineItems":[%s]}`, i, strings.Join(items, ","))
+	}
+	page := []byte(`{"result":[` + strings.Join(orders, ",") + `]}`)
+
+	b.Run("eager", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var response RetrieveAllOrdersResponse
+			if err := json.Unmarshal(page, &response); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("lazy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var response RetrieveAllLazyOrdersResponse
+			if err := json.Unmarshal(page, &response); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
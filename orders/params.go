@@ -0,0 +1,49 @@
+package orders
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// ListParams is a typed alternative to common.QueryParams for
+// RetrieveAllOrders. Zero times are omitted; non-zero times are sent as
+// RFC 3339 UTC strings.
+type ListParams struct {
+	Cursor         string
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+	Status         FulfillmentStatus
+}
+
+// Validate checks the parameters before a request is made.
+func (p ListParams) Validate() error {
+	if p.Cursor != "" {
+		return common.ValidateCursor(p.QueryParams())
+	}
+	if err := common.ValidateModifiedRange(p.ModifiedAfter, p.ModifiedBefore); err != nil {
+		return err
+	}
+	if p.Status != "" && !p.Status.Valid() {
+		return fmt.Errorf("status must be one of PENDING, FULFILLED or CANCELED, got: %s", p.Status)
+	}
+	return nil
+}
+
+func (p ListParams) QueryParams() common.QueryParams {
+	return common.QueryParams{
+		Cursor:         p.Cursor,
+		ModifiedAfter:  common.FormatTime(p.ModifiedAfter),
+		ModifiedBefore: common.FormatTime(p.ModifiedBefore),
+		Status:         string(p.Status),
+	}
+}
+
+func RetrieveAllOrdersWithParams(ctx context.Context, config *common.Config, params ListParams) (*RetrieveAllOrdersResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid query parameters: %w", err)
+	}
+	return RetrieveAllOrders(ctx, config, params.QueryParams())
+}
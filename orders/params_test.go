@@ -0,0 +1,65 @@
+package orders
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestListParams(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		params  ListParams
+		want    common.QueryParams
+		wantErr string
+	}{
+		{
+			name:   "range and status",
+			params: ListParams{ModifiedAfter: jan, ModifiedBefore: feb, Status: StatusPending},
+			want:   common.QueryParams{ModifiedAfter: "2024-01-01T00:00:00Z", ModifiedBefore: "2024-02-01T00:00:00Z", Status: "PENDING"},
+		},
+		{
+			name:   "cursor only",
+			params: ListParams{Cursor: "abc"},
+			want:   common.QueryParams{Cursor: "abc"},
+		},
+		{
+			name:    "cursor with status",
+			params:  ListParams{Cursor: "abc", Status: StatusFulfilled},
+			wantErr: "cannot use cursor alongside other query parameters",
+		},
+		{
+			name:    "only one bound",
+			params:  ListParams{ModifiedBefore: feb},
+			wantErr: "must both be specified together",
+		},
+		{
+			name:    "unknown status",
+			params:  ListParams{Status: "SHIPPED"},
+			wantErr: "status must be one of PENDING, FULFILLED or CANCELED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got := tt.params.QueryParams(); got != tt.want {
+				t.Errorf("QueryParams() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
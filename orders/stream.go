@@ -0,0 +1,37 @@
+package orders
+
+import (
+	"context"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Stream pages through the orders matching params in the background and sends
+// each one on the returned order channel, which is unbuffered so at most one
+// page is held in memory. Both channels are closed when paging finishes; the
+// error channel receives at most one error, including the context's error if
+// ctx is canceled before every order is delivered.
+func Stream(ctx context.Context, config *common.Config, params common.QueryParams) (<-chan Order, <-chan error) {
+	orders := make(chan Order)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(orders)
+		defer close(errs)
+
+		it := RetrieveAllOrdersIter(ctx, config, params)
+		for it.Next() {
+			select {
+			case orders <- it.Value():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errs <- err
+		}
+	}()
+
+	return orders, errs
+}
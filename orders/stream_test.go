@@ -0,0 +1,96 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/internal/pagedtest"
+)
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		pages       map[string]string
+		wantIDs     []string
+		errContains string
+	}{
+		{
+			name:    "streams every page",
+			pages:   pagedOrders,
+			wantIDs: []string{"o1", "o2", "o3"},
+		},
+		{
+			name: "error after first page",
+			pages: map[string]string{
+				"": `{"result": [{"id": "o1"}], "pagination": {"hasNextPage": true, "nextPageCursor": "bad"}}`,
+			},
+			wantIDs:     []string{"o1"},
+			errContains: "Invalid cursor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := pagedtest.NewServer(t, tt.pages)
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			orders, errs := Stream(context.Background(), config, common.QueryParams{})
+
+			var ids []string
+			for order := range orders {
+				ids = append(ids, order.ID)
+			}
+			err := <-errs
+
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected orders %v, got %v", tt.wantIDs, ids)
+			}
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("Stream() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("expected error containing %q, got %v", tt.errContains, err)
+			}
+		})
+	}
+}
+
+func TestStreamCanceled(t *testing.T) {
+	server := pagedtest.NewServer(t, pagedOrders)
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	orders, errs := Stream(ctx, config, common.QueryParams{})
+
+	first := <-orders
+	if first.ID != "o1" {
+		t.Errorf("expected first order o1, got %s", first.ID)
+	}
+	cancel()
+
+	for range orders {
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package orders
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Totals aggregates the money fields of a set of orders. Gross is the sum of
+// order subtotals and Net is what was collected after refunds: grand totals
+// minus refunded totals.
+type Totals struct {
+	Orders    int
+	Gross     common.Amount
+	Discounts common.Amount
+	Shipping  common.Amount
+	Tax       common.Amount
+	Refunded  common.Amount
+	Net       common.Amount
+}
+
+type PeriodTotals struct {
+	// Start is the UTC midnight beginning the day, or the Monday beginning the
+	// ISO week.
+	Start time.Time
+	Totals
+}
+
+type Summary struct {
+	Total     Totals
+	Daily     []PeriodTotals
+	Weekly    []PeriodTotals
+	ByChannel map[string]Totals
+}
+
+// Summarize totals the orders created between from and to by day, by week and
+// by channel. Test mode and canceled orders are left out. Because orders are
+// only filterable by modification time, every order modified since from is
+// paged in and filtered on its creation time. All orders must share one
+// currency.
+func Summarize(ctx context.Context, config *common.Config, from, to time.Time) (*Summary, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	params, err := betweenParams(from, time.Time{}, ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !from.IsZero() && !from.Before(to) {
+		return nil, fmt.Errorf("from (%s) must be before to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	summary := &Summary{ByChannel: make(map[string]Totals)}
+	daily := make(map[time.Time]*Totals)
+	weekly := make(map[time.Time]*Totals)
+
+	it := RetrieveAllOrdersIter(ctx, config, params)
+	for it.Next() {
+		order := it.Value()
+		if order.TestMode || order.FulfillmentStatus == StatusCanceled {
+			continue
+		}
+
+		createdOn, err := time.Parse(time.RFC3339, order.CreatedOn)
+		if err != nil {
+			return nil, fmt.Errorf("order %s: invalid createdOn %q: %w", order.ID, order.CreatedOn, err)
+		}
+		if createdOn.Before(from) || createdOn.After(to) {
+			continue
+		}
+
+		day := createdOn.UTC().Truncate(24 * time.Hour)
+		week := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+
+		channel := order.ChannelName
+		if channel == "" {
+			channel = order.Channel
+		}
+		channelTotals := summary.ByChannel[channel]
+
+		for _, totals := range []*Totals{&summary.Total, bucket(daily, day), bucket(weekly, week), &channelTotals} {
+			if err := totals.add(order); err != nil {
+				return nil, fmt.Errorf("order %s: %w", order.ID, err)
+			}
+		}
+		summary.ByChannel[channel] = channelTotals
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	summary.Daily = sortedPeriods(daily)
+	summary.Weekly = sortedPeriods(weekly)
+	return summary, nil
+}
+
+func (t *Totals) add(order Order) error {
+	net, err := order.GrandTotal.Sub(order.RefundedTotal)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range []struct {
+		total  *common.Amount
+		amount common.Amount
+	}{
+		{&t.Gross, order.Subtotal},
+		{&t.Discounts, order.DiscountTotal},
+		{&t.Shipping, order.ShippingTotal},
+		{&t.Tax, order.TaxTotal},
+		{&t.Refunded, order.RefundedTotal},
+		{&t.Net, net},
+	} {
+		sum, err := field.total.Add(field.amount)
+		if err != nil {
+			return err
+		}
+		*field.total = sum
+	}
+
+	t.Orders++
+	return nil
+}
+
+func bucket(buckets map[time.Time]*Totals, start time.Time) *Totals {
+	totals, ok := buckets[start]
+	if !ok {
+		totals = &Totals{}
+		buckets[start] = totals
+	}
+	return totals
+}
+
+func sortedPeriods(buckets map[time.Time]*Totals) []PeriodTotals {
+	periods := make([]PeriodTotals, 0, len(buckets))
+	for start, totals := range buckets {
+		periods = append(periods, PeriodTotals{Start: start, Totals: *totals})
+	}
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Start.Before(periods[j].Start)
+	})
+	return periods
+}
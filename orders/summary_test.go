@@ -0,0 +1,100 @@
+package orders
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/internal/pagedtest"
+)
+
+func TestSummarize(t *testing.T) {
+	pages := map[string]string{
+		"": `{"result": [
+			{"id": "o1", "createdOn": "2024-01-01T10:00:00Z", "channelName": "web",
+			 "subtotal": {"currency": "USD", "value": "100.00"}, "discountTotal": {"currency": "USD", "value": "10.00"},
+			 "shippingTotal": {"currency": "USD", "value": "5.00"}, "taxTotal": {"currency": "USD", "value": "8.00"},
+			 "refundedTotal": {"currency": "USD", "value": "0.00"}, "grandTotal": {"currency": "USD", "value": "103.00"}},
+			{"id": "o2", "createdOn": "2024-01-03T09:00:00Z", "channelName": "pos",
+			 "subtotal": {"currency": "USD", "value": "50.00"}, "refundedTotal": {"currency": "USD", "value": "20.00"},
+			 "grandTotal": {"currency": "USD", "value": "50.00"}}
+		], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`,
+		"c2": `{"result": [
+			{"id": "o3", "createdOn": "2024-01-08T12:00:00Z", "channel": "web",
+			 "subtotal": {"currency": "USD", "value": "20.00"}, "grandTotal": {"currency": "USD", "value": "20.00"}},
+			{"id": "o4", "createdOn": "2024-01-02T12:00:00Z", "testmode": true,
+			 "grandTotal": {"currency": "USD", "value": "999.00"}},
+			{"id": "o5", "createdOn": "2024-01-02T12:00:00Z", "fulfillmentStatus": "CANCELED",
+			 "grandTotal": {"currency": "USD", "value": "999.00"}},
+			{"id": "o6", "createdOn": "2024-03-01T12:00:00Z",
+			 "grandTotal": {"currency": "USD", "value": "999.00"}}
+		], "pagination": {"hasNextPage": false}}`,
+	}
+
+	server := pagedtest.NewServer(t, pages)
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	summary, err := Summarize(context.Background(), config, time.Time{}, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Summarize() unexpected error = %v", err)
+	}
+
+	if summary.Total.Orders != 3 {
+		t.Errorf("expected 3 orders, got %d", summary.Total.Orders)
+	}
+	if summary.Total.Gross.Value != "170.00" {
+		t.Errorf("expected gross 170.00, got %s", summary.Total.Gross.Value)
+	}
+	if summary.Total.Net.Value != "153.00" {
+		t.Errorf("expected net 153.00, got %s", summary.Total.Net.Value)
+	}
+	if summary.Total.Refunded.Value != "20.00" {
+		t.Errorf("expected refunded 20.00, got %s", summary.Total.Refunded.Value)
+	}
+
+	if len(summary.Daily) != 3 || !summary.Daily[0].Start.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected daily buckets %+v", summary.Daily)
+	}
+	if len(summary.Weekly) != 2 || summary.Weekly[0].Orders != 2 || !summary.Weekly[1].Start.Equal(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected weekly buckets %+v", summary.Weekly)
+	}
+	if web := summary.ByChannel["web"]; web.Orders != 2 || web.Gross.Value != "120.00" {
+		t.Errorf("unexpected web channel totals %+v", web)
+	}
+	if pos := summary.ByChannel["pos"]; pos.Orders != 1 || pos.Net.Value != "30.00" {
+		t.Errorf("unexpected pos channel totals %+v", pos)
+	}
+}
+
+func TestSummarizeMixedCurrencies(t *testing.T) {
+	pages := map[string]string{
+		"": `{"result": [
+			{"id": "o1", "createdOn": "2024-01-01T10:00:00Z", "grandTotal": {"currency": "USD", "value": "10.00"}},
+			{"id": "o2", "createdOn": "2024-01-01T11:00:00Z", "grandTotal": {"currency": "EUR", "value": "10.00"}}
+		], "pagination": {"hasNextPage": false}}`,
+	}
+
+	server := pagedtest.NewServer(t, pages)
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	_, err := Summarize(context.Background(), config, time.Time{}, time.Time{})
+	if err == nil || !strings.Contains(err.Error(), "order o2") {
+		t.Errorf("expected currency error for order o2, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package orders
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// TrackingNumberPlaceholder marks where the tracking number goes in a carrier
+// tracking URL template.
+const TrackingNumberPlaceholder = "{trackingNumber}"
+
+var (
+	trackingTemplatesMu sync.RWMutex
+	trackingTemplates   = map[string]string{
+		"ups":             "https://www.ups.com/track?tracknum={trackingNumber}",
+		"usps":            "https://tools.usps.com/go/TrackConfirmAction?tLabels={trackingNumber}",
+		"fedex":           "https://www.fedex.com/fedextrack/?trknbr={trackingNumber}",
+		"federal express": "https://www.fedex.com/fedextrack/?trknbr={trackingNumber}",
+		"dhl":             "https://www.dhl.com/global-en/home/tracking/tracking-express.html?tracking-id={trackingNumber}",
+		"dhl express":     "https://www.dhl.com/global-en/home/tracking/tracking-express.html?tracking-id={trackingNumber}",
+	}
+)
+
+// RegisterTrackingTemplate adds or replaces the tracking URL template used for
+// carrier. The template must contain TrackingNumberPlaceholder. Carrier names
+// are matched case-insensitively.
+func RegisterTrackingTemplate(carrier, template string) {
+	trackingTemplatesMu.Lock()
+	defer trackingTemplatesMu.Unlock()
+	trackingTemplates[normalizeCarrier(carrier)] = template
+}
+
+// TrackingURL returns the tracking page for trackingNumber at carrier, or an
+// empty string when the carrier is unknown or the tracking number is empty.
+func TrackingURL(carrier, trackingNumber string) string {
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	if trackingNumber == "" {
+		return ""
+	}
+
+	trackingTemplatesMu.RLock()
+	template, ok := trackingTemplates[normalizeCarrier(carrier)]
+	trackingTemplatesMu.RUnlock()
+	if !ok || !strings.Contains(template, TrackingNumberPlaceholder) {
+		return ""
+	}
+
+	return strings.ReplaceAll(template, TrackingNumberPlaceholder, url.QueryEscape(trackingNumber))
+}
+
+func normalizeCarrier(carrier string) string {
+	return strings.Join(strings.Fields(strings.ToLower(carrier)), " ")
+}
+
+// withTrackingURLs returns a copy of shipments with blank tracking URLs filled
+// in for known carriers.
+func withTrackingURLs(shipments []Shipment) []Shipment {
+	if len(shipments) == 0 {
+		return shipments
+	}
+
+	filled := make([]Shipment, len(shipments))
+	copy(filled, shipments)
+	for i := range filled {
+		if filled[i].TrackingURL == "" {
+			filled[i].TrackingURL = TrackingURL(filled[i].CarrierName, filled[i].TrackingNumber)
+		}
+	}
+	return filled
+}
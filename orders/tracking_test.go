@@ -0,0 +1,62 @@
+package orders
+
+import "testing"
+
+func TestTrackingURL(t *testing.T) {
+	RegisterTrackingTemplate("Canada Post", "https://www.canadapost-postescanada.ca/track-reperage/en#/search?searchFor={trackingNumber}")
+
+	tests := []struct {
+		name           string
+		carrier        string
+		trackingNumber string
+		want           string
+	}{
+		{
+			name:           "ups",
+			carrier:        "UPS",
+			trackingNumber: "1Z999AA10123456784",
+			want:           "https://www.ups.com/track?tracknum=1Z999AA10123456784",
+		},
+		{
+			name:           "usps",
+			carrier:        "usps",
+			trackingNumber: "9400111899223100000000",
+			want:           "https://tools.usps.com/go/TrackConfirmAction?tLabels=9400111899223100000000",
+		},
+		{
+			name:           "fedex alias and spacing",
+			carrier:        "  Federal   Express ",
+			trackingNumber: "123456789012",
+			want:           "https://www.fedex.com/fedextrack/?trknbr=123456789012",
+		},
+		{
+			name:           "dhl escapes tracking number",
+			carrier:        "DHL",
+			trackingNumber: "12 34&5",
+			want:           "https://www.dhl.com/global-en/home/tracking/tracking-express.html?tracking-id=12+34%265",
+		},
+		{
+			name:           "custom template",
+			carrier:        "canada post",
+			trackingNumber: "CP123",
+			want:           "https://www.canadapost-postescanada.ca/track-reperage/en#/search?searchFor=CP123",
+		},
+		{
+			name:           "unknown carrier",
+			carrier:        "Local Courier",
+			trackingNumber: "LC-1",
+		},
+		{
+			name:    "empty tracking number",
+			carrier: "UPS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TrackingURL(tt.carrier, tt.trackingNumber); got != tt.want {
+				t.Errorf("TrackingURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
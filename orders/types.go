@@ -6,27 +6,43 @@ const (
 	OrdersAPIVersion = "1.0"
 )
 
+type FulfillmentStatus string
+
+const (
+	StatusPending   FulfillmentStatus = "PENDING"
+	StatusFulfilled FulfillmentStatus = "FULFILLED"
+	StatusCanceled  FulfillmentStatus = "CANCELED"
+)
+
+func (s FulfillmentStatus) Valid() bool {
+	switch s {
+	case StatusPending, StatusFulfilled, StatusCanceled:
+		return true
+	}
+	return false
+}
+
 type CreateOrderRequest struct {
-	ChannelName                            string         `json:"channelName"`
-	ExternalOrderReference                 string         `json:"externalOrderReference"`
-	CustomerEmail                          string         `json:"customerEmail,omitempty"`
-	BillingAddress                         common.Address `json:"billingAddress,omitempty"`
-	ShippingAddress                        common.Address `json:"shippingAddress,omitempty"`
-	InventoryBehavior                      string         `json:"inventoryBehavior,omitempty"`
-	LineItems                              []LineItem     `json:"lineItems"`
-	ShippingLines                          []ShippingLine `json:"shippingLines,omitempty"`
-	DiscountLines                          []DiscountLine `json:"discountLines,omitempty"`
-	PriceTaxInterpretation                 string         `json:"priceTaxInterpretation"`
-	Subtotal                               common.Amount  `json:"subtotal,omitempty"`
-	ShippingTotal                          common.Amount  `json:"shippingTotal,omitempty"`
-	DiscountTotal                          common.Amount  `json:"discountTotal,omitempty"`
-	TaxTotal                               common.Amount  `json:"taxTotal,omitempty"`
-	GrandTotal                             common.Amount  `json:"grandTotal"`
-	FulfillmentStatus                      string         `json:"fulfillmentStatus,omitempty"`
-	ShopperFulfillmentNotificationBehavior string         `json:"shopperFulfillmentNotificationBehavior,omitempty"`
-	FulfilledOn                            string         `json:"fulfilledOn,omitempty"`
-	Fulfillments                           []Fulfillment  `json:"fulfillments"`
-	CreatedOn                              string         `json:"createdOn"`
+	ChannelName                            string            `json:"channelName"`
+	ExternalOrderReference                 string            `json:"externalOrderReference"`
+	CustomerEmail                          string            `json:"customerEmail,omitempty"`
+	BillingAddress                         common.Address    `json:"billingAddress,omitempty"`
+	ShippingAddress                        common.Address    `json:"shippingAddress,omitempty"`
+	InventoryBehavior                      string            `json:"inventoryBehavior,omitempty"`
+	LineItems                              []LineItem        `json:"lineItems"`
+	ShippingLines                          []ShippingLine    `json:"shippingLines,omitempty"`
+	DiscountLines                          []DiscountLine    `json:"discountLines,omitempty"`
+	PriceTaxInterpretation                 string            `json:"priceTaxInterpretation"`
+	Subtotal                               common.Amount     `json:"subtotal,omitempty"`
+	ShippingTotal                          common.Amount     `json:"shippingTotal,omitempty"`
+	DiscountTotal                          common.Amount     `json:"discountTotal,omitempty"`
+	TaxTotal                               common.Amount     `json:"taxTotal,omitempty"`
+	GrandTotal                             common.Amount     `json:"grandTotal"`
+	FulfillmentStatus                      FulfillmentStatus `json:"fulfillmentStatus,omitempty"`
+	ShopperFulfillmentNotificationBehavior string            `json:"shopperFulfillmentNotificationBehavior,omitempty"`
+	FulfilledOn                            string            `json:"fulfilledOn,omitempty"`
+	Fulfillments                           []Fulfillment     `json:"fulfillments"`
+	CreatedOn                              string            `json:"createdOn"`
 }
 
 type FulfillOrderRequest struct {
@@ -40,32 +56,32 @@ type RetrieveAllOrdersResponse struct {
 }
 
 type Order struct {
-	ID                     string           `json:"id"`
-	OrderNumber            string           `json:"orderNumber"`
-	CreatedOn              string           `json:"createdOn"`
-	ModifiedOn             string           `json:"modifiedOn"`
-	Channel                string           `json:"channel"`
-	TestMode               bool             `json:"testmode"`
-	CustomerEmail          string           `json:"customerEmail"`
-	BillingAddress         common.Address   `json:"billingAddress"`
-	ShippingAddress        common.Address   `json:"shippingAddress"`
-	FulfillmentStatus      string           `json:"fulfillmentStatus"`
-	LineItems              []LineItem       `json:"lineItems"`
-	InternalNotes          []Note           `json:"internalNotes"`
-	ShippingLines          []ShippingLine   `json:"shippingLines"`
-	DiscountLines          []DiscountLine   `json:"discountLines"`
-	FormSubmission         []FormSubmission `json:"formSubmission"`
-	Fulfillments           []Fulfillment    `json:"fulfillments"`
-	Subtotal               common.Amount    `json:"subtotal"`
-	ShippingTotal          common.Amount    `json:"shippingTotal"`
-	DiscountTotal          common.Amount    `json:"discountTotal"`
-	TaxTotal               common.Amount    `json:"taxTotal"`
-	RefundedTotal          common.Amount    `json:"refundedTotal"`
-	GrandTotal             common.Amount    `json:"grandTotal"`
-	ChannelName            string           `json:"channelName"`
-	ExternalOrderReference string           `json:"externalOrderReference"`
-	FulfilledOn            string           `json:"fulfilledOn"`
-	PriceTaxInterpretation string           `json:"priceTaxInterpretation"`
+	ID                     string            `json:"id"`
+	OrderNumber            string            `json:"orderNumber"`
+	CreatedOn              string            `json:"createdOn"`
+	ModifiedOn             string            `json:"modifiedOn"`
+	Channel                string            `json:"channel"`
+	TestMode               bool              `json:"testmode"`
+	CustomerEmail          string            `json:"customerEmail"`
+	BillingAddress         common.Address    `json:"billingAddress"`
+	ShippingAddress        common.Address    `json:"shippingAddress"`
+	FulfillmentStatus      FulfillmentStatus `json:"fulfillmentStatus"`
+	LineItems              []LineItem        `json:"lineItems"`
+	InternalNotes          []Note            `json:"internalNotes"`
+	ShippingLines          []ShippingLine    `json:"shippingLines"`
+	DiscountLines          []DiscountLine    `json:"discountLines"`
+	FormSubmission         []FormSubmission  `json:"formSubmission"`
+	Fulfillments           []Fulfillment     `json:"fulfillments"`
+	Subtotal               common.Amount     `json:"subtotal"`
+	ShippingTotal          common.Amount     `json:"shippingTotal"`
+	DiscountTotal          common.Amount     `json:"discountTotal"`
+	TaxTotal               common.Amount     `json:"taxTotal"`
+	RefundedTotal          common.Amount     `json:"refundedTotal"`
+	GrandTotal             common.Amount     `json:"grandTotal"`
+	ChannelName            string            `json:"channelName"`
+	ExternalOrderReference string            `json:"externalOrderReference"`
+	FulfilledOn            string            `json:"fulfilledOn"`
+	PriceTaxInterpretation string            `json:"priceTaxInterpretation"`
 }
 
 type LineItem struct {
@@ -0,0 +1,74 @@
+package orders
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const (
+	defaultPollInterval    = time.Second
+	defaultMaxPollInterval = 30 * time.Second
+	defaultPollMultiplier  = 2
+)
+
+type PollOptions struct {
+	// Interval is the delay before the first re-check. Defaults to 1s.
+	Interval time.Duration
+	// MaxInterval caps the delay between checks. Defaults to 30s.
+	MaxInterval time.Duration
+	// Multiplier grows the delay after each check. Defaults to 2.
+	Multiplier float64
+}
+
+// WaitForStatus polls the order until its fulfillment status is target,
+// backing off between checks, and returns the order as last retrieved. It
+// stops early if the order is canceled while waiting for another status, and
+// returns the context's error if ctx ends first.
+func WaitForStatus(ctx context.Context, config *common.Config, orderID string, target FulfillmentStatus, opts PollOptions) (*Order, error) {
+	if !target.Valid() {
+		return nil, fmt.Errorf("invalid target status: %s", target)
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultMaxPollInterval
+	}
+	multiplier := opts.Multiplier
+	if multiplier < 1 {
+		multiplier = defaultPollMultiplier
+	}
+	interval = min(interval, maxInterval)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		order, err := RetrieveSpecificOrder(ctx, config, orderID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch order.FulfillmentStatus {
+		case target:
+			return order, nil
+		case StatusCanceled:
+			return order, fmt.Errorf("order %s was canceled while waiting for %s", orderID, target)
+		}
+
+		timer.Reset(interval)
+		interval = min(time.Duration(float64(interval)*multiplier), maxInterval)
+	}
+}
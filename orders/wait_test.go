@@ -0,0 +1,119 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestWaitForStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []string
+		target      FulfillmentStatus
+		interval    time.Duration
+		timeout     time.Duration
+		wantPolls   int32
+		wantErr     bool
+		errContains string
+		wantCtxErr  bool
+	}{
+		{
+			name:      "reaches target",
+			statuses:  []string{"PENDING", "PENDING", "FULFILLED"},
+			target:    StatusFulfilled,
+			wantPolls: 3,
+		},
+		{
+			name:      "interval above the cap",
+			statuses:  []string{"PENDING", "FULFILLED"},
+			target:    StatusFulfilled,
+			interval:  time.Hour,
+			timeout:   5 * time.Second,
+			wantPolls: 2,
+		},
+		{
+			name:        "canceled while waiting",
+			statuses:    []string{"PENDING", "CANCELED"},
+			target:      StatusFulfilled,
+			wantPolls:   2,
+			wantErr:     true,
+			errContains: "was canceled while waiting for FULFILLED",
+		},
+		{
+			name:       "context deadline",
+			statuses:   []string{"PENDING"},
+			target:     StatusFulfilled,
+			timeout:    20 * time.Millisecond,
+			wantErr:    true,
+			wantCtxErr: true,
+		},
+		{
+			name:        "invalid target",
+			target:      "SHIPPED",
+			wantErr:     true,
+			errContains: "invalid target status: SHIPPED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/commerce/orders/order-123") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				n := atomic.AddInt32(&polls, 1)
+				status := tt.statuses[min(int(n), len(tt.statuses))-1]
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintf(w, `{"id": "order-123", "fulfillmentStatus": %q}`, status)
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			interval := time.Millisecond
+			if tt.interval > 0 {
+				interval = tt.interval
+			}
+			order, err := WaitForStatus(ctx, config, "order-123", tt.target, PollOptions{Interval: interval, MaxInterval: 2 * time.Millisecond})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WaitForStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantCtxErr && !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if err != nil && tt.errContains != "" && !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+			}
+			if tt.wantPolls > 0 && atomic.LoadInt32(&polls) != tt.wantPolls {
+				t.Errorf("expected %d polls, got %d", tt.wantPolls, polls)
+			}
+			if !tt.wantErr && order.FulfillmentStatus != tt.target {
+				t.Errorf("expected status %s, got %s", tt.target, order.FulfillmentStatus)
+			}
+		})
+	}
+}
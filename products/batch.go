@@ -0,0 +1,39 @@
+package products
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const maxSpecificProductIDs = 50
+
+type BatchOptions struct {
+	// Concurrency is the number of chunks fetched in parallel. Defaults to 1.
+	Concurrency int
+}
+
+// RetrieveManyProducts fetches any number of products by splitting productIDs
+// into chunks accepted by RetrieveSpecificProducts. Products are returned in
+// the order of productIDs; duplicate IDs are fetched once and IDs the API does
+// not return are skipped.
+func RetrieveManyProducts(ctx context.Context, config *common.Config, productIDs []string, opts BatchOptions) (*RetrieveSpecificProductsResponse, error) {
+	if len(productIDs) == 0 {
+		return nil, fmt.Errorf("at least one product ID is required")
+	}
+
+	items, err := common.FetchAll(ctx, productIDs, maxSpecificProductIDs, func(ctx context.Context, chunk []string) ([]Product, error) {
+		resp, err := RetrieveSpecificProducts(ctx, config, chunk)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Products, nil
+	}, common.FetchOptions{Concurrency: opts.Concurrency})
+	if err != nil {
+		return nil, err
+	}
+
+	products, _ := common.OrderByIDs(productIDs, items, func(product Product) string { return product.ID })
+	return &RetrieveSpecificProductsResponse{Products: products}, nil
+}
@@ -0,0 +1,105 @@
+package products
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// ProductUpdateBuilder accumulates a partial product update. Only fields that
+// were explicitly set are serialized, so zero values such as an empty
+// description or isVisible=false are sent when requested and omitted otherwise.
+type ProductUpdateBuilder struct {
+	productID string
+	fields    map[string]interface{}
+	seo       map[string]interface{}
+	tags      []string
+	tagsSet   bool
+}
+
+func UpdateBuilder(productID string) *ProductUpdateBuilder {
+	return &ProductUpdateBuilder{
+		productID: productID,
+		fields:    make(map[string]interface{}),
+		seo:       make(map[string]interface{}),
+	}
+}
+
+func (b *ProductUpdateBuilder) SetName(name string) *ProductUpdateBuilder {
+	b.fields["name"] = name
+	return b
+}
+
+func (b *ProductUpdateBuilder) SetDescription(description string) *ProductUpdateBuilder {
+	b.fields["description"] = description
+	return b
+}
+
+func (b *ProductUpdateBuilder) SetURLSlug(urlSlug string) *ProductUpdateBuilder {
+	b.fields["urlSlug"] = urlSlug
+	return b
+}
+
+// SetTags replaces any tags previously set or added on the builder.
+func (b *ProductUpdateBuilder) SetTags(tags ...string) *ProductUpdateBuilder {
+	b.tags = append([]string{}, tags...)
+	b.tagsSet = true
+	return b
+}
+
+// AddTags appends to the tags accumulated on the builder. The API replaces the
+// product's tag list wholesale, so include existing tags that should be kept.
+func (b *ProductUpdateBuilder) AddTags(tags ...string) *ProductUpdateBuilder {
+	b.tags = append(b.tags, tags...)
+	b.tagsSet = true
+	return b
+}
+
+func (b *ProductUpdateBuilder) SetVisible(isVisible bool) *ProductUpdateBuilder {
+	b.fields["isVisible"] = isVisible
+	return b
+}
+
+func (b *ProductUpdateBuilder) SetVariantAttributes(attributes ...string) *ProductUpdateBuilder {
+	b.fields["variantAttributes"] = append([]string{}, attributes...)
+	return b
+}
+
+func (b *ProductUpdateBuilder) SetSEOTitle(title string) *ProductUpdateBuilder {
+	b.seo["title"] = title
+	return b
+}
+
+func (b *ProductUpdateBuilder) SetSEODescription(description string) *ProductUpdateBuilder {
+	b.seo["description"] = description
+	return b
+}
+
+// Payload returns the request body the builder would send.
+func (b *ProductUpdateBuilder) Payload() map[string]interface{} {
+	payload := make(map[string]interface{}, len(b.fields)+2)
+	for k, v := range b.fields {
+		payload[k] = v
+	}
+	if b.tagsSet {
+		payload["tags"] = append([]string{}, b.tags...)
+	}
+	if len(b.seo) > 0 {
+		seo := make(map[string]interface{}, len(b.seo))
+		for k, v := range b.seo {
+			seo[k] = v
+		}
+		payload["seoOptions"] = seo
+	}
+	return payload
+}
+
+func (b *ProductUpdateBuilder) Do(ctx context.Context, config *common.Config) (*UpdateProductResponse, error) {
+	payload := b.Payload()
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("no fields set for product update")
+	}
+
+	return updateProduct(ctx, config, b.productID, payload)
+}
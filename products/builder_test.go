@@ -0,0 +1,123 @@
+package products
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestProductUpdateBuilder(t *testing.T) {
+	tests := []struct {
+		name        string
+		builder     *ProductUpdateBuilder
+		mockStatus  int
+		mockResp    string
+		wantBody    string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:       "explicit false visibility is sent",
+			builder:    UpdateBuilder("product-123").SetName("New Name").SetVisible(false),
+			mockStatus: http.StatusOK,
+			mockResp:   `{"id": "product-123", "name": "New Name", "isVisible": false}`,
+			wantBody:   `{"isVisible":false,"name":"New Name"}`,
+		},
+		{
+			name:       "tags accumulate",
+			builder:    UpdateBuilder("product-123").AddTags("a").AddTags("b", "c"),
+			mockStatus: http.StatusOK,
+			mockResp:   `{"id": "product-123"}`,
+			wantBody:   `{"tags":["a","b","c"]}`,
+		},
+		{
+			name:       "set tags replaces added tags",
+			builder:    UpdateBuilder("product-123").AddTags("a").SetTags("z"),
+			mockStatus: http.StatusOK,
+			mockResp:   `{"id": "product-123"}`,
+			wantBody:   `{"tags":["z"]}`,
+		},
+		{
+			name:       "empty description and seo options",
+			builder:    UpdateBuilder("product-123").SetDescription("").SetSEOTitle("Title"),
+			mockStatus: http.StatusOK,
+			mockResp:   `{"id": "product-123"}`,
+			wantBody:   `{"description":"","seoOptions":{"title":"Title"}}`,
+		},
+		{
+			name:        "no fields set",
+			builder:     UpdateBuilder("product-123"),
+			wantErr:     true,
+			errContains: "no fields set for product update",
+		},
+		{
+			name:        "missing product ID",
+			builder:     UpdateBuilder("").SetName("New Name"),
+			wantErr:     true,
+			errContains: "productID is required",
+		},
+		{
+			name:        "server error",
+			builder:     UpdateBuilder("product-123").SetName("New Name"),
+			mockStatus:  http.StatusBadRequest,
+			mockResp:    `{"type":"INVALID_REQUEST_ERROR","message":"Invalid update"}`,
+			wantBody:    `{"name":"New Name"}`,
+			wantErr:     true,
+			errContains: "Invalid update",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("expected POST request, got %s", r.Method)
+				}
+				if !strings.HasSuffix(r.URL.Path, "/1.0/commerce/products/product-123") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("failed to read request body: %v", err)
+				}
+				if string(body) != tt.wantBody {
+					t.Errorf("expected request body %s, got %s", tt.wantBody, string(body))
+				}
+
+				w.WriteHeader(tt.mockStatus)
+				w.Write([]byte(tt.mockResp))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			resp, err := tt.builder.Do(context.Background(), config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Do() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if !tt.wantErr && resp == nil {
+				t.Error("expected non-nil response when no error")
+			}
+		})
+	}
+}
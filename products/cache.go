@@ -0,0 +1,23 @@
+package products
+
+import "github.com/j-low/gocommerce/common"
+
+// invalidateProduct drops the cached reads a successful write to productID
+// makes stale: the product on its own and the first page of the unfiltered
+// product list. Multi-product reads and later or filtered list pages still
+// expire with the cache's TTL.
+func invalidateProduct(config *common.Config, productID string) {
+	if config.Cache == nil {
+		return
+	}
+
+	paths := []string{"commerce/products"}
+	if productID != "" {
+		paths = append(paths, "commerce/products/"+productID)
+	}
+	for _, path := range paths {
+		if url, err := common.BuildBaseURL(config, ProductsAPIVersion, path); err == nil {
+			common.InvalidateCached(config, url)
+		}
+	}
+}
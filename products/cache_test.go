@@ -0,0 +1,170 @@
+package products
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestProductReadsUseCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockResp string
+		read     func(config *common.Config) error
+	}{
+		{
+			name:     "retrieve all store pages",
+			mockResp: `{"storePages": [{"id": "page-1"}], "pagination": {}}`,
+			read: func(config *common.Config) error {
+				_, err := RetrieveAllStorePages(context.Background(), config, common.QueryParams{})
+				return err
+			},
+		},
+		{
+			name:     "retrieve all products",
+			mockResp: `{"products": [{"id": "product-1"}], "pagination": {}}`,
+			read: func(config *common.Config) error {
+				_, err := RetrieveAllProducts(context.Background(), config, common.QueryParams{})
+				return err
+			},
+		},
+		{
+			name:     "retrieve specific products",
+			mockResp: `{"products": [{"id": "product-1"}]}`,
+			read: func(config *common.Config) error {
+				_, err := RetrieveSpecificProducts(context.Background(), config, []string{"product-1"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(tt.mockResp))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+				Cache:     common.NewMemoryCache(time.Minute),
+			}
+
+			for i := 0; i < 3; i++ {
+				if err := tt.read(config); err != nil {
+					t.Fatalf("read %d failed: %v", i, err)
+				}
+			}
+			if calls != 1 {
+				t.Errorf("expected 1 request with cache enabled, got %d", calls)
+			}
+
+			config.Cache = nil
+			if err := tt.read(config); err != nil {
+				t.Fatalf("uncached read failed: %v", err)
+			}
+			if calls != 2 {
+				t.Errorf("expected uncached read to hit the server, got %d requests", calls)
+			}
+		})
+	}
+}
+
+func TestProductWritesInvalidateCache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPost:
+			w.Write([]byte(`{"id": "product-1"}`))
+		default:
+			calls++
+			w.Write([]byte(`{"products": [{"id": "product-1"}], "pagination": {}}`))
+		}
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+		Cache:     common.NewMemoryCache(time.Minute),
+	}
+	read := func() {
+		t.Helper()
+		if _, err := RetrieveSpecificProducts(context.Background(), config, []string{"product-1"}); err != nil {
+			t.Fatalf("RetrieveSpecificProducts() unexpected error = %v", err)
+		}
+		if _, err := RetrieveAllProducts(context.Background(), config, common.QueryParams{}); err != nil {
+			t.Fatalf("RetrieveAllProducts() unexpected error = %v", err)
+		}
+	}
+
+	read()
+	read()
+	if calls != 2 {
+		t.Fatalf("expected 2 requests before a write, got %d", calls)
+	}
+
+	if _, err := UpdateProduct(context.Background(), config, "product-1", UpdateProductRequest{}); err != nil {
+		t.Fatalf("UpdateProduct() unexpected error = %v", err)
+	}
+	read()
+	if calls != 4 {
+		t.Errorf("expected reads after an update to hit the server, got %d requests", calls)
+	}
+
+	if _, err := DeleteProduct(context.Background(), config, "product-1"); err != nil {
+		t.Fatalf("DeleteProduct() unexpected error = %v", err)
+	}
+	read()
+	if calls != 6 {
+		t.Errorf("expected reads after a delete to hit the server, got %d requests", calls)
+	}
+}
+
+func TestCallReportsCacheHit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"products": [{"id": "product-1"}]}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+		Cache:     common.NewMemoryCache(time.Minute),
+	}
+	retrieve := func(ctx context.Context) (*RetrieveSpecificProductsResponse, error) {
+		return RetrieveSpecificProducts(ctx, config, []string{"product-1"})
+	}
+
+	resp, err := common.Call(context.Background(), retrieve)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get(common.CacheHeader) != "" {
+		t.Errorf("expected an uncached 200, got %d %v", resp.StatusCode, resp.Header)
+	}
+
+	resp, err = common.Call(context.Background(), retrieve)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get(common.CacheHeader) != "hit" || len(resp.Body.Products) != 1 {
+		t.Errorf("expected a cache hit, got %d %v %+v", resp.StatusCode, resp.Header, resp.Body)
+	}
+}
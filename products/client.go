@@ -16,6 +16,14 @@ import (
 )
 
 func CreateProduct(ctx context.Context, config *common.Config, request CreateProductRequest) (*Product, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, "commerce/products")
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -32,10 +40,10 @@ func CreateProduct(ctx context.Context, config *common.Config, request CreatePro
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
@@ -46,13 +54,14 @@ func CreateProduct(ctx context.Context, config *common.Config, request CreatePro
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 	if resp.StatusCode != http.StatusCreated {
-		return nil, common.ParseErrorResponse("CreateProduct", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("CreateProduct", baseURL, resp, body)
 	}
 
 	var product Product
 	if err := json.Unmarshal(body, &product); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
+	invalidateProduct(config, product.ID)
 
 	return &product, nil
 }
@@ -62,6 +71,10 @@ func CreateProductVariant(ctx context.Context, config *common.Config, request Cr
 		return nil, fmt.Errorf("productID is required")
 	}
 
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s/variants", request.ProductID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -78,10 +91,10 @@ func CreateProductVariant(ctx context.Context, config *common.Config, request Cr
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create product variant: %w", err)
 	}
@@ -93,7 +106,7 @@ func CreateProductVariant(ctx context.Context, config *common.Config, request Cr
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, common.ParseErrorResponse("CreateProductVariant", baseURL, body, resp.StatusCode)
+		return nil, wrapDigitalRejection(common.ParseResponseError("CreateProductVariant", baseURL, resp, body))
 	}
 
 	var createdVariant CreateProductVariantResponse
@@ -101,28 +114,38 @@ func CreateProductVariant(ctx context.Context, config *common.Config, request Cr
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
+	invalidateProduct(config, request.ProductID)
+
 	return &createdVariant, nil
 }
 
 func UploadProductImage(ctx context.Context, config *common.Config, productID, filePath string) (*UploadProductImageResponse, error) {
-	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s/images", productID))
-	if err != nil {
-		return nil, fmt.Errorf("failed to build base URL: %w", err)
-	}
-
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
+	return uploadProductImage(ctx, config, productID, file.Name(), file)
+}
+
+func uploadProductImage(ctx context.Context, config *common.Config, productID, filename string, content io.Reader) (*UploadProductImageResponse, error) {
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s/images", productID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build base URL: %w", err)
+	}
+
 	var requestBody bytes.Buffer
 	writer := multipart.NewWriter(&requestBody)
-	part, err := writer.CreateFormFile("file", file.Name())
+	part, err := writer.CreateFormFile("file", filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create form file: %w", err)
 	}
-	if _, err := io.Copy(part, file); err != nil {
+	if _, err := io.Copy(part, common.ContextReader(ctx, content)); err != nil {
 		return nil, fmt.Errorf("failed to copy file content: %w", err)
 	}
 	if err := writer.Close(); err != nil {
@@ -134,10 +157,10 @@ func UploadProductImage(ctx context.Context, config *common.Config, productID, f
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload product image: %w", err)
 	}
@@ -148,13 +171,14 @@ func UploadProductImage(ctx context.Context, config *common.Config, productID, f
 		return nil, fmt.Errorf("failed to read response body: %w", readErr)
 	}
 	if resp.StatusCode != http.StatusAccepted {
-		return nil, common.ParseErrorResponse("UploadProductImage", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("UploadProductImage", baseURL, resp, body)
 	}
 
 	var response UploadProductImageResponse
 	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
+	invalidateProduct(config, productID)
 
 	return &response, nil
 }
@@ -164,6 +188,10 @@ func RetrieveAllStorePages(ctx context.Context, config *common.Config, params co
 		return nil, fmt.Errorf("invalid query parameters: %w", err)
 	}
 
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, "commerce/store_pages")
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -180,30 +208,34 @@ func RetrieveAllStorePages(ctx context.Context, config *common.Config, params co
 		u.RawQuery = query.Encode()
 	}
 
+	var cached RetrieveAllStorePagesResponse
+	if common.LoadCached(ctx, config, u.String(), &cached) {
+		return &cached, nil
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch store pages: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveAllStorePages", u.String(), body, resp.StatusCode)
+		body, err := common.ReadBody(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, common.ParseResponseError("RetrieveAllStorePages", u.String(), resp, body)
 	}
 
 	var response RetrieveAllStorePagesResponse
-	if err := json.Unmarshal(body, &response); err != nil {
+	if err := common.DecodeAndCache(config, u.String(), resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
@@ -211,18 +243,32 @@ func RetrieveAllStorePages(ctx context.Context, config *common.Config, params co
 }
 
 func RetrieveAllProducts(ctx context.Context, config *common.Config, params common.QueryParams) (*RetrieveAllProductsResponse, error) {
+	var response RetrieveAllProductsResponse
+	if err := retrieveAllProducts(ctx, config, params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// retrieveAllProducts fetches one page of products into response, which must
+// decode the products list shape. Both shapes share the cached body.
+func retrieveAllProducts(ctx context.Context, config *common.Config, params common.QueryParams, response interface{}) error {
 	if err := common.ValidateQueryParams(params); err != nil {
-		return nil, fmt.Errorf("invalid query parameters: %w", err)
+		return fmt.Errorf("invalid query parameters: %w", err)
+	}
+
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
 	}
 
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, "commerce/products")
 	if err != nil {
-		return nil, fmt.Errorf("failed to build base URL: %w", err)
+		return fmt.Errorf("failed to build base URL: %w", err)
 	}
 
 	u, err := url.Parse(baseURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+		return fmt.Errorf("failed to parse base URL: %w", err)
 	}
 
 	query := u.Query()
@@ -240,60 +286,72 @@ func RetrieveAllProducts(ctx context.Context, config *common.Config, params comm
 	}
 	u.RawQuery = query.Encode()
 
+	if common.LoadCached(ctx, config, u.String(), response) {
+		return nil
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveAllProducts", u.String(), body, resp.StatusCode)
+		body, err := common.ReadBody(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+		return common.ParseResponseError("RetrieveAllProducts", u.String(), resp, body)
 	}
 
-	var response RetrieveAllProductsResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	if err := common.DecodeAndCache(config, u.String(), resp.Body, response); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
-	return &response, nil
+	return nil
 }
 
 func RetrieveSpecificProducts(ctx context.Context, config *common.Config, productIDs []string) (*RetrieveSpecificProductsResponse, error) {
 	if len(productIDs) == 0 {
 		return nil, fmt.Errorf("at least one product ID is required")
 	}
-	if len(productIDs) > 50 {
+	if len(productIDs) > maxSpecificProductIDs {
 		return nil, fmt.Errorf("cannot retrieve more than 50 products at once")
 	}
 
 	joinedIDs := strings.Join(productIDs, ",")
 
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s", joinedIDs))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
 	}
 
+	var cached RetrieveSpecificProductsResponse
+	if common.LoadCached(ctx, config, baseURL, &cached) {
+		return &cached, nil
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve specific products: %w", err)
 	}
@@ -305,7 +363,7 @@ func RetrieveSpecificProducts(ctx context.Context, config *common.Config, produc
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveSpecificProducts", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("RetrieveSpecificProducts", baseURL, resp, body)
 	}
 
 	var response RetrieveSpecificProductsResponse
@@ -313,10 +371,16 @@ func RetrieveSpecificProducts(ctx context.Context, config *common.Config, produc
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
+	common.StoreCached(config, baseURL, body)
+
 	return &response, nil
 }
 
 func GetProductImageUploadStatus(ctx context.Context, config *common.Config, productID, imageID string) (*GetProductImageUploadStatusResponse, error) {
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s/images/%s/status", productID, imageID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -327,9 +391,9 @@ func GetProductImageUploadStatus(ctx context.Context, config *common.Config, pro
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get product image upload status: %w", err)
 	}
@@ -340,7 +404,7 @@ func GetProductImageUploadStatus(ctx context.Context, config *common.Config, pro
 		return nil, fmt.Errorf("failed to read response body: %w", readErr)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("GetProductImageUploadStatus", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("GetProductImageUploadStatus", baseURL, resp, body)
 	}
 
 	var statusResponse GetProductImageUploadStatusResponse
@@ -356,6 +420,10 @@ func AssignProductImageToVariant(ctx context.Context, config *common.Config, req
 		return http.StatusBadRequest, fmt.Errorf("context cannot be nil")
 	}
 
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s/variants/%s/image", request.ProductID, request.VariantID))
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to build base URL: %w", err)
@@ -372,10 +440,10 @@ func AssignProductImageToVariant(ctx context.Context, config *common.Config, req
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to assign image to variant: %w", err)
 	}
@@ -386,13 +454,18 @@ func AssignProductImageToVariant(ctx context.Context, config *common.Config, req
 		if err != nil {
 			return http.StatusBadRequest, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return resp.StatusCode, common.ParseErrorResponse("AssignProductImageToVariant", baseURL, body, resp.StatusCode)
+		return resp.StatusCode, common.ParseResponseError("AssignProductImageToVariant", baseURL, resp, body)
 	}
+	invalidateProduct(config, request.ProductID)
 
 	return http.StatusNoContent, nil
 }
 
 func ReorderProductImage(ctx context.Context, config *common.Config, request ReorderProductImageRequest) (int, error) {
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s/images/%s/order", request.ProductID, request.ImageID))
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to build base URL: %w", err)
@@ -409,10 +482,10 @@ func ReorderProductImage(ctx context.Context, config *common.Config, request Reo
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to reorder product image: %w", err)
 	}
@@ -423,23 +496,34 @@ func ReorderProductImage(ctx context.Context, config *common.Config, request Reo
 		if readErr != nil {
 			return http.StatusBadRequest, fmt.Errorf("failed to read response body: %w", readErr)
 		}
-		return resp.StatusCode, common.ParseErrorResponse("ReorderProductImage", baseURL, body, resp.StatusCode)
+		return resp.StatusCode, wrapDigitalRejection(common.ParseResponseError("ReorderProductImage", baseURL, resp, body))
 	}
+	invalidateProduct(config, request.ProductID)
 
 	return http.StatusNoContent, nil
 }
 
 func UpdateProduct(ctx context.Context, config *common.Config, productID string, request UpdateProductRequest) (*UpdateProductResponse, error) {
+	return updateProduct(ctx, config, productID, request)
+}
+
+// updateProduct posts an arbitrary JSON payload to the update product endpoint.
+// It backs both UpdateProduct and ProductUpdateBuilder.
+func updateProduct(ctx context.Context, config *common.Config, productID string, payload interface{}) (*UpdateProductResponse, error) {
 	if productID == "" {
 		return nil, fmt.Errorf("productID is required")
 	}
 
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s", productID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
 	}
 
-	reqBody, err := json.Marshal(request)
+	reqBody, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
@@ -450,10 +534,10 @@ func UpdateProduct(ctx context.Context, config *common.Config, productID string,
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
@@ -464,7 +548,7 @@ func UpdateProduct(ctx context.Context, config *common.Config, productID string,
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("UpdateProduct", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("UpdateProduct", baseURL, resp, body)
 	}
 
 	var updatedProduct UpdateProductResponse
@@ -472,16 +556,29 @@ func UpdateProduct(ctx context.Context, config *common.Config, productID string,
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
+	invalidateProduct(config, productID)
+
 	return &updatedProduct, nil
 }
 
 func UpdateProductVariant(ctx context.Context, config *common.Config, request UpdateProductVariantRequest) (*UpdateProductVariantResponse, error) {
-	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s/variants/%s", request.ProductID, request.VariantID))
+	return updateProductVariant(ctx, config, request.ProductID, request.VariantID, request)
+}
+
+// updateProductVariant posts an arbitrary JSON payload to the update variant
+// endpoint, for callers that must send fields UpdateProductVariantRequest omits
+// when false, such as pricing.onSale.
+func updateProductVariant(ctx context.Context, config *common.Config, productID, variantID string, payload interface{}) (*UpdateProductVariantResponse, error) {
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s/variants/%s", productID, variantID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
 	}
 
-	reqBody, err := json.Marshal(request)
+	reqBody, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
@@ -491,10 +588,10 @@ func UpdateProductVariant(ctx context.Context, config *common.Config, request Up
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update product variant: %w", err)
 	}
@@ -506,7 +603,7 @@ func UpdateProductVariant(ctx context.Context, config *common.Config, request Up
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("UpdateProductVariant", baseURL, body, resp.StatusCode)
+		return nil, wrapDigitalRejection(common.ParseResponseError("UpdateProductVariant", baseURL, resp, body))
 	}
 
 	var updatedVariant UpdateProductVariantResponse
@@ -514,10 +611,16 @@ func UpdateProductVariant(ctx context.Context, config *common.Config, request Up
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
+	invalidateProduct(config, productID)
+
 	return &updatedVariant, nil
 }
 
 func UpdateProductImage(ctx context.Context, config *common.Config, request UpdateProductImageRequest) (*UpdateProductImageResponse, error) {
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s/images/%s", request.ProductID, request.ImageID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -534,10 +637,10 @@ func UpdateProductImage(ctx context.Context, config *common.Config, request Upda
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update product image: %w", err)
 	}
@@ -549,7 +652,7 @@ func UpdateProductImage(ctx context.Context, config *common.Config, request Upda
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("UpdateProductImage", baseURL, body, resp.StatusCode)
+		return nil, wrapDigitalRejection(common.ParseResponseError("UpdateProductImage", baseURL, resp, body))
 	}
 
 	var updatedImage UpdateProductImageResponse
@@ -557,6 +660,8 @@ func UpdateProductImage(ctx context.Context, config *common.Config, request Upda
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
+	invalidateProduct(config, request.ProductID)
+
 	return &updatedImage, nil
 }
 
@@ -565,6 +670,10 @@ func DeleteProduct(ctx context.Context, config *common.Config, productID string)
 		return http.StatusBadRequest, fmt.Errorf("productID is required")
 	}
 
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s", productID))
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to build base URL: %w", err)
@@ -576,9 +685,9 @@ func DeleteProduct(ctx context.Context, config *common.Config, productID string)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to delete product: %w", err)
 	}
@@ -589,13 +698,18 @@ func DeleteProduct(ctx context.Context, config *common.Config, productID string)
 		if err != nil {
 			return http.StatusBadRequest, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return resp.StatusCode, common.ParseErrorResponse("DeleteProduct", baseURL, body, resp.StatusCode)
+		return resp.StatusCode, common.ParseResponseError("DeleteProduct", baseURL, resp, body)
 	}
+	invalidateProduct(config, productID)
 
 	return http.StatusNoContent, nil
 }
 
 func DeleteProductVariant(ctx context.Context, config *common.Config, productID, variantID string) (int, error) {
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s/variants/%s", productID, variantID))
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to build base URL: %w", err)
@@ -606,9 +720,9 @@ func DeleteProductVariant(ctx context.Context, config *common.Config, productID,
 		return http.StatusBadRequest, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to delete product variant: %w", err)
 	}
@@ -619,13 +733,18 @@ func DeleteProductVariant(ctx context.Context, config *common.Config, productID,
 		if readErr != nil {
 			return http.StatusBadRequest, fmt.Errorf("failed to read response body: %w", readErr)
 		}
-		return resp.StatusCode, common.ParseErrorResponse("DeleteProductVariant", baseURL, body, resp.StatusCode)
+		return resp.StatusCode, common.ParseResponseError("DeleteProductVariant", baseURL, resp, body)
 	}
+	invalidateProduct(config, productID)
 
 	return http.StatusNoContent, nil
 }
 
 func DeleteProductImage(ctx context.Context, config *common.Config, productID, imageID string) (int, error) {
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProductsAPIVersion, fmt.Sprintf("commerce/products/%s/images/%s", productID, imageID))
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to build base URL: %w", err)
@@ -636,9 +755,9 @@ func DeleteProductImage(ctx context.Context, config *common.Config, productID, i
 		return http.StatusBadRequest, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "products"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to delete product image: %w", err)
 	}
@@ -649,8 +768,9 @@ func DeleteProductImage(ctx context.Context, config *common.Config, productID, i
 		if readErr != nil {
 			return http.StatusBadRequest, fmt.Errorf("failed to read response body: %w", readErr)
 		}
-		return resp.StatusCode, common.ParseErrorResponse("DeleteProductImage", baseURL, body, resp.StatusCode)
+		return resp.StatusCode, common.ParseResponseError("DeleteProductImage", baseURL, resp, body)
 	}
+	invalidateProduct(config, productID)
 
 	return http.StatusNoContent, nil
 }
@@ -55,6 +55,23 @@ func TestCreateProduct(t *testing.T) {
 				// Missing required fields
 				Name: "Test Product",
 			},
+			wantErr:     true,
+			errContains: "storePageId is required",
+		},
+		{
+			name: "rejected by server",
+			request: CreateProductRequest{
+				Type:        "PHYSICAL",
+				StorePageID: "store-page-123",
+				Name:        "Test Product",
+				Variants: []ProductVariant{
+					{
+						Pricing: Pricing{
+							BasePrice: common.Amount{Value: "10.00", Currency: "USD"},
+						},
+					},
+				},
+			},
 			mockStatus: http.StatusBadRequest,
 			mockResp: `{
                 "type": "ERROR",
@@ -66,8 +83,16 @@ func TestCreateProduct(t *testing.T) {
 		{
 			name: "server error",
 			request: CreateProductRequest{
-				Type: "PHYSICAL",
-				Name: "Test Product",
+				Type:        "PHYSICAL",
+				StorePageID: "store-page-123",
+				Name:        "Test Product",
+				Variants: []ProductVariant{
+					{
+						Pricing: Pricing{
+							BasePrice: common.Amount{Value: "10.00", Currency: "USD"},
+						},
+					},
+				},
 			},
 			mockStatus: http.StatusInternalServerError,
 			mockResp: `{
@@ -0,0 +1,72 @@
+package products
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// ErrNotSupportedForDigital is wrapped by errors for operations the API
+// rejects on digital products, both those CheckOperation finds locally and
+// the API's own OPERATION_NOT_ALLOWED_FOR_PRODUCT_TYPE responses.
+var ErrNotSupportedForDigital = errors.New("not supported for digital products")
+
+type ProductOperation string
+
+const (
+	OperationCreateVariant        ProductOperation = "create variants"
+	OperationReorderImage         ProductOperation = "reorder images"
+	OperationUpdateImage          ProductOperation = "update images"
+	OperationShippingMeasurements ProductOperation = "set shipping measurements"
+)
+
+func (p Product) IsDigital() bool {
+	return p.Type == common.ProductTypeDigital
+}
+
+// NewDigitalProductRequest returns a request for a visible digital product
+// sold at price. The downloadable file is attached separately.
+func NewDigitalProductRequest(storePageID, name string, price common.Amount) CreateProductRequest {
+	return CreateProductRequest{
+		Type:        common.ProductTypeDigital,
+		StorePageID: storePageID,
+		Name:        name,
+		IsVisible:   true,
+		Pricing:     &Pricing{BasePrice: price},
+	}
+}
+
+// CheckOperation returns an error wrapping ErrNotSupportedForDigital when the
+// API would reject op for product, so callers holding the product can fail
+// before sending the request.
+func CheckOperation(product Product, op ProductOperation) error {
+	if !product.IsDigital() {
+		return nil
+	}
+	switch op {
+	case OperationCreateVariant, OperationReorderImage, OperationUpdateImage, OperationShippingMeasurements:
+		return fmt.Errorf("cannot %s on product %s: %w", op, product.ID, ErrNotSupportedForDigital)
+	}
+	return nil
+}
+
+// CheckVariantUpdate checks an update of one of product's variants against
+// the operations the API rejects for the product's type.
+func CheckVariantUpdate(product Product, request UpdateProductVariantRequest) error {
+	if request.ShippingMeasurements != (ShippingMeasurements{}) {
+		return CheckOperation(product, OperationShippingMeasurements)
+	}
+	return nil
+}
+
+// wrapDigitalRejection makes the API's rejection of an operation on a digital
+// product wrap ErrNotSupportedForDigital, keeping the *common.ResponseError.
+func wrapDigitalRejection(err error) error {
+	var respErr *common.ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusMethodNotAllowed && respErr.APIError.IsSubtype(common.SubtypeOperationNotAllowedForProductType) {
+		return fmt.Errorf("%w: %w", ErrNotSupportedForDigital, err)
+	}
+	return err
+}
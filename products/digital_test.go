@@ -0,0 +1,99 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestNewDigitalProductRequest(t *testing.T) {
+	request := NewDigitalProductRequest("store-page-1", "Glazing Guide", common.Amount{Currency: "USD", Value: "15.00"})
+	if err := request.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if request.Type != common.ProductTypeDigital || request.Pricing.BasePrice.Value != "15.00" || !request.IsVisible {
+		t.Errorf("unexpected request %+v", request)
+	}
+}
+
+func TestCheckOperation(t *testing.T) {
+	digital := Product{ID: "p1", Type: common.ProductTypeDigital}
+	physical := Product{ID: "p2", Type: common.ProductTypePhysical}
+
+	for _, op := range []ProductOperation{OperationCreateVariant, OperationReorderImage, OperationUpdateImage, OperationShippingMeasurements} {
+		if err := CheckOperation(digital, op); !errors.Is(err, ErrNotSupportedForDigital) {
+			t.Errorf("CheckOperation(digital, %s) error = %v, want ErrNotSupportedForDigital", op, err)
+		}
+		if err := CheckOperation(physical, op); err != nil {
+			t.Errorf("CheckOperation(physical, %s) error = %v", op, err)
+		}
+	}
+
+	measured := UpdateProductVariantRequest{ShippingMeasurements: ShippingMeasurements{Weight: Weight{Unit: "POUND", Value: 1}}}
+	if err := CheckVariantUpdate(digital, measured); !errors.Is(err, ErrNotSupportedForDigital) {
+		t.Errorf("expected shipping measurements to be rejected, got %v", err)
+	}
+	if err := CheckVariantUpdate(digital, UpdateProductVariantRequest{SKU: "NEW"}); err != nil {
+		t.Errorf("expected a SKU update to pass, got %v", err)
+	}
+}
+
+func TestDigitalRejectionWrapsErr(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"type":"METHOD_NOT_ALLOWED","subtype":"OPERATION_NOT_ALLOWED_FOR_PRODUCT_TYPE","message":"Operation not allowed for digital products"}`))
+	}))
+	defer server.Close()
+	config := &common.Config{APIKey: "test-key", Client: server.Client(), UserAgent: "test-agent", BaseURL: server.URL}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "create variant",
+			call: func() error {
+				_, err := CreateProductVariant(ctx, config, CreateProductVariantRequest{ProductID: "p1"})
+				return err
+			},
+		},
+		{
+			name: "update variant",
+			call: func() error {
+				_, err := UpdateProductVariant(ctx, config, UpdateProductVariantRequest{ProductID: "p1", VariantID: "v1"})
+				return err
+			},
+		},
+		{
+			name: "reorder image",
+			call: func() error {
+				_, err := ReorderProductImage(ctx, config, ReorderProductImageRequest{ProductID: "p1", ImageID: "i1"})
+				return err
+			},
+		},
+		{
+			name: "update image",
+			call: func() error {
+				_, err := UpdateProductImage(ctx, config, UpdateProductImageRequest{ProductID: "p1", ImageID: "i1", AltText: "Guide"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if !errors.Is(err, ErrNotSupportedForDigital) {
+				t.Errorf("expected ErrNotSupportedForDigital, got %v", err)
+			}
+			if _, ok := common.AsAPIError(err); !ok {
+				t.Errorf("expected the API error to stay available, got %v", err)
+			}
+		})
+	}
+}
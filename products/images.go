@@ -0,0 +1,325 @@
+package products
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/j-low/gocommerce/bulk"
+	"github.com/j-low/gocommerce/common"
+)
+
+const (
+	defaultUploadConcurrency = 4
+	defaultImagePollInterval = time.Second
+)
+
+// ImageSource describes one image to upload. Either FilePath or Reader must be
+// set; Filename defaults to the base name of FilePath.
+type ImageSource struct {
+	FilePath   string
+	Reader     io.Reader
+	Filename   string
+	VariantIDs []string
+}
+
+type UploadImagesOptions struct {
+	// Concurrency bounds the number of uploads in flight. Defaults to 4.
+	Concurrency int
+	// PollInterval is the delay between upload status checks. Defaults to 1s.
+	PollInterval time.Duration
+	// Reorder moves the uploaded images to the front of the product's image
+	// list in the order the sources were given.
+	Reorder bool
+	// Limiter, Retry and OnProgress are passed to bulk.Run. A retry uploads
+	// the image again, so only retry Reader sources that can be re-read.
+	Limiter    *bulk.Limiter
+	Retry      bulk.RetryPolicy
+	OnProgress func(bulk.Progress)
+	// Cleanup, when set, makes room for uploads rejected with
+	// IMAGE_LIMIT_REACHED as UploadProductImageWithCleanup does. Images
+	// uploaded by the same call are never deleted.
+	Cleanup *ImageCleanupOptions
+}
+
+type ImageCleanupOptions struct {
+	// SelectVictim returns the ID of the image to delete, given the product's
+	// current images. Defaults to the first image, which is the oldest unless
+	// the images have been reordered.
+	SelectVictim func(images []ProductImage) (string, error)
+	// MaxDeletes caps the images deleted for one upload. Defaults to 1.
+	MaxDeletes int
+	// OnDelete, when set, is called with each image the call deleted. An
+	// image already gone when its delete was sent is not reported.
+	OnDelete func(ProductImage)
+}
+
+type UploadImagesResult struct {
+	Source  ImageSource
+	ImageID string
+	Err     error
+}
+
+// UploadImages uploads sources in parallel, waits for each image to become
+// READY, assigns it to the source's variants and optionally reorders the
+// uploaded images. Results are returned in source order; the error joins every
+// per-image failure.
+func UploadImages(ctx context.Context, config *common.Config, productID string, sources []ImageSource, opts UploadImagesOptions) ([]UploadImagesResult, error) {
+	if productID == "" {
+		return nil, fmt.Errorf("productID is required")
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("at least one image source is required")
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultUploadConcurrency
+	}
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultImagePollInterval
+	}
+
+	uploads := &uploadedImages{ids: make(map[string]bool)}
+	report := bulk.Run(ctx, sources, func(ctx context.Context, _ int, source ImageSource) (string, error) {
+		return uploadAndWait(ctx, config, productID, source, pollInterval, opts.Cleanup, uploads)
+	}, bulk.Options{
+		Concurrency: concurrency,
+		Limiter:     opts.Limiter,
+		Retry:       opts.Retry,
+		OnProgress:  opts.OnProgress,
+	})
+
+	results := make([]UploadImagesResult, len(sources))
+	for i, result := range report.Results {
+		results[i] = UploadImagesResult{Source: sources[i], ImageID: result.Value, Err: result.Err}
+	}
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("image %q: %w", sourceName(result.Source), result.Err))
+		}
+	}
+
+	if opts.Reorder && len(errs) == 0 {
+		var afterImageID *string
+		for _, result := range results {
+			if _, err := ReorderProductImage(ctx, config, ReorderProductImageRequest{
+				ProductID:    productID,
+				ImageID:      result.ImageID,
+				AfterImageID: afterImageID,
+			}); err != nil {
+				errs = append(errs, fmt.Errorf("failed to reorder image %s: %w", result.ImageID, err))
+				break
+			}
+			imageID := result.ImageID
+			afterImageID = &imageID
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func uploadAndWait(ctx context.Context, config *common.Config, productID string, source ImageSource, pollInterval time.Duration, cleanup *ImageCleanupOptions, uploads *uploadedImages) (string, error) {
+	content := source.Reader
+	if content == nil {
+		if source.FilePath == "" {
+			return "", fmt.Errorf("image source requires a FilePath or Reader")
+		}
+		file, err := os.Open(source.FilePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to open file: %w", err)
+		}
+		defer file.Close()
+		content = file
+	}
+
+	var uploaded *UploadProductImageResponse
+	var err error
+	if cleanup != nil {
+		uploaded, err = uploadWithCleanup(ctx, config, productID, sourceName(source), content, *cleanup, uploads.has)
+	} else {
+		uploaded, err = uploadProductImage(ctx, config, productID, sourceName(source), content)
+	}
+	if err != nil {
+		return "", err
+	}
+	uploads.add(uploaded.ImageID)
+
+	if err := waitForImageReady(ctx, config, productID, uploaded.ImageID, pollInterval); err != nil {
+		return uploaded.ImageID, err
+	}
+
+	for _, variantID := range source.VariantIDs {
+		if _, err := AssignProductImageToVariant(ctx, config, AssignProductImageToVariantRequest{
+			ProductID: productID,
+			VariantID: variantID,
+			ImageID:   uploaded.ImageID,
+		}); err != nil {
+			return uploaded.ImageID, fmt.Errorf("failed to assign image to variant %s: %w", variantID, err)
+		}
+	}
+
+	return uploaded.ImageID, nil
+}
+
+// UploadProductImageWithCleanup uploads an image like UploadProductImage, but
+// when the product has reached its image limit it deletes an image chosen by
+// opts and retries, for jobs that refresh a product's images.
+func UploadProductImageWithCleanup(ctx context.Context, config *common.Config, productID, filePath string, opts ImageCleanupOptions) (*UploadProductImageResponse, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return uploadWithCleanup(ctx, config, productID, file.Name(), file, opts, func(string) bool { return false })
+}
+
+// uploadWithCleanup uploads content, deleting an image that keep does not
+// protect whenever the upload is rejected for the image limit. content is
+// buffered so it can be sent again.
+func uploadWithCleanup(ctx context.Context, config *common.Config, productID, filename string, content io.Reader, opts ImageCleanupOptions, keep func(imageID string) bool) (*UploadProductImageResponse, error) {
+	data, err := io.ReadAll(common.ContextReader(ctx, content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image content: %w", err)
+	}
+
+	maxDeletes := opts.MaxDeletes
+	if maxDeletes <= 0 {
+		maxDeletes = 1
+	}
+
+	for deleted := 0; ; deleted++ {
+		uploaded, err := uploadProductImage(ctx, config, productID, filename, bytes.NewReader(data))
+		if err == nil {
+			return uploaded, nil
+		}
+		if apiErr, ok := common.AsAPIError(err); !ok || !apiErr.IsSubtype(common.SubtypeImageLimitReached) || deleted == maxDeletes {
+			return nil, err
+		}
+
+		if err := deleteVictimImage(ctx, config, productID, opts, keep); err != nil {
+			return nil, fmt.Errorf("failed to make room for image: %w", err)
+		}
+	}
+}
+
+func deleteVictimImage(ctx context.Context, config *common.Config, productID string, opts ImageCleanupOptions, keep func(imageID string) bool) error {
+	// The image list must be current, so the response cache is bypassed.
+	uncached := *config
+	uncached.Cache = nil
+
+	resp, err := RetrieveSpecificProducts(ctx, &uncached, []string{productID})
+	if err != nil {
+		return err
+	}
+	if len(resp.Products) == 0 {
+		return fmt.Errorf("product %s not found", productID)
+	}
+
+	var images []ProductImage
+	for _, image := range resp.Products[0].Images {
+		if !keep(image.ID) {
+			images = append(images, image)
+		}
+	}
+	if len(images) == 0 {
+		return fmt.Errorf("product %s has no image that may be deleted", productID)
+	}
+
+	victimID := images[0].ID
+	if opts.SelectVictim != nil {
+		if victimID, err = opts.SelectVictim(images); err != nil {
+			return err
+		}
+	}
+
+	var victim *ProductImage
+	for i := range images {
+		if images[i].ID == victimID {
+			victim = &images[i]
+			break
+		}
+	}
+	if victim == nil {
+		return fmt.Errorf("selected image %s is not one of the product's deletable images", victimID)
+	}
+
+	// A concurrent upload may already have deleted the image, which frees the
+	// room all the same, but only this call's delete is reported to OnDelete.
+	status, err := DeleteProductImage(ctx, config, productID, victim.ID)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to delete image %s: %w", victim.ID, err)
+	}
+	if opts.OnDelete != nil {
+		opts.OnDelete(*victim)
+	}
+	return nil
+}
+
+// uploadedImages records the images a call has uploaded so cleanup never
+// deletes them.
+type uploadedImages struct {
+	mu  sync.Mutex
+	ids map[string]bool
+}
+
+func (u *uploadedImages) add(imageID string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.ids[imageID] = true
+}
+
+func (u *uploadedImages) has(imageID string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.ids[imageID]
+}
+
+func waitForImageReady(ctx context.Context, config *common.Config, productID, imageID string, pollInterval time.Duration) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := GetProductImageUploadStatus(ctx, config, productID, imageID)
+		if err != nil {
+			return err
+		}
+
+		switch status.Status {
+		case ImageStatusReady:
+			return nil
+		case ImageStatusError:
+			return fmt.Errorf("image %s failed processing", imageID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func sourceName(source ImageSource) string {
+	if source.Filename != "" {
+		return source.Filename
+	}
+	if source.FilePath != "" {
+		return filepath.Base(source.FilePath)
+	}
+	return "image"
+}
@@ -0,0 +1,362 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestUploadImages(t *testing.T) {
+	tests := []struct {
+		name         string
+		sources      []ImageSource
+		opts         UploadImagesOptions
+		failUpload   string
+		statusResult string
+		wantAssigned []string
+		wantOrder    []string
+		wantErr      bool
+		errContains  string
+	}{
+		{
+			name: "uploads, assigns and reorders",
+			sources: []ImageSource{
+				{Reader: strings.NewReader("a"), Filename: "a.jpg", VariantIDs: []string{"variant-1"}},
+				{Reader: strings.NewReader("b"), Filename: "b.jpg"},
+			},
+			opts:         UploadImagesOptions{Concurrency: 2, PollInterval: time.Millisecond, Reorder: true},
+			statusResult: ImageStatusReady,
+			wantAssigned: []string{"variant-1:image-a.jpg"},
+			wantOrder:    []string{"image-a.jpg:null", "image-b.jpg:image-a.jpg"},
+		},
+		{
+			name: "upload failure is reported per image",
+			sources: []ImageSource{
+				{Reader: strings.NewReader("a"), Filename: "a.jpg"},
+				{Reader: strings.NewReader("b"), Filename: "b.jpg"},
+			},
+			opts:         UploadImagesOptions{PollInterval: time.Millisecond, Reorder: true},
+			failUpload:   "b.jpg",
+			statusResult: ImageStatusReady,
+			wantErr:      true,
+			errContains:  `image "b.jpg"`,
+		},
+		{
+			name: "processing error",
+			sources: []ImageSource{
+				{Reader: strings.NewReader("a"), Filename: "a.jpg"},
+			},
+			opts:         UploadImagesOptions{PollInterval: time.Millisecond},
+			statusResult: ImageStatusError,
+			wantErr:      true,
+			errContains:  "failed processing",
+		},
+		{
+			name:        "missing content",
+			sources:     []ImageSource{{Filename: "a.jpg"}},
+			wantErr:     true,
+			errContains: "requires a FilePath or Reader",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var assigned, order []string
+			polls := make(map[string]int)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/images") && r.Method == http.MethodPost:
+					_, header, err := r.FormFile("file")
+					if err != nil {
+						t.Errorf("failed to get form file: %v", err)
+					}
+					if header.Filename == tt.failUpload {
+						w.WriteHeader(http.StatusConflict)
+						w.Write([]byte(`{"type":"CONFLICT","subtype":"IMAGE_LIMIT_REACHED","message":"Product has reached image limit"}`))
+						return
+					}
+					w.WriteHeader(http.StatusAccepted)
+					fmt.Fprintf(w, `{"imageId": "image-%s"}`, header.Filename)
+				case strings.HasSuffix(r.URL.Path, "/status"):
+					polls[r.URL.Path]++
+					status := ImageStatusProcessing
+					if polls[r.URL.Path] > 1 {
+						status = tt.statusResult
+					}
+					fmt.Fprintf(w, `{"status": %q}`, status)
+				case strings.HasSuffix(r.URL.Path, "/image"):
+					var body AssignProductImageToVariantRequest
+					json.NewDecoder(r.Body).Decode(&body)
+					parts := strings.Split(r.URL.Path, "/")
+					assigned = append(assigned, parts[len(parts)-2]+":"+body.ImageID)
+					w.WriteHeader(http.StatusNoContent)
+				case strings.HasSuffix(r.URL.Path, "/order"):
+					var body map[string]*string
+					json.NewDecoder(r.Body).Decode(&body)
+					parts := strings.Split(r.URL.Path, "/")
+					after := "null"
+					if body["afterImageId"] != nil {
+						after = *body["afterImageId"]
+					}
+					order = append(order, parts[len(parts)-2]+":"+after)
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			results, err := UploadImages(context.Background(), config, "product-123", tt.sources, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UploadImages() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+			}
+
+			if len(results) != len(tt.sources) {
+				t.Fatalf("expected %d results, got %d", len(tt.sources), len(results))
+			}
+			if tt.wantErr {
+				if len(order) != 0 {
+					t.Errorf("expected no reorder on failure, got %v", order)
+				}
+				return
+			}
+			if strings.Join(assigned, ",") != strings.Join(tt.wantAssigned, ",") {
+				t.Errorf("expected assignments %v, got %v", tt.wantAssigned, assigned)
+			}
+			if strings.Join(order, ",") != strings.Join(tt.wantOrder, ",") {
+				t.Errorf("expected order %v, got %v", tt.wantOrder, order)
+			}
+		})
+	}
+}
+
+// imageLimitServer serves one product whose image list rejects uploads past
+// limit.
+func imageLimitServer(t *testing.T, limit int, images ...string) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	next := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/images"):
+			if len(images) >= limit {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"type":"CONFLICT","subtype":"IMAGE_LIMIT_REACHED","message":"Product has reached image limit"}`))
+				return
+			}
+			next++
+			id := fmt.Sprintf("new-%d", next)
+			images = append(images, id)
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprintf(w, `{"imageId": %q}`, id)
+		case r.Method == http.MethodDelete:
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			for i, image := range images {
+				if image == id {
+					images = append(images[:i], images[i+1:]...)
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type":"NOT_FOUND","message":"Not found"}`))
+		case strings.HasSuffix(r.URL.Path, "/status"):
+			fmt.Fprint(w, `{"status": "READY"}`)
+		case r.Method == http.MethodGet:
+			product := Product{ID: "product-123"}
+			for _, id := range images {
+				product.Images = append(product.Images, ProductImage{ID: id})
+			}
+			json.NewEncoder(w).Encode(RetrieveSpecificProductsResponse{Products: []Product{product}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), images...)
+	}
+}
+
+func TestUploadProductImageWithCleanup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "new.jpg")
+	if err := os.WriteFile(path, []byte("jpeg"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		opts             ImageCleanupOptions
+		concurrentDelete bool
+		wantErr          bool
+		wantImages       []string
+		wantDeleted      int
+	}{
+		{
+			name:        "deletes the oldest image",
+			wantImages:  []string{"old-2", "new-1"},
+			wantDeleted: 1,
+		},
+		{
+			name: "deletes the selected image",
+			opts: ImageCleanupOptions{SelectVictim: func(images []ProductImage) (string, error) {
+				return images[len(images)-1].ID, nil
+			}},
+			wantImages:  []string{"old-1", "new-1"},
+			wantDeleted: 1,
+		},
+		{
+			name:             "does not report an image deleted elsewhere",
+			concurrentDelete: true,
+			wantImages:       []string{"old-2", "new-1"},
+		},
+		{
+			name: "rejects a victim that is not on the product",
+			opts: ImageCleanupOptions{SelectVictim: func(images []ProductImage) (string, error) {
+				return "elsewhere", nil
+			}},
+			wantErr:    true,
+			wantImages: []string{"old-1", "old-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, images := imageLimitServer(t, 2, "old-1", "old-2")
+			defer server.Close()
+			config := &common.Config{APIKey: "test-key", Client: server.Client(), UserAgent: "test-agent", BaseURL: server.URL}
+
+			var deleted []string
+			tt.opts.OnDelete = func(image ProductImage) { deleted = append(deleted, image.ID) }
+			if tt.concurrentDelete {
+				tt.opts.SelectVictim = func(images []ProductImage) (string, error) {
+					_, err := DeleteProductImage(context.Background(), config, "product-123", images[0].ID)
+					return images[0].ID, err
+				}
+			}
+
+			uploaded, err := UploadProductImageWithCleanup(context.Background(), config, "product-123", path, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UploadProductImageWithCleanup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := images(); strings.Join(got, ",") != strings.Join(tt.wantImages, ",") {
+				t.Errorf("expected images %v, got %v", tt.wantImages, got)
+			}
+			if !tt.wantErr && (uploaded.ImageID != "new-1" || len(deleted) != tt.wantDeleted) {
+				t.Errorf("unexpected upload %+v after deleting %v", uploaded, deleted)
+			}
+		})
+	}
+}
+
+func TestUploadImagesCleanup(t *testing.T) {
+	server, images := imageLimitServer(t, 2, "old-1", "old-2")
+	defer server.Close()
+	config := &common.Config{APIKey: "test-key", Client: server.Client(), UserAgent: "test-agent", BaseURL: server.URL}
+
+	sources := []ImageSource{
+		{Reader: strings.NewReader("a"), Filename: "a.jpg"},
+		{Reader: strings.NewReader("b"), Filename: "b.jpg"},
+		{Reader: strings.NewReader("c"), Filename: "c.jpg"},
+	}
+	results, err := UploadImages(context.Background(), config, "product-123", sources, UploadImagesOptions{
+		Concurrency:  1,
+		PollInterval: time.Millisecond,
+		Cleanup:      &ImageCleanupOptions{},
+	})
+
+	// The third upload would have to delete one uploaded by the same call.
+	if err == nil || results[2].Err == nil || results[0].Err != nil || results[1].Err != nil {
+		t.Fatalf("expected only the third upload to fail, got %+v, %v", results, err)
+	}
+	if got := images(); strings.Join(got, ",") != "new-1,new-2" {
+		t.Errorf("expected both old images to be replaced, got %v", got)
+	}
+}
+
+// cancelingReader cancels the upload's context once its first chunk has been
+// read, as a caller giving up partway through a large file would.
+type cancelingReader struct {
+	cancel context.CancelFunc
+	reads  int
+}
+
+func (r *cancelingReader) Read(p []byte) (int, error) {
+	r.reads++
+	if r.reads == 1 {
+		r.cancel()
+	}
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestUploadProductImageCanceledMidStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	content := &cancelingReader{cancel: cancel}
+	_, err := uploadProductImage(ctx, config, "product-1", "large.jpg", content)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if content.reads != 1 {
+		t.Errorf("expected the copy to stop after the first read, got %d reads", content.reads)
+	}
+
+	content = &cancelingReader{cancel: func() {}}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := uploadWithCleanup(canceled, config, "product-1", "large.jpg", content, ImageCleanupOptions{}, func(string) bool { return false }); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected uploadWithCleanup to fail with context.Canceled, got %v", err)
+	}
+	if content.reads != 0 {
+		t.Errorf("expected no reads with a canceled context, got %d", content.reads)
+	}
+}
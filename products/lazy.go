@@ -0,0 +1,60 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type RetrieveAllLazyProductsResponse struct {
+	Products   []LazyProduct     `json:"products"`
+	Pagination common.Pagination `json:"pagination"`
+}
+
+// LazyProduct is a Product whose variants are left undecoded until asked for,
+// for consumers that only need IDs, names and URLs. The embedded Product's
+// Variants is always empty; use the Variants method.
+type LazyProduct struct {
+	Product
+	RawVariants json.RawMessage `json:"variants"`
+}
+
+// Variants decodes the product's variants.
+func (p *LazyProduct) Variants() ([]ProductVariant, error) {
+	if len(p.RawVariants) == 0 {
+		return nil, nil
+	}
+	var variants []ProductVariant
+	return variants, json.Unmarshal(p.RawVariants, &variants)
+}
+
+// Decode returns the fully decoded product.
+func (p *LazyProduct) Decode() (Product, error) {
+	product := p.Product
+	var err error
+	if product.Variants, err = p.Variants(); err != nil {
+		return Product{}, err
+	}
+	return product, nil
+}
+
+// RetrieveAllProductsLazy is RetrieveAllProducts returning LazyProducts.
+func RetrieveAllProductsLazy(ctx context.Context, config *common.Config, params common.QueryParams) (*RetrieveAllLazyProductsResponse, error) {
+	var response RetrieveAllLazyProductsResponse
+	if err := retrieveAllProducts(ctx, config, params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// RetrieveAllProductsLazyIter is RetrieveAllProductsIter over LazyProducts.
+func RetrieveAllProductsLazyIter(ctx context.Context, config *common.Config, params common.QueryParams) *common.Iterator[LazyProduct] {
+	return common.NewIterator(ctx, func(ctx context.Context, cursor string) ([]LazyProduct, common.Pagination, error) {
+		resp, err := RetrieveAllProductsLazy(ctx, config, common.PageParams(params, cursor))
+		if err != nil {
+			return nil, common.Pagination{}, err
+		}
+		return resp.Products, resp.Pagination, nil
+	})
+}
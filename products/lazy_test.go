@@ -0,0 +1,52 @@
+package products
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestRetrieveAllProductsLazy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"products": [{
+				"id": "product-1",
+				"name": "Tee",
+				"variants": [{"id": "variant-1", "sku": "SQ-1", "pricing": {"basePrice": {"currency": "USD", "value": "20.00"}}}]
+			}],
+			"pagination": {"hasNextPage": false}
+		}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	resp, err := RetrieveAllProductsLazy(context.Background(), config, common.QueryParams{})
+	if err != nil {
+		t.Fatalf("RetrieveAllProductsLazy() error = %v", err)
+	}
+	if len(resp.Products) != 1 {
+		t.Fatalf("expected 1 product, got %d", len(resp.Products))
+	}
+
+	product := resp.Products[0]
+	if product.ID != "product-1" || product.Name != "Tee" || product.Product.Variants != nil {
+		t.Errorf("expected eager fields with raw variants, got %+v", product.Product)
+	}
+
+	full, err := product.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(full.Variants) != 1 || full.Variants[0].SKU != "SQ-1" || full.Variants[0].Pricing.BasePrice.Value != "20.00" {
+		t.Errorf("unexpected variants %+v", full.Variants)
+	}
+}
@@ -0,0 +1,73 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type ProductType string
+
+const (
+	ProductTypePhysical ProductType = common.ProductTypePhysical
+	ProductTypeDigital  ProductType = common.ProductTypeDigital
+)
+
+// ListParams is a typed alternative to common.QueryParams for
+// RetrieveAllProducts. Zero times are omitted; non-zero times are sent as
+// RFC 3339 UTC strings.
+type ListParams struct {
+	Cursor         string
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+	Types          []ProductType
+}
+
+// Validate checks the parameters before a request is made.
+func (p ListParams) Validate() error {
+	if p.Cursor != "" {
+		return common.ValidateCursor(p.QueryParams())
+	}
+	if err := common.ValidateModifiedRange(p.ModifiedAfter, p.ModifiedBefore); err != nil {
+		return err
+	}
+
+	seen := make(map[ProductType]bool)
+	for _, t := range p.Types {
+		if t != ProductTypePhysical && t != ProductTypeDigital {
+			return fmt.Errorf("invalid type: type must be either 'PHYSICAL' or 'DIGITAL', got: %s", t)
+		}
+		if seen[t] {
+			return fmt.Errorf("invalid type: duplicate type: %s", t)
+		}
+		seen[t] = true
+	}
+	return nil
+}
+
+func (p ListParams) QueryParams() common.QueryParams {
+	params := common.QueryParams{
+		Cursor:         p.Cursor,
+		ModifiedAfter:  common.FormatTime(p.ModifiedAfter),
+		ModifiedBefore: common.FormatTime(p.ModifiedBefore),
+	}
+	if len(p.Types) > 0 {
+		types := make([]string, len(p.Types))
+		for i, t := range p.Types {
+			types[i] = string(t)
+		}
+		params.Type = strings.Join(types, ",")
+	}
+
+	return params
+}
+
+func RetrieveAllProductsWithParams(ctx context.Context, config *common.Config, params ListParams) (*RetrieveAllProductsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid query parameters: %w", err)
+	}
+	return RetrieveAllProducts(ctx, config, params.QueryParams())
+}
@@ -0,0 +1,143 @@
+package products
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestListParamsQueryParams(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+
+	tests := []struct {
+		name   string
+		params ListParams
+		want   common.QueryParams
+	}{
+		{
+			name:   "empty params",
+			params: ListParams{},
+			want:   common.QueryParams{},
+		},
+		{
+			name: "times are formatted as UTC RFC 3339",
+			params: ListParams{
+				ModifiedAfter:  time.Date(2024, 1, 1, 0, 0, 0, 0, est),
+				ModifiedBefore: time.Date(2024, 2, 1, 12, 30, 0, 0, time.UTC),
+			},
+			want: common.QueryParams{
+				ModifiedAfter:  "2024-01-01T05:00:00Z",
+				ModifiedBefore: "2024-02-01T12:30:00Z",
+			},
+		},
+		{
+			name:   "types are comma-joined",
+			params: ListParams{Types: []ProductType{ProductTypePhysical, ProductTypeDigital}},
+			want:   common.QueryParams{Type: "PHYSICAL,DIGITAL"},
+		},
+		{
+			name:   "cursor",
+			params: ListParams{Cursor: "next"},
+			want:   common.QueryParams{Cursor: "next"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.params.QueryParams(); got != tt.want {
+				t.Errorf("QueryParams() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetrieveAllProductsWithParams(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      ListParams
+		wantQuery   string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "date range and types",
+			params: ListParams{
+				ModifiedAfter:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				ModifiedBefore: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				Types:          []ProductType{ProductTypeDigital},
+			},
+			wantQuery: "modifiedAfter=2024-01-01T00%3A00%3A00Z&modifiedBefore=2024-02-01T00%3A00%3A00Z&type=DIGITAL",
+		},
+		{
+			name: "only one bound",
+			params: ListParams{
+				ModifiedAfter: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantErr:     true,
+			errContains: "modifiedAfter and modifiedBefore must both be specified together or not at all",
+		},
+		{
+			name:        "invalid type",
+			params:      ListParams{Types: []ProductType{"SERVICE"}},
+			wantErr:     true,
+			errContains: "invalid type",
+		},
+		{
+			name:        "cursor with types",
+			params:      ListParams{Cursor: "abc", Types: []ProductType{ProductTypeDigital}},
+			wantErr:     true,
+			errContains: "cannot use cursor alongside other query parameters",
+		},
+		{
+			name: "range out of order",
+			params: ListParams{
+				ModifiedAfter:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				ModifiedBefore: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantErr:     true,
+			errContains: "must be before modifiedBefore",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("expected query %s, got %s", tt.wantQuery, r.URL.RawQuery)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"products": [], "pagination": {}}`))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			resp, err := RetrieveAllProductsWithParams(context.Background(), config, tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RetrieveAllProductsWithParams() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if !tt.wantErr && resp == nil {
+				t.Error("expected non-nil response when no error")
+			}
+		})
+	}
+}
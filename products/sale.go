@@ -0,0 +1,212 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/j-low/gocommerce/bulk"
+	"github.com/j-low/gocommerce/common"
+)
+
+// ProductSelector reports whether a variant of a product should be changed.
+type ProductSelector func(product Product, variant ProductVariant) bool
+
+func SelectAll() ProductSelector {
+	return func(Product, ProductVariant) bool { return true }
+}
+
+func SelectByTag(tag string) ProductSelector {
+	return func(product Product, _ ProductVariant) bool {
+		return slices.Contains(product.Tags, tag)
+	}
+}
+
+func SelectByProductIDs(productIDs ...string) ProductSelector {
+	return func(product Product, _ ProductVariant) bool {
+		return slices.Contains(productIDs, product.ID)
+	}
+}
+
+// SaleSpec configures ApplySale. Exactly one of PercentOff (e.g. "20" for 20%
+// off the base price) or SalePrice must be set.
+type SaleSpec struct {
+	PercentOff  string
+	SalePrice   *common.Amount
+	DryRun      bool
+	Concurrency int
+	// Limiter and Retry are passed to bulk.Run when applying the changes.
+	Limiter *bulk.Limiter
+	Retry   bulk.RetryPolicy
+}
+
+type SaleChange struct {
+	ProductID string
+	VariantID string
+	SKU       string
+	Before    Pricing
+	After     Pricing
+	Err       error
+}
+
+// SalePlan records every pricing change ApplySale made or, in dry-run mode,
+// would make. Pass it to RevertSale to restore the original pricing.
+type SalePlan struct {
+	DryRun  bool
+	Changes []SaleChange
+}
+
+func (p *SalePlan) Err() error {
+	var errs []error
+	for _, change := range p.Changes {
+		if change.Err != nil {
+			errs = append(errs, fmt.Errorf("product %s variant %s: %w", change.ProductID, change.VariantID, change.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ApplySale walks every product, puts matching variants on sale and returns
+// the resulting plan. Variant failures are recorded on the plan and joined
+// into the returned error.
+func ApplySale(ctx context.Context, config *common.Config, selector ProductSelector, spec SaleSpec) (*SalePlan, error) {
+	if selector == nil {
+		return nil, fmt.Errorf("selector is required")
+	}
+	percentOff, err := parseSaleSpec(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sale spec: %w", err)
+	}
+
+	plan := &SalePlan{DryRun: spec.DryRun}
+	err = forEachProduct(ctx, config, ListParams{}.QueryParams(), func(product Product) error {
+		for _, variant := range product.Variants {
+			if !selector(product, variant) {
+				continue
+			}
+
+			after, err := salePricing(variant.Pricing, spec.SalePrice, percentOff)
+			if err != nil {
+				return fmt.Errorf("product %s variant %s: %w", product.ID, variant.ID, err)
+			}
+
+			plan.Changes = append(plan.Changes, SaleChange{
+				ProductID: product.ID,
+				VariantID: variant.ID,
+				SKU:       variant.SKU,
+				Before:    variant.Pricing,
+				After:     after,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan sale: %w", err)
+	}
+
+	if spec.DryRun {
+		return plan, nil
+	}
+
+	applyPricingChanges(ctx, config, plan.Changes, bulk.Options{
+		Concurrency: spec.Concurrency,
+		Limiter:     spec.Limiter,
+		Retry:       spec.Retry,
+	})
+	return plan, plan.Err()
+}
+
+// RevertSale restores the pricing recorded in plan for every change that was
+// applied successfully.
+func RevertSale(ctx context.Context, config *common.Config, plan *SalePlan, concurrency int) (*SalePlan, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("plan is required")
+	}
+
+	revert := &SalePlan{}
+	if plan.DryRun {
+		return revert, nil
+	}
+
+	for _, change := range plan.Changes {
+		if change.Err != nil {
+			continue
+		}
+		revert.Changes = append(revert.Changes, SaleChange{
+			ProductID: change.ProductID,
+			VariantID: change.VariantID,
+			SKU:       change.SKU,
+			Before:    change.After,
+			After:     change.Before,
+		})
+	}
+
+	applyPricingChanges(ctx, config, revert.Changes, bulk.Options{Concurrency: concurrency})
+	return revert, revert.Err()
+}
+
+func parseSaleSpec(spec SaleSpec) (*big.Rat, error) {
+	if (spec.PercentOff == "") == (spec.SalePrice == nil) {
+		return nil, fmt.Errorf("exactly one of PercentOff or SalePrice is required")
+	}
+	if spec.SalePrice != nil {
+		if err := common.ValidateAmount(*spec.SalePrice); err != nil {
+			return nil, fmt.Errorf("sale price: %w", err)
+		}
+		return nil, nil
+	}
+
+	percentOff, ok := new(big.Rat).SetString(spec.PercentOff)
+	if !ok || percentOff.Sign() <= 0 || percentOff.Cmp(big.NewRat(100, 1)) >= 0 {
+		return nil, fmt.Errorf("percent off must be greater than 0 and less than 100, got: %s", spec.PercentOff)
+	}
+	return percentOff, nil
+}
+
+func salePricing(current Pricing, salePrice *common.Amount, percentOff *big.Rat) (Pricing, error) {
+	after := Pricing{BasePrice: current.BasePrice, OnSale: true}
+
+	if salePrice != nil {
+		if salePrice.Currency != current.BasePrice.Currency {
+			return Pricing{}, fmt.Errorf("sale price currency %s does not match base price currency %s", salePrice.Currency, current.BasePrice.Currency)
+		}
+		after.SalePrice = *salePrice
+		return after, nil
+	}
+
+	factor := new(big.Rat).Sub(big.NewRat(1, 1), new(big.Rat).Quo(percentOff, big.NewRat(100, 1)))
+	discounted, err := current.BasePrice.Mul(factor.FloatString(10))
+	if err != nil {
+		return Pricing{}, fmt.Errorf("failed to compute sale price: %w", err)
+	}
+	after.SalePrice = discounted
+	return after, nil
+}
+
+func applyPricingChanges(ctx context.Context, config *common.Config, changes []SaleChange, opts bulk.Options) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+
+	report := bulk.Run(ctx, changes, func(ctx context.Context, _ int, change SaleChange) (*UpdateProductVariantResponse, error) {
+		return updateProductVariant(ctx, config, change.ProductID, change.VariantID, pricingPayload(change.After))
+	}, opts)
+	for i, result := range report.Results {
+		changes[i].Err = result.Err
+	}
+}
+
+// pricingPayload always sends onSale so that reverting a sale can clear it,
+// which UpdateProductVariantRequest cannot express.
+func pricingPayload(pricing Pricing) map[string]interface{} {
+	payload := map[string]interface{}{
+		"basePrice": pricing.BasePrice,
+		"onSale":    pricing.OnSale,
+	}
+	if pricing.SalePrice != (common.Amount{}) {
+		payload["salePrice"] = pricing.SalePrice
+	}
+	return map[string]interface{}{"pricing": payload}
+}
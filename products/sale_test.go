@@ -0,0 +1,229 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const saleCatalogPage1 = `{
+	"products": [
+		{"id": "p1", "tags": ["summer"], "variants": [
+			{"id": "v1", "sku": "SKU-1", "pricing": {"basePrice": {"currency": "USD", "value": "25.00"}}},
+			{"id": "v2", "sku": "SKU-2", "pricing": {"basePrice": {"currency": "USD", "value": "10.00"}}}
+		]}
+	],
+	"pagination": {"hasNextPage": true, "nextPageCursor": "page-2"}
+}`
+
+const saleCatalogPage2 = `{
+	"products": [
+		{"id": "p2", "tags": ["winter"], "variants": [
+			{"id": "v3", "sku": "SKU-3", "pricing": {"basePrice": {"currency": "USD", "value": "99.99"}}}
+		]}
+	],
+	"pagination": {"hasNextPage": false}
+}`
+
+func newSaleServer(t *testing.T, failVariant string) (*httptest.Server, func() map[string]string) {
+	var mu sync.Mutex
+	updates := make(map[string]string)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1.0/commerce/products":
+			if r.URL.Query().Get("cursor") == "page-2" {
+				w.Write([]byte(saleCatalogPage2))
+			} else {
+				w.Write([]byte(saleCatalogPage1))
+			}
+		case r.Method == http.MethodPost && strings.Contains(r.URL.Path, "/variants/"):
+			parts := strings.Split(r.URL.Path, "/")
+			variantID := parts[len(parts)-1]
+			if variantID == failVariant {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"type":"INVALID_REQUEST_ERROR","message":"Invalid pricing"}`))
+				return
+			}
+
+			var body struct {
+				Pricing map[string]json.RawMessage `json:"pricing"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("failed to decode request body: %v", err)
+			}
+			mu.Lock()
+			updates[variantID] = string(body.Pricing["onSale"]) + " " + string(body.Pricing["salePrice"])
+			mu.Unlock()
+			w.Write([]byte(`{"id": "` + variantID + `"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	return server, func() map[string]string {
+		mu.Lock()
+		defer mu.Unlock()
+		out := make(map[string]string, len(updates))
+		for k, v := range updates {
+			out[k] = v
+		}
+		return out
+	}
+}
+
+func TestApplySale(t *testing.T) {
+	fixed := common.Amount{Currency: "USD", Value: "5.00"}
+
+	tests := []struct {
+		name        string
+		selector    ProductSelector
+		spec        SaleSpec
+		failVariant string
+		wantUpdates map[string]string
+		wantChanges int
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:     "percent off every variant",
+			selector: SelectAll(),
+			spec:     SaleSpec{PercentOff: "20", Concurrency: 2},
+			wantUpdates: map[string]string{
+				"v1": `true {"currency":"USD","value":"20.00"}`,
+				"v2": `true {"currency":"USD","value":"8.00"}`,
+				"v3": `true {"currency":"USD","value":"79.99"}`,
+			},
+			wantChanges: 3,
+		},
+		{
+			name:     "fixed price by tag",
+			selector: SelectByTag("winter"),
+			spec:     SaleSpec{SalePrice: &fixed},
+			wantUpdates: map[string]string{
+				"v3": `true {"currency":"USD","value":"5.00"}`,
+			},
+			wantChanges: 1,
+		},
+		{
+			name:        "dry run sends no updates",
+			selector:    SelectByProductIDs("p1"),
+			spec:        SaleSpec{PercentOff: "50", DryRun: true},
+			wantUpdates: map[string]string{},
+			wantChanges: 2,
+		},
+		{
+			name:        "variant failure is recorded",
+			selector:    SelectAll(),
+			spec:        SaleSpec{PercentOff: "10"},
+			failVariant: "v2",
+			wantChanges: 3,
+			wantErr:     true,
+			errContains: "product p1 variant v2",
+		},
+		{
+			name:        "invalid percent",
+			selector:    SelectAll(),
+			spec:        SaleSpec{PercentOff: "120"},
+			wantErr:     true,
+			errContains: "percent off must be greater than 0 and less than 100",
+		},
+		{
+			name:        "both percent and price",
+			selector:    SelectAll(),
+			spec:        SaleSpec{PercentOff: "10", SalePrice: &fixed},
+			wantErr:     true,
+			errContains: "exactly one of PercentOff or SalePrice is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, updates := newSaleServer(t, tt.failVariant)
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			plan, err := ApplySale(context.Background(), config, tt.selector, tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ApplySale() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+			}
+
+			if plan == nil {
+				if tt.wantChanges != 0 {
+					t.Fatal("expected a plan")
+				}
+				return
+			}
+			if len(plan.Changes) != tt.wantChanges {
+				t.Errorf("expected %d changes, got %d", tt.wantChanges, len(plan.Changes))
+			}
+			if tt.wantUpdates == nil {
+				return
+			}
+
+			got := updates()
+			if len(got) != len(tt.wantUpdates) {
+				t.Errorf("expected updates %v, got %v", tt.wantUpdates, got)
+			}
+			for variantID, want := range tt.wantUpdates {
+				if got[variantID] != want {
+					t.Errorf("variant %s update = %s, want %s", variantID, got[variantID], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRevertSale(t *testing.T) {
+	server, updates := newSaleServer(t, "v2")
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	plan, err := ApplySale(context.Background(), config, SelectAll(), SaleSpec{PercentOff: "20"})
+	if err == nil {
+		t.Fatal("expected error for failing variant")
+	}
+
+	revert, err := RevertSale(context.Background(), config, plan, 2)
+	if err != nil {
+		t.Fatalf("RevertSale() error = %v", err)
+	}
+
+	var reverted []string
+	for _, change := range revert.Changes {
+		reverted = append(reverted, change.VariantID)
+	}
+	sort.Strings(reverted)
+	if strings.Join(reverted, ",") != "v1,v3" {
+		t.Errorf("expected v1 and v3 to be reverted, got %v", reverted)
+	}
+	if got := updates()["v1"]; got != "false " {
+		t.Errorf("expected revert to clear onSale for v1, got %q", got)
+	}
+}
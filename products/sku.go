@@ -0,0 +1,179 @@
+package products
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/j-low/gocommerce/inventory"
+)
+
+const maxSKUAttempts = 10000
+
+// SKUPolicy describes what a well-formed SKU looks like.
+type SKUPolicy struct {
+	// MaxLength caps the SKU length in bytes. Zero means no limit.
+	MaxLength int
+	// Pattern, when set, must match the SKU.
+	Pattern *regexp.Regexp
+}
+
+// DefaultSKUPolicy accepts SKUs of at most 64 upper-case letters and digits,
+// in groups joined by single dashes, dots or underscores, such as
+// "TEE-XL-NAVY".
+var DefaultSKUPolicy = SKUPolicy{
+	MaxLength: 64,
+	Pattern:   regexp.MustCompile(`^[A-Z0-9]+([-._][A-Z0-9]+)*$`),
+}
+
+// ValidateSKU checks sku against DefaultSKUPolicy.
+func ValidateSKU(sku string) error {
+	return DefaultSKUPolicy.Validate(sku)
+}
+
+func (p SKUPolicy) Validate(sku string) error {
+	if sku == "" {
+		return fmt.Errorf("sku is required")
+	}
+	if p.MaxLength > 0 && len(sku) > p.MaxLength {
+		return fmt.Errorf("sku %q is longer than %d characters", sku, p.MaxLength)
+	}
+	if p.Pattern != nil && !p.Pattern.MatchString(sku) {
+		return fmt.Errorf("sku %q does not match %s", sku, p.Pattern)
+	}
+	return nil
+}
+
+type SKUGeneratorOptions struct {
+	// Template builds each SKU. Attribute placeholders such as "{Size}" work
+	// as in VariantMatrix.SKUTemplate, and two placeholders are reserved:
+	// "{product}" expands to the upper-cased slug of the product name, and
+	// "{n}" to a sequence number that is increased until the SKU is unique,
+	// optionally zero-padded as in "{n:3}". Without "{n}", a clash is
+	// resolved by appending "-2", "-3" and so on.
+	Template string
+	// Policy validates each generated SKU. Defaults to DefaultSKUPolicy.
+	Policy *SKUPolicy
+	// Inventory, when set, is checked for SKUs already in use on the site.
+	Inventory *inventory.Cache
+}
+
+// SKUGenerator produces SKUs from a template that are unique among the ones it
+// has generated or reserved and, optionally, the SKUs in an inventory cache.
+// It is safe for concurrent use.
+type SKUGenerator struct {
+	template string
+	policy   SKUPolicy
+	cache    *inventory.Cache
+
+	mu   sync.Mutex
+	used map[string]bool
+}
+
+func NewSKUGenerator(opts SKUGeneratorOptions) (*SKUGenerator, error) {
+	if opts.Template == "" {
+		return nil, fmt.Errorf("sku template is required")
+	}
+	for _, match := range skuPlaceholderPattern.FindAllStringSubmatch(opts.Template, -1) {
+		if width, ok := strings.CutPrefix(match[1], "n:"); ok {
+			if n, err := strconv.Atoi(width); err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid sequence width in sku template: %s", match[0])
+			}
+		}
+	}
+
+	policy := DefaultSKUPolicy
+	if opts.Policy != nil {
+		policy = *opts.Policy
+	}
+
+	return &SKUGenerator{
+		template: opts.Template,
+		policy:   policy,
+		cache:    opts.Inventory,
+		used:     make(map[string]bool),
+	}, nil
+}
+
+// Reserve marks SKUs as taken without validating them, for example the SKUs
+// of products that are about to be imported alongside generated ones.
+func (g *SKUGenerator) Reserve(skus ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, sku := range skus {
+		g.used[sku] = true
+	}
+}
+
+// Generate returns a new SKU for a variant of productName with attributes and
+// marks it as taken.
+func (g *SKUGenerator) Generate(productName string, attributes map[string]string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	sequenced := false
+	for n := 1; n <= maxSKUAttempts; n++ {
+		sku := skuPlaceholderPattern.ReplaceAllStringFunc(g.template, func(placeholder string) string {
+			name := placeholder[1 : len(placeholder)-1]
+			switch {
+			case name == "product":
+				return strings.ToUpper(Slugify(productName))
+			case name == "n":
+				sequenced = true
+				return strconv.Itoa(n)
+			case strings.HasPrefix(name, "n:"):
+				sequenced = true
+				width, _ := strconv.Atoi(name[2:])
+				return fmt.Sprintf("%0*d", width, n)
+			}
+			return expandSKUTemplate(placeholder, attributes)
+		})
+		if !sequenced && n > 1 {
+			sku += "-" + strconv.Itoa(n)
+		}
+
+		if g.used[sku] || g.inInventory(sku) {
+			continue
+		}
+		if err := g.policy.Validate(sku); err != nil {
+			return "", err
+		}
+
+		g.used[sku] = true
+		return sku, nil
+	}
+
+	return "", fmt.Errorf("no unique sku found for template %s after %d attempts", g.template, maxSKUAttempts)
+}
+
+// AssignSKUs generates a SKU for each variant of productName that has none.
+// Variants that already have one are reserved as they are.
+func (g *SKUGenerator) AssignSKUs(productName string, variants []ProductVariant) error {
+	for _, variant := range variants {
+		if variant.SKU != "" {
+			g.Reserve(variant.SKU)
+		}
+	}
+
+	for i := range variants {
+		if variants[i].SKU != "" {
+			continue
+		}
+		sku, err := g.Generate(productName, variants[i].Attributes)
+		if err != nil {
+			return fmt.Errorf("variants[%d]: %w", i, err)
+		}
+		variants[i].SKU = sku
+	}
+	return nil
+}
+
+func (g *SKUGenerator) inInventory(sku string) bool {
+	if g.cache == nil {
+		return false
+	}
+	_, ok := g.cache.GetBySKU(sku)
+	return ok
+}
@@ -0,0 +1,96 @@
+package products
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/inventory"
+)
+
+func TestValidateSKU(t *testing.T) {
+	tests := []struct {
+		sku     string
+		wantErr bool
+	}{
+		{sku: "TEE-XL-NAVY"},
+		{sku: "MUG_01.B"},
+		{sku: "", wantErr: true},
+		{sku: "tee-xl", wantErr: true},
+		{sku: "TEE--XL", wantErr: true},
+		{sku: "TEE XL", wantErr: true},
+		{sku: "-TEE", wantErr: true},
+		{sku: strings.Repeat("A", 65), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateSKU(tt.sku); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateSKU(%q) error = %v, wantErr %v", tt.sku, err, tt.wantErr)
+		}
+	}
+
+	custom := SKUPolicy{MaxLength: 4, Pattern: regexp.MustCompile(`^[a-z]+$`)}
+	if err := custom.Validate("abcd"); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := custom.Validate("abcde"); err == nil {
+		t.Error("expected a length error")
+	}
+}
+
+func TestSKUGenerator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"inventory":[{"variantId":"v1","sku":"BLUE-SHIRT-XL"}],"pagination":{"hasNextPage":false}}`))
+	}))
+	defer server.Close()
+
+	cache := inventory.NewCache(&common.Config{APIKey: "test-key", Client: server.Client(), UserAgent: "test-agent", BaseURL: server.URL})
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	generator, err := NewSKUGenerator(SKUGeneratorOptions{Template: "{product}-{Size}", Inventory: cache})
+	if err != nil {
+		t.Fatalf("NewSKUGenerator() error = %v", err)
+	}
+	generator.Reserve("BLUE-SHIRT-S")
+
+	variants := []ProductVariant{
+		{Attributes: map[string]string{"Size": "XL"}},
+		{Attributes: map[string]string{"Size": "S"}},
+		{SKU: "KEEP-ME", Attributes: map[string]string{"Size": "M"}},
+		{Attributes: map[string]string{"Size": "XL"}},
+	}
+	if err := generator.AssignSKUs("Blue Shirt", variants); err != nil {
+		t.Fatalf("AssignSKUs() error = %v", err)
+	}
+
+	want := []string{"BLUE-SHIRT-XL-2", "BLUE-SHIRT-S-2", "KEEP-ME", "BLUE-SHIRT-XL-3"}
+	for i, variant := range variants {
+		if variant.SKU != want[i] {
+			t.Errorf("variants[%d].SKU = %q, want %q", i, variant.SKU, want[i])
+		}
+	}
+
+	sequenced, err := NewSKUGenerator(SKUGeneratorOptions{Template: "MUG-{n:3}"})
+	if err != nil {
+		t.Fatalf("NewSKUGenerator() error = %v", err)
+	}
+	sequenced.Reserve("MUG-001")
+	if sku, err := sequenced.Generate("Mug", nil); err != nil || sku != "MUG-002" {
+		t.Errorf("Generate() = %q, %v, want MUG-002", sku, err)
+	}
+
+	lower, _ := NewSKUGenerator(SKUGeneratorOptions{Template: "mug-{n}"})
+	if _, err := lower.Generate("Mug", nil); err == nil {
+		t.Error("expected the default policy to reject a lower-case SKU")
+	}
+
+	if _, err := NewSKUGenerator(SKUGeneratorOptions{Template: "MUG-{n:x}"}); err == nil {
+		t.Error("expected an invalid sequence width to be rejected")
+	}
+}
@@ -0,0 +1,154 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const maxSlugConflictRetries = 5
+
+// slugReplacements transliterates common accented Latin letters, and "&", to
+// ASCII; other non-ASCII characters separate words.
+var slugReplacements = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c", 'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ì': "i", 'í': "i",
+	'î': "i", 'ï': "i", 'ñ': "n", 'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o",
+	'ö': "o", 'ø': "o", 'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ý': "y",
+	'ÿ': "y", 'ß': "ss", 'œ': "oe", 'ð': "d", 'þ': "th", 'ł': "l", '&': "and",
+}
+
+// Slugify turns a product name into a URL slug: lowercase ASCII letters and
+// digits separated by single hyphens, e.g. "Crème Brûlée & Co." becomes
+// "creme-brulee-and-co". It returns "" when name has no usable characters.
+func Slugify(name string) string {
+	var b strings.Builder
+	pendingHyphen := false
+
+	for _, r := range strings.ToLower(name) {
+		var part string
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			part = string(r)
+		case slugReplacements[r] != "":
+			part = slugReplacements[r]
+		case r == '\'' || r == '’':
+			continue
+		default:
+			pendingHyphen = b.Len() > 0
+			continue
+		}
+
+		if pendingHyphen {
+			b.WriteByte('-')
+			pendingHyphen = false
+		}
+		b.WriteString(part)
+	}
+
+	return b.String()
+}
+
+// Slugs tracks the URL slugs in use on each store page so that new products
+// can be given ones that do not collide. It is safe for concurrent use, so one
+// Slugs can be shared by every worker of a bulk import.
+type Slugs struct {
+	mu    sync.Mutex
+	taken map[string]map[string]bool
+}
+
+func NewSlugs() *Slugs {
+	return &Slugs{taken: make(map[string]map[string]bool)}
+}
+
+// LoadSlugs returns the slugs of every existing product.
+func LoadSlugs(ctx context.Context, config *common.Config) (*Slugs, error) {
+	slugs := NewSlugs()
+	err := forEachProduct(ctx, config, ListParams{}.QueryParams(), func(product Product) error {
+		slugs.add(product.StorePageID, product.URLSlug)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load product slugs: %w", err)
+	}
+	return slugs, nil
+}
+
+// Taken reports whether slug is in use on the store page.
+func (s *Slugs) Taken(storePageID, slug string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.taken[storePageID][slug]
+}
+
+// Reserve returns base if it is free on the store page, or base with the
+// first free numeric suffix ("-2", "-3", ...) appended, and marks the result
+// as taken.
+func (s *Slugs) Reserve(storePageID, base string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	slug := base
+	for n := 2; s.taken[storePageID][slug]; n++ {
+		slug = base + "-" + strconv.Itoa(n)
+	}
+	s.addLocked(storePageID, slug)
+	return slug
+}
+
+func (s *Slugs) add(storePageID, slug string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addLocked(storePageID, slug)
+}
+
+func (s *Slugs) addLocked(storePageID, slug string) {
+	if slug == "" {
+		return
+	}
+	if s.taken[storePageID] == nil {
+		s.taken[storePageID] = make(map[string]bool)
+	}
+	s.taken[storePageID][slug] = true
+}
+
+// CreateProductWithUniqueSlug creates a product whose URL slug does not collide
+// with another product on its store page. The slug is request.URLSlug, or
+// Slugify(request.Name) when that is empty, with a numeric suffix appended when
+// it is already taken. slugs may be nil, in which case the existing slugs are
+// loaded first; pass the same Slugs to every call of a bulk import to load them
+// once. When the API still reports a conflict, as it can when another client
+// created a product in the meantime, the next suffix is tried.
+func CreateProductWithUniqueSlug(ctx context.Context, config *common.Config, request CreateProductRequest, slugs *Slugs) (*Product, error) {
+	base := request.URLSlug
+	if base == "" {
+		base = Slugify(request.Name)
+	}
+	if base == "" {
+		return nil, fmt.Errorf("invalid request: a name or URL slug is required to generate a unique slug")
+	}
+
+	if slugs == nil {
+		var err error
+		if slugs, err = LoadSlugs(ctx, config); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		request.URLSlug = slugs.Reserve(request.StorePageID, base)
+
+		product, err := CreateProduct(ctx, config, request)
+		if err == nil {
+			return product, nil
+		}
+		if apiErr, ok := common.AsAPIError(err); !ok || !apiErr.IsType(common.TypeConflict) || attempt == maxSlugConflictRetries {
+			return nil, err
+		}
+	}
+}
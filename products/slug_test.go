@@ -0,0 +1,103 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Blue Shirt", want: "blue-shirt"},
+		{name: "  Crème Brûlée & Co. ", want: "creme-brulee-and-co"},
+		{name: "Men's T-Shirt (XL)", want: "mens-t-shirt-xl"},
+		{name: "Größe 42 -- Straße", want: "grosse-42-strasse"},
+		{name: "茶", want: ""},
+		{name: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.name); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSlugsReserve(t *testing.T) {
+	slugs := NewSlugs()
+	slugs.add("page-1", "shirt")
+
+	got := []string{
+		slugs.Reserve("page-1", "shirt"),
+		slugs.Reserve("page-1", "shirt"),
+		slugs.Reserve("page-2", "shirt"),
+	}
+	want := []string{"shirt-2", "shirt-3", "shirt"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Reserve() #%d = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if !slugs.Taken("page-2", "shirt") || slugs.Taken("page-2", "shirt-2") {
+		t.Error("expected slugs to be tracked per store page")
+	}
+}
+
+func TestCreateProductWithUniqueSlug(t *testing.T) {
+	var mu sync.Mutex
+	var created []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"products":[{"id":"p1","storePageId":"page-1","urlSlug":"blue-shirt"},{"id":"p2","storePageId":"page-2","urlSlug":"blue-shirt-2"}],"pagination":{"hasNextPage":false}}`))
+			return
+		}
+
+		var request CreateProductRequest
+		json.NewDecoder(r.Body).Decode(&request)
+
+		mu.Lock()
+		defer mu.Unlock()
+		created = append(created, request.URLSlug)
+		if len(created) == 1 {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"type":"CONFLICT","message":"Slug already in use"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(Product{ID: "p3", StorePageID: request.StorePageID, URLSlug: request.URLSlug})
+	}))
+	defer server.Close()
+
+	config := &common.Config{APIKey: "test-key", Client: server.Client(), UserAgent: "test-agent", BaseURL: server.URL}
+	request := CreateProductRequest{
+		Type:        common.ProductTypePhysical,
+		StorePageID: "page-1",
+		Name:        "Blue Shirt",
+		Variants:    []ProductVariant{{SKU: "SKU-1", Pricing: Pricing{BasePrice: common.Amount{Currency: "USD", Value: "10.00"}}}},
+	}
+
+	product, err := CreateProductWithUniqueSlug(context.Background(), config, request, nil)
+	if err != nil {
+		t.Fatalf("CreateProductWithUniqueSlug() error = %v", err)
+	}
+	if product.URLSlug != "blue-shirt-3" {
+		t.Errorf("expected slug blue-shirt-3, got %q", product.URLSlug)
+	}
+	if len(created) != 2 || created[0] != "blue-shirt-2" {
+		t.Errorf("expected a retry after the conflicting blue-shirt-2, got %v", created)
+	}
+
+	request.Name = ""
+	if _, err := CreateProductWithUniqueSlug(context.Background(), config, request, NewSlugs()); err == nil {
+		t.Error("expected an error without a name or slug")
+	}
+}
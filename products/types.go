@@ -10,6 +10,12 @@ const (
 	ProductsAPIVersion = "1.0"
 )
 
+const (
+	ImageStatusProcessing = "PROCESSING"
+	ImageStatusReady      = "READY"
+	ImageStatusError      = "ERROR"
+)
+
 type CreateProductRequest struct {
 	Type              string           `json:"type"`
 	StorePageID       string           `json:"storePageId"`
@@ -20,6 +26,9 @@ type CreateProductRequest struct {
 	IsVisible         bool             `json:"isVisible"`
 	VariantAttributes []string         `json:"variantAttributes,omitempty"`
 	Variants          []ProductVariant `json:"variants"`
+	// Pricing and DigitalGood apply to digital products only.
+	Pricing     *Pricing     `json:"pricing,omitempty"`
+	DigitalGood *DigitalGood `json:"digitalGood,omitempty"`
 }
 
 type CreateProductResponse struct {
@@ -35,6 +44,8 @@ type CreateProductResponse struct {
 	VariantAttributes []string         `json:"variantAttributes"`
 	Variants          []ProductVariant `json:"variants"`
 	Images            []ProductImage   `json:"images,omitempty"`
+	Pricing           Pricing          `json:"pricing,omitempty"`
+	DigitalGood       *DigitalGood     `json:"digitalGood,omitempty"`
 	CreatedOn         string           `json:"createdOn"`
 	ModifiedOn        string           `json:"modifiedOn"`
 	SEOOptions        SEOOptions       `json:"seoOptions"`
@@ -102,6 +113,8 @@ type UpdateProductRequest struct {
 	IsVisible         bool       `json:"isVisible,omitempty"`
 	VariantAttributes []string   `json:"variantAttributes,omitempty"`
 	SEOOptions        SEOOptions `json:"seoOptions,omitempty"`
+	// Pricing applies to digital products only.
+	Pricing *Pricing `json:"pricing,omitempty"`
 }
 
 type UpdateProductResponse struct {
@@ -118,6 +131,8 @@ type UpdateProductResponse struct {
 	VariantAttributes []string         `json:"variantAttributes"`
 	Variants          []ProductVariant `json:"variants"`
 	Images            []ProductImage   `json:"images"`
+	Pricing           Pricing          `json:"pricing,omitempty"`
+	DigitalGood       *DigitalGood     `json:"digitalGood,omitempty"`
 	CreatedOn         string           `json:"createdOn"`
 	ModifiedOn        string           `json:"modifiedOn"`
 }
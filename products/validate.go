@@ -0,0 +1,86 @@
+package products
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Validate checks the request for problems the API would reject, returning all
+// of them joined into a single error.
+func (r CreateProductRequest) Validate() error {
+	var errs []error
+
+	switch r.Type {
+	case "":
+		errs = append(errs, fmt.Errorf("type is required"))
+	case common.ProductTypePhysical, common.ProductTypeDigital:
+	default:
+		errs = append(errs, fmt.Errorf("type must be either 'PHYSICAL' or 'DIGITAL', got: %s", r.Type))
+	}
+
+	if r.StorePageID == "" {
+		errs = append(errs, fmt.Errorf("storePageId is required"))
+	}
+
+	if r.Type == common.ProductTypeDigital {
+		// Digital products are sold as a single item priced on the product.
+		if len(r.Variants) > 0 {
+			errs = append(errs, fmt.Errorf("digital products cannot have variants; set pricing on the product instead"))
+		}
+		if r.Pricing == nil {
+			errs = append(errs, fmt.Errorf("pricing is required for digital products"))
+		} else if err := validateVariantPricing(*r.Pricing); err != nil {
+			errs = append(errs, err)
+		}
+	} else {
+		if len(r.Variants) == 0 {
+			errs = append(errs, fmt.Errorf("at least one variant is required"))
+		}
+		if r.Pricing != nil {
+			errs = append(errs, fmt.Errorf("pricing is only accepted for digital products; set it on each variant instead"))
+		}
+		if r.DigitalGood != nil {
+			errs = append(errs, fmt.Errorf("digitalGood is only accepted for digital products"))
+		}
+	}
+
+	seenSKUs := make(map[string]int)
+	for i, variant := range r.Variants {
+		if err := validateVariantPricing(variant.Pricing); err != nil {
+			errs = append(errs, fmt.Errorf("variants[%d]: %w", i, err))
+		}
+
+		if variant.SKU == "" {
+			continue
+		}
+		if first, ok := seenSKUs[variant.SKU]; ok {
+			errs = append(errs, fmt.Errorf("variants[%d]: sku %q duplicates variants[%d]", i, variant.SKU, first))
+			continue
+		}
+		seenSKUs[variant.SKU] = i
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateVariantPricing(pricing Pricing) error {
+	if pricing.BasePrice == (common.Amount{}) {
+		return fmt.Errorf("pricing.basePrice is required")
+	}
+	if err := common.ValidateAmount(pricing.BasePrice); err != nil {
+		return fmt.Errorf("pricing.basePrice: %w", err)
+	}
+
+	if pricing.OnSale {
+		if err := common.ValidateAmount(pricing.SalePrice); err != nil {
+			return fmt.Errorf("pricing.salePrice: %w", err)
+		}
+		if pricing.SalePrice.Currency != pricing.BasePrice.Currency {
+			return fmt.Errorf("pricing.salePrice currency %s does not match basePrice currency %s", pricing.SalePrice.Currency, pricing.BasePrice.Currency)
+		}
+	}
+
+	return nil
+}
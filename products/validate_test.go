@@ -0,0 +1,151 @@
+package products
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestCreateProductRequestValidate(t *testing.T) {
+	validVariant := ProductVariant{
+		SKU: "SKU-1",
+		Pricing: Pricing{
+			BasePrice: common.Amount{Value: "10.00", Currency: "USD"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		request     CreateProductRequest
+		errContains []string
+	}{
+		{
+			name: "valid request",
+			request: CreateProductRequest{
+				Type:        "PHYSICAL",
+				StorePageID: "store-page-123",
+				Variants:    []ProductVariant{validVariant},
+			},
+		},
+		{
+			name:    "empty request reports every problem",
+			request: CreateProductRequest{},
+			errContains: []string{
+				"type is required",
+				"storePageId is required",
+				"at least one variant is required",
+			},
+		},
+		{
+			name: "valid digital request",
+			request: CreateProductRequest{
+				Type:        "DIGITAL",
+				StorePageID: "store-page-123",
+				Pricing:     &Pricing{BasePrice: common.Amount{Value: "15.00", Currency: "USD"}},
+			},
+		},
+		{
+			name: "digital request with variants and no pricing",
+			request: CreateProductRequest{
+				Type:        "DIGITAL",
+				StorePageID: "store-page-123",
+				Variants:    []ProductVariant{validVariant},
+			},
+			errContains: []string{
+				"digital products cannot have variants",
+				"pricing is required for digital products",
+			},
+		},
+		{
+			name: "digital fields on a physical request",
+			request: CreateProductRequest{
+				Type:        "PHYSICAL",
+				StorePageID: "store-page-123",
+				Variants:    []ProductVariant{validVariant},
+				Pricing:     &Pricing{BasePrice: common.Amount{Value: "15.00", Currency: "USD"}},
+				DigitalGood: &DigitalGood{ID: "file-1"},
+			},
+			errContains: []string{
+				"pricing is only accepted for digital products",
+				"digitalGood is only accepted for digital products",
+			},
+		},
+		{
+			name: "invalid type",
+			request: CreateProductRequest{
+				Type:        "SERVICE",
+				StorePageID: "store-page-123",
+				Variants:    []ProductVariant{validVariant},
+			},
+			errContains: []string{"type must be either 'PHYSICAL' or 'DIGITAL', got: SERVICE"},
+		},
+		{
+			name: "missing pricing",
+			request: CreateProductRequest{
+				Type:        "PHYSICAL",
+				StorePageID: "store-page-123",
+				Variants:    []ProductVariant{{SKU: "SKU-1"}},
+			},
+			errContains: []string{"variants[0]: pricing.basePrice is required"},
+		},
+		{
+			name: "invalid currency",
+			request: CreateProductRequest{
+				Type:        "PHYSICAL",
+				StorePageID: "store-page-123",
+				Variants: []ProductVariant{{
+					SKU:     "SKU-1",
+					Pricing: Pricing{BasePrice: common.Amount{Value: "10.00", Currency: "dollars"}},
+				}},
+			},
+			errContains: []string{"variants[0]: pricing.basePrice: currency must be a 3-letter ISO 4217 code"},
+		},
+		{
+			name: "sale price currency mismatch",
+			request: CreateProductRequest{
+				Type:        "PHYSICAL",
+				StorePageID: "store-page-123",
+				Variants: []ProductVariant{{
+					SKU: "SKU-1",
+					Pricing: Pricing{
+						BasePrice: common.Amount{Value: "10.00", Currency: "USD"},
+						OnSale:    true,
+						SalePrice: common.Amount{Value: "8.00", Currency: "EUR"},
+					},
+				}},
+			},
+			errContains: []string{"does not match basePrice currency USD"},
+		},
+		{
+			name: "duplicate SKUs",
+			request: CreateProductRequest{
+				Type:        "PHYSICAL",
+				StorePageID: "store-page-123",
+				Variants:    []ProductVariant{validVariant, validVariant},
+			},
+			errContains: []string{`variants[1]: sku "SKU-1" duplicates variants[0]`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if len(tt.errContains) == 0 {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			for _, want := range tt.errContains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error message should contain %q, got %q", want, err.Error())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// VariantNotFoundError is returned by GetVariant when the product or the
+// variant does not exist.
+type VariantNotFoundError struct {
+	ProductID string
+	VariantID string
+	// ProductMissing is set when the product itself was not found.
+	ProductMissing bool
+}
+
+func (e *VariantNotFoundError) Error() string {
+	if e.ProductMissing {
+		return fmt.Sprintf("product %s not found", e.ProductID)
+	}
+	return fmt.Sprintf("variant %s not found on product %s", e.VariantID, e.ProductID)
+}
+
+// Variant returns the product's variant with the given ID.
+func (p Product) Variant(variantID string) (ProductVariant, bool) {
+	for _, variant := range p.Variants {
+		if variant.ID == variantID {
+			return variant, true
+		}
+	}
+	return ProductVariant{}, false
+}
+
+// GetVariant retrieves a single variant. The API has no variant endpoint, so
+// this fetches the product and picks the variant out of it; the read is served
+// from Config.Cache when one is set. It returns a *VariantNotFoundError when
+// the product or the variant does not exist.
+func GetVariant(ctx context.Context, config *common.Config, productID, variantID string) (*ProductVariant, error) {
+	if productID == "" || variantID == "" {
+		return nil, fmt.Errorf("productID and variantID are required")
+	}
+
+	resp, err := RetrieveSpecificProducts(ctx, config, []string{productID})
+	if err != nil {
+		var respErr *common.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+			return nil, &VariantNotFoundError{ProductID: productID, VariantID: variantID, ProductMissing: true}
+		}
+		return nil, err
+	}
+
+	for _, product := range resp.Products {
+		if product.ID != productID {
+			continue
+		}
+		variant, ok := product.Variant(variantID)
+		if !ok {
+			return nil, &VariantNotFoundError{ProductID: productID, VariantID: variantID}
+		}
+		return &variant, nil
+	}
+
+	return nil, &VariantNotFoundError{ProductID: productID, VariantID: variantID, ProductMissing: true}
+}
@@ -0,0 +1,66 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestGetVariant(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/1.0/commerce/products/product-1":
+			w.Write([]byte(`{"products":[{"id":"product-1","variants":[{"id":"variant-1","sku":"SKU-1"},{"id":"variant-2","sku":"SKU-2"}]}]}`))
+		case "/1.0/commerce/products/product-empty":
+			w.Write([]byte(`{"products":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type":"NOT_FOUND","message":"Not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	config := &common.Config{APIKey: "test-key", Client: server.Client(), UserAgent: "test-agent", BaseURL: server.URL}
+
+	tests := []struct {
+		name               string
+		productID          string
+		variantID          string
+		wantSKU            string
+		wantNotFound       bool
+		wantProductMissing bool
+	}{
+		{name: "found", productID: "product-1", variantID: "variant-2", wantSKU: "SKU-2"},
+		{name: "missing variant", productID: "product-1", variantID: "variant-3", wantNotFound: true},
+		{name: "missing product", productID: "product-2", variantID: "variant-1", wantNotFound: true, wantProductMissing: true},
+		{name: "product not returned", productID: "product-empty", variantID: "variant-1", wantNotFound: true, wantProductMissing: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variant, err := GetVariant(context.Background(), config, tt.productID, tt.variantID)
+
+			var notFound *VariantNotFoundError
+			if errors.As(err, &notFound) != tt.wantNotFound {
+				t.Fatalf("GetVariant() error = %v, wantNotFound %v", err, tt.wantNotFound)
+			}
+			if tt.wantNotFound {
+				if notFound.ProductMissing != tt.wantProductMissing || notFound.VariantID != tt.variantID {
+					t.Errorf("unexpected error %+v", notFound)
+				}
+				return
+			}
+			if err != nil || variant.SKU != tt.wantSKU {
+				t.Errorf("GetVariant() = %+v, %v, want SKU %s", variant, err, tt.wantSKU)
+			}
+		})
+	}
+
+	if _, err := GetVariant(context.Background(), config, "product-1", ""); err == nil {
+		t.Error("expected an error without a variant ID")
+	}
+}
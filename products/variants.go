@@ -0,0 +1,107 @@
+package products
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+var skuPlaceholderPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+type VariantAttribute struct {
+	Name   string
+	Values []string
+}
+
+// VariantMatrix describes the cross-product of attribute values to generate.
+// SKUTemplate placeholders such as "{Size}" are replaced with the variant's
+// value for that attribute, upper-cased with spaces turned into dashes, so
+// "TEE-{Size}-{Color}" yields "TEE-XL-NAVY-BLUE".
+type VariantMatrix struct {
+	Attributes  []VariantAttribute
+	SKUTemplate string
+	BasePrice   common.Amount
+	Stock       Stock
+}
+
+func (m VariantMatrix) AttributeNames() []string {
+	names := make([]string, len(m.Attributes))
+	for i, attribute := range m.Attributes {
+		names[i] = attribute.Name
+	}
+	return names
+}
+
+// GenerateVariants returns one variant per combination of attribute values,
+// ordered with the last attribute varying fastest.
+func GenerateVariants(matrix VariantMatrix) ([]ProductVariant, error) {
+	if len(matrix.Attributes) == 0 {
+		return nil, fmt.Errorf("at least one attribute is required")
+	}
+
+	seenNames := make(map[string]bool, len(matrix.Attributes))
+	for _, attribute := range matrix.Attributes {
+		if attribute.Name == "" {
+			return nil, fmt.Errorf("attribute name cannot be empty")
+		}
+		if seenNames[attribute.Name] {
+			return nil, fmt.Errorf("duplicate attribute: %s", attribute.Name)
+		}
+		seenNames[attribute.Name] = true
+		if len(attribute.Values) == 0 {
+			return nil, fmt.Errorf("attribute %s has no values", attribute.Name)
+		}
+	}
+
+	for _, match := range skuPlaceholderPattern.FindAllStringSubmatch(matrix.SKUTemplate, -1) {
+		if !seenNames[match[1]] {
+			return nil, fmt.Errorf("sku template references unknown attribute: %s", match[1])
+		}
+	}
+
+	combinations := []map[string]string{{}}
+	for _, attribute := range matrix.Attributes {
+		next := make([]map[string]string, 0, len(combinations)*len(attribute.Values))
+		for _, combination := range combinations {
+			for _, value := range attribute.Values {
+				extended := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					extended[k] = v
+				}
+				extended[attribute.Name] = value
+				next = append(next, extended)
+			}
+		}
+		combinations = next
+	}
+
+	variants := make([]ProductVariant, 0, len(combinations))
+	seenSKUs := make(map[string]bool, len(combinations))
+	for _, attributes := range combinations {
+		sku := expandSKUTemplate(matrix.SKUTemplate, attributes)
+		if sku != "" {
+			if seenSKUs[sku] {
+				return nil, fmt.Errorf("sku template produces duplicate sku: %s", sku)
+			}
+			seenSKUs[sku] = true
+		}
+
+		variants = append(variants, ProductVariant{
+			SKU:        sku,
+			Pricing:    Pricing{BasePrice: matrix.BasePrice},
+			Stock:      matrix.Stock,
+			Attributes: attributes,
+		})
+	}
+
+	return variants, nil
+}
+
+func expandSKUTemplate(template string, attributes map[string]string) string {
+	return skuPlaceholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value := attributes[placeholder[1:len(placeholder)-1]]
+		return strings.ToUpper(strings.Join(strings.Fields(value), "-"))
+	})
+}
@@ -0,0 +1,128 @@
+package products
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestGenerateVariants(t *testing.T) {
+	price := common.Amount{Value: "25.00", Currency: "USD"}
+
+	tests := []struct {
+		name        string
+		matrix      VariantMatrix
+		wantSKUs    []string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "size by color",
+			matrix: VariantMatrix{
+				Attributes: []VariantAttribute{
+					{Name: "Size", Values: []string{"S", "XL"}},
+					{Name: "Color", Values: []string{"Red", "navy blue"}},
+				},
+				SKUTemplate: "TEE-{Size}-{Color}",
+				BasePrice:   price,
+			},
+			wantSKUs: []string{"TEE-S-RED", "TEE-S-NAVY-BLUE", "TEE-XL-RED", "TEE-XL-NAVY-BLUE"},
+		},
+		{
+			name: "no sku template",
+			matrix: VariantMatrix{
+				Attributes: []VariantAttribute{{Name: "Size", Values: []string{"S", "M"}}},
+				BasePrice:  price,
+			},
+			wantSKUs: []string{"", ""},
+		},
+		{
+			name:        "no attributes",
+			matrix:      VariantMatrix{},
+			wantErr:     true,
+			errContains: "at least one attribute is required",
+		},
+		{
+			name: "attribute without values",
+			matrix: VariantMatrix{
+				Attributes: []VariantAttribute{{Name: "Size"}},
+			},
+			wantErr:     true,
+			errContains: "attribute Size has no values",
+		},
+		{
+			name: "duplicate attribute",
+			matrix: VariantMatrix{
+				Attributes: []VariantAttribute{
+					{Name: "Size", Values: []string{"S"}},
+					{Name: "Size", Values: []string{"M"}},
+				},
+			},
+			wantErr:     true,
+			errContains: "duplicate attribute: Size",
+		},
+		{
+			name: "unknown placeholder",
+			matrix: VariantMatrix{
+				Attributes:  []VariantAttribute{{Name: "Size", Values: []string{"S"}}},
+				SKUTemplate: "TEE-{Colour}",
+			},
+			wantErr:     true,
+			errContains: "unknown attribute: Colour",
+		},
+		{
+			name: "template does not distinguish variants",
+			matrix: VariantMatrix{
+				Attributes: []VariantAttribute{
+					{Name: "Size", Values: []string{"S"}},
+					{Name: "Color", Values: []string{"Red", "Blue"}},
+				},
+				SKUTemplate: "TEE-{Size}",
+			},
+			wantErr:     true,
+			errContains: "duplicate sku: TEE-S",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variants, err := GenerateVariants(tt.matrix)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateVariants() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if len(variants) != len(tt.wantSKUs) {
+				t.Fatalf("expected %d variants, got %d", len(tt.wantSKUs), len(variants))
+			}
+			for i, variant := range variants {
+				if variant.SKU != tt.wantSKUs[i] {
+					t.Errorf("variant %d sku = %q, want %q", i, variant.SKU, tt.wantSKUs[i])
+				}
+				if variant.Pricing.BasePrice != price {
+					t.Errorf("variant %d base price = %+v, want %+v", i, variant.Pricing.BasePrice, price)
+				}
+				if len(variant.Attributes) != len(tt.matrix.Attributes) {
+					t.Errorf("variant %d has %d attributes, want %d", i, len(variant.Attributes), len(tt.matrix.Attributes))
+				}
+			}
+		})
+	}
+}
+
+func TestVariantMatrixAttributeNames(t *testing.T) {
+	matrix := VariantMatrix{
+		Attributes: []VariantAttribute{{Name: "Size"}, {Name: "Color"}},
+	}
+	if got := strings.Join(matrix.AttributeNames(), ","); got != "Size,Color" {
+		t.Errorf("AttributeNames() = %s, want Size,Color", got)
+	}
+}
@@ -0,0 +1,41 @@
+package products
+
+import (
+	"context"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// forEachProduct calls fn for every product matching params, following
+// pagination cursors until the last page or until fn returns an error.
+func forEachProduct(ctx context.Context, config *common.Config, params common.QueryParams, fn func(Product) error) error {
+	for {
+		resp, err := RetrieveAllProducts(ctx, config, params)
+		if err != nil {
+			return err
+		}
+
+		for _, product := range resp.Products {
+			if err := fn(product); err != nil {
+				return err
+			}
+		}
+
+		if !resp.Pagination.HasNext() {
+			return nil
+		}
+		params = resp.Pagination.NextParams()
+	}
+}
+
+// RetrieveAllProductsIter returns an iterator over every product matching
+// params, following pagination cursors automatically.
+func RetrieveAllProductsIter(ctx context.Context, config *common.Config, params common.QueryParams) *common.Iterator[Product] {
+	return common.NewIterator(ctx, func(ctx context.Context, cursor string) ([]Product, common.Pagination, error) {
+		resp, err := RetrieveAllProducts(ctx, config, common.PageParams(params, cursor))
+		if err != nil {
+			return nil, common.Pagination{}, err
+		}
+		return resp.Products, resp.Pagination, nil
+	})
+}
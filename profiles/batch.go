@@ -0,0 +1,39 @@
+package profiles
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const maxSpecificProfileIDs = 50
+
+type BatchOptions struct {
+	// Concurrency is the number of chunks fetched in parallel. Defaults to 1.
+	Concurrency int
+}
+
+// RetrieveManyProfiles fetches any number of profiles by splitting profileIDs
+// into chunks accepted by RetrieveSpecificProfiles. Profiles are returned in
+// the order of profileIDs; duplicate IDs are fetched once and IDs the API does
+// not return are skipped.
+func RetrieveManyProfiles(ctx context.Context, config *common.Config, profileIDs []string, opts BatchOptions) (*RetrieveSpecificProfilesResponse, error) {
+	if len(profileIDs) == 0 {
+		return nil, fmt.Errorf("profileIDs cannot be empty")
+	}
+
+	items, err := common.FetchAll(ctx, profileIDs, maxSpecificProfileIDs, func(ctx context.Context, chunk []string) ([]Profile, error) {
+		resp, err := RetrieveSpecificProfiles(ctx, config, chunk)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Profiles, nil
+	}, common.FetchOptions{Concurrency: opts.Concurrency})
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, _ := common.OrderByIDs(profileIDs, items, func(profile Profile) string { return profile.ID })
+	return &RetrieveSpecificProfilesResponse{Profiles: profiles}, nil
+}
@@ -0,0 +1,146 @@
+package profiles
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestRetrieveManyProfiles(t *testing.T) {
+	makeIDs := func(n int) []string {
+		ids := make([]string, n)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("p%03d", n-i)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name        string
+		profileIDs  []string
+		opts        BatchOptions
+		missing     string
+		failChunkOf string
+		wantCalls   int
+		wantCount   int
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:       "single chunk",
+			profileIDs: makeIDs(3),
+			wantCalls:  1,
+			wantCount:  3,
+		},
+		{
+			name:       "multiple chunks in parallel",
+			profileIDs: makeIDs(120),
+			opts:       BatchOptions{Concurrency: 3},
+			wantCalls:  3,
+			wantCount:  120,
+		},
+		{
+			name:       "duplicates fetched once",
+			profileIDs: append(makeIDs(50), "p001", "p002"),
+			wantCalls:  1,
+			wantCount:  50,
+		},
+		{
+			name:       "missing profiles are skipped",
+			profileIDs: makeIDs(5),
+			missing:    "p003",
+			wantCalls:  1,
+			wantCount:  4,
+		},
+		{
+			name:        "chunk failure",
+			profileIDs:  makeIDs(60),
+			failChunkOf: "p005",
+			wantErr:     true,
+			errContains: "Profile lookup failed",
+		},
+		{
+			name:        "no IDs",
+			profileIDs:  nil,
+			wantErr:     true,
+			errContains: "profileIDs cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			calls := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				calls++
+				mu.Unlock()
+
+				ids := strings.Split(strings.TrimPrefix(r.URL.Path, "/1.0/profiles/"), ",")
+				if len(ids) > 50 {
+					t.Errorf("chunk exceeds 50 IDs: %d", len(ids))
+				}
+
+				var profiles []string
+				for _, id := range ids {
+					if id == tt.failChunkOf {
+						w.WriteHeader(http.StatusInternalServerError)
+						w.Write([]byte(`{"type":"ERROR","message":"Profile lookup failed"}`))
+						return
+					}
+					if id != tt.missing {
+						profiles = append(profiles, fmt.Sprintf(`{"id": %q}`, id))
+					}
+				}
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintf(w, `{"profiles": [%s]}`, strings.Join(profiles, ","))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			resp, err := RetrieveManyProfiles(context.Background(), config, tt.profileIDs, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RetrieveManyProfiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d requests, got %d", tt.wantCalls, calls)
+			}
+			if len(resp.Profiles) != tt.wantCount {
+				t.Fatalf("expected %d profiles, got %d", tt.wantCount, len(resp.Profiles))
+			}
+
+			i := 0
+			for _, id := range tt.profileIDs[:tt.wantCount] {
+				if id == tt.missing {
+					continue
+				}
+				if resp.Profiles[i].ID != id {
+					t.Errorf("expected profile %d to be %s, got %s", i, id, resp.Profiles[i].ID)
+				}
+				i++
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package profiles
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/cache"
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestProfileReadsUseCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockResp string
+		read     func(config *common.Config) error
+	}{
+		{
+			name:     "retrieve all profiles",
+			mockResp: `{"profiles": [{"id": "profile-1"}], "pagination": {}}`,
+			read: func(config *common.Config) error {
+				_, err := RetrieveAllProfiles(context.Background(), config, common.QueryParams{})
+				return err
+			},
+		},
+		{
+			name:     "retrieve specific profiles",
+			mockResp: `{"profiles": [{"id": "profile-1"}]}`,
+			read: func(config *common.Config) error {
+				_, err := RetrieveSpecificProfiles(context.Background(), config, []string{"profile-1"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(tt.mockResp))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+				Cache:     cache.New(cache.NewMemoryStore(), cache.Options{TTL: time.Minute}),
+			}
+
+			for i := 0; i < 3; i++ {
+				if err := tt.read(config); err != nil {
+					t.Fatalf("read %d failed: %v", i, err)
+				}
+			}
+			if calls != 1 {
+				t.Errorf("expected 1 request with cache enabled, got %d", calls)
+			}
+
+			config.Cache = nil
+			if err := tt.read(config); err != nil {
+				t.Fatalf("uncached read failed: %v", err)
+			}
+			if calls != 2 {
+				t.Errorf("expected uncached read to hit the server, got %d requests", calls)
+			}
+		})
+	}
+}
+
+func TestProfileCacheIsPerSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"profiles": [{"id": %q}], "pagination": {}}`, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	}))
+	defer server.Close()
+
+	shared := cache.New(cache.NewMemoryStore(), cache.Options{TTL: time.Minute})
+	for _, apiKey := range []string{"site-a", "site-b", "site-a"} {
+		config := &common.Config{APIKey: apiKey, Client: server.Client(), BaseURL: server.URL, Cache: shared}
+		resp, err := RetrieveAllProfiles(context.Background(), config, common.QueryParams{})
+		if err != nil {
+			t.Fatalf("RetrieveAllProfiles() unexpected error = %v", err)
+		}
+		if resp.Profiles[0].ID != apiKey {
+			t.Errorf("site %s read profile %s from the shared cache", apiKey, resp.Profiles[0].ID)
+		}
+	}
+}
@@ -16,6 +16,13 @@ func RetrieveAllProfiles(ctx context.Context, config *common.Config, params comm
 	if err := common.ValidateQueryParams(params); err != nil {
 		return nil, fmt.Errorf("invalid query parameters: %w", err)
 	}
+	if err := validateParams(params); err != nil {
+		return nil, fmt.Errorf("invalid query parameters: %w", err)
+	}
+
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
 
 	baseURL, err := common.BuildBaseURL(config, ProfilesAPIVersion, "profiles")
 	if err != nil {
@@ -42,30 +49,34 @@ func RetrieveAllProfiles(ctx context.Context, config *common.Config, params comm
 	}
 	u.RawQuery = query.Encode()
 
+	var cached RetrieveAllProfilesResponse
+	if common.LoadCached(ctx, config, u.String(), &cached) {
+		return &cached, nil
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "profiles"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve all profiles: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveAllProfiles", u.String(), body, resp.StatusCode)
+		body, err := common.ReadBody(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, common.ParseResponseError("RetrieveAllProfiles", u.String(), resp, body)
 	}
 
 	var response RetrieveAllProfilesResponse
-	if err := json.Unmarshal(body, &response); err != nil {
+	if err := common.DecodeAndCache(config, u.String(), resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
@@ -76,9 +87,16 @@ func RetrieveSpecificProfiles(ctx context.Context, config *common.Config, profil
 	if len(profileIDs) == 0 {
 		return nil, fmt.Errorf("profileIDs cannot be empty")
 	}
+	if len(profileIDs) > maxSpecificProfileIDs {
+		return nil, fmt.Errorf("profileIDs cannot exceed 50 IDs")
+	}
 
 	joinedIDs := strings.Join(profileIDs, ",")
 
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, ProfilesAPIVersion, fmt.Sprintf("profiles/%s", joinedIDs))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -89,14 +107,19 @@ func RetrieveSpecificProfiles(ctx context.Context, config *common.Config, profil
 		return nil, fmt.Errorf("failed to parse base URL: %w", err)
 	}
 
+	var cached RetrieveSpecificProfilesResponse
+	if common.LoadCached(ctx, config, u.String(), &cached) {
+		return &cached, nil
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "profiles"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve specific profiles: %w", err)
 	}
@@ -108,7 +131,7 @@ func RetrieveSpecificProfiles(ctx context.Context, config *common.Config, profil
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveSpecificProfiles", u.String(), body, resp.StatusCode)
+		return nil, common.ParseResponseError("RetrieveSpecificProfiles", u.String(), resp, body)
 	}
 
 	var response RetrieveSpecificProfilesResponse
@@ -116,5 +139,7 @@ func RetrieveSpecificProfiles(ctx context.Context, config *common.Config, profil
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
+	common.StoreCached(config, u.String(), body)
+
 	return &response, nil
 }
@@ -165,10 +165,8 @@ func TestRetrieveSpecificProfiles(t *testing.T) {
 		{
 			name:        "too many profiles",
 			profileIDs:  make([]string, 51), // More than 50 IDs
-			mockStatus:  http.StatusBadRequest,
-			mockResp:    `{"type":"INVALID_REQUEST_ERROR","message":"Cannot request more than 50 profiles"}`,
 			wantErr:     true,
-			errContains: "Cannot request more than 50 profiles",
+			errContains: "profileIDs cannot exceed 50 IDs",
 		},
 		{
 			name:        "profile not found",
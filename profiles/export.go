@@ -0,0 +1,159 @@
+package profiles
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV       ExportFormat = "csv"
+	ExportFormatMailchimp ExportFormat = "mailchimp"
+	ExportFormatKlaviyo   ExportFormat = "klaviyo"
+)
+
+type ExportOptions struct {
+	// Format selects the column layout. Defaults to ExportFormatCSV.
+	Format  ExportFormat
+	Filters []Filter
+	// ExcludeNonConsenting skips profiles that have not opted in to marketing,
+	// which marketing imports should set to stay compliant.
+	ExcludeNonConsenting bool
+}
+
+// Export streams profiles to w, one row per profile. Profiles are written as
+// they are paged in, so memory use is bounded by a single page. The marketing
+// layouts skip profiles without an email address, which neither tool can
+// import.
+func Export(ctx context.Context, config *common.Config, w io.Writer, opts ExportOptions) error {
+	format := opts.Format
+	if format == "" {
+		format = ExportFormatCSV
+	}
+
+	var header []string
+	var row func(Profile) []string
+	switch format {
+	case ExportFormatCSV:
+		header, row = genericExportHeader, genericRow
+	case ExportFormatMailchimp:
+		header, row = mailchimpExportHeader, mailchimpRow
+	case ExportFormatKlaviyo:
+		header, row = klaviyoExportHeader, klaviyoRow
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	it := RetrieveAllProfilesIter(ctx, config, ListParams{Filters: opts.Filters}.QueryParams())
+	for it.Next() {
+		profile := it.Value()
+		if opts.ExcludeNonConsenting && !profile.AcceptsMarketing {
+			continue
+		}
+		if format != ExportFormatCSV && profile.Email == "" {
+			continue
+		}
+		if err := cw.Write(row(profile)); err != nil {
+			return fmt.Errorf("failed to export profile %s: %w", profile.ID, err)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to retrieve profiles: %w", err)
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+var genericExportHeader = []string{
+	"profile_id", "email", "first_name", "last_name", "is_customer", "has_account", "accepts_marketing",
+	"created_on", "address1", "address2", "city", "state", "postal_code", "country_code", "phone",
+	"order_count", "total_order_amount", "currency", "last_order_submitted_on",
+}
+
+func genericRow(profile Profile) []string {
+	address := profileAddress(profile)
+
+	var orderCount, total, currency, lastOrder string
+	if summary := profile.TransactionsSummary; summary != nil {
+		orderCount = strconv.Itoa(summary.OrderCount)
+		if summary.TotalOrderAmount != nil {
+			total, currency = summary.TotalOrderAmount.Value, summary.TotalOrderAmount.Currency
+		}
+		if summary.LastOrderSubmittedOn != nil {
+			lastOrder = *summary.LastOrderSubmittedOn
+		}
+	}
+
+	return []string{
+		profile.ID, profile.Email, profile.FirstName, profile.LastName, strconv.FormatBool(profile.IsCustomer),
+		strconv.FormatBool(profile.HasAccount), strconv.FormatBool(profile.AcceptsMarketing),
+		profile.CreatedOn, address.Address1, address.Address2, address.City, address.State,
+		address.PostalCode, address.CountryCode, address.Phone,
+		orderCount, total, currency, lastOrder,
+	}
+}
+
+var mailchimpExportHeader = []string{
+	"Email Address", "First Name", "Last Name", "Address", "Phone Number", "Tags",
+}
+
+func mailchimpRow(profile Profile) []string {
+	address := profileAddress(profile)
+
+	// Mailchimp imports a combined address with its parts separated by two
+	// spaces.
+	var parts []string
+	for _, part := range []string{address.Address1, address.Address2, address.City, address.State, address.PostalCode, address.CountryCode} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	var tags []string
+	if profile.IsCustomer {
+		tags = append(tags, "customer")
+	}
+	if profile.HasAccount {
+		tags = append(tags, "account")
+	}
+
+	return []string{
+		profile.Email, profile.FirstName, profile.LastName, strings.Join(parts, "  "), address.Phone,
+		strings.Join(tags, ","),
+	}
+}
+
+var klaviyoExportHeader = []string{
+	"Email", "First Name", "Last Name", "Phone Number", "Address", "City", "State / Region", "Country",
+	"Zip Code", "Accepts Marketing",
+}
+
+func klaviyoRow(profile Profile) []string {
+	address := profileAddress(profile)
+
+	return []string{
+		profile.Email, profile.FirstName, profile.LastName, address.Phone,
+		strings.TrimSpace(address.Address1 + " " + address.Address2), address.City, address.State,
+		address.CountryCode, address.PostalCode, strconv.FormatBool(profile.AcceptsMarketing),
+	}
+}
+
+func profileAddress(profile Profile) common.Address {
+	if profile.Address == nil {
+		return common.Address{}
+	}
+	return *profile.Address
+}
@@ -0,0 +1,141 @@
+package profiles
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const exportProfilesPage = `{
+	"profiles": [
+		{
+			"id": "p1",
+			"firstName": "Jane",
+			"lastName": "Doe",
+			"email": "jane@example.com",
+			"hasAccount": true,
+			"isCustomer": true,
+			"createdOn": "2024-03-05T14:00:00Z",
+			"acceptsMarketing": true,
+			"address": {"address1": "1 Main St", "address2": "Apt 2", "city": "Springfield", "state": "IL", "postalCode": "62701", "countryCode": "US", "phone": "555-0100"},
+			"transactionsSummary": {"orderCount": 3, "totalOrderAmount": {"currency": "USD", "value": "75.00"}, "lastOrderSubmittedOn": "2024-04-01T10:00:00Z"}
+		},
+		{
+			"id": "p2",
+			"firstName": "Sam",
+			"email": "sam@example.com",
+			"createdOn": "2024-03-06T09:00:00Z",
+			"acceptsMarketing": false
+		},
+		{
+			"id": "p3",
+			"firstName": "Anon",
+			"createdOn": "2024-03-07T09:00:00Z",
+			"acceptsMarketing": true
+		}
+	],
+	"pagination": {"hasNextPage": false}
+}`
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        ExportOptions
+		wantQuery   string
+		wantHeader  string
+		wantRows    []string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:       "generic csv",
+			opts:       ExportOptions{},
+			wantHeader: strings.Join(genericExportHeader, ","),
+			wantRows: []string{
+				"p1,jane@example.com,Jane,Doe,true,true,true,2024-03-05T14:00:00Z,1 Main St,Apt 2,Springfield,IL,62701,US,555-0100,3,75.00,USD,2024-04-01T10:00:00Z",
+				"p2,sam@example.com,Sam,,false,false,false,2024-03-06T09:00:00Z,,,,,,,,,,,",
+				"p3,,Anon,,false,false,true,2024-03-07T09:00:00Z,,,,,,,,,,,",
+			},
+		},
+		{
+			name:       "mailchimp consenting only",
+			opts:       ExportOptions{Format: ExportFormatMailchimp, ExcludeNonConsenting: true},
+			wantHeader: strings.Join(mailchimpExportHeader, ","),
+			wantRows: []string{
+				"jane@example.com,Jane,Doe,1 Main St  Apt 2  Springfield  IL  62701  US,555-0100,customer,account",
+			},
+		},
+		{
+			name:       "klaviyo with filter",
+			opts:       ExportOptions{Format: ExportFormatKlaviyo, Filters: []Filter{IsCustomer(true)}},
+			wantQuery:  "filter=isCustomer%2Ctrue",
+			wantHeader: strings.Join(klaviyoExportHeader, ","),
+			wantRows: []string{
+				"jane@example.com,Jane,Doe,555-0100,1 Main St Apt 2,Springfield,IL,US,62701,true",
+				"sam@example.com,Sam,,,,,,,,false",
+			},
+		},
+		{
+			name:        "unsupported format",
+			opts:        ExportOptions{Format: "vcard"},
+			wantErr:     true,
+			errContains: "unsupported export format: vcard",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("expected query %s, got %s", tt.wantQuery, r.URL.RawQuery)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(exportProfilesPage))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			var buf bytes.Buffer
+			err := Export(context.Background(), config, &buf, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Export() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			records, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("failed to parse export: %v", err)
+			}
+			if got := strings.Join(records[0], ","); got != tt.wantHeader {
+				t.Errorf("header = %s, want %s", got, tt.wantHeader)
+			}
+			if len(records)-1 != len(tt.wantRows) {
+				t.Fatalf("expected %d rows, got %d", len(tt.wantRows), len(records)-1)
+			}
+			for i, want := range tt.wantRows {
+				if got := strings.Join(records[i+1], ","); got != want {
+					t.Errorf("row %d = %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
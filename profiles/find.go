@@ -0,0 +1,60 @@
+package profiles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+var ErrProfileNotFound = errors.New("profile not found")
+
+// FindByEmail returns the profile the API's email filter matches. The filter
+// is exact, so a profile stored with different letter case is not found; use
+// FindByEmailOrScan when that matters. It returns ErrProfileNotFound when no
+// profile matches.
+func FindByEmail(ctx context.Context, config *common.Config, email string) (*Profile, error) {
+	return findProfileByEmail(ctx, config, email, false)
+}
+
+// FindByEmailOrScan is FindByEmail, but a filter miss falls back to scanning
+// every profile for a case-insensitive match. The scan pages through the full
+// customer list, so a lookup of an unknown email costs one request per page of
+// customers.
+func FindByEmailOrScan(ctx context.Context, config *common.Config, email string) (*Profile, error) {
+	return findProfileByEmail(ctx, config, email, true)
+}
+
+func findProfileByEmail(ctx context.Context, config *common.Config, email string, scanOnMiss bool) (*Profile, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+
+	profile, err := findByEmail(ctx, config, email, ListParams{Filters: []Filter{Email(email)}}.QueryParams())
+	if err != nil || profile != nil {
+		return profile, err
+	}
+
+	if scanOnMiss {
+		profile, err = findByEmail(ctx, config, email, ListParams{}.QueryParams())
+		if err != nil || profile != nil {
+			return profile, err
+		}
+	}
+
+	return nil, fmt.Errorf("%w: email %q", ErrProfileNotFound, email)
+}
+
+func findByEmail(ctx context.Context, config *common.Config, email string, params common.QueryParams) (*Profile, error) {
+	it := RetrieveAllProfilesIter(ctx, config, params)
+	for it.Next() {
+		profile := it.Value()
+		if strings.EqualFold(profile.Email, email) {
+			return &profile, nil
+		}
+	}
+	return nil, it.Err()
+}
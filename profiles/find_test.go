@@ -0,0 +1,131 @@
+package profiles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestFindByEmail(t *testing.T) {
+	profiles := map[string]string{
+		"p1": "jane@example.com",
+		"p2": "Sam@Example.com",
+	}
+
+	tests := []struct {
+		name        string
+		email       string
+		scanOnMiss  bool
+		wantID      string
+		wantScan    bool
+		wantErr     bool
+		notFound    bool
+		errContains string
+	}{
+		{
+			name:   "filter match",
+			email:  "jane@example.com",
+			wantID: "p1",
+		},
+		{
+			name:     "case mismatch is not found without a scan",
+			email:    "sam@example.com",
+			wantErr:  true,
+			notFound: true,
+		},
+		{
+			name:       "case-insensitive match falls back to scan",
+			email:      "sam@example.com",
+			scanOnMiss: true,
+			wantID:     "p2",
+			wantScan:   true,
+		},
+		{
+			name:     "no match",
+			email:    "nobody@example.com",
+			wantErr:  true,
+			notFound: true,
+		},
+		{
+			name:       "no match after a scan",
+			email:      "nobody@example.com",
+			scanOnMiss: true,
+			wantScan:   true,
+			wantErr:    true,
+			notFound:   true,
+		},
+		{
+			name:        "missing email",
+			email:       " ",
+			wantErr:     true,
+			errContains: "email is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanned := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query := r.URL.Query()
+				w.WriteHeader(http.StatusOK)
+				switch {
+				case strings.HasPrefix(query.Get("filter"), "email,"):
+					email := strings.TrimPrefix(query.Get("filter"), "email,")
+					var matches []string
+					for id, e := range profiles {
+						if e == email {
+							matches = append(matches, fmt.Sprintf(`{"id": %q, "email": %q}`, id, e))
+						}
+					}
+					fmt.Fprintf(w, `{"profiles": [%s], "pagination": {}}`, strings.Join(matches, ","))
+				case query.Get("cursor") == "c2":
+					fmt.Fprintf(w, `{"profiles": [{"id": "p2", "email": %q}], "pagination": {}}`, profiles["p2"])
+				default:
+					scanned = true
+					fmt.Fprintf(w, `{"profiles": [{"id": "p1", "email": %q}], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`, profiles["p1"])
+				}
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			find := FindByEmail
+			if tt.scanOnMiss {
+				find = FindByEmailOrScan
+			}
+			profile, err := find(context.Background(), config, tt.email)
+			if scanned != tt.wantScan {
+				t.Errorf("expected scan %v, got %v", tt.wantScan, scanned)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindByEmail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				if tt.notFound && !errors.Is(err, ErrProfileNotFound) {
+					t.Errorf("expected ErrProfileNotFound, got %v", err)
+				}
+				if tt.errContains != "" && !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if profile.ID != tt.wantID {
+				t.Errorf("expected profile %s, got %s", tt.wantID, profile.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package profiles
+
+import (
+	"context"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// RetrieveAllProfilesIter returns an iterator over every profile matching
+// params, following pagination cursors automatically.
+func RetrieveAllProfilesIter(ctx context.Context, config *common.Config, params common.QueryParams) *common.Iterator[Profile] {
+	return common.NewIterator(ctx, func(ctx context.Context, cursor string) ([]Profile, common.Pagination, error) {
+		resp, err := RetrieveAllProfiles(ctx, config, common.PageParams(params, cursor))
+		if err != nil {
+			return nil, common.Pagination{}, err
+		}
+		return resp.Profiles, resp.Pagination, nil
+	})
+}
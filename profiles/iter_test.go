@@ -0,0 +1,79 @@
+package profiles
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/internal/pagedtest"
+)
+
+var pagedProfiles = map[string]string{
+	"":   `{"profiles": [{"id": "p1"}, {"id": "p2"}], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`,
+	"c2": `{"profiles": [{"id": "p3"}], "pagination": {"hasNextPage": false}}`,
+}
+
+func TestRetrieveAllProfilesIter(t *testing.T) {
+	tests := []struct {
+		name        string
+		pages       map[string]string
+		params      common.QueryParams
+		wantIDs     []string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:  "follows cursors",
+			pages: pagedProfiles,
+			params: common.QueryParams{
+				Filter:        "isCustomer,true",
+				SortField:     "createdOn",
+				SortDirection: "asc",
+			},
+			wantIDs: []string{"p1", "p2", "p3"},
+		},
+		{
+			name: "error on later page",
+			pages: map[string]string{
+				"": `{"profiles": [{"id": "p1"}], "pagination": {"hasNextPage": true, "nextPageCursor": "bad"}}`,
+			},
+			wantIDs:     []string{"p1"},
+			wantErr:     true,
+			errContains: "Invalid cursor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := pagedtest.NewServer(t, tt.pages)
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			it := RetrieveAllProfilesIter(context.Background(), config, tt.params)
+			var ids []string
+			for it.Next() {
+				ids = append(ids, it.Value().ID)
+			}
+
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected profiles %v, got %v", tt.wantIDs, ids)
+			}
+
+			err := it.Err()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Err() = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+			}
+		})
+	}
+}
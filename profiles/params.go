@@ -0,0 +1,145 @@
+package profiles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type FilterField string
+
+const (
+	FilterIsCustomer FilterField = "isCustomer"
+	FilterHasAccount FilterField = "hasAccount"
+	FilterEmail      FilterField = "email"
+)
+
+type SortField string
+
+const (
+	SortByCreatedOn SortField = "createdOn"
+	SortByID        SortField = "id"
+	SortByEmail     SortField = "email"
+	SortByLastName  SortField = "lastName"
+)
+
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// Filter is a single field,value condition; multiple filters are sent
+// semicolon-separated and must all match.
+type Filter struct {
+	Field FilterField
+	Value string
+}
+
+func IsCustomer(value bool) Filter {
+	return Filter{Field: FilterIsCustomer, Value: fmt.Sprint(value)}
+}
+
+func HasAccount(value bool) Filter {
+	return Filter{Field: FilterHasAccount, Value: fmt.Sprint(value)}
+}
+
+func Email(email string) Filter {
+	return Filter{Field: FilterEmail, Value: email}
+}
+
+func (f Filter) String() string {
+	return string(f.Field) + "," + f.Value
+}
+
+func (f Filter) Validate() error {
+	switch f.Field {
+	case FilterIsCustomer, FilterHasAccount:
+		if f.Value != "true" && f.Value != "false" {
+			return fmt.Errorf("filter %s must be true or false, got: %q", f.Field, f.Value)
+		}
+	case FilterEmail:
+		if f.Value == "" {
+			return fmt.Errorf("filter email requires a value")
+		}
+	default:
+		return fmt.Errorf("unsupported filter field: %q", f.Field)
+	}
+	return nil
+}
+
+// ListParams is a typed alternative to common.QueryParams for
+// RetrieveAllProfiles.
+type ListParams struct {
+	Cursor        string
+	Filters       []Filter
+	SortField     SortField
+	SortDirection SortDirection
+}
+
+// Validate checks the parameters before a request is made.
+func (p ListParams) Validate() error {
+	params := p.QueryParams()
+	if err := common.ValidateCursor(params); err != nil {
+		return err
+	}
+	return validateParams(params)
+}
+
+func (p ListParams) QueryParams() common.QueryParams {
+	filters := make([]string, len(p.Filters))
+	for i, f := range p.Filters {
+		filters[i] = f.String()
+	}
+
+	return common.QueryParams{
+		Cursor:        p.Cursor,
+		Filter:        strings.Join(filters, ";"),
+		SortField:     string(p.SortField),
+		SortDirection: string(p.SortDirection),
+	}
+}
+
+func RetrieveAllProfilesWithParams(ctx context.Context, config *common.Config, params ListParams) (*RetrieveAllProfilesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid query parameters: %w", err)
+	}
+	return RetrieveAllProfiles(ctx, config, params.QueryParams())
+}
+
+// validateParams checks the profile-specific filter and sort values the API
+// would otherwise reject.
+func validateParams(params common.QueryParams) error {
+	var errs []error
+
+	if params.Filter != "" {
+		for _, condition := range strings.Split(params.Filter, ";") {
+			field, value, ok := strings.Cut(condition, ",")
+			if !ok {
+				errs = append(errs, fmt.Errorf("filter condition %q must be in the form field,value", condition))
+				continue
+			}
+			if err := (Filter{Field: FilterField(field), Value: value}).Validate(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	switch SortField(params.SortField) {
+	case "", SortByCreatedOn, SortByID, SortByEmail, SortByLastName:
+	default:
+		errs = append(errs, fmt.Errorf("unsupported sort field: %q", params.SortField))
+	}
+
+	switch SortDirection(params.SortDirection) {
+	case "", SortAscending, SortDescending:
+	default:
+		errs = append(errs, fmt.Errorf("sort direction must be either 'asc' or 'desc', got: %s", params.SortDirection))
+	}
+
+	return errors.Join(errs...)
+}
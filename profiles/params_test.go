@@ -0,0 +1,135 @@
+package profiles
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestListParamsQueryParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params ListParams
+		want   common.QueryParams
+	}{
+		{
+			name:   "empty params",
+			params: ListParams{},
+			want:   common.QueryParams{},
+		},
+		{
+			name:   "filters are semicolon-joined",
+			params: ListParams{Filters: []Filter{IsCustomer(true), HasAccount(false)}},
+			want:   common.QueryParams{Filter: "isCustomer,true;hasAccount,false"},
+		},
+		{
+			name:   "sort",
+			params: ListParams{SortField: SortByLastName, SortDirection: SortDescending},
+			want:   common.QueryParams{SortField: "lastName", SortDirection: "desc"},
+		},
+		{
+			name:   "cursor",
+			params: ListParams{Cursor: "next"},
+			want:   common.QueryParams{Cursor: "next"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.params.QueryParams(); got != tt.want {
+				t.Errorf("QueryParams() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetrieveAllProfilesWithParams(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      ListParams
+		wantQuery   string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "filters and sort",
+			params: ListParams{
+				Filters:       []Filter{IsCustomer(true), Email("jane@example.com")},
+				SortField:     SortByCreatedOn,
+				SortDirection: SortAscending,
+			},
+			wantQuery: "filter=isCustomer%2Ctrue%3Bemail%2Cjane%40example.com&sortDirection=asc&sortField=createdOn",
+		},
+		{
+			name:        "unsupported filter field",
+			params:      ListParams{Filters: []Filter{{Field: "firstName", Value: "Jane"}}},
+			wantErr:     true,
+			errContains: `unsupported filter field: "firstName"`,
+		},
+		{
+			name:        "non-boolean filter value",
+			params:      ListParams{Filters: []Filter{{Field: FilterIsCustomer, Value: "yes"}}},
+			wantErr:     true,
+			errContains: "filter isCustomer must be true or false",
+		},
+		{
+			name:        "empty email",
+			params:      ListParams{Filters: []Filter{Email("")}},
+			wantErr:     true,
+			errContains: "filter email requires a value",
+		},
+		{
+			name:        "unsupported sort field",
+			params:      ListParams{SortField: "firstName"},
+			wantErr:     true,
+			errContains: `unsupported sort field: "firstName"`,
+		},
+		{
+			name:        "invalid sort direction",
+			params:      ListParams{SortDirection: "up"},
+			wantErr:     true,
+			errContains: "sort direction must be either 'asc' or 'desc', got: up",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("expected query %s, got %s", tt.wantQuery, r.URL.RawQuery)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"profiles": [], "pagination": {}}`))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			resp, err := RetrieveAllProfilesWithParams(context.Background(), config, tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RetrieveAllProfilesWithParams() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if !tt.wantErr && resp == nil {
+				t.Error("expected non-nil response when no error")
+			}
+		})
+	}
+}
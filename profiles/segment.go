@@ -0,0 +1,79 @@
+package profiles
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type SegmentSpec struct {
+	// Filters narrow the profiles fetched from the API.
+	Filters []Filter
+	// Match, when set, further restricts the profiles that are bucketed.
+	Match func(Profile) bool
+	// CountsOnly skips collecting member lists, keeping memory flat for large
+	// audiences.
+	CountsOnly bool
+}
+
+type Bucket struct {
+	Count   int
+	Members []Profile
+}
+
+type Segments struct {
+	Total int
+	// Subscribed holds profiles that accept marketing; Unsubscribed the rest.
+	Subscribed   Bucket
+	Unsubscribed Bucket
+	// Customers holds profiles that have placed an order; Prospects the rest.
+	Customers Bucket
+	Prospects Bucket
+	// ByCountry buckets profiles by address country code, with "" for
+	// profiles without one.
+	ByCountry map[string]Bucket
+}
+
+// Segment pages through the profiles matching spec and buckets each one by
+// marketing consent, customer status and country.
+func Segment(ctx context.Context, config *common.Config, spec SegmentSpec) (*Segments, error) {
+	segments := &Segments{ByCountry: make(map[string]Bucket)}
+
+	add := func(bucket *Bucket, profile Profile) {
+		bucket.Count++
+		if !spec.CountsOnly {
+			bucket.Members = append(bucket.Members, profile)
+		}
+	}
+
+	it := RetrieveAllProfilesIter(ctx, config, ListParams{Filters: spec.Filters}.QueryParams())
+	for it.Next() {
+		profile := it.Value()
+		if spec.Match != nil && !spec.Match(profile) {
+			continue
+		}
+
+		segments.Total++
+		if profile.AcceptsMarketing {
+			add(&segments.Subscribed, profile)
+		} else {
+			add(&segments.Unsubscribed, profile)
+		}
+		if profile.IsCustomer {
+			add(&segments.Customers, profile)
+		} else {
+			add(&segments.Prospects, profile)
+		}
+
+		country := profileAddress(profile).CountryCode
+		bucket := segments.ByCountry[country]
+		add(&bucket, profile)
+		segments.ByCountry[country] = bucket
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve profiles: %w", err)
+	}
+
+	return segments, nil
+}
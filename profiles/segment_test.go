@@ -0,0 +1,118 @@
+package profiles
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestSegment(t *testing.T) {
+	page := `{"profiles": [
+		{"id": "p1", "email": "a@example.com", "acceptsMarketing": true, "isCustomer": true, "address": {"countryCode": "US"}},
+		{"id": "p2", "email": "b@example.com", "acceptsMarketing": false, "isCustomer": true, "address": {"countryCode": "CA"}},
+		{"id": "p3", "email": "c@example.com", "acceptsMarketing": true, "isCustomer": false, "address": {"countryCode": "US"}},
+		{"id": "p4", "acceptsMarketing": true, "isCustomer": false}
+	], "pagination": {"hasNextPage": false}}`
+
+	tests := []struct {
+		name           string
+		spec           SegmentSpec
+		wantQuery      string
+		wantTotal      int
+		wantSubscribed []string
+		wantCustomers  int
+		wantProspects  int
+		wantByCountry  map[string]int
+	}{
+		{
+			name:           "all profiles",
+			spec:           SegmentSpec{},
+			wantTotal:      4,
+			wantSubscribed: []string{"p1", "p3", "p4"},
+			wantCustomers:  2,
+			wantProspects:  2,
+			wantByCountry:  map[string]int{"US": 2, "CA": 1, "": 1},
+		},
+		{
+			name: "filters and match",
+			spec: SegmentSpec{
+				Filters: []Filter{HasAccount(true)},
+				Match:   func(p Profile) bool { return p.Email != "" },
+			},
+			wantQuery:      "filter=hasAccount%2Ctrue",
+			wantTotal:      3,
+			wantSubscribed: []string{"p1", "p3"},
+			wantCustomers:  2,
+			wantProspects:  1,
+			wantByCountry:  map[string]int{"US": 2, "CA": 1},
+		},
+		{
+			name:           "counts only",
+			spec:           SegmentSpec{CountsOnly: true},
+			wantTotal:      4,
+			wantSubscribed: nil,
+			wantCustomers:  2,
+			wantProspects:  2,
+			wantByCountry:  map[string]int{"US": 2, "CA": 1, "": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("expected query %s, got %s", tt.wantQuery, r.URL.RawQuery)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(page))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			segments, err := Segment(context.Background(), config, tt.spec)
+			if err != nil {
+				t.Fatalf("Segment() unexpected error = %v", err)
+			}
+
+			if segments.Total != tt.wantTotal {
+				t.Errorf("expected total %d, got %d", tt.wantTotal, segments.Total)
+			}
+			if segments.Subscribed.Count+segments.Unsubscribed.Count != tt.wantTotal {
+				t.Errorf("consent buckets do not cover every profile: %d + %d", segments.Subscribed.Count, segments.Unsubscribed.Count)
+			}
+			if tt.spec.CountsOnly && segments.Subscribed.Members != nil {
+				t.Errorf("expected no members with CountsOnly, got %+v", segments.Subscribed.Members)
+			}
+			if !tt.spec.CountsOnly {
+				var ids []string
+				for _, member := range segments.Subscribed.Members {
+					ids = append(ids, member.ID)
+				}
+				if fmt.Sprint(ids) != fmt.Sprint(tt.wantSubscribed) {
+					t.Errorf("expected subscribed %v, got %v", tt.wantSubscribed, ids)
+				}
+			}
+			if segments.Customers.Count != tt.wantCustomers || segments.Prospects.Count != tt.wantProspects {
+				t.Errorf("expected %d customers and %d prospects, got %d and %d", tt.wantCustomers, tt.wantProspects, segments.Customers.Count, segments.Prospects.Count)
+			}
+			if len(segments.ByCountry) != len(tt.wantByCountry) {
+				t.Errorf("expected countries %v, got %v", tt.wantByCountry, segments.ByCountry)
+			}
+			for country, want := range tt.wantByCountry {
+				if got := segments.ByCountry[country].Count; got != want {
+					t.Errorf("expected %d profiles in %q, got %d", want, country, got)
+				}
+			}
+		})
+	}
+}
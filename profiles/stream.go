@@ -0,0 +1,39 @@
+package profiles
+
+import (
+	"context"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Stream pages through the profiles matching params in the background and
+// sends each one on the returned profile channel. The channel is unbuffered,
+// so at most the page being delivered is held ahead of the consumer and the
+// next page is not requested until it has received every profile of this one.
+// Both channels are closed when paging finishes; the error channel receives
+// at most one error, including the context's error if ctx is canceled before
+// every profile is delivered.
+func Stream(ctx context.Context, config *common.Config, params common.QueryParams) (<-chan Profile, <-chan error) {
+	profiles := make(chan Profile)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(profiles)
+		defer close(errs)
+
+		it := RetrieveAllProfilesIter(ctx, config, params)
+		for it.Next() {
+			select {
+			case profiles <- it.Value():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errs <- err
+		}
+	}()
+
+	return profiles, errs
+}
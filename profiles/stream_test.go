@@ -0,0 +1,144 @@
+package profiles
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/internal/pagedtest"
+)
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		pages       map[string]string
+		wantIDs     []string
+		errContains string
+	}{
+		{
+			name:    "streams every page",
+			pages:   pagedProfiles,
+			wantIDs: []string{"p1", "p2", "p3"},
+		},
+		{
+			name: "error after first page",
+			pages: map[string]string{
+				"": `{"profiles": [{"id": "p1"}], "pagination": {"hasNextPage": true, "nextPageCursor": "bad"}}`,
+			},
+			wantIDs:     []string{"p1"},
+			errContains: "Invalid cursor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := pagedtest.NewServer(t, tt.pages)
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			profiles, errs := Stream(context.Background(), config, common.QueryParams{})
+
+			var ids []string
+			for profile := range profiles {
+				ids = append(ids, profile.ID)
+			}
+			err := <-errs
+
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected profiles %v, got %v", tt.wantIDs, ids)
+			}
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("Stream() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("expected error containing %q, got %v", tt.errContains, err)
+			}
+		})
+	}
+}
+
+func TestStreamCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"profiles": [{"id": "p1"}], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`))
+			return
+		}
+		// Hold the second page until the client gives up.
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	profiles, errs := Stream(ctx, config, common.QueryParams{})
+
+	first := <-profiles
+	if first.ID != "p1" {
+		t.Errorf("expected first profile p1, got %s", first.ID)
+	}
+	cancel()
+
+	for range profiles {
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestStreamHoldsOnePageAhead(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"profiles": [{"id": "p1"}, {"id": "p2"}], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`))
+			return
+		}
+		w.Write([]byte(`{"profiles": [{"id": "p3"}], "pagination": {"hasNextPage": false}}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	profiles, errs := Stream(context.Background(), config, common.QueryParams{})
+
+	<-profiles
+	time.Sleep(20 * time.Millisecond)
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected the next page to wait for the consumer, got %d requests", got)
+	}
+
+	for range profiles {
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Stream() unexpected error = %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
@@ -0,0 +1,150 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const defaultCohortMonths = 12
+
+type CohortOptions struct {
+	// From and To limit the cohorts to customers whose first order was placed
+	// within [From, To). A zero From includes every cohort and To defaults to
+	// now.
+	From time.Time
+	To   time.Time
+	// Months is the number of months after the first order that are tracked.
+	// Defaults to 12.
+	Months int
+}
+
+// CohortMatrix holds one row per monthly cohort of first-time buyers and one
+// column per month since the first order, column 0 being the cohort month
+// itself. Rows are cut off at the current month, so later cohorts have fewer
+// columns.
+type CohortMatrix struct {
+	// Cohorts is the first day of each cohort month, in UTC, oldest first.
+	Cohorts []time.Time
+	// Customers is the size of each cohort.
+	Customers []int
+	// Active[i][m] counts the customers of cohort i who ordered in month m.
+	// Active[i][0] equals Customers[i].
+	Active [][]int
+	// Rates[i][m] is Active[i][m] as a fraction of Customers[i].
+	Rates [][]float64
+	// RepeatRates[i][m] is the fraction of cohort i who had placed a second
+	// order by the end of month m, so a repeat within the cohort month counts
+	// from column 0.
+	RepeatRates [][]float64
+}
+
+// Cohorts groups customers by the month of their first order and tracks how
+// many of them order again in each following month. Customers are matched by
+// lowercased email, as in LifetimeValue, and every order is paged in; test
+// mode and canceled orders do not count as purchases.
+func Cohorts(ctx context.Context, config *common.Config, opts CohortOptions) (*CohortMatrix, error) {
+	to := opts.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !opts.From.Before(to) {
+		return nil, fmt.Errorf("from (%s) must be before to (%s)", opts.From.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	months := opts.Months
+	if months <= 0 {
+		months = defaultCohortMonths
+	}
+
+	history, err := ordersByEmail(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	type cohort struct {
+		customers int
+		active    []int
+		repeat    []int
+	}
+	cohorts := make(map[time.Time]*cohort)
+	current := monthStart(time.Now())
+
+	for _, customerOrders := range history {
+		placed := make([]time.Time, 0, len(customerOrders))
+		for _, order := range customerOrders {
+			createdOn, err := time.Parse(time.RFC3339, order.CreatedOn)
+			if err != nil {
+				return nil, fmt.Errorf("order %s: invalid createdOn %q: %w", order.ID, order.CreatedOn, err)
+			}
+			placed = append(placed, createdOn)
+		}
+		if len(placed) == 0 {
+			continue
+		}
+		sort.Slice(placed, func(i, j int) bool { return placed[i].Before(placed[j]) })
+		if placed[0].Before(opts.From) || !placed[0].Before(to) {
+			continue
+		}
+
+		start := monthStart(placed[0])
+		c, ok := cohorts[start]
+		if !ok {
+			width := min(months, monthsBetween(start, current)) + 1
+			c = &cohort{active: make([]int, width), repeat: make([]int, width)}
+			cohorts[start] = c
+		}
+		c.customers++
+
+		orderedIn := make(map[int]bool)
+		for _, createdOn := range placed {
+			if m := monthsBetween(start, createdOn); m < len(c.active) {
+				orderedIn[m] = true
+			}
+		}
+		for m := range orderedIn {
+			c.active[m]++
+		}
+		if len(placed) > 1 {
+			for m := monthsBetween(start, placed[1]); m < len(c.repeat); m++ {
+				c.repeat[m]++
+			}
+		}
+	}
+
+	matrix := &CohortMatrix{}
+	for start := range cohorts {
+		matrix.Cohorts = append(matrix.Cohorts, start)
+	}
+	sort.Slice(matrix.Cohorts, func(i, j int) bool {
+		return matrix.Cohorts[i].Before(matrix.Cohorts[j])
+	})
+
+	for _, start := range matrix.Cohorts {
+		c := cohorts[start]
+		rates := make([]float64, len(c.active))
+		repeatRates := make([]float64, len(c.repeat))
+		for m := range c.active {
+			rates[m] = float64(c.active[m]) / float64(c.customers)
+			repeatRates[m] = float64(c.repeat[m]) / float64(c.customers)
+		}
+		matrix.Customers = append(matrix.Customers, c.customers)
+		matrix.Active = append(matrix.Active, c.active)
+		matrix.Rates = append(matrix.Rates, rates)
+		matrix.RepeatRates = append(matrix.RepeatRates, repeatRates)
+	}
+
+	return matrix, nil
+}
+
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func monthsBetween(from, to time.Time) int {
+	to = to.UTC()
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
@@ -0,0 +1,62 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestCohorts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result": [
+			{"id": "a1", "customerEmail": "a@example.com", "createdOn": "2024-01-05T10:00:00Z"},
+			{"id": "a2", "customerEmail": "A@example.com", "createdOn": "2024-01-20T10:00:00Z"},
+			{"id": "a3", "customerEmail": "a@example.com", "createdOn": "2024-03-03T10:00:00Z"},
+			{"id": "b0", "customerEmail": "b@example.com", "createdOn": "2023-12-01T10:00:00Z", "fulfillmentStatus": "CANCELED"},
+			{"id": "b1", "customerEmail": "b@example.com", "createdOn": "2024-01-10T10:00:00Z"},
+			{"id": "b2", "customerEmail": "b@example.com", "createdOn": "2024-02-15T10:00:00Z"},
+			{"id": "c1", "customerEmail": "c@example.com", "createdOn": "2024-01-31T23:00:00Z"},
+			{"id": "d1", "customerEmail": "d@example.com", "createdOn": "2024-02-01T00:00:00Z"},
+			{"id": "d2", "customerEmail": "d@example.com", "createdOn": "2024-06-01T00:00:00Z"},
+			{"id": "e1", "customerEmail": "e@example.com", "createdOn": "2024-01-02T00:00:00Z", "testmode": true},
+			{"id": "f1", "customerEmail": "f@example.com", "createdOn": "2024-03-05T00:00:00Z"}
+		], "pagination": {"hasNextPage": false}}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	matrix, err := Cohorts(context.Background(), config, CohortOptions{From: from, To: to, Months: 3})
+	if err != nil {
+		t.Fatalf("Cohorts() unexpected error = %v", err)
+	}
+
+	wantCohorts := []time.Time{from, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+	if fmt.Sprint(matrix.Cohorts) != fmt.Sprint(wantCohorts) {
+		t.Fatalf("Cohorts = %v, want %v", matrix.Cohorts, wantCohorts)
+	}
+	if fmt.Sprint(matrix.Customers) != "[3 1]" {
+		t.Errorf("Customers = %v, want [3 1]", matrix.Customers)
+	}
+	if fmt.Sprint(matrix.Active) != "[[3 1 1 0] [1 0 0 0]]" {
+		t.Errorf("Active = %v", matrix.Active)
+	}
+	if got := fmt.Sprintf("%.2f", matrix.Rates[0]); got != "[1.00 0.33 0.33 0.00]" {
+		t.Errorf("Rates[0] = %s", got)
+	}
+	if got := fmt.Sprintf("%.2f", matrix.RepeatRates); got != "[[0.33 0.67 0.67 0.67] [0.00 0.00 0.00 0.00]]" {
+		t.Errorf("RepeatRates = %s", got)
+	}
+}
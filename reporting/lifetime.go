@@ -0,0 +1,234 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/profiles"
+)
+
+type RecencySegment string
+
+const (
+	RecencyActive  RecencySegment = "active"
+	RecencyLapsing RecencySegment = "lapsing"
+	RecencyLapsed  RecencySegment = "lapsed"
+	RecencyNever   RecencySegment = "never"
+)
+
+type FrequencySegment string
+
+const (
+	FrequencyNone    FrequencySegment = "none"
+	FrequencyOneTime FrequencySegment = "one-time"
+	FrequencyRepeat  FrequencySegment = "repeat"
+	FrequencyLoyal   FrequencySegment = "loyal"
+)
+
+const (
+	defaultActiveWithin  = 90 * 24 * time.Hour
+	defaultLapsingWithin = 365 * 24 * time.Hour
+	defaultLoyalOrders   = 5
+	minimumTenure        = 30 * 24 * time.Hour
+	year                 = 365 * 24 * time.Hour
+)
+
+type LifetimeValueOptions struct {
+	// Now is the reference time for recency. Defaults to time.Now().
+	Now time.Time
+	// ActiveWithin is how recently a customer must have ordered to count as
+	// active. Defaults to 90 days.
+	ActiveWithin time.Duration
+	// LapsingWithin is the recency beyond which a customer is lapsed.
+	// Defaults to 365 days.
+	LapsingWithin time.Duration
+	// LoyalOrders is the order count from which a customer is loyal rather
+	// than repeat. Defaults to 5.
+	LoyalOrders int
+	// WithOrders attaches each customer's order history, matched by email.
+	// This pages through every order.
+	WithOrders bool
+}
+
+type CustomerValue struct {
+	Profile profiles.Profile
+	// Orders is only populated when LifetimeValueOptions.WithOrders is set.
+	Orders     []orders.Order
+	OrderCount int
+	// LifetimeValue is the total ordered less the total refunded.
+	LifetimeValue     common.Amount
+	AverageOrderValue common.Amount
+	FirstOrder        time.Time
+	LastOrder         time.Time
+	// OrdersPerYear is the order rate over the customer's tenure, from the
+	// first order to Now, counting tenures under 30 days as 30 days.
+	OrdersPerYear float64
+	Recency       RecencySegment
+	Frequency     FrequencySegment
+}
+
+type LifetimeValueReport struct {
+	// Customers is ordered by lifetime value, highest first.
+	Customers   []CustomerValue
+	Total       common.Amount
+	ByRecency   map[RecencySegment]int
+	ByFrequency map[FrequencySegment]int
+}
+
+// LifetimeValue reports the lifetime value, order frequency and recency of
+// every customer profile, based on the profiles' transaction summaries.
+func LifetimeValue(ctx context.Context, config *common.Config, opts LifetimeValueOptions) (*LifetimeValueReport, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.ActiveWithin <= 0 {
+		opts.ActiveWithin = defaultActiveWithin
+	}
+	if opts.LapsingWithin <= 0 {
+		opts.LapsingWithin = defaultLapsingWithin
+	}
+	if opts.LoyalOrders <= 0 {
+		opts.LoyalOrders = defaultLoyalOrders
+	}
+
+	var history map[string][]orders.Order
+	if opts.WithOrders {
+		var err error
+		if history, err = ordersByEmail(ctx, config); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &LifetimeValueReport{
+		ByRecency:   make(map[RecencySegment]int),
+		ByFrequency: make(map[FrequencySegment]int),
+	}
+
+	params := profiles.ListParams{Filters: []profiles.Filter{profiles.IsCustomer(true)}}.QueryParams()
+	it := profiles.RetrieveAllProfilesIter(ctx, config, params)
+	for it.Next() {
+		profile := it.Value()
+		customer, err := customerValue(profile, opts)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", profile.ID, err)
+		}
+		if history != nil {
+			customer.Orders = history[strings.ToLower(profile.Email)]
+		}
+		if report.Total, err = report.Total.Add(customer.LifetimeValue); err != nil {
+			return nil, fmt.Errorf("profile %s: %w", profile.ID, err)
+		}
+
+		report.ByRecency[customer.Recency]++
+		report.ByFrequency[customer.Frequency]++
+		report.Customers = append(report.Customers, customer)
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve profiles: %w", err)
+	}
+
+	var sortErr error
+	sort.SliceStable(report.Customers, func(i, j int) bool {
+		cmp, err := report.Customers[i].LifetimeValue.Cmp(report.Customers[j].LifetimeValue)
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		return cmp > 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	return report, nil
+}
+
+func customerValue(profile profiles.Profile, opts LifetimeValueOptions) (CustomerValue, error) {
+	customer := CustomerValue{Profile: profile, Recency: RecencyNever, Frequency: FrequencyNone}
+
+	summary := profile.TransactionsSummary
+	if summary == nil {
+		return customer, nil
+	}
+
+	customer.OrderCount = summary.OrderCount
+	var err error
+	if summary.TotalOrderAmount != nil {
+		customer.LifetimeValue = *summary.TotalOrderAmount
+	}
+	if summary.TotalRefundAmount != nil {
+		if customer.LifetimeValue, err = customer.LifetimeValue.Sub(*summary.TotalRefundAmount); err != nil {
+			return CustomerValue{}, err
+		}
+	}
+	if customer.OrderCount > 0 {
+		factor := fmt.Sprintf("1/%d", customer.OrderCount)
+		if customer.AverageOrderValue, err = customer.LifetimeValue.Mul(factor); err != nil {
+			return CustomerValue{}, err
+		}
+	}
+
+	customer.FirstOrder = parseTimestamp(summary.FirstOrderSubmittedOn)
+	customer.LastOrder = parseTimestamp(summary.LastOrderSubmittedOn)
+	if !customer.FirstOrder.IsZero() {
+		tenure := max(opts.Now.Sub(customer.FirstOrder), minimumTenure)
+		customer.OrdersPerYear = float64(customer.OrderCount) / (float64(tenure) / float64(year))
+	}
+
+	switch since := opts.Now.Sub(customer.LastOrder); {
+	case customer.LastOrder.IsZero():
+	case since <= opts.ActiveWithin:
+		customer.Recency = RecencyActive
+	case since <= opts.LapsingWithin:
+		customer.Recency = RecencyLapsing
+	default:
+		customer.Recency = RecencyLapsed
+	}
+
+	switch {
+	case customer.OrderCount >= opts.LoyalOrders:
+		customer.Frequency = FrequencyLoyal
+	case customer.OrderCount > 1:
+		customer.Frequency = FrequencyRepeat
+	case customer.OrderCount == 1:
+		customer.Frequency = FrequencyOneTime
+	}
+
+	return customer, nil
+}
+
+// ordersByEmail groups every order by lowercased customer email, skipping test
+// mode and canceled orders.
+func ordersByEmail(ctx context.Context, config *common.Config) (map[string][]orders.Order, error) {
+	history := make(map[string][]orders.Order)
+
+	it := orders.RetrieveAllOrdersIter(ctx, config, orders.ListParams{}.QueryParams())
+	for it.Next() {
+		order := it.Value()
+		if order.TestMode || order.FulfillmentStatus == orders.StatusCanceled || order.CustomerEmail == "" {
+			continue
+		}
+		email := strings.ToLower(order.CustomerEmail)
+		history[email] = append(history[email], order)
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve orders: %w", err)
+	}
+
+	return history, nil
+}
+
+func parseTimestamp(timestamp *string) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, *timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
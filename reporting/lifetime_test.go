@@ -0,0 +1,111 @@
+package reporting
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestLifetimeValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/profiles"):
+			if got := r.URL.Query().Get("filter"); got != "isCustomer,true" {
+				t.Errorf("expected customer filter, got %q", got)
+			}
+			w.Write([]byte(`{"profiles": [
+				{"id": "p1", "email": "once@example.com", "transactionsSummary": {
+					"orderCount": 1, "totalOrderAmount": {"currency": "USD", "value": "30.00"},
+					"firstOrderSubmittedOn": "2023-01-01T00:00:00Z", "lastOrderSubmittedOn": "2023-01-01T00:00:00Z"}},
+				{"id": "p2", "email": "Loyal@Example.com", "transactionsSummary": {
+					"orderCount": 6, "totalOrderAmount": {"currency": "USD", "value": "310.00"}, "totalRefundAmount": {"currency": "USD", "value": "10.00"},
+					"firstOrderSubmittedOn": "2023-06-01T00:00:00Z", "lastOrderSubmittedOn": "2024-05-20T00:00:00Z"}},
+				{"id": "p3", "email": "repeat@example.com", "transactionsSummary": {
+					"orderCount": 3, "totalOrderAmount": {"currency": "USD", "value": "100.00"},
+					"firstOrderSubmittedOn": "2024-01-01T00:00:00Z", "lastOrderSubmittedOn": "2024-02-01T00:00:00Z"}},
+				{"id": "p4", "email": "prospect@example.com"},
+				{"id": "p5", "email": "new@example.com", "transactionsSummary": {
+					"orderCount": 1, "totalOrderAmount": {"currency": "USD", "value": "10.00"},
+					"firstOrderSubmittedOn": "2024-05-25T00:00:00Z", "lastOrderSubmittedOn": "2024-05-25T00:00:00Z"}}
+			], "pagination": {"hasNextPage": false}}`))
+		case strings.HasSuffix(r.URL.Path, "/commerce/orders"):
+			w.Write([]byte(`{"result": [
+				{"id": "o1", "customerEmail": "loyal@example.com"},
+				{"id": "o2", "customerEmail": "loyal@example.com", "testmode": true},
+				{"id": "o3", "customerEmail": "repeat@example.com", "fulfillmentStatus": "CANCELED"}
+			], "pagination": {"hasNextPage": false}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	report, err := LifetimeValue(context.Background(), config, LifetimeValueOptions{Now: now, WithOrders: true})
+	if err != nil {
+		t.Fatalf("LifetimeValue() unexpected error = %v", err)
+	}
+
+	if report.Total != (common.Amount{Currency: "USD", Value: "440.00"}) {
+		t.Errorf("expected total 440.00 USD, got %+v", report.Total)
+	}
+
+	var ids []string
+	for _, customer := range report.Customers {
+		ids = append(ids, customer.Profile.ID)
+	}
+	if strings.Join(ids, ",") != "p2,p3,p1,p5,p4" {
+		t.Errorf("expected customers ordered by value p2,p3,p1,p5,p4, got %v", ids)
+	}
+
+	loyal := report.Customers[0]
+	if loyal.LifetimeValue.Value != "300.00" || loyal.AverageOrderValue.Value != "50.00" {
+		t.Errorf("unexpected loyal customer values %+v", loyal)
+	}
+	if loyal.Recency != RecencyActive || loyal.Frequency != FrequencyLoyal {
+		t.Errorf("expected active loyal customer, got %s %s", loyal.Recency, loyal.Frequency)
+	}
+	if len(loyal.Orders) != 1 || loyal.Orders[0].ID != "o1" {
+		t.Errorf("expected order history [o1], got %+v", loyal.Orders)
+	}
+
+	repeat := report.Customers[1]
+	if repeat.AverageOrderValue.Value != "33.33" || repeat.Recency != RecencyLapsing || repeat.Frequency != FrequencyRepeat {
+		t.Errorf("unexpected repeat customer %+v", repeat)
+	}
+	if len(repeat.Orders) != 0 {
+		t.Errorf("expected canceled order to be skipped, got %+v", repeat.Orders)
+	}
+
+	if report.Customers[2].Recency != RecencyLapsed || report.Customers[2].Frequency != FrequencyOneTime {
+		t.Errorf("unexpected one-time customer %+v", report.Customers[2])
+	}
+	newest := report.Customers[3]
+	if newest.Recency != RecencyActive || newest.Frequency != FrequencyOneTime {
+		t.Errorf("unexpected new customer %+v", newest)
+	}
+	if want := 365.0 / 30; math.Abs(newest.OrdersPerYear-want) > 1e-9 {
+		t.Errorf("expected tenure floored at 30 days (%v orders/year), got %v", want, newest.OrdersPerYear)
+	}
+	if report.Customers[4].Recency != RecencyNever || report.Customers[4].Frequency != FrequencyNone {
+		t.Errorf("unexpected customer without orders %+v", report.Customers[4])
+	}
+
+	if report.ByRecency[RecencyActive] != 2 || report.ByRecency[RecencyLapsed] != 1 || report.ByFrequency[FrequencyNone] != 1 {
+		t.Errorf("unexpected segment counts %v %v", report.ByRecency, report.ByFrequency)
+	}
+}
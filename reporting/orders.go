@@ -0,0 +1,72 @@
+// Package reporting joins data across the Commerce APIs for reports the
+// individual endpoints cannot answer on their own.
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/transactions"
+)
+
+// OrderTransactions is an order together with the financial documents whose
+// salesOrderId refers to it.
+type OrderTransactions struct {
+	Order        orders.Order
+	Transactions []transactions.Document
+}
+
+// WithTransactions retrieves the order and every transaction document linked
+// to it, scanning the documents modified since the order was created.
+func WithTransactions(ctx context.Context, config *common.Config, orderID string) (*OrderTransactions, error) {
+	order, err := orders.RetrieveSpecificOrder(ctx, config, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve order: %w", err)
+	}
+
+	var window transactions.Window
+	if createdOn, err := time.Parse(time.RFC3339, order.CreatedOn); err == nil {
+		window.From = createdOn
+	}
+
+	documents, err := transactions.FindBySalesOrderID(ctx, config, order.ID, window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transactions: %w", err)
+	}
+
+	return &OrderTransactions{Order: *order, Transactions: documents}, nil
+}
+
+// Refunded sums the refunds issued against the order's payments.
+func (o *OrderTransactions) Refunded() (common.Amount, error) {
+	var total common.Amount
+	for _, document := range o.Transactions {
+		for _, payment := range document.Payments {
+			var err error
+			if total, err = total.Add(payment.RefundedAmount); err != nil {
+				return common.Amount{}, err
+			}
+		}
+	}
+	return total, nil
+}
+
+// ProcessingFees sums the net processing fees charged on the order's
+// payments, after any fee refunds.
+func (o *OrderTransactions) ProcessingFees() (common.Amount, error) {
+	var total common.Amount
+	for _, document := range o.Transactions {
+		for _, payment := range document.Payments {
+			for _, fee := range payment.ProcessingFees {
+				var err error
+				if total, err = total.Add(fee.NetAmount); err != nil {
+					return common.Amount{}, err
+				}
+			}
+		}
+	}
+	return total, nil
+}
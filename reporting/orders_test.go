@@ -0,0 +1,115 @@
+package reporting
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestWithTransactions(t *testing.T) {
+	transactionPages := map[string]string{
+		"": `{"documents": [
+			{"id": "t1", "salesOrderId": "order-123", "payments": [{"id": "p1",
+				"refundedAmount": {"currency": "USD", "value": "5.00"},
+				"processingFees": [{"id": "f1", "netAmount": {"currency": "USD", "value": "0.59"}}]}]},
+			{"id": "t2", "salesOrderId": "order-999"},
+			{"id": "t3"}
+		], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`,
+		"c2": `{"documents": [
+			{"id": "t4", "salesOrderId": "order-123", "payments": [{"id": "p2",
+				"refundedAmount": {"currency": "USD", "value": "2.50"},
+				"processingFees": [{"id": "f2", "netAmount": {"currency": "USD", "value": "0.30"}}]}]}
+		], "pagination": {"hasNextPage": false}}`,
+	}
+
+	tests := []struct {
+		name         string
+		orderStatus  int
+		wantIDs      []string
+		wantRefunded string
+		wantFees     string
+		wantErr      bool
+		errContains  string
+	}{
+		{
+			name:         "matches across pages",
+			orderStatus:  http.StatusOK,
+			wantIDs:      []string{"t1", "t4"},
+			wantRefunded: "7.50",
+			wantFees:     "0.89",
+		},
+		{
+			name:        "order not found",
+			orderStatus: http.StatusNotFound,
+			wantErr:     true,
+			errContains: "failed to retrieve order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/commerce/orders/order-123"):
+					w.WriteHeader(tt.orderStatus)
+					if tt.orderStatus != http.StatusOK {
+						w.Write([]byte(`{"type":"NOT_FOUND","message":"Order not found"}`))
+						return
+					}
+					w.Write([]byte(`{"id": "order-123", "createdOn": "2024-01-01T00:00:00Z"}`))
+				case strings.HasSuffix(r.URL.Path, "/commerce/transactions"):
+					query := r.URL.Query()
+					cursor := query.Get("cursor")
+					if cursor == "" && query.Get("modifiedAfter") != "2024-01-01T00:00:00Z" {
+						t.Errorf("expected modifiedAfter to be the order's creation time, got %s", query.Get("modifiedAfter"))
+					}
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(transactionPages[cursor]))
+				default:
+					t.Errorf("unexpected request %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			result, err := WithTransactions(context.Background(), config, "order-123")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithTransactions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			var ids []string
+			for _, document := range result.Transactions {
+				ids = append(ids, document.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected transactions %v, got %v", tt.wantIDs, ids)
+			}
+
+			refunded, err := result.Refunded()
+			if err != nil || refunded.Value != tt.wantRefunded {
+				t.Errorf("Refunded() = %v, %v, want %s", refunded, err, tt.wantRefunded)
+			}
+			fees, err := result.ProcessingFees()
+			if err != nil || fees.Value != tt.wantFees {
+				t.Errorf("ProcessingFees() = %v, %v, want %s", fees, err, tt.wantFees)
+			}
+		})
+	}
+}
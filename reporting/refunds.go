@@ -0,0 +1,345 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/transactions"
+)
+
+const (
+	defaultSpikeFactor = 2
+	defaultMinRefunds  = 3
+)
+
+type RefundReportOptions struct {
+	From time.Time
+	// To defaults to now.
+	To time.Time
+	// Weekly groups periods by ISO week, starting on Monday, instead of by
+	// UTC day.
+	Weekly bool
+	// SpikeFactor is how many times the overall refund rate a period or
+	// product must exceed to be flagged. Defaults to 2.
+	SpikeFactor float64
+	// MinRefunds is the number of refunds a period or product needs before it
+	// can be flagged, so that a single refund on a slow day is not reported as
+	// a spike. Defaults to 3.
+	MinRefunds int
+}
+
+type RefundStats struct {
+	// Revenue sums the orders created in the period, or for a product the
+	// unit price paid times quantity of its line items.
+	Revenue  common.Amount
+	Refunds  int
+	Refunded common.Amount
+	// Rate is Refunded as a fraction of Revenue.
+	Rate float64
+	// Spike is set when Rate exceeds the overall rate by the report's spike
+	// factor, or when refunds were issued against no revenue at all.
+	Spike bool
+}
+
+type ProductRefunds struct {
+	ProductID   string
+	ProductName string
+	RefundStats
+}
+
+type PeriodRefunds struct {
+	// Start is the UTC midnight beginning the day, or the Monday beginning the
+	// ISO week.
+	Start time.Time
+	RefundStats
+}
+
+type RefundReport struct {
+	From  time.Time
+	To    time.Time
+	Total RefundStats
+	// Products is ordered by amount refunded, highest first. Each refund is
+	// split across the products of its order in proportion to their line item
+	// revenue, so a product's Refunds counts the refunds it had a share of.
+	Products []ProductRefunds
+	// Periods is ordered by start. Refunds fall in the period they were issued
+	// in and revenue in the period the order was created in.
+	Periods []PeriodRefunds
+	// Unattributed sums refunds that could not be split across products
+	// because they have no sales order or the order no longer exists.
+	Unattributed common.Amount
+}
+
+// RefundReportBetween reports the refunds issued between opts.From and
+// opts.To against the revenue of the orders created in that time, by product
+// and by period, and flags the periods and products whose refund rate is
+// unusually high. Orders created before the period are fetched individually
+// to attribute their refunds. Test mode and canceled orders do not count
+// towards revenue. All amounts must share one currency.
+//
+// Disputes and chargebacks are not included, as the API does not expose them.
+func RefundReportBetween(ctx context.Context, config *common.Config, opts RefundReportOptions) (*RefundReport, error) {
+	from, to := opts.From, opts.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("from (%s) must be before to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	spikeFactor := opts.SpikeFactor
+	if spikeFactor <= 0 {
+		spikeFactor = defaultSpikeFactor
+	}
+	minRefunds := opts.MinRefunds
+	if minRefunds <= 0 {
+		minRefunds = defaultMinRefunds
+	}
+
+	report := &RefundReport{From: from, To: to}
+	products := make(map[string]*ProductRefunds)
+	periods := make(map[time.Time]*RefundStats)
+	ordersByID := make(map[string]*orders.Order)
+
+	after, before := modifiedSince(from)
+	orderIter := orders.RetrieveAllOrdersIter(ctx, config, orders.ListParams{ModifiedAfter: after, ModifiedBefore: before}.QueryParams())
+	for orderIter.Next() {
+		order := orderIter.Value()
+		ordersByID[order.ID] = &order
+		if order.TestMode || order.FulfillmentStatus == orders.StatusCanceled || !createdWithin(order.CreatedOn, from, to) {
+			continue
+		}
+		if err := report.addRevenue(order, products, periods, opts.Weekly); err != nil {
+			return nil, fmt.Errorf("order %s: %w", order.ID, err)
+		}
+	}
+	if err := orderIter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve orders: %w", err)
+	}
+
+	documentIter := transactions.RetrieveAllTransactionsIter(ctx, config, transactions.ListParams{ModifiedAfter: after, ModifiedBefore: before}.QueryParams())
+	for documentIter.Next() {
+		document := documentIter.Value()
+		for _, payment := range document.Payments {
+			for _, refund := range payment.Refunds {
+				refundedOn, err := time.Parse(time.RFC3339, refund.RefundedOn)
+				if err != nil || refundedOn.Before(from) || refundedOn.After(to) {
+					continue
+				}
+
+				order, err := refundedOrder(ctx, config, document, ordersByID)
+				if err != nil {
+					return nil, fmt.Errorf("document %s: %w", document.ID, err)
+				}
+				if err := report.addRefund(refund.Amount, refundedOn, order, products, periods, opts.Weekly); err != nil {
+					return nil, fmt.Errorf("document %s: %w", document.ID, err)
+				}
+			}
+		}
+	}
+	if err := documentIter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve transactions: %w", err)
+	}
+
+	var err error
+	if report.Total.Rate, err = ratio(report.Total.Refunded, report.Total.Revenue); err != nil {
+		return nil, err
+	}
+	flag := func(stats *RefundStats) error {
+		var err error
+		if stats.Rate, err = ratio(stats.Refunded, stats.Revenue); err != nil {
+			return err
+		}
+		if stats.Refunds < minRefunds || stats.Refunded.IsZero() {
+			return nil
+		}
+		stats.Spike = stats.Revenue.IsZero() || stats.Rate > spikeFactor*report.Total.Rate
+		return nil
+	}
+
+	for _, product := range products {
+		if err := flag(&product.RefundStats); err != nil {
+			return nil, fmt.Errorf("product %s: %w", product.ProductID, err)
+		}
+		report.Products = append(report.Products, *product)
+	}
+	if err := sortByAmount(report.Products, func(p ProductRefunds) common.Amount { return p.Refunded }, func(p ProductRefunds) string { return p.ProductID }); err != nil {
+		return nil, err
+	}
+
+	for start, stats := range periods {
+		if err := flag(stats); err != nil {
+			return nil, fmt.Errorf("period %s: %w", start.Format(time.DateOnly), err)
+		}
+		report.Periods = append(report.Periods, PeriodRefunds{Start: start, RefundStats: *stats})
+	}
+	sort.Slice(report.Periods, func(i, j int) bool {
+		return report.Periods[i].Start.Before(report.Periods[j].Start)
+	})
+
+	return report, nil
+}
+
+func (r *RefundReport) addRevenue(order orders.Order, products map[string]*ProductRefunds, periods map[time.Time]*RefundStats, weekly bool) error {
+	createdOn, err := time.Parse(time.RFC3339, order.CreatedOn)
+	if err != nil {
+		return err
+	}
+	for _, stats := range []*RefundStats{&r.Total, periodStats(periods, createdOn, weekly)} {
+		if stats.Revenue, err = stats.Revenue.Add(order.GrandTotal); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range order.LineItems {
+		revenue, err := item.UnitPricePaid.Mul(strconv.Itoa(item.Quantity))
+		if err != nil {
+			return err
+		}
+		product := productRefunds(products, item)
+		if product.Revenue, err = product.Revenue.Add(revenue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RefundReport) addRefund(amount common.Amount, refundedOn time.Time, order *orders.Order, products map[string]*ProductRefunds, periods map[time.Time]*RefundStats, weekly bool) error {
+	var err error
+	for _, stats := range []*RefundStats{&r.Total, periodStats(periods, refundedOn, weekly)} {
+		if stats.Refunded, err = stats.Refunded.Add(amount); err != nil {
+			return err
+		}
+		stats.Refunds++
+	}
+
+	shares, err := refundShares(amount, order)
+	if err != nil {
+		return err
+	}
+	if len(shares) == 0 {
+		r.Unattributed, err = r.Unattributed.Add(amount)
+		return err
+	}
+
+	counted := make(map[*ProductRefunds]bool)
+	for i, item := range order.LineItems {
+		if shares[i].IsZero() {
+			continue
+		}
+		product := productRefunds(products, item)
+		if product.Refunded, err = product.Refunded.Add(shares[i]); err != nil {
+			return err
+		}
+		if !counted[product] {
+			counted[product] = true
+			product.Refunds++
+		}
+	}
+	return nil
+}
+
+// refundShares splits amount across the order's line items in proportion to
+// their revenue. The last line item with revenue takes the rounding remainder
+// so the shares add up to amount exactly. It returns nil when the order is nil
+// or has no line item revenue.
+func refundShares(amount common.Amount, order *orders.Order) ([]common.Amount, error) {
+	if order == nil {
+		return nil, nil
+	}
+
+	revenues := make([]*big.Rat, len(order.LineItems))
+	total := new(big.Rat)
+	last := -1
+	for i, item := range order.LineItems {
+		price, err := item.UnitPricePaid.Rat()
+		if err != nil {
+			return nil, err
+		}
+		revenues[i] = price.Mul(price, big.NewRat(int64(item.Quantity), 1))
+		if revenues[i].Sign() > 0 {
+			total.Add(total, revenues[i])
+			last = i
+		}
+	}
+	if last < 0 {
+		return nil, nil
+	}
+
+	shares := make([]common.Amount, len(order.LineItems))
+	remainder := amount
+	for i := range order.LineItems {
+		if revenues[i].Sign() <= 0 {
+			continue
+		}
+		if i == last {
+			shares[i] = remainder
+			break
+		}
+		share, err := amount.Mul(new(big.Rat).Quo(revenues[i], total).RatString())
+		if err != nil {
+			return nil, err
+		}
+		if remainder, err = remainder.Sub(share); err != nil {
+			return nil, err
+		}
+		shares[i] = share
+	}
+	return shares, nil
+}
+
+// refundedOrder returns the sales order a transaction document belongs to,
+// fetching it when it was not modified within the report period. It returns
+// nil when the document has no sales order or the order no longer exists.
+func refundedOrder(ctx context.Context, config *common.Config, document transactions.Document, ordersByID map[string]*orders.Order) (*orders.Order, error) {
+	if document.SalesOrderID == nil || *document.SalesOrderID == "" {
+		return nil, nil
+	}
+	orderID := *document.SalesOrderID
+	if order, ok := ordersByID[orderID]; ok {
+		return order, nil
+	}
+
+	order, err := orders.RetrieveSpecificOrder(ctx, config, orderID)
+	if err != nil {
+		var respErr *common.ResponseError
+		if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusNotFound {
+			return nil, fmt.Errorf("failed to retrieve order %s: %w", orderID, err)
+		}
+	}
+	ordersByID[orderID] = order
+	return order, nil
+}
+
+func productRefunds(products map[string]*ProductRefunds, item orders.LineItem) *ProductRefunds {
+	key := item.ProductID
+	if key == "" {
+		key = item.SKU
+	}
+	product, ok := products[key]
+	if !ok {
+		product = &ProductRefunds{ProductID: item.ProductID, ProductName: item.ProductName}
+		products[key] = product
+	}
+	return product
+}
+
+func periodStats(periods map[time.Time]*RefundStats, t time.Time, weekly bool) *RefundStats {
+	start := t.UTC().Truncate(24 * time.Hour)
+	if weekly {
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	}
+	stats, ok := periods[start]
+	if !ok {
+		stats = &RefundStats{}
+		periods[start] = stats
+	}
+	return stats
+}
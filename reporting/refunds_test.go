@@ -0,0 +1,147 @@
+package reporting
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/orders"
+)
+
+func TestRefundReportBetween(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/commerce/orders"):
+			w.Write([]byte(`{"result": [
+				{"id": "o1", "createdOn": "2024-05-02T10:00:00Z",
+					"grandTotal": {"currency": "USD", "value": "50.00"},
+					"lineItems": [
+						{"productId": "p1", "productName": "Mug", "quantity": 2, "unitPricePaid": {"currency": "USD", "value": "15.00"}},
+						{"productId": "p2", "productName": "Tee", "quantity": 1, "unitPricePaid": {"currency": "USD", "value": "20.00"}}
+					]},
+				{"id": "o2", "createdOn": "2024-05-03T10:00:00Z",
+					"grandTotal": {"currency": "USD", "value": "30.00"},
+					"lineItems": [{"productId": "p1", "productName": "Mug", "quantity": 2, "unitPricePaid": {"currency": "USD", "value": "15.00"}}]},
+				{"id": "o3", "createdOn": "2024-05-03T11:00:00Z",
+					"grandTotal": {"currency": "USD", "value": "20.00"},
+					"lineItems": [{"productId": "p2", "productName": "Tee", "quantity": 1, "unitPricePaid": {"currency": "USD", "value": "20.00"}}]},
+				{"id": "o4", "createdOn": "2024-05-03T12:00:00Z", "fulfillmentStatus": "CANCELED",
+					"grandTotal": {"currency": "USD", "value": "99.00"}}
+			], "pagination": {"hasNextPage": false}}`))
+		case strings.HasSuffix(r.URL.Path, "/commerce/orders/old"):
+			w.Write([]byte(`{"id": "old", "createdOn": "2024-04-01T10:00:00Z",
+				"grandTotal": {"currency": "USD", "value": "10.00"},
+				"lineItems": [{"productId": "p3", "productName": "Cap", "quantity": 1, "unitPricePaid": {"currency": "USD", "value": "10.00"}}]}`))
+		case strings.HasSuffix(r.URL.Path, "/commerce/orders/gone"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type": "NOT_FOUND", "message": "order not found"}`))
+		case strings.HasSuffix(r.URL.Path, "/commerce/transactions"):
+			w.Write([]byte(`{"documents": [
+				{"id": "d1", "salesOrderId": "o1", "payments": [{"refunds": [
+					{"amount": {"currency": "USD", "value": "10.00"}, "refundedOn": "2024-05-03T09:00:00Z"},
+					{"amount": {"currency": "USD", "value": "7.00"}, "refundedOn": "2024-06-10T00:00:00Z"}
+				]}]},
+				{"id": "d2", "salesOrderId": "old", "payments": [{"refunds": [
+					{"amount": {"currency": "USD", "value": "5.00"}, "refundedOn": "2024-05-03T10:00:00Z"}
+				]}]},
+				{"id": "d3", "payments": [{"refunds": [
+					{"amount": {"currency": "USD", "value": "3.00"}, "refundedOn": "2024-05-03T11:00:00Z"}
+				]}]},
+				{"id": "d4", "salesOrderId": "gone", "payments": [{"refunds": [
+					{"amount": {"currency": "USD", "value": "2.00"}, "refundedOn": "2024-05-02T11:00:00Z"}
+				]}]}
+			], "pagination": {"hasNextPage": false}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	report, err := RefundReportBetween(context.Background(), config, RefundReportOptions{From: from, To: to, SpikeFactor: 1.5, MinRefunds: 1})
+	if err != nil {
+		t.Fatalf("RefundReportBetween() unexpected error = %v", err)
+	}
+
+	if report.Total.Revenue.Value != "100.00" || report.Total.Refunded.Value != "20.00" || report.Total.Refunds != 4 {
+		t.Errorf("unexpected totals %+v", report.Total)
+	}
+	if math.Abs(report.Total.Rate-0.2) > 1e-9 || report.Total.Spike {
+		t.Errorf("expected an unflagged total rate of 0.2, got %+v", report.Total)
+	}
+	if report.Unattributed.Value != "5.00" {
+		t.Errorf("expected 5.00 unattributed, got %+v", report.Unattributed)
+	}
+
+	wantProducts := []struct {
+		id, refunded string
+		spike        bool
+	}{
+		{"p1", "6.00", false},
+		{"p3", "5.00", true},
+		{"p2", "4.00", false},
+	}
+	if len(report.Products) != len(wantProducts) {
+		t.Fatalf("expected %d products, got %+v", len(wantProducts), report.Products)
+	}
+	for i, want := range wantProducts {
+		got := report.Products[i]
+		if got.ProductID != want.id || got.Refunded.Value != want.refunded || got.Refunds != 1 || got.Spike != want.spike {
+			t.Errorf("products[%d] = %+v, want %s refunded %s, spike %v", i, got, want.id, want.refunded, want.spike)
+		}
+	}
+	if math.Abs(report.Products[0].Rate-0.1) > 1e-9 {
+		t.Errorf("expected p1 refund rate 0.1, got %v", report.Products[0].Rate)
+	}
+
+	if len(report.Periods) != 2 {
+		t.Fatalf("expected 2 periods, got %+v", report.Periods)
+	}
+	may2, may3 := report.Periods[0], report.Periods[1]
+	if !may2.Start.Equal(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)) || may2.Revenue.Value != "50.00" || may2.Refunded.Value != "2.00" || may2.Spike {
+		t.Errorf("unexpected first period %+v", may2)
+	}
+	if may3.Revenue.Value != "50.00" || may3.Refunded.Value != "18.00" || may3.Refunds != 3 || !may3.Spike {
+		t.Errorf("expected the second period to be flagged, got %+v", may3)
+	}
+
+	weekly, err := RefundReportBetween(context.Background(), config, RefundReportOptions{From: from, To: to, Weekly: true})
+	if err != nil {
+		t.Fatalf("RefundReportBetween() unexpected error = %v", err)
+	}
+	if len(weekly.Periods) != 1 || !weekly.Periods[0].Start.Equal(time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC)) || weekly.Periods[0].Spike {
+		t.Errorf("expected one unflagged week starting 2024-04-29, got %+v", weekly.Periods)
+	}
+}
+
+func TestRefundSharesRoundsToTotal(t *testing.T) {
+	line := orders.LineItem{ProductID: "p", Quantity: 1, UnitPricePaid: common.Amount{Currency: "USD", Value: "5.00"}}
+	order := &orders.Order{LineItems: []orders.LineItem{line, line, line}}
+	shares, err := refundShares(common.Amount{Currency: "USD", Value: "10.00"}, order)
+	if err != nil {
+		t.Fatalf("refundShares() unexpected error = %v", err)
+	}
+
+	var total common.Amount
+	for _, share := range shares {
+		if total, err = total.Add(share); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if total.Value != "10.00" || shares[0].Value != "3.33" || shares[2].Value != "3.34" {
+		t.Errorf("unexpected shares %+v", shares)
+	}
+}
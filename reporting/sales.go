@@ -0,0 +1,223 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/transactions"
+)
+
+const defaultTopCustomers = 10
+
+type SalesReportOptions struct {
+	From time.Time
+	// To defaults to now.
+	To time.Time
+	// TopCustomers is the number of customers ranked. Defaults to 10.
+	TopCustomers int
+}
+
+type ProductSales struct {
+	ProductID   string
+	ProductName string
+	Units       int
+	// Revenue sums unit price paid times quantity, before order-level
+	// discounts, shipping and tax.
+	Revenue common.Amount
+}
+
+type CustomerSales struct {
+	Email   string
+	Orders  int
+	Revenue common.Amount
+}
+
+type SalesReport struct {
+	From time.Time
+	To   time.Time
+	// Orders counts the orders created in the period, leaving out test mode
+	// and canceled orders.
+	Orders  int
+	Units   int
+	Revenue common.Amount
+	// Refunded sums the refunds issued in the period, whenever the refunded
+	// order was placed.
+	Refunded common.Amount
+	Refunds  int
+	// RefundRate is Refunded as a fraction of Revenue.
+	RefundRate float64
+	// Products is ordered by revenue, highest first.
+	Products []ProductSales
+	// TopCustomers is ordered by revenue, highest first.
+	TopCustomers []CustomerSales
+}
+
+// SalesReportBetween reports the sales of orders created in the period and the
+// refunds issued in it, walking the orders and transactions iterators. All
+// amounts must share one currency.
+func SalesReportBetween(ctx context.Context, config *common.Config, opts SalesReportOptions) (*SalesReport, error) {
+	from, to := opts.From, opts.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("from (%s) must be before to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	topCustomers := opts.TopCustomers
+	if topCustomers <= 0 {
+		topCustomers = defaultTopCustomers
+	}
+
+	report := &SalesReport{From: from, To: to}
+	productSales := make(map[string]*ProductSales)
+	customerSales := make(map[string]*CustomerSales)
+
+	after, before := modifiedSince(from)
+	orderIter := orders.RetrieveAllOrdersIter(ctx, config, orders.ListParams{ModifiedAfter: after, ModifiedBefore: before}.QueryParams())
+	for orderIter.Next() {
+		order := orderIter.Value()
+		if order.TestMode || order.FulfillmentStatus == orders.StatusCanceled || !createdWithin(order.CreatedOn, from, to) {
+			continue
+		}
+		if err := report.addOrder(order, productSales, customerSales); err != nil {
+			return nil, fmt.Errorf("order %s: %w", order.ID, err)
+		}
+	}
+	if err := orderIter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve orders: %w", err)
+	}
+
+	documentIter := transactions.RetrieveAllTransactionsIter(ctx, config, transactions.ListParams{ModifiedAfter: after, ModifiedBefore: before}.QueryParams())
+	for documentIter.Next() {
+		document := documentIter.Value()
+		for _, payment := range document.Payments {
+			for _, refund := range payment.Refunds {
+				if !createdWithin(refund.RefundedOn, from, to) {
+					continue
+				}
+				var err error
+				if report.Refunded, err = report.Refunded.Add(refund.Amount); err != nil {
+					return nil, fmt.Errorf("document %s: %w", document.ID, err)
+				}
+				report.Refunds++
+			}
+		}
+	}
+	if err := documentIter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve transactions: %w", err)
+	}
+
+	var err error
+	if report.RefundRate, err = ratio(report.Refunded, report.Revenue); err != nil {
+		return nil, err
+	}
+
+	for _, sales := range productSales {
+		report.Products = append(report.Products, *sales)
+	}
+	if err := sortByAmount(report.Products, func(p ProductSales) common.Amount { return p.Revenue }, func(p ProductSales) string { return p.ProductID }); err != nil {
+		return nil, err
+	}
+
+	for _, sales := range customerSales {
+		report.TopCustomers = append(report.TopCustomers, *sales)
+	}
+	if err := sortByAmount(report.TopCustomers, func(c CustomerSales) common.Amount { return c.Revenue }, func(c CustomerSales) string { return c.Email }); err != nil {
+		return nil, err
+	}
+	if len(report.TopCustomers) > topCustomers {
+		report.TopCustomers = report.TopCustomers[:topCustomers]
+	}
+
+	return report, nil
+}
+
+func (r *SalesReport) addOrder(order orders.Order, productSales map[string]*ProductSales, customerSales map[string]*CustomerSales) error {
+	var err error
+	if r.Revenue, err = r.Revenue.Add(order.GrandTotal); err != nil {
+		return err
+	}
+	r.Orders++
+
+	for _, item := range order.LineItems {
+		key := item.ProductID
+		if key == "" {
+			key = item.SKU
+		}
+		sales, ok := productSales[key]
+		if !ok {
+			sales = &ProductSales{ProductID: item.ProductID, ProductName: item.ProductName}
+			productSales[key] = sales
+		}
+
+		revenue, err := item.UnitPricePaid.Mul(strconv.Itoa(item.Quantity))
+		if err != nil {
+			return err
+		}
+		if sales.Revenue, err = sales.Revenue.Add(revenue); err != nil {
+			return err
+		}
+		sales.Units += item.Quantity
+		r.Units += item.Quantity
+	}
+
+	if order.CustomerEmail == "" {
+		return nil
+	}
+	email := strings.ToLower(order.CustomerEmail)
+	customer, ok := customerSales[email]
+	if !ok {
+		customer = &CustomerSales{Email: email}
+		customerSales[email] = customer
+	}
+	if customer.Revenue, err = customer.Revenue.Add(order.GrandTotal); err != nil {
+		return err
+	}
+	customer.Orders++
+	return nil
+}
+
+// sortByAmount orders items by the amount returned by amount, such as revenue
+// or refunds, highest first, breaking ties by key.
+func sortByAmount[T any](items []T, amount func(T) common.Amount, key func(T) string) error {
+	var sortErr error
+	sort.Slice(items, func(i, j int) bool {
+		cmp, err := amount(items[i]).Cmp(amount(items[j]))
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		if cmp != 0 {
+			return cmp > 0
+		}
+		return key(items[i]) < key(items[j])
+	})
+	return sortErr
+}
+
+// ratio returns part / whole, or 0 when whole is zero.
+func ratio(part, whole common.Amount) (float64, error) {
+	if whole.IsZero() || part.IsZero() {
+		return 0, nil
+	}
+	if part.Currency != whole.Currency {
+		return 0, fmt.Errorf("currency mismatch: %s and %s", part.Currency, whole.Currency)
+	}
+
+	p, err := part.Rat()
+	if err != nil {
+		return 0, err
+	}
+	w, err := whole.Rat()
+	if err != nil {
+		return 0, err
+	}
+	f, _ := new(big.Rat).Quo(p, w).Float64()
+	return f, nil
+}
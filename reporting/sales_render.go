@@ -0,0 +1,89 @@
+package reporting
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+var salesCSVHeader = []string{"section", "id", "name", "orders", "units", "amount", "currency"}
+
+// WriteCSV writes the report as one CSV table whose section column is
+// summary, product or customer, so it loads into a spreadsheet as is.
+func (r *SalesReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{
+		salesCSVHeader,
+		salesCSVRow("summary", "revenue", "", r.Orders, r.Units, r.Revenue),
+		salesCSVRow("summary", "refunded", "", r.Refunds, 0, r.Refunded),
+		{"summary", "refund_rate", "", "", "", strconv.FormatFloat(r.RefundRate, 'f', 4, 64), ""},
+	}
+	for _, product := range r.Products {
+		rows = append(rows, salesCSVRow("product", product.ProductID, product.ProductName, 0, product.Units, product.Revenue))
+	}
+	for _, customer := range r.TopCustomers {
+		rows = append(rows, salesCSVRow("customer", customer.Email, "", customer.Orders, 0, customer.Revenue))
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write sales report: %w", err)
+	}
+	return nil
+}
+
+func salesCSVRow(section, id, name string, orders, units int, amount common.Amount) []string {
+	count := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	return []string{section, id, name, count(orders), count(units), amount.Value, amount.Currency}
+}
+
+var salesHTMLTemplate = template.Must(template.New("sales").Funcs(template.FuncMap{
+	"date":    func(t time.Time) string { return t.Format("2006-01-02") },
+	"amount":  func(a common.Amount) string { return a.Value + " " + a.Currency },
+	"percent": func(f float64) string { return strconv.FormatFloat(f*100, 'f', 1, 64) + "%" },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Sales {{date .From}} to {{date .To}}</title></head>
+<body>
+<h1>Sales {{date .From}} to {{date .To}}</h1>
+<table>
+<tr><th>Orders</th><td>{{.Orders}}</td></tr>
+<tr><th>Units</th><td>{{.Units}}</td></tr>
+<tr><th>Revenue</th><td>{{amount .Revenue}}</td></tr>
+<tr><th>Refunded</th><td>{{amount .Refunded}} ({{.Refunds}} refunds)</td></tr>
+<tr><th>Refund rate</th><td>{{percent .RefundRate}}</td></tr>
+</table>
+<h2>Products</h2>
+<table>
+<tr><th>Product</th><th>Units</th><th>Revenue</th></tr>
+{{- range .Products}}
+<tr><td>{{if .ProductName}}{{.ProductName}}{{else}}{{.ProductID}}{{end}}</td><td>{{.Units}}</td><td>{{amount .Revenue}}</td></tr>
+{{- end}}
+</table>
+<h2>Top customers</h2>
+<table>
+<tr><th>Customer</th><th>Orders</th><th>Revenue</th></tr>
+{{- range .TopCustomers}}
+<tr><td>{{.Email}}</td><td>{{.Orders}}</td><td>{{amount .Revenue}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a standalone HTML page, e.g. for email.
+func (r *SalesReport) WriteHTML(w io.Writer) error {
+	if err := salesHTMLTemplate.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to write sales report: %w", err)
+	}
+	return nil
+}
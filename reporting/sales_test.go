@@ -0,0 +1,114 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestSalesReportBetween(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/commerce/orders"):
+			w.Write([]byte(`{"result": [
+				{"id": "o1", "createdOn": "2024-05-02T10:00:00Z", "customerEmail": "A@example.com",
+					"grandTotal": {"currency": "USD", "value": "50.00"},
+					"lineItems": [
+						{"productId": "p1", "productName": "Mug", "quantity": 2, "unitPricePaid": {"currency": "USD", "value": "15.00"}},
+						{"productId": "p2", "productName": "Tee", "quantity": 1, "unitPricePaid": {"currency": "USD", "value": "20.00"}}
+					]},
+				{"id": "o2", "createdOn": "2024-05-03T10:00:00Z", "customerEmail": "a@example.com",
+					"grandTotal": {"currency": "USD", "value": "30.00"},
+					"lineItems": [{"productId": "p1", "productName": "Mug", "quantity": 2, "unitPricePaid": {"currency": "USD", "value": "15.00"}}]},
+				{"id": "o3", "createdOn": "2024-05-04T10:00:00Z", "customerEmail": "b@example.com",
+					"grandTotal": {"currency": "USD", "value": "20.00"},
+					"lineItems": [{"productId": "p2", "productName": "Tee", "quantity": 1, "unitPricePaid": {"currency": "USD", "value": "20.00"}}]},
+				{"id": "o4", "createdOn": "2024-05-04T11:00:00Z", "fulfillmentStatus": "CANCELED",
+					"grandTotal": {"currency": "USD", "value": "99.00"}},
+				{"id": "o5", "createdOn": "2024-05-04T12:00:00Z", "testmode": true,
+					"grandTotal": {"currency": "USD", "value": "99.00"}},
+				{"id": "o6", "createdOn": "2024-04-01T00:00:00Z",
+					"grandTotal": {"currency": "USD", "value": "99.00"}}
+			], "pagination": {"hasNextPage": false}}`))
+		case strings.HasSuffix(r.URL.Path, "/commerce/transactions"):
+			w.Write([]byte(`{"documents": [
+				{"id": "d1", "payments": [{"refunds": [
+					{"amount": {"currency": "USD", "value": "10.00"}, "refundedOn": "2024-05-10T00:00:00Z"},
+					{"amount": {"currency": "USD", "value": "5.00"}, "refundedOn": "2024-06-10T00:00:00Z"}
+				]}]}
+			], "pagination": {"hasNextPage": false}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	report, err := SalesReportBetween(context.Background(), config, SalesReportOptions{From: from, To: to, TopCustomers: 1})
+	if err != nil {
+		t.Fatalf("SalesReportBetween() unexpected error = %v", err)
+	}
+
+	if report.Orders != 3 || report.Units != 6 || report.Revenue.Value != "100.00" {
+		t.Errorf("unexpected totals: %d orders, %d units, revenue %+v", report.Orders, report.Units, report.Revenue)
+	}
+	if report.Refunded.Value != "10.00" || report.Refunds != 1 {
+		t.Errorf("expected one 10.00 refund in the period, got %d totaling %+v", report.Refunds, report.Refunded)
+	}
+	if math.Abs(report.RefundRate-0.1) > 1e-9 {
+		t.Errorf("expected refund rate 0.1, got %v", report.RefundRate)
+	}
+
+	if len(report.Products) != 2 || report.Products[0].ProductID != "p1" || report.Products[0].Units != 4 || report.Products[0].Revenue.Value != "60.00" {
+		t.Errorf("unexpected products %+v", report.Products)
+	}
+	if len(report.TopCustomers) != 1 || report.TopCustomers[0].Email != "a@example.com" || report.TopCustomers[0].Orders != 2 {
+		t.Errorf("unexpected top customers %+v", report.TopCustomers)
+	}
+
+	var csvOut bytes.Buffer
+	if err := report.WriteCSV(&csvOut); err != nil {
+		t.Fatalf("WriteCSV() unexpected error = %v", err)
+	}
+	wantCSV := `section,id,name,orders,units,amount,currency
+summary,revenue,,3,6,100.00,USD
+summary,refunded,,1,,10.00,USD
+summary,refund_rate,,,,0.1000,
+product,p1,Mug,,4,60.00,USD
+product,p2,Tee,,2,40.00,USD
+customer,a@example.com,,2,,80.00,USD
+`
+	if csvOut.String() != wantCSV {
+		t.Errorf("WriteCSV() =\n%s\nwant\n%s", csvOut.String(), wantCSV)
+	}
+
+	var htmlOut bytes.Buffer
+	if err := report.WriteHTML(&htmlOut); err != nil {
+		t.Fatalf("WriteHTML() unexpected error = %v", err)
+	}
+	for _, want := range []string{"Sales 2024-05-01 to 2024-06-01", "<td>100.00 USD</td>", "<td>10.0%</td>", "<td>Mug</td><td>4</td><td>60.00 USD</td>"} {
+		if !strings.Contains(htmlOut.String(), want) {
+			t.Errorf("expected HTML to contain %q", want)
+		}
+	}
+
+	if _, err := SalesReportBetween(context.Background(), config, SalesReportOptions{From: to, To: from}); err == nil {
+		t.Error("expected an error for an inverted period")
+	}
+}
@@ -0,0 +1,131 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/transactions"
+)
+
+type TaxLine struct {
+	Name         string
+	Jurisdiction string
+	Rate         string
+	// Taxable sums the net sales of the line items the tax was charged on.
+	Taxable common.Amount
+	Tax     common.Amount
+	// LineItems counts the sales and shipping line items carrying the tax.
+	LineItems int
+}
+
+type TaxReport struct {
+	// Lines holds one entry per tax name, jurisdiction and rate, ordered by
+	// jurisdiction, then name, then rate.
+	Lines    []TaxLine
+	TotalTax common.Amount
+}
+
+// TaxReportBetween aggregates the taxes on every sales and shipping line item
+// of the transaction documents created between from and to, skipping voided
+// documents. A zero to means now. Refunded tax is not deducted, as the API does
+// not itemize it.
+func TaxReportBetween(ctx context.Context, config *common.Config, from, to time.Time) (*TaxReport, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("from (%s) must be before to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	type taxKey struct {
+		name, jurisdiction, rate string
+	}
+	lines := make(map[taxKey]*TaxLine)
+	report := &TaxReport{}
+
+	addTaxes := func(taxable common.Amount, taxes []transactions.Tax) error {
+		for _, tax := range taxes {
+			key := taxKey{tax.Name, tax.Jurisdiction, tax.Rate}
+			line, ok := lines[key]
+			if !ok {
+				line = &TaxLine{Name: tax.Name, Jurisdiction: tax.Jurisdiction, Rate: tax.Rate}
+				lines[key] = line
+			}
+
+			var err error
+			if line.Taxable, err = line.Taxable.Add(taxable); err != nil {
+				return err
+			}
+			if line.Tax, err = line.Tax.Add(tax.Amount); err != nil {
+				return err
+			}
+			if report.TotalTax, err = report.TotalTax.Add(tax.Amount); err != nil {
+				return err
+			}
+			line.LineItems++
+		}
+		return nil
+	}
+
+	after, before := modifiedSince(from)
+	it := transactions.RetrieveAllTransactionsIter(ctx, config, transactions.ListParams{ModifiedAfter: after, ModifiedBefore: before}.QueryParams())
+	for it.Next() {
+		document := it.Value()
+		if document.Voided || !createdWithin(document.CreatedOn, from, to) {
+			continue
+		}
+
+		for _, item := range document.SalesLineItems {
+			if err := addTaxes(item.TotalNetSales, item.Taxes); err != nil {
+				return nil, fmt.Errorf("document %s: %w", document.ID, err)
+			}
+		}
+		for _, item := range document.ShippingLineItems {
+			if err := addTaxes(item.NetAmount, item.Taxes); err != nil {
+				return nil, fmt.Errorf("document %s: %w", document.ID, err)
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve transactions: %w", err)
+	}
+
+	for _, line := range lines {
+		report.Lines = append(report.Lines, *line)
+	}
+	sort.Slice(report.Lines, func(i, j int) bool {
+		a, b := report.Lines[i], report.Lines[j]
+		if a.Jurisdiction != b.Jurisdiction {
+			return a.Jurisdiction < b.Jurisdiction
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Rate < b.Rate
+	})
+
+	return report, nil
+}
+
+// modifiedSince returns the modified window from from until now, or the zero
+// window selecting everything when from is zero; anything created within a
+// period was necessarily modified after its start.
+func modifiedSince(from time.Time) (after, before time.Time) {
+	if from.IsZero() {
+		return time.Time{}, time.Time{}
+	}
+	return from, time.Now()
+}
+
+// createdWithin reports whether the RFC 3339 timestamp falls within [from, to].
+// Unparseable timestamps are treated as outside the period.
+func createdWithin(timestamp string, from, to time.Time) bool {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return false
+	}
+	return !t.Before(from) && !t.After(to)
+}
@@ -0,0 +1,80 @@
+package reporting
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestTaxReportBetween(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("modifiedAfter") != "2024-03-01T00:00:00Z" {
+			t.Errorf("expected modifiedAfter to be the period start, got %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"documents": [
+			{"id": "t1", "createdOn": "2024-03-05T14:00:00Z",
+			 "salesLineItems": [
+				{"id": "li1", "totalNetSales": {"currency": "USD", "value": "100.00"}, "taxes": [
+					{"amount": {"currency": "USD", "value": "4.00"}, "rate": "0.04", "name": "State Tax", "jurisdiction": "NY"},
+					{"amount": {"currency": "USD", "value": "4.50"}, "rate": "0.045", "name": "City Tax", "jurisdiction": "NY"}
+				]}
+			 ],
+			 "shippingLineItems": [
+				{"id": "s1", "netAmount": {"currency": "USD", "value": "10.00"}, "taxes": [
+					{"amount": {"currency": "USD", "value": "0.40"}, "rate": "0.04", "name": "State Tax", "jurisdiction": "NY"}
+				]}
+			 ]},
+			{"id": "t2", "createdOn": "2024-03-06T09:00:00Z",
+			 "salesLineItems": [
+				{"id": "li2", "totalNetSales": {"currency": "USD", "value": "50.00"}, "taxes": [
+					{"amount": {"currency": "USD", "value": "3.63"}, "rate": "0.0725", "name": "State Tax", "jurisdiction": "CA"}
+				]}
+			 ]},
+			{"id": "t3", "createdOn": "2024-03-06T10:00:00Z", "voided": true,
+			 "salesLineItems": [{"id": "li3", "taxes": [{"amount": {"currency": "USD", "value": "9.00"}, "rate": "0.04", "name": "State Tax", "jurisdiction": "NY"}]}]},
+			{"id": "t4", "createdOn": "2024-04-02T10:00:00Z",
+			 "salesLineItems": [{"id": "li4", "taxes": [{"amount": {"currency": "USD", "value": "9.00"}, "rate": "0.04", "name": "State Tax", "jurisdiction": "NY"}]}]}
+		], "pagination": {"hasNextPage": false}}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+	report, err := TaxReportBetween(context.Background(), config, from, to)
+	if err != nil {
+		t.Fatalf("TaxReportBetween() unexpected error = %v", err)
+	}
+
+	want := []TaxLine{
+		{Name: "State Tax", Jurisdiction: "CA", Rate: "0.0725", Taxable: usd("50.00"), Tax: usd("3.63"), LineItems: 1},
+		{Name: "City Tax", Jurisdiction: "NY", Rate: "0.045", Taxable: usd("100.00"), Tax: usd("4.50"), LineItems: 1},
+		{Name: "State Tax", Jurisdiction: "NY", Rate: "0.04", Taxable: usd("110.00"), Tax: usd("4.40"), LineItems: 2},
+	}
+	if len(report.Lines) != len(want) {
+		t.Fatalf("expected %d lines, got %+v", len(want), report.Lines)
+	}
+	for i := range want {
+		if report.Lines[i] != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i, report.Lines[i], want[i])
+		}
+	}
+	if report.TotalTax != usd("12.53") {
+		t.Errorf("expected total tax 12.53 USD, got %+v", report.TotalTax)
+	}
+}
+
+func usd(value string) common.Amount {
+	return common.Amount{Currency: "USD", Value: value}
+}
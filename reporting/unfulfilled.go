@@ -0,0 +1,102 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/orders"
+)
+
+// unfulfilledAgeEdges are the upper bounds of the age buckets; the last bucket
+// is open-ended.
+var unfulfilledAgeEdges = []time.Duration{
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+type UnfulfilledOrder struct {
+	Order orders.Order
+	// Age is the time since the order was created.
+	Age time.Duration
+}
+
+// AgeBucket counts the orders whose age falls within [Min, Max). A zero Max
+// means the bucket is open-ended.
+type AgeBucket struct {
+	Min    time.Duration
+	Max    time.Duration
+	Orders int
+}
+
+type UnfulfilledReport struct {
+	// Orders is ordered by age, oldest first.
+	Orders []UnfulfilledOrder
+	// Buckets starts at the olderThan threshold and continues at one, three,
+	// seven, fourteen and thirty days, leaving out the edges below the
+	// threshold. Every bucket is present, even when empty.
+	Buckets []AgeBucket
+}
+
+// UnfulfilledOrders lists the pending orders created more than olderThan ago,
+// leaving out test mode orders, and counts them by age. Every pending order is
+// paged in, however long ago it was modified.
+func UnfulfilledOrders(ctx context.Context, config *common.Config, olderThan time.Duration) (*UnfulfilledReport, error) {
+	if olderThan < 0 {
+		return nil, fmt.Errorf("olderThan must not be negative, got %s", olderThan)
+	}
+
+	report := &UnfulfilledReport{Buckets: ageBuckets(olderThan)}
+	now := time.Now()
+
+	it := orders.RetrieveAllOrdersIter(ctx, config, orders.ListParams{Status: orders.StatusPending}.QueryParams())
+	for it.Next() {
+		order := it.Value()
+		if order.TestMode || order.FulfillmentStatus != orders.StatusPending {
+			continue
+		}
+
+		createdOn, err := time.Parse(time.RFC3339, order.CreatedOn)
+		if err != nil {
+			return nil, fmt.Errorf("order %s: invalid createdOn %q: %w", order.ID, order.CreatedOn, err)
+		}
+		age := now.Sub(createdOn)
+		if age < olderThan {
+			continue
+		}
+
+		report.Orders = append(report.Orders, UnfulfilledOrder{Order: order, Age: age})
+		for i := range report.Buckets {
+			if bucket := &report.Buckets[i]; bucket.Max == 0 || age < bucket.Max {
+				bucket.Orders++
+				break
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve orders: %w", err)
+	}
+
+	sort.SliceStable(report.Orders, func(i, j int) bool {
+		return report.Orders[i].Age > report.Orders[j].Age
+	})
+	return report, nil
+}
+
+func ageBuckets(olderThan time.Duration) []AgeBucket {
+	var buckets []AgeBucket
+	start := olderThan
+	for _, edge := range unfulfilledAgeEdges {
+		if edge <= start {
+			continue
+		}
+		buckets = append(buckets, AgeBucket{Min: start, Max: edge})
+		start = edge
+	}
+	return append(buckets, AgeBucket{Min: start})
+}
@@ -0,0 +1,71 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestUnfulfilledOrders(t *testing.T) {
+	now := time.Now().UTC()
+	ago := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339) }
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fulfillmentStatus"); got != "PENDING" {
+			t.Errorf("expected fulfillmentStatus=PENDING, got %q", got)
+		}
+		w.Write([]byte(fmt.Sprintf(`{"result": [
+			{"id": "fresh", "createdOn": %q, "fulfillmentStatus": "PENDING"},
+			{"id": "two-days", "createdOn": %q, "fulfillmentStatus": "PENDING"},
+			{"id": "ten-hours", "createdOn": %q, "fulfillmentStatus": "PENDING"},
+			{"id": "month", "createdOn": %q, "fulfillmentStatus": "PENDING"},
+			{"id": "test", "createdOn": %q, "fulfillmentStatus": "PENDING", "testmode": true}
+		], "pagination": {"hasNextPage": false}}`,
+			ago(time.Hour), ago(48*time.Hour), ago(10*time.Hour), ago(40*24*time.Hour), ago(48*time.Hour))))
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	report, err := UnfulfilledOrders(context.Background(), config, 4*time.Hour)
+	if err != nil {
+		t.Fatalf("UnfulfilledOrders() unexpected error = %v", err)
+	}
+
+	var ids []string
+	for _, order := range report.Orders {
+		ids = append(ids, order.Order.ID)
+	}
+	if fmt.Sprint(ids) != "[month two-days ten-hours]" {
+		t.Errorf("expected orders oldest first, got %v", ids)
+	}
+
+	wantBuckets := []AgeBucket{
+		{Min: 4 * time.Hour, Max: 24 * time.Hour, Orders: 1},
+		{Min: 24 * time.Hour, Max: 72 * time.Hour, Orders: 1},
+		{Min: 72 * time.Hour, Max: 7 * 24 * time.Hour},
+		{Min: 7 * 24 * time.Hour, Max: 14 * 24 * time.Hour},
+		{Min: 14 * 24 * time.Hour, Max: 30 * 24 * time.Hour},
+		{Min: 30 * 24 * time.Hour, Orders: 1},
+	}
+	if fmt.Sprint(report.Buckets) != fmt.Sprint(wantBuckets) {
+		t.Errorf("Buckets = %v, want %v", report.Buckets, wantBuckets)
+	}
+}
+
+func TestAgeBucketsSkipsEdgesBelowThreshold(t *testing.T) {
+	buckets := ageBuckets(5 * 24 * time.Hour)
+	if len(buckets) != 4 || buckets[0].Min != 5*24*time.Hour || buckets[0].Max != 7*24*time.Hour || buckets[3].Max != 0 {
+		t.Errorf("unexpected buckets %v", buckets)
+	}
+}
@@ -0,0 +1,97 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/orders"
+)
+
+type SKUUnits struct {
+	// SKU is empty for line items sold without one, which are grouped by
+	// VariantID instead.
+	SKU         string
+	VariantID   string
+	ProductID   string
+	ProductName string
+	Units       int
+	// Revenue sums unit price paid times quantity, before order-level
+	// discounts, shipping and tax.
+	Revenue common.Amount
+	// Orders counts the orders that included the SKU.
+	Orders int
+}
+
+// UnitsBySKU totals the units sold and revenue per SKU over the orders created
+// between from and to, leaving out test mode and canceled orders. to defaults
+// to now. Lines are ordered by units sold, highest first, then by SKU. All
+// amounts must share one currency.
+func UnitsBySKU(ctx context.Context, config *common.Config, from, to time.Time) ([]SKUUnits, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("from (%s) must be before to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	totals := make(map[string]*SKUUnits)
+
+	after, before := modifiedSince(from)
+	it := orders.RetrieveAllOrdersIter(ctx, config, orders.ListParams{ModifiedAfter: after, ModifiedBefore: before}.QueryParams())
+	for it.Next() {
+		order := it.Value()
+		if order.TestMode || order.FulfillmentStatus == orders.StatusCanceled || !createdWithin(order.CreatedOn, from, to) {
+			continue
+		}
+
+		counted := make(map[string]bool)
+		for _, item := range order.LineItems {
+			key := "sku:" + item.SKU
+			if item.SKU == "" {
+				key = "variant:" + item.VariantID
+			}
+
+			line, ok := totals[key]
+			if !ok {
+				line = &SKUUnits{SKU: item.SKU, VariantID: item.VariantID, ProductID: item.ProductID, ProductName: item.ProductName}
+				totals[key] = line
+			}
+
+			revenue, err := item.UnitPricePaid.Mul(strconv.Itoa(item.Quantity))
+			if err != nil {
+				return nil, fmt.Errorf("order %s: %w", order.ID, err)
+			}
+			if line.Revenue, err = line.Revenue.Add(revenue); err != nil {
+				return nil, fmt.Errorf("order %s: %w", order.ID, err)
+			}
+			line.Units += item.Quantity
+			if !counted[key] {
+				counted[key] = true
+				line.Orders++
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve orders: %w", err)
+	}
+
+	lines := make([]SKUUnits, 0, len(totals))
+	for _, line := range totals {
+		lines = append(lines, *line)
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].Units != lines[j].Units {
+			return lines[i].Units > lines[j].Units
+		}
+		if lines[i].SKU != lines[j].SKU {
+			return lines[i].SKU < lines[j].SKU
+		}
+		return lines[i].VariantID < lines[j].VariantID
+	})
+
+	return lines, nil
+}
@@ -0,0 +1,65 @@
+package reporting
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestUnitsBySKU(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result": [
+			{"id": "o1", "createdOn": "2024-05-02T10:00:00Z", "lineItems": [
+				{"sku": "MUG", "variantId": "v1", "productId": "p1", "productName": "Mug", "quantity": 2, "unitPricePaid": {"currency": "USD", "value": "15.00"}},
+				{"sku": "MUG", "variantId": "v1", "productId": "p1", "productName": "Mug", "quantity": 1, "unitPricePaid": {"currency": "USD", "value": "12.00"}},
+				{"variantId": "v9", "productId": "p9", "productName": "Gift", "quantity": 1, "unitPricePaid": {"currency": "USD", "value": "5.00"}}
+			]},
+			{"id": "o2", "createdOn": "2024-05-03T10:00:00Z", "lineItems": [
+				{"sku": "TEE", "variantId": "v2", "productId": "p2", "productName": "Tee", "quantity": 1, "unitPricePaid": {"currency": "USD", "value": "20.00"}},
+				{"sku": "MUG", "variantId": "v1", "productId": "p1", "productName": "Mug", "quantity": 1, "unitPricePaid": {"currency": "USD", "value": "15.00"}}
+			]},
+			{"id": "o3", "createdOn": "2024-05-04T10:00:00Z", "fulfillmentStatus": "CANCELED", "lineItems": [
+				{"sku": "TEE", "quantity": 9, "unitPricePaid": {"currency": "USD", "value": "20.00"}}
+			]},
+			{"id": "o4", "createdOn": "2024-04-01T00:00:00Z", "lineItems": [
+				{"sku": "TEE", "quantity": 9, "unitPricePaid": {"currency": "USD", "value": "20.00"}}
+			]}
+		], "pagination": {"hasNextPage": false}}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{APIKey: "test-key", Client: server.Client(), UserAgent: "test-agent", BaseURL: server.URL}
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	lines, err := UnitsBySKU(context.Background(), config, from, to)
+	if err != nil {
+		t.Fatalf("UnitsBySKU() error = %v", err)
+	}
+
+	want := []SKUUnits{
+		{SKU: "MUG", VariantID: "v1", ProductID: "p1", ProductName: "Mug", Units: 4, Revenue: common.Amount{Currency: "USD", Value: "57.00"}, Orders: 2},
+		{SKU: "", VariantID: "v9", ProductID: "p9", ProductName: "Gift", Units: 1, Revenue: common.Amount{Currency: "USD", Value: "5.00"}, Orders: 1},
+		{SKU: "TEE", VariantID: "v2", ProductID: "p2", ProductName: "Tee", Units: 1, Revenue: common.Amount{Currency: "USD", Value: "20.00"}, Orders: 1},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %+v", len(want), lines)
+	}
+	for i := range want {
+		got := lines[i]
+		if got.SKU != want[i].SKU || got.VariantID != want[i].VariantID || got.Units != want[i].Units || got.Orders != want[i].Orders {
+			t.Errorf("line %d = %+v, want %+v", i, got, want[i])
+		}
+		if cmp, err := got.Revenue.Cmp(want[i].Revenue); err != nil || cmp != 0 {
+			t.Errorf("line %d revenue = %v, want %v", i, got.Revenue, want[i].Revenue)
+		}
+	}
+
+	if _, err := UnitsBySKU(context.Background(), config, to, from); err == nil {
+		t.Error("expected an error when from is after to")
+	}
+}
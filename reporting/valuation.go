@@ -0,0 +1,104 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/inventory"
+	"github.com/j-low/gocommerce/products"
+)
+
+type ValuationLine struct {
+	ProductID   string
+	ProductName string
+	VariantID   string
+	SKU         string
+	Descriptor  string
+	Quantity    int
+	UnitPrice   common.Amount
+	Value       common.Amount
+}
+
+type ValuationReport struct {
+	// Lines holds one entry per finite-stock variant, ordered by SKU.
+	Lines []ValuationLine
+	Total common.Amount
+	// Unlimited lists variants with unlimited stock, which have no value.
+	Unlimited []inventory.InventoryRecord
+	// Unmatched lists inventory records with no corresponding product
+	// variant, such as variants deleted since the inventory was read.
+	Unmatched []inventory.InventoryRecord
+}
+
+// InventoryValuation values every variant's stock at its base price by joining
+// the inventory and products APIs. All prices must share one currency.
+func InventoryValuation(ctx context.Context, config *common.Config) (*ValuationReport, error) {
+	type variantInfo struct {
+		product products.Product
+		variant products.ProductVariant
+	}
+	variants := make(map[string]variantInfo)
+
+	productIter := products.RetrieveAllProductsIter(ctx, config, products.ListParams{}.QueryParams())
+	for productIter.Next() {
+		product := productIter.Value()
+		for _, variant := range product.Variants {
+			variants[variant.ID] = variantInfo{product: product, variant: variant}
+		}
+	}
+	if err := productIter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve products: %w", err)
+	}
+
+	report := &ValuationReport{}
+	inventoryIter := inventory.RetrieveAllInventoryIter(ctx, config, inventory.ListParams{}.QueryParams())
+	for inventoryIter.Next() {
+		record := inventoryIter.Value()
+		if record.IsUnlimited {
+			report.Unlimited = append(report.Unlimited, record)
+			continue
+		}
+
+		info, ok := variants[record.VariantID]
+		if !ok {
+			report.Unmatched = append(report.Unmatched, record)
+			continue
+		}
+
+		unitPrice := info.variant.Pricing.BasePrice
+		value, err := unitPrice.Mul(strconv.Itoa(record.Quantity))
+		if err != nil {
+			return nil, fmt.Errorf("variant %s: %w", record.VariantID, err)
+		}
+		if report.Total, err = report.Total.Add(value); err != nil {
+			return nil, fmt.Errorf("variant %s: %w", record.VariantID, err)
+		}
+
+		sku := record.SKU
+		if sku == "" {
+			sku = info.variant.SKU
+		}
+		report.Lines = append(report.Lines, ValuationLine{
+			ProductID:   info.product.ID,
+			ProductName: info.product.Name,
+			VariantID:   record.VariantID,
+			SKU:         sku,
+			Descriptor:  record.Descriptor,
+			Quantity:    record.Quantity,
+			UnitPrice:   unitPrice,
+			Value:       value,
+		})
+	}
+	if err := inventoryIter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve inventory: %w", err)
+	}
+
+	sort.SliceStable(report.Lines, func(i, j int) bool {
+		return report.Lines[i].SKU < report.Lines[j].SKU
+	})
+
+	return report, nil
+}
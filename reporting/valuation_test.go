@@ -0,0 +1,67 @@
+package reporting
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestInventoryValuation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		w.WriteHeader(http.StatusOK)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/commerce/products") && cursor == "":
+			w.Write([]byte(`{"products": [{"id": "p1", "name": "Shirt", "variants": [
+				{"id": "v1", "sku": "SHIRT-M", "pricing": {"basePrice": {"currency": "USD", "value": "20.00"}}},
+				{"id": "v2", "sku": "SHIRT-L", "pricing": {"basePrice": {"currency": "USD", "value": "22.50"}}}
+			]}], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`))
+		case strings.HasSuffix(r.URL.Path, "/commerce/products"):
+			w.Write([]byte(`{"products": [{"id": "p2", "name": "Poster", "variants": [
+				{"id": "v3", "sku": "POSTER", "pricing": {"basePrice": {"currency": "USD", "value": "5.00"}}}
+			]}], "pagination": {"hasNextPage": false}}`))
+		case strings.HasSuffix(r.URL.Path, "/commerce/inventory"):
+			w.Write([]byte(`{"inventory": [
+				{"variantId": "v1", "sku": "SHIRT-M", "descriptor": "Shirt [M]", "quantity": 3},
+				{"variantId": "v2", "sku": "SHIRT-L", "quantity": 2},
+				{"variantId": "v3", "sku": "POSTER", "isUnlimited": true},
+				{"variantId": "v9", "sku": "GONE", "quantity": 4}
+			], "pagination": {"hasNextPage": false}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	report, err := InventoryValuation(context.Background(), config)
+	if err != nil {
+		t.Fatalf("InventoryValuation() unexpected error = %v", err)
+	}
+
+	if report.Total != (common.Amount{Currency: "USD", Value: "105.00"}) {
+		t.Errorf("expected total 105.00 USD, got %+v", report.Total)
+	}
+	if len(report.Lines) != 2 || report.Lines[0].SKU != "SHIRT-L" || report.Lines[0].Value.Value != "45.00" {
+		t.Errorf("unexpected lines %+v", report.Lines)
+	}
+	if report.Lines[1].ProductName != "Shirt" || report.Lines[1].Descriptor != "Shirt [M]" {
+		t.Errorf("expected product details on line, got %+v", report.Lines[1])
+	}
+	if len(report.Unlimited) != 1 || report.Unlimited[0].VariantID != "v3" {
+		t.Errorf("unexpected unlimited %+v", report.Unlimited)
+	}
+	if len(report.Unmatched) != 1 || report.Unmatched[0].VariantID != "v9" {
+		t.Errorf("unexpected unmatched %+v", report.Unmatched)
+	}
+}
@@ -0,0 +1,39 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const maxSpecificTransactionIDs = 50
+
+type BatchOptions struct {
+	// Concurrency is the number of chunks fetched in parallel. Defaults to 1.
+	Concurrency int
+}
+
+// RetrieveManyTransactions fetches any number of documents by splitting
+// transactionIDs into chunks accepted by RetrieveSpecificTransactions.
+// Documents are returned in the order of transactionIDs; duplicate IDs are
+// fetched once and IDs the API does not return are skipped.
+func RetrieveManyTransactions(ctx context.Context, config *common.Config, transactionIDs []string, opts BatchOptions) (*RetrieveSpecificTransactionsResponse, error) {
+	if len(transactionIDs) == 0 {
+		return nil, fmt.Errorf("transactionIDs cannot be empty")
+	}
+
+	items, err := common.FetchAll(ctx, transactionIDs, maxSpecificTransactionIDs, func(ctx context.Context, chunk []string) ([]Document, error) {
+		resp, err := RetrieveSpecificTransactions(ctx, config, chunk)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Documents, nil
+	}, common.FetchOptions{Concurrency: opts.Concurrency})
+	if err != nil {
+		return nil, err
+	}
+
+	documents, _ := common.OrderByIDs(transactionIDs, items, func(document Document) string { return document.ID })
+	return &RetrieveSpecificTransactionsResponse{Documents: documents}, nil
+}
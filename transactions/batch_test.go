@@ -0,0 +1,146 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestRetrieveManyTransactions(t *testing.T) {
+	makeIDs := func(n int) []string {
+		ids := make([]string, n)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("t%03d", n-i)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name           string
+		transactionIDs []string
+		opts           BatchOptions
+		missing        string
+		failChunkOf    string
+		wantCalls      int
+		wantCount      int
+		wantErr        bool
+		errContains    string
+	}{
+		{
+			name:           "single chunk",
+			transactionIDs: makeIDs(3),
+			wantCalls:      1,
+			wantCount:      3,
+		},
+		{
+			name:           "multiple chunks in parallel",
+			transactionIDs: makeIDs(120),
+			opts:           BatchOptions{Concurrency: 3},
+			wantCalls:      3,
+			wantCount:      120,
+		},
+		{
+			name:           "duplicates fetched once",
+			transactionIDs: append(makeIDs(50), "t001", "t002"),
+			wantCalls:      1,
+			wantCount:      50,
+		},
+		{
+			name:           "missing documents are skipped",
+			transactionIDs: makeIDs(5),
+			missing:        "t003",
+			wantCalls:      1,
+			wantCount:      4,
+		},
+		{
+			name:           "chunk failure",
+			transactionIDs: makeIDs(60),
+			failChunkOf:    "t005",
+			wantErr:        true,
+			errContains:    "Transaction lookup failed",
+		},
+		{
+			name:           "no IDs",
+			transactionIDs: nil,
+			wantErr:        true,
+			errContains:    "transactionIDs cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			calls := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				calls++
+				mu.Unlock()
+
+				ids := strings.Split(strings.TrimPrefix(r.URL.Path, "/1.0/commerce/transactions/"), ",")
+				if len(ids) > 50 {
+					t.Errorf("chunk exceeds 50 IDs: %d", len(ids))
+				}
+
+				var documents []string
+				for _, id := range ids {
+					if id == tt.failChunkOf {
+						w.WriteHeader(http.StatusInternalServerError)
+						w.Write([]byte(`{"type":"ERROR","message":"Transaction lookup failed"}`))
+						return
+					}
+					if id != tt.missing {
+						documents = append(documents, fmt.Sprintf(`{"id": %q}`, id))
+					}
+				}
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintf(w, `{"documents": [%s]}`, strings.Join(documents, ","))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			resp, err := RetrieveManyTransactions(context.Background(), config, tt.transactionIDs, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RetrieveManyTransactions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d requests, got %d", tt.wantCalls, calls)
+			}
+			if len(resp.Documents) != tt.wantCount {
+				t.Fatalf("expected %d documents, got %d", tt.wantCount, len(resp.Documents))
+			}
+
+			i := 0
+			for _, id := range tt.transactionIDs[:tt.wantCount] {
+				if id == tt.missing {
+					continue
+				}
+				if resp.Documents[i].ID != id {
+					t.Errorf("expected document %d to be %s, got %s", i, id, resp.Documents[i].ID)
+				}
+				i++
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// ListBetween returns every transaction document modified between from and to,
+// paging through all results. A zero from means the beginning of time and a
+// zero to means now, so the API's both-or-neither rule for
+// modifiedAfter/modifiedBefore is always satisfied.
+func ListBetween(ctx context.Context, config *common.Config, from, to time.Time) ([]Document, error) {
+	params, err := betweenParams(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return RetrieveAllTransactionsIter(ctx, config, params).Collect()
+}
+
+func betweenParams(from, to time.Time) (common.QueryParams, error) {
+	if from.IsZero() && to.IsZero() {
+		return ListParams{}.QueryParams(), nil
+	}
+	if from.IsZero() {
+		from = time.Unix(0, 0)
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.Before(to) {
+		return ListParams{}.QueryParams(), fmt.Errorf("from (%s) must be before to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	return ListParams{ModifiedAfter: from, ModifiedBefore: to}.QueryParams(), nil
+}
@@ -0,0 +1,101 @@
+package transactions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestListBetween(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		from        time.Time
+		to          time.Time
+		wantQuery   string
+		wantIDs     []string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:      "date range",
+			from:      from,
+			to:        to,
+			wantQuery: "modifiedAfter=2024-01-01T00%3A00%3A00Z&modifiedBefore=2024-01-31T23%3A59%3A59Z",
+			wantIDs:   []string{"t1", "t2", "t3"},
+		},
+		{
+			name:      "open-ended start",
+			to:        to,
+			wantQuery: "modifiedAfter=1970-01-01T00%3A00%3A00Z&modifiedBefore=2024-01-31T23%3A59%3A59Z",
+			wantIDs:   []string{"t1", "t2", "t3"},
+		},
+		{
+			name:      "non-UTC times are converted",
+			from:      time.Date(2024, 1, 1, 2, 0, 0, 0, time.FixedZone("EET", 2*60*60)),
+			to:        to,
+			wantQuery: "modifiedAfter=2024-01-01T00%3A00%3A00Z&modifiedBefore=2024-01-31T23%3A59%3A59Z",
+			wantIDs:   []string{"t1", "t2", "t3"},
+		},
+		{
+			name:    "no bounds",
+			wantIDs: []string{"t1", "t2", "t3"},
+		},
+		{
+			name:        "inverted range",
+			from:        to,
+			to:          from,
+			wantErr:     true,
+			errContains: "must be before",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				cursor := r.URL.Query().Get("cursor")
+				if cursor == "" && r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("expected query %s, got %s", tt.wantQuery, r.URL.RawQuery)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(pagedTransactions[cursor]))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			documents, err := ListBetween(context.Background(), config, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListBetween() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && tt.errContains != "" {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			var ids []string
+			for _, document := range documents {
+				ids = append(ids, document.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected documents %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
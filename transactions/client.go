@@ -17,6 +17,10 @@ func RetrieveAllTransactions(ctx context.Context, config *common.Config, params
 		return nil, fmt.Errorf("invalid query parameters: %w", err)
 	}
 
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, TransactionsAPIVersion, "commerce/transactions")
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -45,25 +49,24 @@ func RetrieveAllTransactions(ctx context.Context, config *common.Config, params
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "transactions"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveAllTransactions", u.String(), body, resp.StatusCode)
+		body, err := common.ReadBody(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, common.ParseResponseError("RetrieveAllTransactions", u.String(), resp, body)
 	}
 
 	var response RetrieveAllTransactionsResponse
-	if err := json.Unmarshal(body, &response); err != nil {
+	if err := common.DecodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
@@ -74,11 +77,15 @@ func RetrieveSpecificTransactions(ctx context.Context, config *common.Config, tr
 	if len(transactionIDs) == 0 {
 		return nil, fmt.Errorf("transactionIDs cannot be empty")
 	}
-	if len(transactionIDs) > 50 {
+	if len(transactionIDs) > maxSpecificTransactionIDs {
 		return nil, fmt.Errorf("transactionIDs cannot exceed 50 IDs")
 	}
 
 	ids := url.PathEscape(strings.Join(transactionIDs, ","))
+	if err := config.ValidateFor(common.CredentialAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	baseURL, err := common.BuildBaseURL(config, TransactionsAPIVersion, fmt.Sprintf("commerce/transactions/%s", ids))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
@@ -95,9 +102,9 @@ func RetrieveSpecificTransactions(ctx context.Context, config *common.Config, tr
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "transactions"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -109,7 +116,7 @@ func RetrieveSpecificTransactions(ctx context.Context, config *common.Config, tr
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveSpecificTransactions", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("RetrieveSpecificTransactions", baseURL, resp, body)
 	}
 
 	var response RetrieveSpecificTransactionsResponse
@@ -0,0 +1,254 @@
+package transactions
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV        ExportFormat = "csv"
+	ExportFormatQuickBooks ExportFormat = "quickbooks"
+	ExportFormatXero       ExportFormat = "xero"
+)
+
+const (
+	EntryPayment   = "PAYMENT"
+	EntryRefund    = "REFUND"
+	EntryFee       = "PROCESSING_FEE"
+	EntryFeeRefund = "FEE_REFUND"
+	EntryTax       = "TAX"
+)
+
+type ExportOptions struct {
+	// Format selects the column layout. Defaults to ExportFormatCSV.
+	Format ExportFormat
+	From   time.Time
+	To     time.Time
+	// Filter limits the export to matching documents.
+	Filter Filter
+}
+
+// Export streams transaction documents modified between opts.From and opts.To
+// to w as one row per payment, refund, processing fee, fee refund and tax.
+// Money leaving the merchant (refunds and fees) is negative. The generic
+// layout carries every entry, voided documents included, along with fee
+// amounts in the gateway's currency. The QuickBooks and Xero layouts are bank
+// statement imports, so they skip voided documents and taxes, which move no
+// money.
+func Export(ctx context.Context, config *common.Config, w io.Writer, opts ExportOptions) error {
+	format := opts.Format
+	if format == "" {
+		format = ExportFormatCSV
+	}
+
+	var header []string
+	var writeEntry func(*csv.Writer, Document, exportEntry) error
+	switch format {
+	case ExportFormatCSV:
+		header, writeEntry = genericExportHeader, writeGenericEntry
+	case ExportFormatQuickBooks:
+		header, writeEntry = quickBooksExportHeader, writeQuickBooksEntry
+	case ExportFormatXero:
+		header, writeEntry = xeroExportHeader, writeXeroEntry
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	params, err := betweenParams(opts.From, opts.To)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	it := RetrieveFilteredTransactionsIter(ctx, config, params, opts.Filter)
+	for it.Next() {
+		document := it.Value()
+		if document.Voided && format != ExportFormatCSV {
+			continue
+		}
+
+		entries, err := documentEntries(document)
+		if err != nil {
+			return fmt.Errorf("failed to export document %s: %w", document.ID, err)
+		}
+		for _, entry := range entries {
+			if entry.kind == EntryTax && format != ExportFormatCSV {
+				continue
+			}
+			if err := writeEntry(cw, document, entry); err != nil {
+				return fmt.Errorf("failed to export document %s: %w", document.ID, err)
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to retrieve transactions: %w", err)
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+type exportEntry struct {
+	kind          string
+	id            string
+	date          string
+	provider      string
+	description   string
+	amount        common.Amount
+	gatewayAmount common.Amount
+	exchangeRate  string
+}
+
+// documentEntries flattens a document into signed ledger entries, in the
+// order payments, their refunds, fees and fee refunds, then taxes.
+func documentEntries(document Document) ([]exportEntry, error) {
+	var entries []exportEntry
+
+	for _, payment := range document.Payments {
+		entries = append(entries, exportEntry{
+			kind: EntryPayment, id: payment.ID, date: payment.PaidOn, provider: payment.Provider,
+			description: withReference("Payment", payment.ExternalTransactionID), amount: payment.Amount,
+		})
+
+		for _, refund := range payment.Refunds {
+			amount, err := negate(refund.Amount)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, exportEntry{
+				kind: EntryRefund, id: refund.ID, date: refund.RefundedOn, provider: payment.Provider,
+				description: withReference("Refund", refund.ExternalTransactionID), amount: amount,
+			})
+		}
+
+		for _, fee := range payment.ProcessingFees {
+			amount, err := negate(fee.Amount)
+			if err != nil {
+				return nil, err
+			}
+			gatewayAmount, err := negate(fee.AmountGatewayCurrency)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, exportEntry{
+				kind: EntryFee, id: fee.ID, date: payment.PaidOn, provider: payment.Provider,
+				description: payment.Provider + " processing fee", amount: amount,
+				gatewayAmount: gatewayAmount, exchangeRate: fee.ExchangeRate,
+			})
+
+			for _, feeRefund := range fee.FeeRefunds {
+				entries = append(entries, exportEntry{
+					kind: EntryFeeRefund, id: feeRefund.ID, date: feeRefund.RefundedOn, provider: payment.Provider,
+					description: payment.Provider + " processing fee refund", amount: feeRefund.Amount,
+					gatewayAmount: feeRefund.AmountGatewayCurrency, exchangeRate: feeRefund.ExchangeRate,
+				})
+			}
+		}
+	}
+
+	addTaxes := func(lineID string, taxes []Tax) {
+		for _, tax := range taxes {
+			description := tax.Name
+			if tax.Jurisdiction != "" {
+				description += " (" + tax.Jurisdiction + ")"
+			}
+			entries = append(entries, exportEntry{
+				kind: EntryTax, id: lineID, date: document.CreatedOn,
+				description: description, amount: tax.Amount, exchangeRate: tax.Rate,
+			})
+		}
+	}
+	for _, item := range document.SalesLineItems {
+		addTaxes(item.ID, item.Taxes)
+	}
+	for _, item := range document.ShippingLineItems {
+		addTaxes(item.ID, item.Taxes)
+	}
+
+	return entries, nil
+}
+
+var genericExportHeader = []string{
+	"document_id", "sales_order_id", "document_created_on", "voided", "customer_email",
+	"entry_type", "entry_id", "date", "provider", "description", "currency", "amount",
+	"gateway_currency", "gateway_amount", "rate",
+}
+
+func writeGenericEntry(cw *csv.Writer, document Document, entry exportEntry) error {
+	return cw.Write([]string{
+		document.ID, stringValue(document.SalesOrderID), document.CreatedOn, fmt.Sprint(document.Voided), stringValue(document.CustomerEmail),
+		entry.kind, entry.id, entry.date, entry.provider, entry.description, entry.amount.Currency, entry.amount.Value,
+		entry.gatewayAmount.Currency, entry.gatewayAmount.Value, entry.exchangeRate,
+	})
+}
+
+var quickBooksExportHeader = []string{"Date", "Description", "Amount", "Currency"}
+
+func writeQuickBooksEntry(cw *csv.Writer, document Document, entry exportEntry) error {
+	return cw.Write([]string{
+		exportDate(entry.date), entryDescription(document, entry), entry.amount.Value, entry.amount.Currency,
+	})
+}
+
+var xeroExportHeader = []string{"*Date", "*Amount", "Payee", "Description", "Reference", "Currency"}
+
+func writeXeroEntry(cw *csv.Writer, document Document, entry exportEntry) error {
+	payee := stringValue(document.CustomerEmail)
+	if entry.kind == EntryFee || entry.kind == EntryFeeRefund {
+		payee = entry.provider
+	}
+	return cw.Write([]string{
+		exportDate(entry.date), entry.amount.Value, payee, entryDescription(document, entry), document.ID, entry.amount.Currency,
+	})
+}
+
+func entryDescription(document Document, entry exportEntry) string {
+	if document.SalesOrderID == nil {
+		return entry.description
+	}
+	return fmt.Sprintf("%s (order %s)", entry.description, *document.SalesOrderID)
+}
+
+func withReference(label, reference string) string {
+	if reference == "" {
+		return label
+	}
+	return label + " " + reference
+}
+
+func negate(amount common.Amount) (common.Amount, error) {
+	if amount.Value == "" {
+		return amount, nil
+	}
+	negated, err := common.Amount{}.Sub(amount)
+	if err != nil {
+		return common.Amount{}, err
+	}
+	return negated, nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func exportDate(timestamp string) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return timestamp
+	}
+	return t.UTC().Format("2006-01-02")
+}
@@ -0,0 +1,154 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+const exportTransactionsPage = `{
+	"documents": [
+		{
+			"id": "t1",
+			"createdOn": "2024-03-05T14:00:00Z",
+			"customerEmail": "jane@example.com",
+			"salesOrderId": "o1",
+			"payments": [{
+				"id": "p1",
+				"amount": {"currency": "USD", "value": "28.00"},
+				"provider": "STRIPE",
+				"paidOn": "2024-03-05T14:00:01Z",
+				"externalTransactionId": "ch_1",
+				"refunds": [{"id": "r1", "amount": {"currency": "USD", "value": "5.00"}, "refundedOn": "2024-03-07T10:00:00Z", "externalTransactionId": "re_1"}],
+				"processingFees": [{
+					"id": "f1",
+					"amount": {"currency": "USD", "value": "1.11"},
+					"amountGatewayCurrency": {"currency": "EUR", "value": "1.02"},
+					"exchangeRate": "0.92",
+					"feeRefunds": [{"id": "fr1", "amount": {"currency": "USD", "value": "0.15"}, "amountGatewayCurrency": {"currency": "EUR", "value": "0.14"}, "exchangeRate": "0.92", "refundedOn": "2024-03-07T10:00:00Z"}]
+				}]
+			}],
+			"salesLineItems": [{"id": "li1", "taxes": [{"amount": {"currency": "USD", "value": "1.00"}, "rate": "0.04", "name": "Sales Tax", "jurisdiction": "NY"}]}]
+		},
+		{
+			"id": "t2",
+			"createdOn": "2024-03-06T09:00:00Z",
+			"voided": true,
+			"payments": [{"id": "p2", "amount": {"currency": "USD", "value": "9.00"}, "provider": "SQUARE", "paidOn": "2024-03-06T09:00:00Z"}]
+		}
+	],
+	"pagination": {"hasNextPage": false}
+}`
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        ExportOptions
+		wantHeader  string
+		wantRows    []string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:       "generic csv",
+			opts:       ExportOptions{},
+			wantHeader: strings.Join(genericExportHeader, ","),
+			wantRows: []string{
+				"t1,o1,2024-03-05T14:00:00Z,false,jane@example.com,PAYMENT,p1,2024-03-05T14:00:01Z,STRIPE,Payment ch_1,USD,28.00,,,",
+				"t1,o1,2024-03-05T14:00:00Z,false,jane@example.com,REFUND,r1,2024-03-07T10:00:00Z,STRIPE,Refund re_1,USD,-5.00,,,",
+				"t1,o1,2024-03-05T14:00:00Z,false,jane@example.com,PROCESSING_FEE,f1,2024-03-05T14:00:01Z,STRIPE,STRIPE processing fee,USD,-1.11,EUR,-1.02,0.92",
+				"t1,o1,2024-03-05T14:00:00Z,false,jane@example.com,FEE_REFUND,fr1,2024-03-07T10:00:00Z,STRIPE,STRIPE processing fee refund,USD,0.15,EUR,0.14,0.92",
+				"t1,o1,2024-03-05T14:00:00Z,false,jane@example.com,TAX,li1,2024-03-05T14:00:00Z,,Sales Tax (NY),USD,1.00,,,0.04",
+				"t2,,2024-03-06T09:00:00Z,true,,PAYMENT,p2,2024-03-06T09:00:00Z,SQUARE,Payment,USD,9.00,,,",
+			},
+		},
+		{
+			name:       "quickbooks",
+			opts:       ExportOptions{Format: ExportFormatQuickBooks},
+			wantHeader: strings.Join(quickBooksExportHeader, ","),
+			wantRows: []string{
+				"2024-03-05,Payment ch_1 (order o1),28.00,USD",
+				"2024-03-07,Refund re_1 (order o1),-5.00,USD",
+				"2024-03-05,STRIPE processing fee (order o1),-1.11,USD",
+				"2024-03-07,STRIPE processing fee refund (order o1),0.15,USD",
+			},
+		},
+		{
+			name:       "xero",
+			opts:       ExportOptions{Format: ExportFormatXero},
+			wantHeader: strings.Join(xeroExportHeader, ","),
+			wantRows: []string{
+				"2024-03-05,28.00,jane@example.com,Payment ch_1 (order o1),t1,USD",
+				"2024-03-07,-5.00,jane@example.com,Refund re_1 (order o1),t1,USD",
+				"2024-03-05,-1.11,STRIPE,STRIPE processing fee (order o1),t1,USD",
+				"2024-03-07,0.15,STRIPE,STRIPE processing fee refund (order o1),t1,USD",
+			},
+		},
+		{
+			name:       "filtered by provider",
+			opts:       ExportOptions{Filter: Filter{Provider: "SQUARE"}},
+			wantHeader: strings.Join(genericExportHeader, ","),
+			wantRows: []string{
+				"t2,,2024-03-06T09:00:00Z,true,,PAYMENT,p2,2024-03-06T09:00:00Z,SQUARE,Payment,USD,9.00,,,",
+			},
+		},
+		{
+			name:        "unsupported format",
+			opts:        ExportOptions{Format: "ofx"},
+			wantErr:     true,
+			errContains: "unsupported export format: ofx",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(exportTransactionsPage))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			var buf bytes.Buffer
+			err := Export(context.Background(), config, &buf, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Export() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			records, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("failed to parse export: %v", err)
+			}
+			if got := strings.Join(records[0], ","); got != tt.wantHeader {
+				t.Errorf("expected header %s, got %s", tt.wantHeader, got)
+			}
+			if len(records)-1 != len(tt.wantRows) {
+				t.Fatalf("expected %d rows, got %d: %v", len(tt.wantRows), len(records)-1, records[1:])
+			}
+			for i, want := range tt.wantRows {
+				if got := strings.Join(records[i+1], ","); got != want {
+					t.Errorf("row %d:\nwant %s\ngot  %s", i, want, got)
+				}
+			}
+		})
+	}
+}
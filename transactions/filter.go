@@ -0,0 +1,72 @@
+package transactions
+
+import (
+	"context"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Filter selects documents client-side, since the API offers no such query
+// parameters. The zero Filter matches every document; set fields narrow the
+// match and must all hold.
+type Filter struct {
+	// VoidedOnly matches voided documents.
+	VoidedOnly bool
+	// RefundedOnly matches documents with at least one refunded payment.
+	RefundedOnly bool
+	// Provider matches documents with a payment through this provider, such
+	// as "STRIPE" or "PAYPAL".
+	Provider string
+	// GiftCardOnly matches documents with a payment made by gift card.
+	GiftCardOnly bool
+}
+
+func (f Filter) Match(document Document) bool {
+	if f.VoidedOnly && !document.Voided {
+		return false
+	}
+	if f.RefundedOnly && !anyPayment(document, func(p Payment) bool {
+		return len(p.Refunds) > 0 || !p.RefundedAmount.IsZero()
+	}) {
+		return false
+	}
+	if f.Provider != "" && !anyPayment(document, func(p Payment) bool {
+		return p.Provider == f.Provider
+	}) {
+		return false
+	}
+	if f.GiftCardOnly && !anyPayment(document, func(p Payment) bool {
+		return p.GiftCardID != nil && *p.GiftCardID != ""
+	}) {
+		return false
+	}
+	return true
+}
+
+func anyPayment(document Document, match func(Payment) bool) bool {
+	for _, payment := range document.Payments {
+		if match(payment) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetrieveFilteredTransactionsIter is RetrieveAllTransactionsIter yielding
+// only the documents matched by filter.
+func RetrieveFilteredTransactionsIter(ctx context.Context, config *common.Config, params common.QueryParams, filter Filter) *common.Iterator[Document] {
+	return common.NewIterator(ctx, func(ctx context.Context, cursor string) ([]Document, common.Pagination, error) {
+		resp, err := RetrieveAllTransactions(ctx, config, common.PageParams(params, cursor))
+		if err != nil {
+			return nil, common.Pagination{}, err
+		}
+
+		matched := resp.Documents[:0]
+		for _, document := range resp.Documents {
+			if filter.Match(document) {
+				matched = append(matched, document)
+			}
+		}
+		return matched, resp.Pagination, nil
+	})
+}
@@ -0,0 +1,77 @@
+package transactions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/internal/pagedtest"
+)
+
+func TestFilterMatch(t *testing.T) {
+	giftCardID := "gc-1"
+	refunded := Document{ID: "refunded", Payments: []Payment{{Provider: "STRIPE", RefundedAmount: common.Amount{Currency: "USD", Value: "5.00"}}}}
+	voided := Document{ID: "voided", Voided: true, Payments: []Payment{{Provider: "PAYPAL"}}}
+	giftCard := Document{ID: "gift-card", Payments: []Payment{{Provider: "STRIPE"}, {Provider: "GIFT_CARD", GiftCardID: &giftCardID}}}
+	plain := Document{ID: "plain", Payments: []Payment{{Provider: "STRIPE", RefundedAmount: common.Amount{Currency: "USD", Value: "0.00"}}}}
+	documents := []Document{refunded, voided, giftCard, plain}
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		wantIDs []string
+	}{
+		{"zero filter", Filter{}, []string{"refunded", "voided", "gift-card", "plain"}},
+		{"voided only", Filter{VoidedOnly: true}, []string{"voided"}},
+		{"refunded only", Filter{RefundedOnly: true}, []string{"refunded"}},
+		{"provider", Filter{Provider: "STRIPE"}, []string{"refunded", "gift-card", "plain"}},
+		{"gift card only", Filter{GiftCardOnly: true}, []string{"gift-card"}},
+		{"combined", Filter{Provider: "STRIPE", RefundedOnly: true}, []string{"refunded"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			for _, document := range documents {
+				if tt.filter.Match(document) {
+					ids = append(ids, document.ID)
+				}
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
+
+func TestRetrieveFilteredTransactionsIter(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"documents": [{"id": "t1"}, {"id": "t2"}], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`,
+		"c2": `{"documents": [{"id": "t3", "voided": true}], "pagination": {"hasNextPage": true, "nextPageCursor": "c3"}}`,
+		"c3": `{"documents": [{"id": "t4"}, {"id": "t5", "voided": true}], "pagination": {"hasNextPage": false}}`,
+	}
+
+	server := pagedtest.NewServer(t, pages)
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	documents, err := RetrieveFilteredTransactionsIter(context.Background(), config, common.QueryParams{}, Filter{VoidedOnly: true}).Collect()
+	if err != nil {
+		t.Fatalf("unexpected error = %v", err)
+	}
+
+	var ids []string
+	for _, document := range documents {
+		ids = append(ids, document.ID)
+	}
+	if strings.Join(ids, ",") != "t3,t5" {
+		t.Errorf("expected [t3 t5], got %v", ids)
+	}
+}
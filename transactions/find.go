@@ -0,0 +1,45 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Window bounds a scan by modification time, with the same open-ended rules as
+// ListBetween: a zero From means the beginning of time and a zero To means now.
+type Window struct {
+	From time.Time
+	To   time.Time
+}
+
+// FindBySalesOrderID returns the documents modified within window whose
+// salesOrderId is orderID. The API cannot filter by order, so every document
+// in the window is paged through; a tight window keeps the scan short. It
+// returns an empty slice, not an error, when nothing matches.
+func FindBySalesOrderID(ctx context.Context, config *common.Config, orderID string, window Window) ([]Document, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("orderID is required")
+	}
+
+	params, err := betweenParams(window.From, window.To)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := []Document{}
+	it := RetrieveAllTransactionsIter(ctx, config, params)
+	for it.Next() {
+		document := it.Value()
+		if document.SalesOrderID != nil && *document.SalesOrderID == orderID {
+			documents = append(documents, document)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
@@ -0,0 +1,84 @@
+package transactions
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/internal/pagedtest"
+)
+
+func TestFindBySalesOrderID(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"documents": [{"id": "t1", "salesOrderId": "o1"}, {"id": "t2", "salesOrderId": "o2"}, {"id": "t3"}], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`,
+		"c2": `{"documents": [{"id": "t4", "salesOrderId": "o1"}], "pagination": {"hasNextPage": false}}`,
+	}
+
+	tests := []struct {
+		name        string
+		orderID     string
+		window      Window
+		wantIDs     []string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:    "matches across pages",
+			orderID: "o1",
+			window:  Window{From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			wantIDs: []string{"t1", "t4"},
+		},
+		{
+			name:    "no matches",
+			orderID: "o9",
+		},
+		{
+			name:        "missing order ID",
+			wantErr:     true,
+			errContains: "orderID is required",
+		},
+		{
+			name:        "inverted window",
+			orderID:     "o1",
+			window:      Window{From: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			wantErr:     true,
+			errContains: "must be before",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := pagedtest.NewServer(t, pages)
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			documents, err := FindBySalesOrderID(context.Background(), config, tt.orderID, tt.window)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindBySalesOrderID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			var ids []string
+			for _, document := range documents {
+				ids = append(ids, document.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected documents %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package transactions
+
+import (
+	"context"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// RetrieveAllTransactionsIter returns an iterator over every transaction
+// document matching params, following pagination cursors automatically.
+func RetrieveAllTransactionsIter(ctx context.Context, config *common.Config, params common.QueryParams) *common.Iterator[Document] {
+	return common.NewIterator(ctx, func(ctx context.Context, cursor string) ([]Document, common.Pagination, error) {
+		resp, err := RetrieveAllTransactions(ctx, config, common.PageParams(params, cursor))
+		if err != nil {
+			return nil, common.Pagination{}, err
+		}
+		return resp.Documents, resp.Pagination, nil
+	})
+}
@@ -0,0 +1,78 @@
+package transactions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/internal/pagedtest"
+)
+
+var pagedTransactions = map[string]string{
+	"":   `{"documents": [{"id": "t1"}, {"id": "t2"}], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`,
+	"c2": `{"documents": [{"id": "t3"}], "pagination": {"hasNextPage": false}}`,
+}
+
+func TestRetrieveAllTransactionsIter(t *testing.T) {
+	tests := []struct {
+		name        string
+		pages       map[string]string
+		params      common.QueryParams
+		wantIDs     []string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:  "follows cursors",
+			pages: pagedTransactions,
+			params: common.QueryParams{
+				ModifiedAfter:  "2024-01-01T00:00:00Z",
+				ModifiedBefore: "2024-02-01T00:00:00Z",
+			},
+			wantIDs: []string{"t1", "t2", "t3"},
+		},
+		{
+			name: "error on later page",
+			pages: map[string]string{
+				"": `{"documents": [{"id": "t1"}], "pagination": {"hasNextPage": true, "nextPageCursor": "bad"}}`,
+			},
+			wantIDs:     []string{"t1"},
+			wantErr:     true,
+			errContains: "Invalid cursor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := pagedtest.NewServer(t, tt.pages)
+			defer server.Close()
+
+			config := &common.Config{
+				APIKey:    "test-key",
+				Client:    server.Client(),
+				UserAgent: "test-agent",
+				BaseURL:   server.URL,
+			}
+
+			it := RetrieveAllTransactionsIter(context.Background(), config, tt.params)
+			var ids []string
+			for it.Next() {
+				ids = append(ids, it.Value().ID)
+			}
+
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected documents %v, got %v", tt.wantIDs, ids)
+			}
+
+			err := it.Err()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Err() = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// ListParams is a typed alternative to common.QueryParams for
+// RetrieveAllTransactions. Zero times are omitted; non-zero times are sent as
+// RFC 3339 UTC strings.
+type ListParams struct {
+	Cursor         string
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+}
+
+// Validate checks the parameters before a request is made.
+func (p ListParams) Validate() error {
+	if p.Cursor != "" {
+		return common.ValidateCursor(p.QueryParams())
+	}
+	return common.ValidateModifiedRange(p.ModifiedAfter, p.ModifiedBefore)
+}
+
+func (p ListParams) QueryParams() common.QueryParams {
+	return common.QueryParams{
+		Cursor:         p.Cursor,
+		ModifiedAfter:  common.FormatTime(p.ModifiedAfter),
+		ModifiedBefore: common.FormatTime(p.ModifiedBefore),
+	}
+}
+
+func RetrieveAllTransactionsWithParams(ctx context.Context, config *common.Config, params ListParams) (*RetrieveAllTransactionsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid query parameters: %w", err)
+	}
+	return RetrieveAllTransactions(ctx, config, params.QueryParams())
+}
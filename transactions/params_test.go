@@ -0,0 +1,55 @@
+package transactions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestListParams(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		params  ListParams
+		want    common.QueryParams
+		wantErr string
+	}{
+		{
+			name:   "times are formatted as UTC RFC 3339",
+			params: ListParams{ModifiedAfter: jan, ModifiedBefore: feb},
+			want:   common.QueryParams{ModifiedAfter: "2024-01-01T05:00:00Z", ModifiedBefore: "2024-02-01T00:00:00Z"},
+		},
+		{
+			name:    "cursor with range",
+			params:  ListParams{Cursor: "abc", ModifiedAfter: jan, ModifiedBefore: feb},
+			wantErr: "cannot use cursor alongside other query parameters",
+		},
+		{
+			name:    "range out of order",
+			params:  ListParams{ModifiedAfter: feb, ModifiedBefore: jan},
+			wantErr: "must be before modifiedBefore",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got := tt.params.QueryParams(); got != tt.want {
+				t.Errorf("QueryParams() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Settlement totals the payments sharing one provider transaction, the unit
+// payout reports from providers such as Stripe and PayPal are itemized by.
+// Net is what the provider should settle: gross less refunds and net fees.
+type Settlement struct {
+	Provider              string
+	ExternalTransactionID string
+	DocumentIDs           []string
+	Gross                 common.Amount
+	Refunded              common.Amount
+	Fees                  common.Amount
+	Net                   common.Amount
+}
+
+type ProviderPayout struct {
+	Provider string
+	// Settlements are ordered by external transaction ID.
+	Settlements []Settlement
+	Gross       common.Amount
+	Refunded    common.Amount
+	Fees        common.Amount
+	Net         common.Amount
+}
+
+// PayoutMismatch flags a document whose amounts disagree with each other, so
+// its settlement cannot be trusted without a closer look.
+type PayoutMismatch struct {
+	DocumentID string
+	PaymentID  string
+	Reason     string
+	Expected   common.Amount
+	Actual     common.Amount
+}
+
+type PayoutReport struct {
+	// Providers are ordered by provider name.
+	Providers  []ProviderPayout
+	Mismatches []PayoutMismatch
+}
+
+// ReconcilePayouts groups the payments and fees of the non-voided documents
+// modified within window by provider and external transaction ID, for matching
+// against provider payout reports. Each document is also checked for internal
+// consistency: refunds must sum to the payment's refunded amount, the payment's
+// net amount must equal its amount less refunds, and payments must sum to the
+// document total.
+func ReconcilePayouts(ctx context.Context, config *common.Config, window Window) (*PayoutReport, error) {
+	params, err := betweenParams(window.From, window.To)
+	if err != nil {
+		return nil, err
+	}
+
+	type settlementKey struct {
+		provider, externalID string
+	}
+	settlements := make(map[settlementKey]*Settlement)
+	report := &PayoutReport{}
+
+	it := RetrieveAllTransactionsIter(ctx, config, params)
+	for it.Next() {
+		document := it.Value()
+		if document.Voided {
+			continue
+		}
+
+		mismatches, err := checkDocument(document)
+		if err != nil {
+			return nil, fmt.Errorf("document %s: %w", document.ID, err)
+		}
+		report.Mismatches = append(report.Mismatches, mismatches...)
+
+		for _, payment := range document.Payments {
+			key := settlementKey{payment.Provider, payment.ExternalTransactionID}
+			settlement, ok := settlements[key]
+			if !ok {
+				settlement = &Settlement{Provider: payment.Provider, ExternalTransactionID: payment.ExternalTransactionID}
+				settlements[key] = settlement
+			}
+			if n := len(settlement.DocumentIDs); n == 0 || settlement.DocumentIDs[n-1] != document.ID {
+				settlement.DocumentIDs = append(settlement.DocumentIDs, document.ID)
+			}
+
+			payout, err := paymentPayout(payment)
+			if err != nil {
+				return nil, fmt.Errorf("document %s: payment %s: %w", document.ID, payment.ID, err)
+			}
+			if err := addAll([]amountSum{
+				{&settlement.Gross, payment.Amount},
+				{&settlement.Refunded, payment.RefundedAmount},
+				{&settlement.Fees, payout.fees},
+				{&settlement.Net, payout.net},
+			}); err != nil {
+				return nil, fmt.Errorf("document %s: payment %s: %w", document.ID, payment.ID, err)
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	providers := make(map[string]*ProviderPayout)
+	for _, settlement := range settlements {
+		provider, ok := providers[settlement.Provider]
+		if !ok {
+			provider = &ProviderPayout{Provider: settlement.Provider}
+			providers[settlement.Provider] = provider
+		}
+		provider.Settlements = append(provider.Settlements, *settlement)
+		if err := addAll([]amountSum{
+			{&provider.Gross, settlement.Gross},
+			{&provider.Refunded, settlement.Refunded},
+			{&provider.Fees, settlement.Fees},
+			{&provider.Net, settlement.Net},
+		}); err != nil {
+			return nil, fmt.Errorf("provider %s: %w", settlement.Provider, err)
+		}
+	}
+
+	for _, provider := range providers {
+		sort.Slice(provider.Settlements, func(i, j int) bool {
+			return provider.Settlements[i].ExternalTransactionID < provider.Settlements[j].ExternalTransactionID
+		})
+		report.Providers = append(report.Providers, *provider)
+	}
+	sort.Slice(report.Providers, func(i, j int) bool {
+		return report.Providers[i].Provider < report.Providers[j].Provider
+	})
+
+	return report, nil
+}
+
+func checkDocument(document Document) ([]PayoutMismatch, error) {
+	var mismatches []PayoutMismatch
+	flag := func(paymentID, reason string, expected, actual common.Amount) error {
+		c, err := expected.Cmp(actual)
+		if err != nil {
+			return err
+		}
+		if c != 0 {
+			mismatches = append(mismatches, PayoutMismatch{
+				DocumentID: document.ID,
+				PaymentID:  paymentID,
+				Reason:     reason,
+				Expected:   expected,
+				Actual:     actual,
+			})
+		}
+		return nil
+	}
+
+	var payments common.Amount
+	for _, payment := range document.Payments {
+		var refunds common.Amount
+		for _, refund := range payment.Refunds {
+			var err error
+			if refunds, err = refunds.Add(refund.Amount); err != nil {
+				return nil, err
+			}
+		}
+		if err := flag(payment.ID, "refunds do not sum to refunded amount", refunds, payment.RefundedAmount); err != nil {
+			return nil, err
+		}
+
+		if payment.NetAmount.Value != "" {
+			net, err := payment.Amount.Sub(payment.RefundedAmount)
+			if err != nil {
+				return nil, err
+			}
+			if err := flag(payment.ID, "net amount is not amount less refunds", net, payment.NetAmount); err != nil {
+				return nil, err
+			}
+		}
+
+		var err error
+		if payments, err = payments.Add(payment.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	if document.Total.Value != "" {
+		if err := flag("", "payments do not sum to document total", document.Total, payments); err != nil {
+			return nil, err
+		}
+	}
+
+	return mismatches, nil
+}
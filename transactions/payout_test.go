@@ -0,0 +1,81 @@
+package transactions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/internal/pagedtest"
+)
+
+func TestReconcilePayouts(t *testing.T) {
+	pages := map[string]string{
+		"": `{"documents": [
+			{"id": "t1", "total": {"currency": "USD", "value": "20.00"}, "payments": [
+				{"id": "p1", "provider": "STRIPE", "externalTransactionId": "ch_1",
+				 "amount": {"currency": "USD", "value": "20.00"}, "refundedAmount": {"currency": "USD", "value": "5.00"},
+				 "netAmount": {"currency": "USD", "value": "15.00"},
+				 "refunds": [{"id": "r1", "amount": {"currency": "USD", "value": "5.00"}}],
+				 "processingFees": [{"id": "f1", "netAmount": {"currency": "USD", "value": "0.88"}}]}
+			]},
+			{"id": "t2", "total": {"currency": "USD", "value": "12.00"}, "payments": [
+				{"id": "p2", "provider": "PAYPAL", "externalTransactionId": "pp_1",
+				 "amount": {"currency": "USD", "value": "10.00"}, "refundedAmount": {"currency": "USD", "value": "2.00"},
+				 "netAmount": {"currency": "USD", "value": "10.00"},
+				 "processingFees": [{"id": "f2", "netAmount": {"currency": "USD", "value": "0.50"}}]}
+			]},
+			{"id": "t3", "voided": true, "payments": [{"id": "p3", "provider": "STRIPE", "externalTransactionId": "ch_9", "amount": {"currency": "USD", "value": "99.00"}}]}
+		], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`,
+		"c2": `{"documents": [
+			{"id": "t4", "payments": [
+				{"id": "p4", "provider": "STRIPE", "externalTransactionId": "ch_0",
+				 "amount": {"currency": "USD", "value": "30.00"},
+				 "processingFees": [{"id": "f4", "netAmount": {"currency": "USD", "value": "1.17"}}]}
+			]}
+		], "pagination": {"hasNextPage": false}}`,
+	}
+
+	server := pagedtest.NewServer(t, pages)
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	report, err := ReconcilePayouts(context.Background(), config, Window{})
+	if err != nil {
+		t.Fatalf("ReconcilePayouts() unexpected error = %v", err)
+	}
+
+	if len(report.Providers) != 2 || report.Providers[0].Provider != "PAYPAL" || report.Providers[1].Provider != "STRIPE" {
+		t.Fatalf("unexpected providers %+v", report.Providers)
+	}
+
+	stripe := report.Providers[1]
+	if len(stripe.Settlements) != 2 || stripe.Settlements[0].ExternalTransactionID != "ch_0" || stripe.Settlements[1].ExternalTransactionID != "ch_1" {
+		t.Errorf("unexpected STRIPE settlements %+v", stripe.Settlements)
+	}
+	if stripe.Net.Value != "42.95" || stripe.Fees.Value != "2.05" || stripe.Gross.Value != "50.00" {
+		t.Errorf("unexpected STRIPE totals %+v", stripe)
+	}
+	if got := stripe.Settlements[1].DocumentIDs; len(got) != 1 || got[0] != "t1" {
+		t.Errorf("expected settlement ch_1 to reference t1, got %v", got)
+	}
+
+	wantMismatches := []PayoutMismatch{
+		{DocumentID: "t2", PaymentID: "p2", Reason: "refunds do not sum to refunded amount", Expected: common.Amount{}, Actual: common.Amount{Currency: "USD", Value: "2.00"}},
+		{DocumentID: "t2", PaymentID: "p2", Reason: "net amount is not amount less refunds", Expected: common.Amount{Currency: "USD", Value: "8.00"}, Actual: common.Amount{Currency: "USD", Value: "10.00"}},
+		{DocumentID: "t2", Reason: "payments do not sum to document total", Expected: common.Amount{Currency: "USD", Value: "12.00"}, Actual: common.Amount{Currency: "USD", Value: "10.00"}},
+	}
+	if len(report.Mismatches) != len(wantMismatches) {
+		t.Fatalf("expected %d mismatches, got %+v", len(wantMismatches), report.Mismatches)
+	}
+	for i, want := range wantMismatches {
+		if report.Mismatches[i] != want {
+			t.Errorf("mismatch %d = %+v, want %+v", i, report.Mismatches[i], want)
+		}
+	}
+}
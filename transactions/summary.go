@@ -0,0 +1,202 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Totals aggregates a set of transaction documents. NetPayout is what reaches
+// the merchant: payments minus refunds minus net processing fees.
+type Totals struct {
+	Documents      int
+	GrossSales     common.Amount
+	Taxes          common.Amount
+	Payments       common.Amount
+	Refunds        common.Amount
+	ProcessingFees common.Amount
+	NetPayout      common.Amount
+}
+
+// ProviderTotals aggregates the payments taken through one payment provider.
+type ProviderTotals struct {
+	Payments       int
+	Amount         common.Amount
+	Refunds        common.Amount
+	ProcessingFees common.Amount
+	NetPayout      common.Amount
+}
+
+type DayTotals struct {
+	// Day is the UTC midnight beginning the day.
+	Day time.Time
+	Totals
+}
+
+type Summary struct {
+	Total      Totals
+	Daily      []DayTotals
+	ByProvider map[string]ProviderTotals
+}
+
+// Summarize totals the transaction documents created between from and to,
+// overall, by day and by payment provider. Voided documents are left out.
+// Because documents are only filterable by modification time, every document
+// modified since from is paged in and filtered on its creation time. All
+// amounts must share one currency.
+func Summarize(ctx context.Context, config *common.Config, from, to time.Time) (*Summary, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	params, err := betweenParams(from, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if !from.IsZero() && !from.Before(to) {
+		return nil, fmt.Errorf("from (%s) must be before to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	summary := &Summary{ByProvider: make(map[string]ProviderTotals)}
+	daily := make(map[time.Time]*Totals)
+
+	it := RetrieveAllTransactionsIter(ctx, config, params)
+	for it.Next() {
+		document := it.Value()
+		if document.Voided {
+			continue
+		}
+
+		createdOn, err := time.Parse(time.RFC3339, document.CreatedOn)
+		if err != nil {
+			return nil, fmt.Errorf("document %s: invalid createdOn %q: %w", document.ID, document.CreatedOn, err)
+		}
+		if createdOn.Before(from) || createdOn.After(to) {
+			continue
+		}
+
+		day := createdOn.UTC().Truncate(24 * time.Hour)
+		dayTotals, ok := daily[day]
+		if !ok {
+			dayTotals = &Totals{}
+			daily[day] = dayTotals
+		}
+
+		for _, totals := range []*Totals{&summary.Total, dayTotals} {
+			if err := totals.add(document); err != nil {
+				return nil, fmt.Errorf("document %s: %w", document.ID, err)
+			}
+		}
+
+		for _, payment := range document.Payments {
+			providerTotals := summary.ByProvider[payment.Provider]
+			if err := providerTotals.add(payment); err != nil {
+				return nil, fmt.Errorf("document %s: payment %s: %w", document.ID, payment.ID, err)
+			}
+			summary.ByProvider[payment.Provider] = providerTotals
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	summary.Daily = make([]DayTotals, 0, len(daily))
+	for day, totals := range daily {
+		summary.Daily = append(summary.Daily, DayTotals{Day: day, Totals: *totals})
+	}
+	sort.Slice(summary.Daily, func(i, j int) bool {
+		return summary.Daily[i].Day.Before(summary.Daily[j].Day)
+	})
+
+	return summary, nil
+}
+
+func (t *Totals) add(document Document) error {
+	sums := []amountSum{
+		{&t.GrossSales, document.TotalSales},
+		{&t.Taxes, document.TotalTaxes},
+	}
+	for _, payment := range document.Payments {
+		payout, err := paymentPayout(payment)
+		if err != nil {
+			return err
+		}
+		sums = append(sums,
+			amountSum{&t.Payments, payment.Amount},
+			amountSum{&t.Refunds, payment.RefundedAmount},
+			amountSum{&t.ProcessingFees, payout.fees},
+			amountSum{&t.NetPayout, payout.net},
+		)
+	}
+
+	if err := addAll(sums); err != nil {
+		return err
+	}
+	t.Documents++
+	return nil
+}
+
+func (p *ProviderTotals) add(payment Payment) error {
+	payout, err := paymentPayout(payment)
+	if err != nil {
+		return err
+	}
+
+	if err := addAll([]amountSum{
+		{&p.Amount, payment.Amount},
+		{&p.Refunds, payment.RefundedAmount},
+		{&p.ProcessingFees, payout.fees},
+		{&p.NetPayout, payout.net},
+	}); err != nil {
+		return err
+	}
+	p.Payments++
+	return nil
+}
+
+type amountSum struct {
+	total  *common.Amount
+	amount common.Amount
+}
+
+// addAll adds each amount to its running total.
+func addAll(sums []amountSum) error {
+	for _, sum := range sums {
+		total, err := sum.total.Add(sum.amount)
+		if err != nil {
+			return err
+		}
+		*sum.total = total
+	}
+	return nil
+}
+
+type payout struct {
+	fees common.Amount
+	net  common.Amount
+}
+
+// paymentPayout returns a payment's net processing fees and what remains of it
+// after refunds and those fees.
+func paymentPayout(payment Payment) (payout, error) {
+	var fees common.Amount
+	for _, fee := range payment.ProcessingFees {
+		var err error
+		if fees, err = fees.Add(fee.NetAmount); err != nil {
+			return payout{}, err
+		}
+	}
+
+	net, err := payment.Amount.Sub(payment.RefundedAmount)
+	if err == nil {
+		net, err = net.Sub(fees)
+	}
+	if err != nil {
+		return payout{}, err
+	}
+
+	return payout{fees: fees, net: net}, nil
+}
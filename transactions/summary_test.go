@@ -0,0 +1,91 @@
+package transactions
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/internal/pagedtest"
+)
+
+func TestSummarize(t *testing.T) {
+	pages := map[string]string{
+		"": `{"documents": [
+			{"id": "t1", "createdOn": "2024-03-05T14:00:00Z",
+			 "totalSales": {"currency": "USD", "value": "27.00"}, "totalTaxes": {"currency": "USD", "value": "1.00"},
+			 "payments": [
+				{"id": "p1", "provider": "STRIPE", "amount": {"currency": "USD", "value": "20.00"}, "refundedAmount": {"currency": "USD", "value": "5.00"},
+				 "processingFees": [{"id": "f1", "netAmount": {"currency": "USD", "value": "0.88"}}]},
+				{"id": "p2", "provider": "GIFT_CARD", "amount": {"currency": "USD", "value": "8.00"}, "refundedAmount": {"currency": "USD", "value": "0.00"}}
+			 ]},
+			{"id": "t2", "createdOn": "2024-03-05T20:00:00Z", "voided": true,
+			 "payments": [{"id": "p3", "provider": "STRIPE", "amount": {"currency": "USD", "value": "99.00"}}]}
+		], "pagination": {"hasNextPage": true, "nextPageCursor": "c2"}}`,
+		"c2": `{"documents": [
+			{"id": "t3", "createdOn": "2024-03-06T09:00:00Z",
+			 "totalSales": {"currency": "USD", "value": "10.00"},
+			 "payments": [{"id": "p4", "provider": "STRIPE", "amount": {"currency": "USD", "value": "10.00"},
+				"processingFees": [{"id": "f2", "netAmount": {"currency": "USD", "value": "0.59"}}]}]},
+			{"id": "t4", "createdOn": "2024-04-01T09:00:00Z",
+			 "payments": [{"id": "p5", "provider": "STRIPE", "amount": {"currency": "USD", "value": "50.00"}}]}
+		], "pagination": {"hasNextPage": false}}`,
+	}
+
+	server := pagedtest.NewServer(t, pages)
+	defer server.Close()
+
+	config := &common.Config{
+		APIKey:    "test-key",
+		Client:    server.Client(),
+		UserAgent: "test-agent",
+		BaseURL:   server.URL,
+	}
+
+	summary, err := Summarize(context.Background(), config, time.Time{}, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Summarize() unexpected error = %v", err)
+	}
+
+	total := summary.Total
+	if total.Documents != 2 {
+		t.Errorf("expected 2 documents, got %d", total.Documents)
+	}
+	for _, check := range []struct {
+		name string
+		got  common.Amount
+		want string
+	}{
+		{"gross sales", total.GrossSales, "37.00"},
+		{"taxes", total.Taxes, "1.00"},
+		{"payments", total.Payments, "38.00"},
+		{"refunds", total.Refunds, "5.00"},
+		{"processing fees", total.ProcessingFees, "1.47"},
+		{"net payout", total.NetPayout, "31.53"},
+	} {
+		if check.got.Value != check.want {
+			t.Errorf("expected %s %s, got %s", check.name, check.want, check.got.Value)
+		}
+	}
+
+	if len(summary.Daily) != 2 || summary.Daily[0].Documents != 1 || summary.Daily[1].NetPayout.Value != "9.41" {
+		t.Errorf("unexpected daily totals %+v", summary.Daily)
+	}
+
+	stripe := summary.ByProvider["STRIPE"]
+	if stripe.Payments != 2 || stripe.NetPayout.Value != "23.53" || stripe.ProcessingFees.Value != "1.47" {
+		t.Errorf("unexpected STRIPE totals %+v", stripe)
+	}
+	if giftCard := summary.ByProvider["GIFT_CARD"]; giftCard.Payments != 1 || giftCard.NetPayout.Value != "8.00" {
+		t.Errorf("unexpected GIFT_CARD totals %+v", giftCard)
+	}
+}
+
+func TestSummarizeInvertedRange(t *testing.T) {
+	config := &common.Config{APIKey: "test-key"}
+	_, err := Summarize(context.Background(), config, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	if err == nil || !strings.Contains(err.Error(), "must be before") {
+		t.Errorf("expected range error, got %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+package gocommerce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/inventory"
+	"github.com/j-low/gocommerce/orders"
+	"github.com/j-low/gocommerce/products"
+	"github.com/j-low/gocommerce/profiles"
+	"github.com/j-low/gocommerce/transactions"
+	"github.com/j-low/gocommerce/webhooks"
+)
+
+// CredentialCheck is the outcome of one probe request.
+type CredentialCheck struct {
+	// API is the API probed: products, orders, inventory, profiles,
+	// transactions or webhooks.
+	API        string
+	Credential common.Credential
+	StatusCode int
+	// Granted reports that the credential may read the API.
+	Granted bool
+	Err     error
+}
+
+type CredentialReport struct {
+	// Reachable reports that at least one probe got an HTTP response.
+	Reachable bool
+	// APIKeyValid and AccessTokenValid report that the credential was set and
+	// accepted by at least one API.
+	APIKeyValid      bool
+	AccessTokenValid bool
+	// APIKeyRejected and AccessTokenRejected report that the credential got
+	// a 401 and no API accepted it. A credential whose probes all hit rate
+	// limits, server errors or transport errors is neither valid nor
+	// rejected.
+	APIKeyRejected      bool
+	AccessTokenRejected bool
+	Checks              []CredentialCheck
+}
+
+// Granted reports whether any credential may read api.
+func (r *CredentialReport) Granted(api string) bool {
+	for _, check := range r.Checks {
+		if check.API == api && check.Granted {
+			return true
+		}
+	}
+	return false
+}
+
+// Require returns an error naming every api no credential may read.
+func (r *CredentialReport) Require(apis ...string) error {
+	var errs []error
+	for _, api := range apis {
+		if r.Granted(api) {
+			continue
+		}
+		if err := r.checkErr(api); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", api, err))
+		} else {
+			errs = append(errs, fmt.Errorf("%s: permission not granted", api))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *CredentialReport) checkErr(api string) error {
+	for _, check := range r.Checks {
+		if check.API == api && check.Err != nil {
+			return check.Err
+		}
+	}
+	return nil
+}
+
+type credentialProbe struct {
+	api        string
+	credential common.Credential
+	version    string
+	path       string
+}
+
+var credentialProbes = []credentialProbe{
+	{"products", common.CredentialAPIKey, products.ProductsAPIVersion, "commerce/store_pages"},
+	{"orders", common.CredentialAPIKey, orders.OrdersAPIVersion, "commerce/orders"},
+	{"inventory", common.CredentialAPIKey, inventory.InventoryAPIVersion, "commerce/inventory"},
+	{"profiles", common.CredentialAPIKey, profiles.ProfilesAPIVersion, "profiles"},
+	{"transactions", common.CredentialAPIKey, transactions.TransactionsAPIVersion, "commerce/transactions"},
+	{"webhooks", common.CredentialAccessToken, webhooks.WebhooksAPIVersion, "webhook_subscriptions"},
+}
+
+// VerifyCredentials makes one cheap read per API with each configured
+// credential so a service can fail fast at startup. It returns an error when
+// the API cannot be reached or a configured credential is rejected; missing
+// permissions are only recorded in the report, see Require.
+func VerifyCredentials(ctx context.Context, config *common.Config) (*CredentialReport, error) {
+	if config == nil {
+		return nil, common.ErrNilConfig
+	}
+	if config.APIKey == "" && config.AccessToken == "" {
+		return nil, fmt.Errorf("no APIKey or AccessToken configured")
+	}
+
+	report := &CredentialReport{}
+	rejected := map[common.Credential]bool{}
+	var transportErr error
+
+	for _, probe := range credentialProbes {
+		token := config.APIKey
+		if probe.credential == common.CredentialAccessToken {
+			token = config.AccessToken
+		}
+		if token == "" {
+			continue
+		}
+
+		check := runCredentialProbe(ctx, config, probe, token)
+		report.Checks = append(report.Checks, check)
+
+		switch {
+		case check.StatusCode == 0:
+			transportErr = check.Err
+			continue
+		case check.StatusCode == http.StatusUnauthorized:
+			rejected[probe.credential] = true
+		case check.StatusCode != http.StatusForbidden && check.Err != nil:
+			// Rate limits and server errors say nothing about the credential.
+		default:
+			if probe.credential == common.CredentialAccessToken {
+				report.AccessTokenValid = true
+			} else {
+				report.APIKeyValid = true
+			}
+		}
+		report.Reachable = true
+	}
+
+	if !report.Reachable {
+		return report, fmt.Errorf("API is not reachable: %w", transportErr)
+	}
+
+	report.APIKeyRejected = rejected[common.CredentialAPIKey] && !report.APIKeyValid
+	report.AccessTokenRejected = rejected[common.CredentialAccessToken] && !report.AccessTokenValid
+
+	var errs []error
+	if report.APIKeyRejected {
+		errs = append(errs, fmt.Errorf("APIKey was rejected"))
+	}
+	if report.AccessTokenRejected {
+		errs = append(errs, fmt.Errorf("AccessToken was rejected"))
+	}
+	return report, errors.Join(errs...)
+}
+
+func runCredentialProbe(ctx context.Context, config *common.Config, probe credentialProbe, token string) CredentialCheck {
+	check := CredentialCheck{API: probe.api, Credential: probe.credential}
+
+	baseURL, err := common.BuildBaseURL(config, probe.version, probe.path)
+	if err != nil {
+		check.Err = fmt.Errorf("failed to build base URL: %w", err)
+		return check
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
+	if err != nil {
+		check.Err = fmt.Errorf("failed to create request: %w", err)
+		return check
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, probe.api))
+
+	resp, err := common.Do(config, req)
+	if err != nil {
+		check.Err = fmt.Errorf("failed to execute request: %w", err)
+		return check
+	}
+	defer resp.Body.Close()
+
+	check.StatusCode = resp.StatusCode
+	if resp.StatusCode == http.StatusOK {
+		check.Granted = true
+		return check
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		check.Err = fmt.Errorf("failed to read response body: %w", err)
+		return check
+	}
+	check.Err = common.ParseResponseError("VerifyCredentials", baseURL, resp, body)
+	return check
+}
+
+// String summarizes the report for startup logs, e.g.
+// "reachable, APIKey valid (products, orders), AccessToken not set".
+func (r *CredentialReport) String() string {
+	if !r.Reachable {
+		return "unreachable"
+	}
+
+	parts := []string{"reachable"}
+	for _, credential := range []common.Credential{common.CredentialAPIKey, common.CredentialAccessToken} {
+		var granted []string
+		probed := false
+		for _, check := range r.Checks {
+			if check.Credential != credential {
+				continue
+			}
+			probed = true
+			if check.Granted {
+				granted = append(granted, check.API)
+			}
+		}
+
+		valid, rejected := r.APIKeyValid, r.APIKeyRejected
+		if credential == common.CredentialAccessToken {
+			valid, rejected = r.AccessTokenValid, r.AccessTokenRejected
+		}
+		switch {
+		case !probed:
+			parts = append(parts, string(credential)+" not set")
+		case valid:
+			parts = append(parts, fmt.Sprintf("%s valid (%s)", credential, strings.Join(granted, ", ")))
+		case rejected:
+			parts = append(parts, string(credential)+" invalid")
+		default:
+			parts = append(parts, string(credential)+" unverified")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,146 @@
+package gocommerce
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/mocks"
+)
+
+func TestVerifyCredentials(t *testing.T) {
+	server := mocks.NewServer()
+	defer server.Close()
+
+	forbidden := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/profiles") {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"type":"AUTHORIZATION_ERROR","message":"Missing permission"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer forbidden.Close()
+
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"type":"ERROR","message":"Unavailable"}`))
+	}))
+	defer unavailable.Close()
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	tests := []struct {
+		name            string
+		config          func() *common.Config
+		wantErr         bool
+		errContains     string
+		wantReachable   bool
+		wantKeyValid    bool
+		wantTokenValid  bool
+		wantKeyRejected bool
+		wantGranted     []string
+		wantNotGranted  []string
+		wantSummary     string
+		wantRequireFail string
+	}{
+		{
+			name:           "all credentials valid",
+			config:         server.Config,
+			wantReachable:  true,
+			wantKeyValid:   true,
+			wantTokenValid: true,
+			wantGranted:    []string{"products", "orders", "inventory", "profiles", "transactions", "webhooks"},
+			wantSummary:    "reachable, APIKey valid (products, orders, inventory, profiles, transactions), AccessToken valid (webhooks)",
+		},
+		{
+			name: "rejected api key",
+			config: func() *common.Config {
+				config := server.Config()
+				config.APIKey = "wrong-key"
+				return config
+			},
+			wantErr:         true,
+			errContains:     "APIKey was rejected",
+			wantReachable:   true,
+			wantTokenValid:  true,
+			wantKeyRejected: true,
+			wantGranted:     []string{"webhooks"},
+			wantNotGranted:  []string{"orders"},
+			wantSummary:     "reachable, APIKey invalid, AccessToken valid (webhooks)",
+		},
+		{
+			name: "missing permission",
+			config: func() *common.Config {
+				return &common.Config{APIKey: "test-key", Client: forbidden.Client(), UserAgent: "test-agent", BaseURL: forbidden.URL}
+			},
+			wantReachable:   true,
+			wantKeyValid:    true,
+			wantGranted:     []string{"orders"},
+			wantNotGranted:  []string{"profiles", "webhooks"},
+			wantSummary:     "reachable, APIKey valid (products, orders, inventory, transactions), AccessToken not set",
+			wantRequireFail: "profiles: VerifyCredentials url:",
+		},
+		{
+			name: "server errors leave the credential unverified",
+			config: func() *common.Config {
+				return &common.Config{APIKey: "test-key", Client: unavailable.Client(), UserAgent: "test-agent", BaseURL: unavailable.URL}
+			},
+			wantReachable: true,
+			wantSummary:   "reachable, APIKey unverified, AccessToken not set",
+		},
+		{
+			name: "unreachable",
+			config: func() *common.Config {
+				return &common.Config{APIKey: "test-key", Client: unreachable.Client(), UserAgent: "test-agent", BaseURL: unreachable.URL}
+			},
+			wantErr:     true,
+			errContains: "API is not reachable",
+			wantSummary: "unreachable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := VerifyCredentials(context.Background(), tt.config())
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("expected error containing %q, got %v", tt.errContains, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if report.Reachable != tt.wantReachable || report.APIKeyValid != tt.wantKeyValid || report.AccessTokenValid != tt.wantTokenValid || report.APIKeyRejected != tt.wantKeyRejected {
+				t.Errorf("unexpected report %+v", report)
+			}
+			for _, api := range tt.wantGranted {
+				if !report.Granted(api) {
+					t.Errorf("expected %s to be granted", api)
+				}
+			}
+			for _, api := range tt.wantNotGranted {
+				if report.Granted(api) {
+					t.Errorf("expected %s not to be granted", api)
+				}
+			}
+			if got := report.String(); got != tt.wantSummary {
+				t.Errorf("String() = %q, want %q", got, tt.wantSummary)
+			}
+			if tt.wantRequireFail != "" {
+				if err := report.Require("orders", "profiles"); err == nil || !strings.Contains(err.Error(), tt.wantRequireFail) {
+					t.Errorf("expected Require error containing %q, got %v", tt.wantRequireFail, err)
+				}
+			}
+		})
+	}
+
+	if _, err := VerifyCredentials(context.Background(), &common.Config{}); err == nil {
+		t.Error("expected an error without credentials")
+	}
+}
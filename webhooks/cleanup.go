@@ -0,0 +1,72 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type DeleteFilter struct {
+	// EndpointPrefix matches subscriptions whose endpoint URL starts with it.
+	EndpointPrefix string
+	// Topics matches subscriptions listening to any of the topics.
+	Topics []string
+	// All must be set to match every subscription when no other criteria are
+	// given, so an empty filter cannot wipe an account by accident.
+	All bool
+	// DryRun reports the matching subscriptions without deleting them.
+	DryRun bool
+}
+
+// Match reports whether the subscription meets every criterion set on the
+// filter.
+func (f DeleteFilter) Match(subscription WebhookSubscription) bool {
+	if f.EndpointPrefix != "" && !strings.HasPrefix(subscription.EndpointURL, f.EndpointPrefix) {
+		return false
+	}
+	if len(f.Topics) > 0 {
+		for _, topic := range subscription.Topics {
+			for _, want := range f.Topics {
+				if topic == want {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// DeleteAll deletes every subscription matching filter and returns them. A
+// failed deletion does not stop the others; the returned subscriptions are
+// those actually deleted, and the error joins every failure.
+func DeleteAll(ctx context.Context, config *common.Config, filter DeleteFilter) ([]WebhookSubscription, error) {
+	if filter.EndpointPrefix == "" && len(filter.Topics) == 0 && !filter.All {
+		return nil, fmt.Errorf("filter requires an endpoint prefix, topics or All")
+	}
+
+	existing, err := RetrieveAllWebhookSubscriptions(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook subscriptions: %w", err)
+	}
+
+	var deleted []WebhookSubscription
+	var errs []error
+	for _, subscription := range existing.WebhookSubscriptions {
+		if !filter.Match(subscription) {
+			continue
+		}
+		if !filter.DryRun {
+			if _, err := DeleteWebhookSubscription(ctx, config, subscription.ID); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete subscription %s: %w", subscription.ID, err))
+				continue
+			}
+		}
+		deleted = append(deleted, subscription)
+	}
+
+	return deleted, errors.Join(errs...)
+}
@@ -0,0 +1,112 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestDeleteAll(t *testing.T) {
+	existing := []WebhookSubscription{
+		{ID: "s1", EndpointURL: "https://staging.example.com/orders", Topics: []string{TopicOrderCreate}},
+		{ID: "s2", EndpointURL: "https://staging.example.com/uninstall", Topics: []string{TopicExtensionUninstall}},
+		{ID: "s3", EndpointURL: "https://example.com/orders", Topics: []string{TopicOrderCreate, TopicOrderUpdate}},
+	}
+
+	tests := []struct {
+		name        string
+		filter      DeleteFilter
+		failID      string
+		wantDeleted string
+		wantCalls   string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:        "endpoint prefix",
+			filter:      DeleteFilter{EndpointPrefix: "https://staging.example.com/"},
+			wantDeleted: "s1,s2",
+			wantCalls:   "s1,s2",
+		},
+		{
+			name:        "prefix and topic",
+			filter:      DeleteFilter{EndpointPrefix: "https://staging.example.com/", Topics: []string{TopicOrderCreate}},
+			wantDeleted: "s1",
+			wantCalls:   "s1",
+		},
+		{
+			name:        "dry run",
+			filter:      DeleteFilter{Topics: []string{TopicOrderCreate}, DryRun: true},
+			wantDeleted: "s1,s3",
+		},
+		{
+			name:        "all",
+			filter:      DeleteFilter{All: true},
+			failID:      "s2",
+			wantDeleted: "s1,s3",
+			wantCalls:   "s1,s2,s3",
+			wantErr:     true,
+			errContains: "failed to delete subscription s2",
+		},
+		{
+			name:        "empty filter",
+			filter:      DeleteFilter{},
+			wantErr:     true,
+			errContains: "filter requires an endpoint prefix, topics or All",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode(RetrieveAllWebhookSubscriptionsResponse{WebhookSubscriptions: existing})
+					return
+				}
+
+				id := strings.TrimPrefix(r.URL.Path, "/1.0/webhook_subscriptions/")
+				calls = append(calls, id)
+				if id == tt.failID {
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte(`{"type":"ERROR","message":"Delete failed"}`))
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				AccessToken: "test-token",
+				Client:      server.Client(),
+				BaseURL:     server.URL,
+			}
+
+			deleted, err := DeleteAll(context.Background(), config, tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+			}
+
+			var ids []string
+			for _, subscription := range deleted {
+				ids = append(ids, subscription.ID)
+			}
+			if got := strings.Join(ids, ","); got != tt.wantDeleted {
+				t.Errorf("expected deleted %q, got %q", tt.wantDeleted, got)
+			}
+			if got := strings.Join(calls, ","); got != tt.wantCalls {
+				t.Errorf("expected delete calls %q, got %q", tt.wantCalls, got)
+			}
+		})
+	}
+}
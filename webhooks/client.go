@@ -12,8 +12,8 @@ import (
 )
 
 func CreateWebhookSubscription(ctx context.Context, config *common.Config, request WebhookSubscriptionRequest) (*WebhookSubscription, error) {
-	if config.AccessToken == "" {
-		return nil, fmt.Errorf("access token is required")
+	if err := config.ValidateFor(common.CredentialAccessToken); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
 	baseURL, err := common.BuildBaseURL(config, WebhooksAPIVersion, "webhook_subscriptions")
@@ -21,8 +21,8 @@ func CreateWebhookSubscription(ctx context.Context, config *common.Config, reque
 		return nil, fmt.Errorf("failed to build base URL: %w", err)
 	}
 
-	if len(request.Topics) == 0 {
-		return nil, fmt.Errorf("topics cannot be empty")
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
 	reqBody, err := json.Marshal(request)
@@ -36,10 +36,10 @@ func CreateWebhookSubscription(ctx context.Context, config *common.Config, reque
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.AccessToken)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "webhooks"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
 	}
@@ -51,7 +51,7 @@ func CreateWebhookSubscription(ctx context.Context, config *common.Config, reque
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, common.ParseErrorResponse("CreateWebhookSubscription", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("CreateWebhookSubscription", baseURL, resp, body)
 	}
 
 	var response WebhookSubscription
@@ -63,8 +63,8 @@ func CreateWebhookSubscription(ctx context.Context, config *common.Config, reque
 }
 
 func UpdateWebhookSubscription(ctx context.Context, config *common.Config, subscriptionID string, request WebhookSubscriptionRequest) (*WebhookSubscription, error) {
-	if config.AccessToken == "" {
-		return nil, fmt.Errorf("access token is required")
+	if err := config.ValidateFor(common.CredentialAccessToken); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
 	baseURL, err := common.BuildBaseURL(config, WebhooksAPIVersion, fmt.Sprintf("webhook_subscriptions/%s", subscriptionID))
@@ -76,8 +76,8 @@ func UpdateWebhookSubscription(ctx context.Context, config *common.Config, subsc
 		return nil, fmt.Errorf("subscriptionID cannot be empty")
 	}
 
-	if request.Topics != nil && len(request.Topics) == 0 {
-		return nil, fmt.Errorf("topics cannot be an empty array")
+	if err := request.validateUpdate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
 	reqBody, err := json.Marshal(request)
@@ -91,10 +91,10 @@ func UpdateWebhookSubscription(ctx context.Context, config *common.Config, subsc
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.AccessToken)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "webhooks"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update webhook subscription: %w", err)
 	}
@@ -106,7 +106,7 @@ func UpdateWebhookSubscription(ctx context.Context, config *common.Config, subsc
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("UpdateWebhookSubscription", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("UpdateWebhookSubscription", baseURL, resp, body)
 	}
 
 	var response WebhookSubscription
@@ -118,8 +118,8 @@ func UpdateWebhookSubscription(ctx context.Context, config *common.Config, subsc
 }
 
 func RetrieveAllWebhookSubscriptions(ctx context.Context, config *common.Config) (*RetrieveAllWebhookSubscriptionsResponse, error) {
-	if config.AccessToken == "" {
-		return nil, fmt.Errorf("access token is required")
+	if err := config.ValidateFor(common.CredentialAccessToken); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
 	baseURL, err := common.BuildBaseURL(config, WebhooksAPIVersion, "webhook_subscriptions")
@@ -133,25 +133,24 @@ func RetrieveAllWebhookSubscriptions(ctx context.Context, config *common.Config)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.AccessToken)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "webhooks"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve webhook subscriptions: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveAllWebhookSubscriptions", baseURL, body, resp.StatusCode)
+		body, err := common.ReadBody(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, common.ParseResponseError("RetrieveAllWebhookSubscriptions", baseURL, resp, body)
 	}
 
 	var response RetrieveAllWebhookSubscriptionsResponse
-	if err := json.Unmarshal(body, &response); err != nil {
+	if err := common.DecodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
@@ -159,8 +158,8 @@ func RetrieveAllWebhookSubscriptions(ctx context.Context, config *common.Config)
 }
 
 func RetrieveSpecificWebhookSubscription(ctx context.Context, config *common.Config, subscriptionID string) (*WebhookSubscription, error) {
-	if config.AccessToken == "" {
-		return nil, fmt.Errorf("access token is required")
+	if err := config.ValidateFor(common.CredentialAccessToken); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
 	if subscriptionID == "" {
@@ -178,9 +177,9 @@ func RetrieveSpecificWebhookSubscription(ctx context.Context, config *common.Con
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.AccessToken)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "webhooks"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve webhook subscription: %w", err)
 	}
@@ -192,7 +191,7 @@ func RetrieveSpecificWebhookSubscription(ctx context.Context, config *common.Con
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RetrieveSpecificWebhookSubscription", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("RetrieveSpecificWebhookSubscription", baseURL, resp, body)
 	}
 
 	var response WebhookSubscription
@@ -204,8 +203,8 @@ func RetrieveSpecificWebhookSubscription(ctx context.Context, config *common.Con
 }
 
 func DeleteWebhookSubscription(ctx context.Context, config *common.Config, subscriptionID string) (int, error) {
-	if config.AccessToken == "" {
-		return http.StatusBadRequest, fmt.Errorf("access token is required")
+	if err := config.ValidateFor(common.CredentialAccessToken); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid config: %w", err)
 	}
 
 	if subscriptionID == "" {
@@ -223,9 +222,9 @@ func DeleteWebhookSubscription(ctx context.Context, config *common.Config, subsc
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.AccessToken)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "webhooks"))
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to delete webhook subscription: %w", err)
 	}
@@ -236,15 +235,15 @@ func DeleteWebhookSubscription(ctx context.Context, config *common.Config, subsc
 		if readErr != nil {
 			return http.StatusBadRequest, fmt.Errorf("failed to read response body: %w", readErr)
 		}
-		return resp.StatusCode, common.ParseErrorResponse("DeleteWebhookSubscription", baseURL, body, resp.StatusCode)
+		return resp.StatusCode, common.ParseResponseError("DeleteWebhookSubscription", baseURL, resp, body)
 	}
 
 	return resp.StatusCode, nil
 }
 
 func SendTestNotification(ctx context.Context, config *common.Config, subscriptionID string, request SendTestNotificationRequest) (*SendTestNotificationResponse, error) {
-	if config.AccessToken == "" {
-		return nil, fmt.Errorf("access token is required")
+	if err := config.ValidateFor(common.CredentialAccessToken); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
 	if subscriptionID == "" {
@@ -271,10 +270,10 @@ func SendTestNotification(ctx context.Context, config *common.Config, subscripti
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.AccessToken)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "webhooks"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send test notification: %w", err)
 	}
@@ -286,7 +285,7 @@ func SendTestNotification(ctx context.Context, config *common.Config, subscripti
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("SendTestNotification", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("SendTestNotification", baseURL, resp, body)
 	}
 
 	var response SendTestNotificationResponse
@@ -298,8 +297,8 @@ func SendTestNotification(ctx context.Context, config *common.Config, subscripti
 }
 
 func RotateSubscriptionSecret(ctx context.Context, config *common.Config, subscriptionID string) (*RotateSubscriptionSecretResponse, error) {
-	if config.AccessToken == "" {
-		return nil, fmt.Errorf("access token is required")
+	if err := config.ValidateFor(common.CredentialAccessToken); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
 	if subscriptionID == "" {
@@ -317,10 +316,10 @@ func RotateSubscriptionSecret(ctx context.Context, config *common.Config, subscr
 	}
 
 	req.Header.Set("Authorization", "Bearer "+config.AccessToken)
-	req.Header.Set("User-Agent", common.SetUserAgent(config.UserAgent))
+	req.Header.Set("User-Agent", common.UserAgent(config.UserAgent, "webhooks"))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := config.Client.Do(req)
+	resp, err := common.Do(config, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to rotate subscription secret: %w", err)
 	}
@@ -332,7 +331,7 @@ func RotateSubscriptionSecret(ctx context.Context, config *common.Config, subscr
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, common.ParseErrorResponse("RotateSubscriptionSecret", baseURL, body, resp.StatusCode)
+		return nil, common.ParseResponseError("RotateSubscriptionSecret", baseURL, resp, body)
 	}
 
 	var response RotateSubscriptionSecretResponse
@@ -28,13 +28,13 @@ func TestCreateWebhookSubscription(t *testing.T) {
 			},
 			request: WebhookSubscriptionRequest{
 				EndpointURL: "https://example.com/webhook",
-				Topics:      []string{"order.created"},
+				Topics:      []string{"order.create"},
 			},
 			mockStatus: http.StatusCreated,
 			mockResp: `{
 				"id": "webhook-123",
 				"endpointUrl": "https://example.com/webhook",
-				"topics": ["order.created"],
+				"topics": ["order.create"],
 				"secret": "secret123",
 				"createdOn": "2024-01-01T00:00:00Z",
 				"updatedOn": "2024-01-01T00:00:00Z"
@@ -47,7 +47,7 @@ func TestCreateWebhookSubscription(t *testing.T) {
 			},
 			request: WebhookSubscriptionRequest{
 				EndpointURL: "https://example.com/webhook",
-				Topics:      []string{"order.created"},
+				Topics:      []string{"order.create"},
 			},
 			wantErr:     true,
 			errContains: "access token is required",
@@ -64,6 +64,30 @@ func TestCreateWebhookSubscription(t *testing.T) {
 			wantErr:     true,
 			errContains: "topics cannot be empty",
 		},
+		{
+			name: "typo in topic",
+			config: &common.Config{
+				AccessToken: "test-token",
+			},
+			request: WebhookSubscriptionRequest{
+				EndpointURL: "https://example.com/webhook",
+				Topics:      []string{"orders.created"},
+			},
+			wantErr:     true,
+			errContains: `invalid topic "orders.created", valid topics are: order.create`,
+		},
+		{
+			name: "plain http endpoint",
+			config: &common.Config{
+				AccessToken: "test-token",
+			},
+			request: WebhookSubscriptionRequest{
+				EndpointURL: "http://example.com/webhook",
+				Topics:      []string{"order.create"},
+			},
+			wantErr:     true,
+			errContains: "endpointUrl must be an absolute https URL",
+		},
 		{
 			name: "server error",
 			config: &common.Config{
@@ -71,7 +95,7 @@ func TestCreateWebhookSubscription(t *testing.T) {
 			},
 			request: WebhookSubscriptionRequest{
 				EndpointURL: "https://example.com/webhook",
-				Topics:      []string{"order.created"},
+				Topics:      []string{"order.create"},
 			},
 			mockStatus:  http.StatusBadRequest,
 			mockResp:    `{"type":"ERROR","message":"Invalid request"}`,
@@ -137,13 +161,13 @@ func TestUpdateWebhookSubscription(t *testing.T) {
 			subscriptionID: "webhook-123",
 			request: WebhookSubscriptionRequest{
 				EndpointURL: "https://example.com/webhook-updated",
-				Topics:      []string{"order.created", "order.fulfilled"},
+				Topics:      []string{"order.create", "order.update"},
 			},
 			mockStatus: http.StatusOK,
 			mockResp: `{
 				"id": "webhook-123",
 				"endpointUrl": "https://example.com/webhook-updated",
-				"topics": ["order.created", "order.fulfilled"]
+				"topics": ["order.create", "order.update"]
 			}`,
 		},
 		{
@@ -177,6 +201,18 @@ func TestUpdateWebhookSubscription(t *testing.T) {
 			wantErr:     true,
 			errContains: "topics cannot be an empty array",
 		},
+		{
+			name: "invalid topic",
+			config: &common.Config{
+				AccessToken: "test-token",
+			},
+			subscriptionID: "webhook-123",
+			request: WebhookSubscriptionRequest{
+				Topics: []string{"order.create", "order.deleted"},
+			},
+			wantErr:     true,
+			errContains: `invalid topic "order.deleted"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -238,7 +274,7 @@ func TestRetrieveAllWebhookSubscriptions(t *testing.T) {
 					{
 						"id": "webhook-123",
 						"endpointUrl": "https://example.com/webhook",
-						"topics": ["order.created"]
+						"topics": ["order.create"]
 					}
 				]
 			}`,
@@ -318,7 +354,7 @@ func TestRetrieveSpecificWebhookSubscription(t *testing.T) {
 			mockResp: `{
 				"id": "webhook-123",
 				"endpointUrl": "https://example.com/webhook",
-				"topics": ["order.created"]
+				"topics": ["order.create"]
 			}`,
 		},
 		{
@@ -495,7 +531,7 @@ func TestSendTestNotification(t *testing.T) {
 			},
 			subscriptionID: "webhook-123",
 			request: SendTestNotificationRequest{
-				Topic: "order.created",
+				Topic: "order.create",
 			},
 			mockStatus: http.StatusOK,
 			mockResp:   `{"statusCode": 200}`,
@@ -507,7 +543,7 @@ func TestSendTestNotification(t *testing.T) {
 			},
 			subscriptionID: "webhook-123",
 			request: SendTestNotificationRequest{
-				Topic: "order.created",
+				Topic: "order.create",
 			},
 			wantErr:     true,
 			errContains: "access token is required",
@@ -519,7 +555,7 @@ func TestSendTestNotification(t *testing.T) {
 			},
 			subscriptionID: "",
 			request: SendTestNotificationRequest{
-				Topic: "order.created",
+				Topic: "order.create",
 			},
 			wantErr:     true,
 			errContains: "subscriptionID cannot be empty",
@@ -543,7 +579,7 @@ func TestSendTestNotification(t *testing.T) {
 			},
 			subscriptionID: "webhook-123",
 			request: SendTestNotificationRequest{
-				Topic: "order.created",
+				Topic: "order.create",
 			},
 			mockStatus:  http.StatusBadRequest,
 			mockResp:    `{"type":"ERROR","message":"Invalid request"}`,
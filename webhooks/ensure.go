@@ -0,0 +1,122 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type EnsureOptions struct {
+	// Prune deletes existing subscriptions that match no desired one. When
+	// false they are left in place and reported as Unmanaged.
+	Prune bool
+}
+
+type EnsureReport struct {
+	Created   []WebhookSubscription
+	Updated   []WebhookSubscription
+	Unchanged []WebhookSubscription
+	Deleted   []WebhookSubscription
+	Unmanaged []WebhookSubscription
+}
+
+// Ensure reconciles the account's webhook subscriptions with desired. Existing
+// subscriptions are matched by endpoint URL, then by topic set, so a moved
+// endpoint is updated in place and keeps its secret. Matches whose topics or
+// endpoint drifted are updated and desired subscriptions without a match are
+// created. The report covers the changes made before any error.
+func Ensure(ctx context.Context, config *common.Config, desired []WebhookSubscriptionRequest, opts EnsureOptions) (*EnsureReport, error) {
+	endpoints := make(map[string]bool, len(desired))
+	for i, request := range desired {
+		if err := request.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid request %d: %w", i, err)
+		}
+		if endpoints[request.EndpointURL] {
+			return nil, fmt.Errorf("invalid request %d: duplicate endpointUrl %s", i, request.EndpointURL)
+		}
+		endpoints[request.EndpointURL] = true
+	}
+
+	existing, err := RetrieveAllWebhookSubscriptions(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook subscriptions: %w", err)
+	}
+
+	unmatched := append([]WebhookSubscription(nil), existing.WebhookSubscriptions...)
+	take := func(match func(WebhookSubscription) bool) (WebhookSubscription, bool) {
+		for i, subscription := range unmatched {
+			if match(subscription) {
+				unmatched = append(unmatched[:i], unmatched[i+1:]...)
+				return subscription, true
+			}
+		}
+		return WebhookSubscription{}, false
+	}
+
+	matches := make([]*WebhookSubscription, len(desired))
+	for i, request := range desired {
+		if subscription, ok := take(func(s WebhookSubscription) bool { return s.EndpointURL == request.EndpointURL }); ok {
+			matches[i] = &subscription
+		}
+	}
+	for i, request := range desired {
+		if matches[i] != nil {
+			continue
+		}
+		if subscription, ok := take(func(s WebhookSubscription) bool { return sameTopics(s.Topics, request.Topics) }); ok {
+			matches[i] = &subscription
+		}
+	}
+
+	report := &EnsureReport{}
+	for i, request := range desired {
+		current := matches[i]
+		switch {
+		case current == nil:
+			created, err := CreateWebhookSubscription(ctx, config, request)
+			if err != nil {
+				return report, fmt.Errorf("failed to create subscription for %s: %w", request.EndpointURL, err)
+			}
+			report.Created = append(report.Created, *created)
+		case current.EndpointURL != request.EndpointURL || !sameTopics(current.Topics, request.Topics):
+			updated, err := UpdateWebhookSubscription(ctx, config, current.ID, request)
+			if err != nil {
+				return report, fmt.Errorf("failed to update subscription %s: %w", current.ID, err)
+			}
+			report.Updated = append(report.Updated, *updated)
+		default:
+			report.Unchanged = append(report.Unchanged, *current)
+		}
+	}
+
+	for _, subscription := range unmatched {
+		if !opts.Prune {
+			report.Unmanaged = append(report.Unmanaged, subscription)
+			continue
+		}
+		if _, err := DeleteWebhookSubscription(ctx, config, subscription.ID); err != nil {
+			return report, fmt.Errorf("failed to delete subscription %s: %w", subscription.ID, err)
+		}
+		report.Deleted = append(report.Deleted, subscription)
+	}
+
+	return report, nil
+}
+
+func sameTopics(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
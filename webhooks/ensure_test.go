@@ -0,0 +1,162 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestEnsure(t *testing.T) {
+	existing := []WebhookSubscription{
+		{ID: "s1", EndpointURL: "https://example.com/orders", Topics: []string{TopicOrderUpdate, TopicOrderCreate}},
+		{ID: "s2", EndpointURL: "https://example.com/inventory", Topics: []string{TopicOrderCreate}},
+		{ID: "s3", EndpointURL: "https://old.example.com/uninstall", Topics: []string{TopicExtensionUninstall}},
+		{ID: "s4", EndpointURL: "https://example.com/legacy", Topics: []string{TopicOrderUpdate}},
+	}
+	desired := []WebhookSubscriptionRequest{
+		{EndpointURL: "https://example.com/orders", Topics: []string{TopicOrderCreate, TopicOrderUpdate}},
+		{EndpointURL: "https://example.com/inventory", Topics: []string{TopicOrderUpdate}},
+		{EndpointURL: "https://new.example.com/uninstall", Topics: []string{TopicExtensionUninstall}},
+		{EndpointURL: "https://example.com/fresh", Topics: []string{TopicOrderCreate}},
+	}
+
+	ids := func(subscriptions []WebhookSubscription) string {
+		var out []string
+		for _, s := range subscriptions {
+			out = append(out, s.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		name          string
+		opts          EnsureOptions
+		desired       []WebhookSubscriptionRequest
+		wantCalls     []string
+		wantCreated   string
+		wantUpdated   string
+		wantUnchanged string
+		wantDeleted   string
+		wantUnmanaged string
+		wantErr       bool
+		errContains   string
+	}{
+		{
+			name:    "reconciles without pruning",
+			desired: desired,
+			wantCalls: []string{
+				"GET /1.0/webhook_subscriptions",
+				"POST /1.0/webhook_subscriptions/s2",
+				"POST /1.0/webhook_subscriptions/s3",
+				"POST /1.0/webhook_subscriptions",
+			},
+			wantCreated:   "new",
+			wantUpdated:   "s2,s3",
+			wantUnchanged: "s1",
+			wantUnmanaged: "s4",
+		},
+		{
+			name:    "prunes unknown subscriptions",
+			opts:    EnsureOptions{Prune: true},
+			desired: desired[:1],
+			wantCalls: []string{
+				"GET /1.0/webhook_subscriptions",
+				"DELETE /1.0/webhook_subscriptions/s2",
+				"DELETE /1.0/webhook_subscriptions/s3",
+				"DELETE /1.0/webhook_subscriptions/s4",
+			},
+			wantUnchanged: "s1",
+			wantDeleted:   "s2,s3,s4",
+		},
+		{
+			name: "duplicate endpoint",
+			desired: []WebhookSubscriptionRequest{
+				{EndpointURL: "https://example.com/orders", Topics: []string{TopicOrderCreate}},
+				{EndpointURL: "https://example.com/orders", Topics: []string{TopicOrderUpdate}},
+			},
+			wantErr:     true,
+			errContains: "invalid request 1: duplicate endpointUrl https://example.com/orders",
+		},
+		{
+			name:        "invalid desired subscription",
+			desired:     []WebhookSubscriptionRequest{{EndpointURL: "https://example.com/orders", Topics: []string{"orders.created"}}},
+			wantErr:     true,
+			errContains: `invalid request 0: invalid topic "orders.created"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+
+				switch {
+				case r.Method == http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode(RetrieveAllWebhookSubscriptionsResponse{WebhookSubscriptions: existing})
+				case r.Method == http.MethodDelete:
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					var request WebhookSubscriptionRequest
+					json.NewDecoder(r.Body).Decode(&request)
+					id := strings.TrimPrefix(r.URL.Path, "/1.0/webhook_subscriptions/")
+					status := http.StatusOK
+					if r.URL.Path == "/1.0/webhook_subscriptions" {
+						id, status = "new", http.StatusCreated
+					}
+					w.WriteHeader(status)
+					fmt.Fprintf(w, `{"id": %q, "endpointUrl": %q}`, id, request.EndpointURL)
+				}
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				AccessToken: "test-token",
+				Client:      server.Client(),
+				BaseURL:     server.URL,
+			}
+
+			report, err := Ensure(context.Background(), config, tt.desired, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ensure() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				if len(calls) != 0 {
+					t.Errorf("expected no requests for invalid input, got %v", calls)
+				}
+				return
+			}
+
+			if strings.Join(calls, "\n") != strings.Join(tt.wantCalls, "\n") {
+				t.Errorf("expected calls %v, got %v", tt.wantCalls, calls)
+			}
+			if got := ids(report.Created); got != tt.wantCreated {
+				t.Errorf("expected created %q, got %q", tt.wantCreated, got)
+			}
+			if got := ids(report.Updated); got != tt.wantUpdated {
+				t.Errorf("expected updated %q, got %q", tt.wantUpdated, got)
+			}
+			if got := ids(report.Unchanged); got != tt.wantUnchanged {
+				t.Errorf("expected unchanged %q, got %q", tt.wantUnchanged, got)
+			}
+			if got := ids(report.Deleted); got != tt.wantDeleted {
+				t.Errorf("expected deleted %q, got %q", tt.wantDeleted, got)
+			}
+			if got := ids(report.Unmanaged); got != tt.wantUnmanaged {
+				t.Errorf("expected unmanaged %q, got %q", tt.wantUnmanaged, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// The topics the webhook subscriptions API documents. Fulfillments arrive as
+// order.update notifications; see Event.OrderFulfilled.
+const (
+	TopicOrderCreate        = "order.create"
+	TopicOrderUpdate        = "order.update"
+	TopicExtensionUninstall = "extension.uninstall"
+)
+
+// Event is the envelope of a webhook notification. Payload holds the typed
+// data for known topics, such as *OrderCreatePayload for order.create, and is
+// nil for topics this package does not know; Data always holds the raw JSON.
+type Event struct {
+	ID             string          `json:"id"`
+	WebsiteID      string          `json:"websiteId"`
+	SubscriptionID string          `json:"subscriptionId"`
+	Topic          string          `json:"topic"`
+	CreatedOn      string          `json:"createdOn"`
+	Data           json.RawMessage `json:"data"`
+	Payload        interface{}     `json:"-"`
+}
+
+type OrderCreatePayload struct {
+	OrderID string `json:"orderId"`
+}
+
+type OrderUpdatePayload struct {
+	OrderID string `json:"orderId"`
+	// Update names what changed, such as OrderUpdateFulfilled or "CANCELED".
+	Update string `json:"update"`
+}
+
+type ExtensionUninstallPayload struct {
+	ClientID string `json:"clientId"`
+}
+
+// ParseEvent decodes a webhook notification body and its topic's payload.
+func ParseEvent(body []byte) (Event, error) {
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return Event{}, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	if event.Topic == "" {
+		return Event{}, fmt.Errorf("event topic is required")
+	}
+
+	var payload interface{}
+	switch event.Topic {
+	case TopicOrderCreate:
+		payload = &OrderCreatePayload{}
+	case TopicOrderUpdate:
+		payload = &OrderUpdatePayload{}
+	case TopicExtensionUninstall:
+		payload = &ExtensionUninstallPayload{}
+	default:
+		return event, nil
+	}
+
+	if len(event.Data) > 0 && string(event.Data) != "null" {
+		if err := json.Unmarshal(event.Data, payload); err != nil {
+			return Event{}, fmt.Errorf("failed to unmarshal %s payload: %w", event.Topic, err)
+		}
+	}
+	event.Payload = payload
+
+	return event, nil
+}
@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantTopic   string
+		wantPayload interface{}
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "order create",
+			body: `{"id": "n1", "websiteId": "w1", "subscriptionId": "s1", "topic": "order.create",
+				"createdOn": "2024-03-05T14:00:00Z", "data": {"orderId": "o1"}}`,
+			wantTopic:   TopicOrderCreate,
+			wantPayload: &OrderCreatePayload{OrderID: "o1"},
+		},
+		{
+			name:        "order update",
+			body:        `{"topic": "order.update", "data": {"orderId": "o1", "update": "FULFILLED"}}`,
+			wantTopic:   TopicOrderUpdate,
+			wantPayload: &OrderUpdatePayload{OrderID: "o1", Update: "FULFILLED"},
+		},
+		{
+			name:        "undocumented topic is not typed",
+			body:        `{"topic": "inventory.update", "data": {"variantId": "v1", "sku": "SKU-1", "quantity": 4}}`,
+			wantTopic:   "inventory.update",
+			wantPayload: nil,
+		},
+		{
+			name:        "extension uninstall without data",
+			body:        `{"topic": "extension.uninstall"}`,
+			wantTopic:   TopicExtensionUninstall,
+			wantPayload: &ExtensionUninstallPayload{},
+		},
+		{
+			name:        "unknown topic keeps raw data",
+			body:        `{"topic": "product.create", "data": {"productId": "p1"}}`,
+			wantTopic:   "product.create",
+			wantPayload: nil,
+		},
+		{
+			name:        "missing topic",
+			body:        `{"data": {}}`,
+			wantErr:     true,
+			errContains: "event topic is required",
+		},
+		{
+			name:        "malformed payload",
+			body:        `{"topic": "order.create", "data": {"orderId": 12}}`,
+			wantErr:     true,
+			errContains: "failed to unmarshal order.create payload",
+		},
+		{
+			name:        "invalid json",
+			body:        `{`,
+			wantErr:     true,
+			errContains: "failed to unmarshal event",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := ParseEvent([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if event.Topic != tt.wantTopic {
+				t.Errorf("expected topic %s, got %s", tt.wantTopic, event.Topic)
+			}
+			if !reflect.DeepEqual(event.Payload, tt.wantPayload) {
+				t.Errorf("expected payload %#v, got %#v", tt.wantPayload, event.Payload)
+			}
+			if tt.wantPayload == nil && len(event.Data) == 0 {
+				t.Error("expected raw data to be kept for unknown topic")
+			}
+		})
+	}
+}
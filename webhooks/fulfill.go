@@ -0,0 +1,52 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/orders"
+)
+
+// OrderUpdateFulfilled is the Update of an order.update notification sent
+// when an order is fulfilled.
+const OrderUpdateFulfilled = "FULFILLED"
+
+// FulfilledOrder is an order.update notification for a fulfillment together
+// with the order it refers to. Notifications carry only the order ID and the
+// kind of update, so the order's status, shipments and fulfillment date come
+// from Order.
+type FulfilledOrder struct {
+	Payload *OrderUpdatePayload
+	Order   *orders.Order
+}
+
+// OrderFulfilled returns the payload of an order.update event whose update is
+// FULFILLED, or false for any other event.
+func (e Event) OrderFulfilled() (*OrderUpdatePayload, bool) {
+	payload, ok := e.Payload.(*OrderUpdatePayload)
+	if !ok || payload == nil || payload.Update != OrderUpdateFulfilled {
+		return nil, false
+	}
+	return payload, true
+}
+
+// RetrieveFulfilledOrder fetches the order a fulfillment notification refers
+// to. The notification does not say what the status was before, so compare
+// against a stored copy of the order to detect a transition.
+func RetrieveFulfilledOrder(ctx context.Context, config *common.Config, event Event) (*FulfilledOrder, error) {
+	payload, ok := event.OrderFulfilled()
+	if !ok {
+		return nil, fmt.Errorf("event %s is not a %s %s notification", event.ID, TopicOrderUpdate, OrderUpdateFulfilled)
+	}
+	if payload.OrderID == "" {
+		return nil, fmt.Errorf("event %s has no order ID", event.ID)
+	}
+
+	order, err := orders.RetrieveSpecificOrder(ctx, config, payload.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve fulfilled order %s: %w", payload.OrderID, err)
+	}
+
+	return &FulfilledOrder{Payload: payload, Order: order}, nil
+}
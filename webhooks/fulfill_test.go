@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/orders"
+)
+
+func TestOrderFulfilled(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{
+			name: "fulfillment update",
+			body: `{"id":"n1","topic":"order.update","data":{"orderId":"order-1","update":"FULFILLED"}}`,
+			want: true,
+		},
+		{
+			name: "other update",
+			body: `{"id":"n2","topic":"order.update","data":{"orderId":"order-1","update":"CANCELED"}}`,
+		},
+		{
+			name: "other topic",
+			body: `{"id":"n3","topic":"order.create","data":{"orderId":"order-1"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := ParseEvent([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("ParseEvent() error = %v", err)
+			}
+			payload, ok := event.OrderFulfilled()
+			if ok != tt.want {
+				t.Fatalf("OrderFulfilled() ok = %v, want %v", ok, tt.want)
+			}
+			if ok && payload.OrderID != "order-1" {
+				t.Errorf("unexpected payload %+v", payload)
+			}
+		})
+	}
+}
+
+func TestRetrieveFulfilledOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1.0/commerce/orders/order-1" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type":"NOT_FOUND","message":"Not found"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"order-1","orderNumber":"1001","fulfillmentStatus":"FULFILLED","fulfilledOn":"2024-01-02T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	config := &common.Config{APIKey: "test-key", Client: server.Client(), UserAgent: "test-agent", BaseURL: server.URL}
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{
+			name: "fetches the order",
+			body: `{"id":"n1","topic":"order.update","data":{"orderId":"order-1","update":"FULFILLED"}}`,
+		},
+		{
+			name:    "not a fulfillment",
+			body:    `{"id":"n1","topic":"order.update","data":{"orderId":"order-1","update":"CANCELED"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong topic",
+			body:    `{"id":"n1","topic":"order.create","data":{"orderId":"order-1"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing order",
+			body:    `{"id":"n1","topic":"order.update","data":{"orderId":"order-2","update":"FULFILLED"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := ParseEvent([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("ParseEvent() error = %v", err)
+			}
+
+			got, err := RetrieveFulfilledOrder(context.Background(), config, event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RetrieveFulfilledOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Order.ID != "order-1" || got.Order.FulfillmentStatus != orders.StatusFulfilled || got.Payload.OrderID != "order-1" {
+				t.Errorf("unexpected result order=%+v payload=%+v", got.Order, got.Payload)
+			}
+		})
+	}
+}
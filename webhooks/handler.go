@@ -0,0 +1,178 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const SignatureHeader = "Squarespace-Signature"
+
+const (
+	defaultDedupeTTL    = 72 * time.Hour
+	defaultClaimTTL     = 5 * time.Minute
+	defaultMaxBodyBytes = 1 << 20
+)
+
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// VerifySignature checks signature, the hex-encoded HMAC-SHA256 of body sent
+// in the Squarespace-Signature header, against the subscription's hex-encoded
+// secret.
+func VerifySignature(secret string, body []byte, signature string) error {
+	key, err := hex.DecodeString(secret)
+	if err != nil || len(key) == 0 {
+		return fmt.Errorf("invalid webhook secret")
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyAnySignature is VerifySignature against each candidate secret in turn,
+// succeeding if any of them produced signature.
+func VerifyAnySignature(secrets []string, body []byte, signature string) error {
+	if len(secrets) == 0 {
+		return fmt.Errorf("invalid webhook secret")
+	}
+
+	var result error
+	for _, secret := range secrets {
+		err := VerifySignature(secret, body, signature)
+		if err == nil {
+			return nil
+		}
+		if result == nil || errors.Is(err, ErrInvalidSignature) {
+			result = err
+		}
+	}
+	return result
+}
+
+type HandlerFunc func(ctx context.Context, event Event) error
+
+type HandlerOptions struct {
+	// Secret is the subscription secret used to verify every notification.
+	Secret string
+	// Keyring, when set, replaces Secret so secrets can be rotated while the
+	// handler is serving.
+	Keyring *Keyring
+	// InsecureSkipVerify accepts unsigned notifications. Only use it for local
+	// development.
+	InsecureSkipVerify bool
+	// Store, when set, drops notifications whose ID has already been handled
+	// or is being handled by a concurrent delivery.
+	Store EventStore
+	// DedupeTTL is how long handled IDs are remembered. Defaults to 72 hours.
+	DedupeTTL time.Duration
+	// ClaimTTL is how long an ID stays claimed while its handler runs, so a
+	// process that dies mid-delivery does not block redelivery for the full
+	// DedupeTTL. Defaults to 5 minutes.
+	ClaimTTL time.Duration
+	// MaxBodyBytes bounds the notification body. Defaults to 1 MiB.
+	MaxBodyBytes int64
+	// OnError receives errors that do not fail the delivery, such as a failure
+	// to mark a handled notification or to release a failed one.
+	OnError func(error)
+}
+
+// Handler is an http.Handler that verifies, parses and deduplicates webhook
+// notifications before passing them to a HandlerFunc. A HandlerFunc error
+// responds 500 so Squarespace redelivers the notification.
+type Handler struct {
+	fn   HandlerFunc
+	opts HandlerOptions
+}
+
+func NewHandler(fn HandlerFunc, opts HandlerOptions) *Handler {
+	if opts.DedupeTTL <= 0 {
+		opts.DedupeTTL = defaultDedupeTTL
+	}
+	if opts.ClaimTTL <= 0 {
+		opts.ClaimTTL = defaultClaimTTL
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	return &Handler{fn: fn, opts: opts}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.opts.MaxBodyBytes))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if !h.opts.InsecureSkipVerify {
+		secrets := []string{h.opts.Secret}
+		if h.opts.Keyring != nil {
+			secrets = h.opts.Keyring.Secrets()
+		}
+		if err := VerifyAnySignature(secrets, body, r.Header.Get(SignatureHeader)); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	event, err := ParseEvent(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	dedupe := h.opts.Store != nil && event.ID != ""
+	if dedupe {
+		claimed, err := h.opts.Store.Claim(ctx, event.ID, h.opts.ClaimTTL)
+		if err != nil {
+			http.Error(w, "failed to check event", http.StatusInternalServerError)
+			return
+		}
+		if !claimed {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	// The claim must be settled even if the delivery's request is canceled.
+	storeCtx := context.WithoutCancel(ctx)
+	if err := h.fn(ctx, event); err != nil {
+		if dedupe {
+			h.report(h.opts.Store.Release(storeCtx, event.ID))
+		}
+		http.Error(w, "failed to handle event", http.StatusInternalServerError)
+		return
+	}
+
+	if dedupe {
+		h.report(h.opts.Store.Mark(storeCtx, event.ID, h.opts.DedupeTTL))
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) report(err error) {
+	if err != nil && h.opts.OnError != nil {
+		h.opts.OnError(err)
+	}
+}
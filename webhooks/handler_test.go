@@ -0,0 +1,198 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func sign(t *testing.T, secret, body string) string {
+	t.Helper()
+	key, err := hex.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("invalid test secret: %v", err)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := `{"topic": "order.create"}`
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		wantErr   error
+	}{
+		{name: "valid", secret: testSecret, signature: sign(t, testSecret, body)},
+		{name: "wrong secret", secret: testSecret, signature: sign(t, "ffff", body), wantErr: ErrInvalidSignature},
+		{name: "not hex", secret: testSecret, signature: "zz", wantErr: ErrInvalidSignature},
+		{name: "missing", secret: testSecret, signature: "", wantErr: ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature(tt.secret, []byte(body), tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifySignature() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := VerifySignature("not-hex", []byte(body), "00"); err == nil || !strings.Contains(err.Error(), "invalid webhook secret") {
+		t.Errorf("expected invalid secret error, got %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	body := `{"id": "n1", "topic": "order.create", "data": {"orderId": "o1"}}`
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		signature  string
+		deliveries int
+		failWith   error
+		wantStatus int
+		wantCalls  int
+	}{
+		{
+			name:       "handles a signed notification",
+			body:       body,
+			signature:  sign(t, testSecret, body),
+			deliveries: 1,
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:       "drops redeliveries",
+			body:       body,
+			signature:  sign(t, testSecret, body),
+			deliveries: 3,
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:       "handler failure is redelivered",
+			body:       body,
+			signature:  sign(t, testSecret, body),
+			deliveries: 2,
+			failWith:   errors.New("database down"),
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  2,
+		},
+		{
+			name:       "bad signature",
+			body:       body,
+			signature:  sign(t, testSecret, `{"tampered": true}`),
+			deliveries: 1,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unparseable event",
+			body:       `{"id": "n2"}`,
+			signature:  sign(t, testSecret, `{"id": "n2"}`),
+			deliveries: 1,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			deliveries: 1,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			handler := NewHandler(func(ctx context.Context, event Event) error {
+				calls++
+				if payload, ok := event.Payload.(*OrderCreatePayload); !ok || payload.OrderID != "o1" {
+					t.Errorf("unexpected payload %#v", event.Payload)
+				}
+				return tt.failWith
+			}, HandlerOptions{Secret: testSecret, Store: NewMemoryEventStore()})
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+
+			var status int
+			for i := 0; i < tt.deliveries; i++ {
+				req := httptest.NewRequest(method, "/webhook", strings.NewReader(tt.body))
+				req.Header.Set(SignatureHeader, tt.signature)
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				status = rec.Code
+			}
+
+			if status != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d handler calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestHandlerConcurrentRedelivery(t *testing.T) {
+	body := `{"id": "n1", "topic": "order.create", "data": {"orderId": "o1"}}`
+	signature := sign(t, testSecret, body)
+
+	started := make(chan struct{})
+	finish := make(chan error)
+	var calls atomic.Int32
+	handler := NewHandler(func(ctx context.Context, event Event) error {
+		calls.Add(1)
+		close(started)
+		return <-finish
+	}, HandlerOptions{Secret: testSecret, Store: NewMemoryEventStore()})
+
+	deliver := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+		req.Header.Set(SignatureHeader, signature)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	first := make(chan int)
+	go func() { first <- deliver() }()
+	<-started
+
+	if status := deliver(); status != http.StatusOK {
+		t.Errorf("expected a concurrent redelivery to be acknowledged, got %d", status)
+	}
+	finish <- errors.New("boom")
+	if status := <-first; status != http.StatusInternalServerError {
+		t.Errorf("expected the failed delivery to respond 500, got %d", status)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected the handler to run once while claimed, got %d", got)
+	}
+
+	// The failure released the claim, so the next redelivery runs the handler.
+	started = make(chan struct{})
+	go func() { finish <- nil }()
+	if status := deliver(); status != http.StatusOK {
+		t.Errorf("expected redelivery to succeed, got %d", status)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected the handler to run again after a failure, got %d calls", got)
+	}
+}
@@ -0,0 +1,72 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+// Keyring holds a subscription's current secret and, for a grace window after
+// a rotation, its previous one, so notifications signed before the rotation
+// still verify. It is safe for concurrent use.
+type Keyring struct {
+	mu            sync.RWMutex
+	current       string
+	previous      string
+	previousUntil time.Time
+	now           func() time.Time
+}
+
+func NewKeyring(secret string) *Keyring {
+	return &Keyring{current: secret, now: time.Now}
+}
+
+// Secrets returns the secrets a notification may currently be signed with,
+// newest first.
+func (k *Keyring) Secrets() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	secrets := []string{k.current}
+	if k.previous != "" && k.now().Before(k.previousUntil) {
+		secrets = append(secrets, k.previous)
+	}
+	return secrets
+}
+
+// Rotate makes secret current and keeps the replaced secret valid for grace.
+func (k *Keyring) Rotate(secret string, grace time.Duration) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.previous, k.previousUntil = k.current, k.now().Add(grace)
+	k.current = secret
+}
+
+// RotateSecret rotates the subscription's secret, installs the new secret in
+// keyring with the old one honored for grace, then calls persist so the new
+// secret survives restarts. The keyring is updated even if persist fails, so
+// the running process keeps verifying; the returned error tells the caller to
+// retry persisting the secret in the response.
+func RotateSecret(ctx context.Context, config *common.Config, subscriptionID string, keyring *Keyring, grace time.Duration, persist func(ctx context.Context, secret string) error) (*RotateSubscriptionSecretResponse, error) {
+	if keyring == nil {
+		return nil, fmt.Errorf("keyring is required")
+	}
+
+	resp, err := RotateSubscriptionSecret(ctx, config, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	keyring.Rotate(resp.Secret, grace)
+
+	if persist != nil {
+		if err := persist(ctx, resp.Secret); err != nil {
+			return resp, fmt.Errorf("failed to persist rotated secret: %w", err)
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,108 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestRotateSecret(t *testing.T) {
+	const oldSecret = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	const newSecret = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+
+	tests := []struct {
+		name        string
+		persistErr  error
+		wantErr     bool
+		errContains string
+	}{
+		{name: "rotates and persists"},
+		{
+			name:        "persist failure still installs the new secret",
+			persistErr:  errors.New("vault unavailable"),
+			wantErr:     true,
+			errContains: "failed to persist rotated secret: vault unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/webhook_subscriptions/s1/actions/rotateSecret") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"secret": "` + newSecret + `"}`))
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				AccessToken: "test-token",
+				Client:      server.Client(),
+				BaseURL:     server.URL,
+			}
+
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			keyring := NewKeyring(oldSecret)
+			keyring.now = func() time.Time { return now }
+
+			var persisted string
+			resp, err := RotateSecret(context.Background(), config, "s1", keyring, time.Hour, func(ctx context.Context, secret string) error {
+				persisted = secret
+				return tt.persistErr
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RotateSecret() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("error message should contain %q, got %q", tt.errContains, err.Error())
+			}
+			if resp == nil || resp.Secret != newSecret || persisted != newSecret {
+				t.Errorf("expected new secret to be returned and persisted, got %+v and %q", resp, persisted)
+			}
+
+			body := `{"id": "n1", "topic": "order.create"}`
+			handler := NewHandler(func(ctx context.Context, event Event) error { return nil }, HandlerOptions{Keyring: keyring})
+			deliver := func(secret string) int {
+				req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+				req.Header.Set(SignatureHeader, sign(t, secret, body))
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				return rec.Code
+			}
+
+			if status := deliver(newSecret); status != http.StatusOK {
+				t.Errorf("expected new secret to verify, got %d", status)
+			}
+			if status := deliver(oldSecret); status != http.StatusOK {
+				t.Errorf("expected old secret to verify during grace window, got %d", status)
+			}
+			now = now.Add(2 * time.Hour)
+			if status := deliver(oldSecret); status != http.StatusUnauthorized {
+				t.Errorf("expected old secret to be rejected after grace window, got %d", status)
+			}
+		})
+	}
+}
+
+func TestVerifyAnySignature(t *testing.T) {
+	body := []byte(`{"topic": "order.create"}`)
+	signature := sign(t, testSecret, string(body))
+
+	if err := VerifyAnySignature([]string{"ffff", testSecret}, body, signature); err != nil {
+		t.Errorf("expected second candidate to verify, got %v", err)
+	}
+	if err := VerifyAnySignature([]string{testSecret, "not-hex"}, body, sign(t, "ffff", string(body))); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+	if err := VerifyAnySignature(nil, body, signature); err == nil {
+		t.Error("expected error with no candidate secrets")
+	}
+}
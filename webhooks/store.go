@@ -0,0 +1,160 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/j-low/gocommerce/internal/redis"
+)
+
+// EventStore remembers which notifications have been processed so redelivered
+// notifications can be dropped.
+type EventStore interface {
+	// Seen reports whether the notification ID has been claimed or marked and
+	// not yet expired.
+	Seen(ctx context.Context, id string) (bool, error)
+	// Claim atomically records the notification ID for ttl unless it is
+	// already recorded, reporting whether this call recorded it. Of several
+	// concurrent deliveries of one notification, exactly one claims it.
+	Claim(ctx context.Context, id string, ttl time.Duration) (bool, error)
+	// Release forgets a claimed ID so a redelivery can claim it again.
+	Release(ctx context.Context, id string) error
+	// Mark records the notification ID for ttl, replacing any claim.
+	Mark(ctx context.Context, id string, ttl time.Duration) error
+}
+
+// MemoryEventStore is an EventStore for a single process. Expired IDs are
+// swept as new ones are marked.
+type MemoryEventStore struct {
+	mu        sync.Mutex
+	ids       map[string]time.Time
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryEventStore() *MemoryEventStore {
+	return &MemoryEventStore{ids: make(map[string]time.Time), now: time.Now}
+}
+
+func (s *MemoryEventStore) Seen(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expires, ok := s.ids[id]
+	return ok && s.now().Before(expires), nil
+}
+
+func (s *MemoryEventStore) Claim(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if expires, ok := s.ids[id]; ok && s.now().Before(expires) {
+		return false, nil
+	}
+	s.markLocked(id, ttl)
+	return true, nil
+}
+
+func (s *MemoryEventStore) Release(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.ids, id)
+	return nil
+}
+
+func (s *MemoryEventStore) Mark(ctx context.Context, id string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.markLocked(id, ttl)
+	return nil
+}
+
+func (s *MemoryEventStore) markLocked(id string, ttl time.Duration) {
+	now := s.now()
+	if now.After(s.nextSweep) {
+		for seen, expires := range s.ids {
+			if !now.Before(expires) {
+				delete(s.ids, seen)
+			}
+		}
+		s.nextSweep = now.Add(time.Minute)
+	}
+
+	s.ids[id] = now.Add(ttl)
+}
+
+type RedisOptions struct {
+	Password string
+	DB       int
+	// KeyPrefix namespaces the stored IDs. Defaults to "gocommerce:webhooks:".
+	KeyPrefix string
+}
+
+// RedisEventStore is an EventStore shared by every process connected to the
+// same Redis server, with expiry handled by Redis.
+type RedisEventStore struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisEventStore(addr string, opts RedisOptions) *RedisEventStore {
+	prefix := opts.KeyPrefix
+	if prefix == "" {
+		prefix = "gocommerce:webhooks:"
+	}
+	return &RedisEventStore{
+		client: redis.NewClient(addr, redis.Options{Password: opts.Password, DB: opts.DB}),
+		prefix: prefix,
+	}
+}
+
+func (s *RedisEventStore) Seen(ctx context.Context, id string) (bool, error) {
+	reply, err := s.client.Do(ctx, "EXISTS", s.prefix+id)
+	if err != nil {
+		return false, fmt.Errorf("failed to check event %s: %w", id, err)
+	}
+	n, ok := reply.(int64)
+	if !ok {
+		return false, fmt.Errorf("failed to check event %s: unexpected reply %v", id, reply)
+	}
+	return n > 0, nil
+}
+
+// Claim uses SET NX, so the claim is atomic across every process sharing the
+// Redis server.
+func (s *RedisEventStore) Claim(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	ms := max(ttl.Milliseconds(), 1)
+	_, err := s.client.Do(ctx, "SET", s.prefix+id, "1", "NX", "PX", strconv.FormatInt(ms, 10))
+	if errors.Is(err, redis.ErrNil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim event %s: %w", id, err)
+	}
+	return true, nil
+}
+
+func (s *RedisEventStore) Release(ctx context.Context, id string) error {
+	if _, err := s.client.Do(ctx, "DEL", s.prefix+id); err != nil {
+		return fmt.Errorf("failed to release event %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *RedisEventStore) Mark(ctx context.Context, id string, ttl time.Duration) error {
+	ms := max(ttl.Milliseconds(), 1)
+	if _, err := s.client.Do(ctx, "SET", s.prefix+id, "1", "PX", strconv.FormatInt(ms, 10)); err != nil && !errors.Is(err, redis.ErrNil) {
+		return fmt.Errorf("failed to mark event %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *RedisEventStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/internal/redis/redistest"
+)
+
+func TestMemoryEventStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryEventStore()
+	store.now = func() time.Time { return now }
+
+	if seen, _ := store.Seen(ctx, "n1"); seen {
+		t.Fatal("expected unmarked ID to be unseen")
+	}
+	store.Mark(ctx, "n1", time.Hour)
+	if seen, _ := store.Seen(ctx, "n1"); !seen {
+		t.Error("expected marked ID to be seen")
+	}
+
+	now = now.Add(2 * time.Hour)
+	if seen, _ := store.Seen(ctx, "n1"); seen {
+		t.Error("expected expired ID to be unseen")
+	}
+	store.Mark(ctx, "n2", time.Hour)
+	if _, ok := store.ids["n1"]; ok {
+		t.Error("expected expired ID to be swept")
+	}
+}
+
+func TestRedisEventStore(t *testing.T) {
+	server := redistest.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	store := NewRedisEventStore(server.Addr(), RedisOptions{KeyPrefix: "test:"})
+	defer store.Close()
+
+	if seen, err := store.Seen(ctx, "n1"); err != nil || seen {
+		t.Fatalf("Seen() = %v, %v; want false, nil", seen, err)
+	}
+	if err := store.Mark(ctx, "n1", time.Minute); err != nil {
+		t.Fatalf("Mark() unexpected error = %v", err)
+	}
+	if seen, err := store.Seen(ctx, "n1"); err != nil || !seen {
+		t.Errorf("Seen() = %v, %v; want true, nil", seen, err)
+	}
+
+	server.Advance(2 * time.Minute)
+	if seen, err := store.Seen(ctx, "n1"); err != nil || seen {
+		t.Errorf("expected expired ID to be unseen, got %v, %v", seen, err)
+	}
+}
+
+func TestEventStoreClaim(t *testing.T) {
+	server := redistest.NewServer()
+	defer server.Close()
+
+	redisStore := NewRedisEventStore(server.Addr(), RedisOptions{KeyPrefix: "test:"})
+	defer redisStore.Close()
+
+	stores := map[string]EventStore{
+		"memory": NewMemoryEventStore(),
+		"redis":  redisStore,
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if claimed, err := store.Claim(ctx, "n1", time.Minute); err != nil || !claimed {
+				t.Fatalf("first Claim() = %v, %v; want true, nil", claimed, err)
+			}
+			if claimed, err := store.Claim(ctx, "n1", time.Minute); err != nil || claimed {
+				t.Errorf("second Claim() = %v, %v; want false, nil", claimed, err)
+			}
+			if seen, _ := store.Seen(ctx, "n1"); !seen {
+				t.Error("expected a claimed ID to be seen")
+			}
+
+			if err := store.Release(ctx, "n1"); err != nil {
+				t.Fatalf("Release() unexpected error = %v", err)
+			}
+			if claimed, err := store.Claim(ctx, "n1", time.Minute); err != nil || !claimed {
+				t.Errorf("Claim() after Release = %v, %v; want true, nil", claimed, err)
+			}
+
+			store.Mark(ctx, "n2", time.Hour)
+			if claimed, _ := store.Claim(ctx, "n2", time.Minute); claimed {
+				t.Error("expected a marked ID not to be claimable")
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+type TestNotificationResult struct {
+	Topic string
+	// StatusCode is the status the subscription's endpoint responded with.
+	StatusCode int
+	Err        error
+}
+
+// OK reports whether the notification was sent and the endpoint responded 2xx.
+func (r TestNotificationResult) OK() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// SendTestNotifications sends a test notification for each of topics, or for
+// every topic of the subscription when none are given. Each topic gets a
+// result, in order; a failure for one topic does not stop the others.
+func SendTestNotifications(ctx context.Context, config *common.Config, subscriptionID string, topics ...string) ([]TestNotificationResult, error) {
+	if len(topics) == 0 {
+		subscription, err := RetrieveSpecificWebhookSubscription(ctx, config, subscriptionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve subscription topics: %w", err)
+		}
+		topics = subscription.Topics
+	}
+
+	results := make([]TestNotificationResult, len(topics))
+	for i, topic := range topics {
+		results[i].Topic = topic
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		resp, err := SendTestNotification(ctx, config, subscriptionID, SendTestNotificationRequest{Topic: topic})
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].StatusCode = resp.StatusCode
+	}
+
+	return results, nil
+}
@@ -0,0 +1,84 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/j-low/gocommerce/common"
+)
+
+func TestSendTestNotifications(t *testing.T) {
+	tests := []struct {
+		name        string
+		topics      []string
+		wantResults []string
+	}{
+		{
+			name:        "every subscribed topic",
+			wantResults: []string{"order.create:200:ok", "order.update:500:failed"},
+		},
+		{
+			name:        "given topics",
+			topics:      []string{TopicOrderCreate, TopicExtensionUninstall},
+			wantResults: []string{"order.create:200:ok", "extension.uninstall:0:error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(`{"id": "s1", "topics": ["order.create", "order.update"]}`))
+					return
+				}
+
+				var request SendTestNotificationRequest
+				json.NewDecoder(r.Body).Decode(&request)
+				switch request.Topic {
+				case TopicExtensionUninstall:
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"type":"INVALID_REQUEST_ERROR","message":"Topic not subscribed"}`))
+				case TopicOrderUpdate:
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(`{"statusCode": 500}`))
+				default:
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(`{"statusCode": 200}`))
+				}
+			}))
+			defer server.Close()
+
+			config := &common.Config{
+				AccessToken: "test-token",
+				Client:      server.Client(),
+				BaseURL:     server.URL,
+			}
+
+			results, err := SendTestNotifications(context.Background(), config, "s1", tt.topics...)
+			if err != nil {
+				t.Fatalf("SendTestNotifications() unexpected error = %v", err)
+			}
+
+			var got []string
+			for _, result := range results {
+				outcome := "ok"
+				switch {
+				case result.Err != nil:
+					outcome = "error"
+				case !result.OK():
+					outcome = "failed"
+				}
+				got = append(got, fmt.Sprintf("%s:%d:%s", result.Topic, result.StatusCode, outcome))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantResults, ",") {
+				t.Errorf("expected results %v, got %v", tt.wantResults, got)
+			}
+		})
+	}
+}
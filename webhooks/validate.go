@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var validTopics = []string{
+	TopicOrderCreate,
+	TopicOrderUpdate,
+	TopicExtensionUninstall,
+}
+
+// ValidTopics returns every topic a webhook subscription may listen to.
+func ValidTopics() []string {
+	return append([]string(nil), validTopics...)
+}
+
+// Validate checks a subscription creation request for problems the API would
+// reject, returning all of them joined into a single error.
+func (r WebhookSubscriptionRequest) Validate() error {
+	var errs []error
+
+	if r.EndpointURL == "" {
+		errs = append(errs, fmt.Errorf("endpointUrl is required"))
+	} else if err := validateEndpointURL(r.EndpointURL); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(r.Topics) == 0 {
+		errs = append(errs, fmt.Errorf("topics cannot be empty"))
+	} else if err := validateTopics(r.Topics); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateUpdate checks a subscription update request, where an empty
+// endpoint and nil topics leave the existing values unchanged.
+func (r WebhookSubscriptionRequest) validateUpdate() error {
+	var errs []error
+
+	if r.EndpointURL != "" {
+		if err := validateEndpointURL(r.EndpointURL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.Topics != nil {
+		if len(r.Topics) == 0 {
+			errs = append(errs, fmt.Errorf("topics cannot be an empty array"))
+		} else if err := validateTopics(r.Topics); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateEndpointURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("endpointUrl is not a valid URL: %w", err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("endpointUrl must be an absolute https URL, got: %s", endpoint)
+	}
+	return nil
+}
+
+func validateTopics(topics []string) error {
+	var errs []error
+	seen := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		if !isValidTopic(topic) {
+			errs = append(errs, fmt.Errorf("invalid topic %q, valid topics are: %s", topic, strings.Join(validTopics, ", ")))
+			continue
+		}
+		if seen[topic] {
+			errs = append(errs, fmt.Errorf("duplicate topic %q", topic))
+		}
+		seen[topic] = true
+	}
+	return errors.Join(errs...)
+}
+
+func isValidTopic(topic string) bool {
+	for _, valid := range validTopics {
+		if topic == valid {
+			return true
+		}
+	}
+	return false
+}
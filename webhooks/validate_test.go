@@ -0,0 +1,67 @@
+package webhooks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWebhookSubscriptionRequestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     WebhookSubscriptionRequest
+		errContains []string
+	}{
+		{
+			name: "valid request",
+			request: WebhookSubscriptionRequest{
+				EndpointURL: "https://example.com/webhook",
+				Topics:      []string{TopicOrderCreate, TopicOrderUpdate},
+			},
+		},
+		{
+			name:    "empty request reports every problem",
+			request: WebhookSubscriptionRequest{},
+			errContains: []string{
+				"endpointUrl is required",
+				"topics cannot be empty",
+			},
+		},
+		{
+			name: "relative endpoint",
+			request: WebhookSubscriptionRequest{
+				EndpointURL: "/webhook",
+				Topics:      []string{TopicOrderCreate},
+			},
+			errContains: []string{"endpointUrl must be an absolute https URL, got: /webhook"},
+		},
+		{
+			name: "duplicate topic",
+			request: WebhookSubscriptionRequest{
+				EndpointURL: "https://example.com/webhook",
+				Topics:      []string{TopicOrderCreate, TopicOrderCreate},
+			},
+			errContains: []string{`duplicate topic "order.create"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if len(tt.errContains) == 0 {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			for _, want := range tt.errContains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error message should contain %q, got %q", want, err.Error())
+				}
+			}
+		})
+	}
+}
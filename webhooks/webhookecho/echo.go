@@ -0,0 +1,151 @@
+// Package webhookecho runs a local HTTPS webhook receiver that prints and
+// verifies incoming notifications, for debugging integrations without
+// deploying them.
+package webhookecho
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/webhooks"
+)
+
+type Options struct {
+	// Addr is the local address to listen on. Defaults to a random port on
+	// 127.0.0.1.
+	Addr string
+	// Secret verifies notification signatures. When empty, notifications are
+	// accepted and printed as unverified.
+	Secret string
+	// Out receives one line per notification. Defaults to os.Stdout.
+	Out io.Writer
+}
+
+// Receiver is a running HTTPS server with a self-signed certificate that
+// records every notification it accepts.
+type Receiver struct {
+	server *httptest.Server
+	secret string
+	out    io.Writer
+
+	mu       sync.Mutex
+	events   []webhooks.Event
+	received chan struct{}
+}
+
+func Start(opts Options) (*Receiver, error) {
+	r := &Receiver{secret: opts.Secret, out: opts.Out, received: make(chan struct{})}
+	if r.out == nil {
+		r.out = os.Stdout
+	}
+
+	r.server = httptest.NewUnstartedServer(http.HandlerFunc(r.serveHTTP))
+	if opts.Addr != "" {
+		listener, err := net.Listen("tcp", opts.Addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", opts.Addr, err)
+		}
+		r.server.Listener.Close()
+		r.server.Listener = listener
+	}
+	r.server.StartTLS()
+
+	return r, nil
+}
+
+// URL is the receiver's https endpoint.
+func (r *Receiver) URL() string {
+	return r.server.URL
+}
+
+// Client returns an HTTP client that trusts the receiver's certificate.
+func (r *Receiver) Client() *http.Client {
+	return r.server.Client()
+}
+
+func (r *Receiver) Close() {
+	r.server.Close()
+}
+
+// Events returns the notifications accepted so far.
+func (r *Receiver) Events() []webhooks.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]webhooks.Event(nil), r.events...)
+}
+
+// Wait blocks until at least n notifications have been accepted or ctx is
+// done, returning the notifications accepted so far.
+func (r *Receiver) Wait(ctx context.Context, n int) ([]webhooks.Event, error) {
+	for {
+		r.mu.Lock()
+		events := append([]webhooks.Event(nil), r.events...)
+		received := r.received
+		r.mu.Unlock()
+
+		if len(events) >= n {
+			return events, nil
+		}
+		select {
+		case <-received:
+		case <-ctx.Done():
+			return events, ctx.Err()
+		}
+	}
+}
+
+// Ping sends a test notification for every topic of the subscription, with one
+// result per topic including the status the subscription's endpoint responded
+// with.
+func (r *Receiver) Ping(ctx context.Context, config *common.Config, subscriptionID string) ([]webhooks.TestNotificationResult, error) {
+	return webhooks.SendTestNotifications(ctx, config, subscriptionID)
+}
+
+func (r *Receiver) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	verification := "unverified"
+	if r.secret != "" {
+		if err := webhooks.VerifySignature(r.secret, body, req.Header.Get(webhooks.SignatureHeader)); err != nil {
+			r.printf("%s rejected: %v\n", time.Now().Format(time.RFC3339), err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		verification = "verified"
+	}
+
+	event, err := webhooks.ParseEvent(body)
+	if err != nil {
+		r.printf("%s rejected: %v\n", time.Now().Format(time.RFC3339), err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	fmt.Fprintf(r.out, "%s %s %s id=%s subscription=%s data=%s\n",
+		time.Now().Format(time.RFC3339), verification, event.Topic, event.ID, event.SubscriptionID, event.Data)
+	r.events = append(r.events, event)
+	close(r.received)
+	r.received = make(chan struct{})
+	r.mu.Unlock()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *Receiver) printf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fmt.Fprintf(r.out, format, args...)
+}
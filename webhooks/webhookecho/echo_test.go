@@ -0,0 +1,129 @@
+package webhookecho
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-low/gocommerce/common"
+	"github.com/j-low/gocommerce/webhooks"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func sign(body string) string {
+	key, _ := hex.DecodeString(testSecret)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestReceiverPing(t *testing.T) {
+	var out bytes.Buffer
+	receiver, err := Start(Options{Secret: testSecret, Out: &out})
+	if err != nil {
+		t.Fatalf("Start() unexpected error = %v", err)
+	}
+	defer receiver.Close()
+
+	if !strings.HasPrefix(receiver.URL(), "https://") {
+		t.Errorf("expected https receiver, got %s", receiver.URL())
+	}
+
+	// The fake API delivers each test notification to the receiver, as
+	// Squarespace would to the subscription's endpoint.
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"id": "s1", "endpointUrl": %q, "topics": ["order.create", "order.update"]}`, receiver.URL())
+			return
+		}
+
+		var request webhooks.SendTestNotificationRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		body := fmt.Sprintf(`{"id": "n-%s", "subscriptionId": "s1", "topic": %q, "data": {"orderId": "o1"}}`, request.Topic, request.Topic)
+
+		delivery, _ := http.NewRequest(http.MethodPost, receiver.URL(), strings.NewReader(body))
+		delivery.Header.Set(webhooks.SignatureHeader, sign(body))
+		resp, err := receiver.Client().Do(delivery)
+		if err != nil {
+			t.Errorf("failed to deliver notification: %v", err)
+			return
+		}
+		resp.Body.Close()
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"statusCode": %d}`, resp.StatusCode)
+	}))
+	defer api.Close()
+
+	config := &common.Config{
+		AccessToken: "test-token",
+		Client:      api.Client(),
+		BaseURL:     api.URL,
+	}
+
+	results, err := receiver.Ping(context.Background(), config, "s1")
+	if err != nil {
+		t.Fatalf("Ping() unexpected error = %v", err)
+	}
+	if len(results) != 2 || !results[0].OK() || !results[1].OK() {
+		t.Errorf("expected 200 for every topic, got %+v", results)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	events, err := receiver.Wait(ctx, 2)
+	if err != nil {
+		t.Fatalf("Wait() unexpected error = %v", err)
+	}
+	if events[0].Topic != webhooks.TopicOrderCreate || events[1].Topic != webhooks.TopicOrderUpdate {
+		t.Errorf("unexpected events %+v", events)
+	}
+
+	forged, _ := http.NewRequest(http.MethodPost, receiver.URL(), strings.NewReader(`{"topic": "order.create"}`))
+	forged.Header.Set(webhooks.SignatureHeader, sign(`{"topic": "order.update"}`))
+	resp, err := receiver.Client().Do(forged)
+	if err != nil {
+		t.Fatalf("failed to send forged notification: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected forged notification to be rejected, got %d", resp.StatusCode)
+	}
+
+	receiver.Close()
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 output lines, got %q", out.String())
+	}
+	if !strings.Contains(lines[0], "verified order.create id=n-order.create subscription=s1") {
+		t.Errorf("unexpected output line %q", lines[0])
+	}
+	if !strings.Contains(lines[2], "rejected: invalid webhook signature") {
+		t.Errorf("unexpected output line %q", lines[2])
+	}
+}
+
+func TestReceiverWaitTimeout(t *testing.T) {
+	receiver, err := Start(Options{Out: &bytes.Buffer{}})
+	if err != nil {
+		t.Fatalf("Start() unexpected error = %v", err)
+	}
+	defer receiver.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := receiver.Wait(ctx, 1); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}